@@ -20,6 +20,7 @@
 package main
 
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/controllers"
@@ -34,15 +35,17 @@ import (
 	"e2mgr/services"
 	"e2mgr/services/rmrreceiver"
 	"e2mgr/services/rmrsender"
-	//"fmt"
+	"fmt"
     "flag"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/reader"
     "gerrit.o-ran-sc.org/r/ric-plt/sdlgo"
-    "github.com/spf13/viper"
-    "github.com/fsnotify/fsnotify"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
 )
 
 const GeneralKeyDefaultValue = "{\"enableRic\":true}"
@@ -67,57 +70,113 @@ func initKeys(logger *logger.Logger, sdl *sdlgo.SyncStorage) error {
 	return nil
 
 }
+// leaderHolderId identifies the replica in the leader lease, the pod name when running in Kubernetes
+func leaderHolderId() string {
+	hostname, err := os.Hostname()
+
+	if err != nil {
+		Log.Errorf("#app.main - failed reading hostname, using the process id as leader holder id. error: %s", err)
+		return "e2mgr-" + strconv.Itoa(os.Getpid())
+	}
+
+	return hostname
+}
+
 /**Dynamic log-level changes **/
 
-func loadConfig() {
-        viper.SetConfigFile(parseCmd())
-        if err := viper.ReadInConfig(); err != nil {
-                Log.Errorf("Error reading config file, %s", err)
-        }
-        Log.Infof("Using config file: %s\n", viper.ConfigFileUsed())
-        // Watch for config file changes and re-read data ...
-        watch()
+func loadConfig(config *configuration.Configuration, fileName string) {
+	configFile, err := configuration.WatchConfiguration(fileName, func() {
+		Log.Infof("#app.main - configuration file changed")
+		reloadConfig(config, fileName)
+	})
+	if err != nil {
+		Log.Errorf("#app.main - configuration changes are not watched. error: %s", err)
+		return
+	}
+
+	Log.Infof("#app.main - watching configuration file %s", configFile)
 }
-func parseCmd() string {
+
+func parseCmd() (string, bool) {
         var fileName *string
         fileName = flag.String("f", DEFAULT_CONFIG_FILE, "Specify the configuration file.")
 	flag.String("port", DEFAULT_PORT, "Specify the port file.")
+	validateConfig := flag.Bool("validate-config", false, "Validate the configuration file E2 Manager runs with, environment overrides included, and exit.")
         flag.Parse()
 
-        return *fileName
+        return configurationFile(*fileName), *validateConfig
+}
+
+// configurationFile returns the file given with -f, or an empty name selecting the file found in the search paths
+// when -f is not given. E2 Manager runs with, validates and reloads the same file
+func configurationFile(fileName string) string {
+	given := ""
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "f" {
+			given = fileName
+		}
+	})
+	return given
 }
 
-func watch() {
-        viper.WatchConfig()
-        viper.OnConfigChange(func(e fsnotify.Event) {
-                Log.Infof("config file changed  %s", e.Name)
-        	setLoglevel()
-        })
+// validateConfiguration reports the result of --validate-config and returns the exit code
+func validateConfiguration(config *configuration.Configuration, err error) int {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "#app.main - invalid configuration:\n%s", err)
+		return 1
+	}
+
+	fmt.Printf("#app.main - configuration is valid: %s\n", config)
+	return 0
 }
 
-/*********MDC LOG CHNAGES ********/
-func setLoglevel() {
-    var loglevel int
-    if err := viper.UnmarshalKey("loglevel", &loglevel); err != nil {
-        Log.Errorf("Unmarshalling failed while reading %d", loglevel)
-    }
-
-    switch loglevel {
-    case 1:
-        Log.Infof("LOGLEVEL is set to ERROR\n")
-    case 2:
-        Log.Infof("LOGLEVEL is set to WARNING\n")
-    case 3:
-        Log.Infof("LOGLEVEL is set to INFO\n")
-    case 4:
-        Log.Infof("LOGLEVEL is set to DEBUG\n")
-    }
-    Log.SetLevel(loglevel)
+// reloadConfig applies the settings which are safe to change at runtime. An invalid file is rejected as a whole. The
+// log level is set again only when logging.logLevel changed, so a level set with PUT /v1/admin/loglevel is kept
+func reloadConfig(config *configuration.Configuration, fileName string) {
+	logLevel := config.GetLogLevel()
+	reloaded, err := configuration.ReloadConfiguration(fileName)
+	if err != nil {
+		Log.Errorf("#app.main - configuration reload rejected, keeping the current settings. error: %s", err)
+		return
+	}
+
+	changes := config.ApplyReloadable(reloaded)
+	if len(changes) == 0 {
+		Log.Infof("#app.main - configuration reloaded, no changes")
+		return
+	}
+
+	if config.GetLogLevel() != logLevel {
+		setLoglevel(config)
+	}
+	Log.Infof("#app.main - configuration reloaded, changes: %s", strings.Join(changes, ", "))
+}
+
+func setLoglevel(config *configuration.Configuration) {
+	level, err := logger.ParseLevel(config.GetLogLevel())
+	if err != nil {
+		Log.Warnf("#app.main - %s, using info", err)
+		level = logger.InfoLevel
+	}
+
+	Log.SetLevel(level)
+	Log.Infof("#app.main - log level is set to %s", logger.LevelName(level))
 }
 
 
 func main() {
-	config := configuration.ParseConfiguration()
+	configFile, validateConfig := parseCmd()
+
+	if validateConfig {
+		os.Exit(validateConfiguration(configuration.LoadConfiguration(configFile)))
+	}
+
+	config, err := configuration.LoadConfiguration(configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "#app.main - invalid configuration:\n%s", err)
+		os.Exit(1)
+	}
+
         level := int8(4)
 	Log, _ = logger.InitLogger(level)
 	Log.SetFormat(0)
@@ -127,21 +186,24 @@ func main() {
 		os.Exit(1)
 	}*/
 	Log.Infof("#app.main - Configuration %s", config)
-	loadConfig()
-	
-	setLoglevel()
+	loadConfig(config, configFile)
+
+	setLoglevel(config)
 	sdl := sdlgo.NewSyncStorage()
-	err := initKeys(Log, sdl)
+	err = initKeys(Log, sdl)
 
 	if err != nil {
 		os.Exit(1)
 	}
 
 	defer sdl.Close()
-	rnibDataService := services.NewRnibDataService(Log, config, reader.GetNewRNibReader(sdl), rNibWriter.GetRNibWriter(sdl, config.RnibWriter))
+	publishedNotifications := managers.NewPublishedNotifications()
+	rnibDataService := services.NewRnibDataService(Log, config, reader.GetNewRNibReader(sdl), rNibWriter.GetRNibWriter(publishedNotifications.Recording(sdl), config.RnibWriter))
 
 	ranListManager := managers.NewRanListManager(Log, rnibDataService)
-	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(Log, rnibDataService)
+	ranProcedureTracker := managers.NewRanProcedureTracker(Log, config, services.NewRanProcedureStore(Log, config, sdl))
+	errorIndicationStore := services.NewErrorIndicationStore(sdl)
+	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(Log, rnibDataService, ranProcedureTracker, ranListManager)
 
 	err = ranListManager.InitNbIdentityMap()
 
@@ -150,40 +212,110 @@ func main() {
 		os.Exit(1)
 	}
 
+	err = ranListManager.LoadNbIdentityAttributes()
+
+	if err != nil {
+		Log.Warnf("#app.main - failed loading the RAN attributes, filtering the RAN list by node type or E2T address may be incomplete")
+	}
+
+	// indexing the served cells and RAN functions reads every nodeb from rNib, so it does not delay the startup
+	go func() {
+		if err := ranListManager.InitNodebIndexes(); err != nil {
+			Log.Warnf("#app.main - failed indexing the served cells and RAN functions, cell and RAN function lookup may be incomplete")
+		}
+	}()
+
 	var msgImpl *rmrCgo.Context
 	rmrMessenger := msgImpl.Init("tcp:"+strconv.Itoa(config.Rmr.Port), config.Rmr.MaxMsgSize, 0, Log)
 	rmrSender := rmrsender.NewRmrSender(Log, rmrMessenger)
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, Log)
-	routingManagerClient := clients.NewRoutingManagerClient(Log, config, clients.NewHttpClient())
-	ranAlarmService := services.NewRanAlarmService(Log, config)
+	alarmManagerClient := clients.NewAlarmManagerClient(Log, config, clients.NewHttpClient())
+	routingManagerClient := clients.NewRoutingManagerClient(Log, config, clients.NewHttpClient(), alarmManagerClient)
+	ranAlarmService := services.NewRanAlarmService(Log, config, alarmManagerClient)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(Log, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(Log, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager)
-	e2tShutdownManager := managers.NewE2TShutdownManager(Log, config, rnibDataService, e2tInstancesManager, e2tAssociationManager, ranConnectStatusChangeManager)
-	e2tKeepAliveWorker := managers.NewE2TKeepAliveWorker(Log, rmrSender, e2tInstancesManager, e2tShutdownManager, config)
+	e2tShutdownManager := managers.NewE2TShutdownManager(Log, config, rnibDataService, e2tInstancesManager, e2tAssociationManager, ranConnectStatusChangeManager, ranAlarmService)
+	leaderElector := managers.NewLeaderElector(Log, config, services.NewLeaderLeaseStore(sdl), leaderHolderId())
+	e2tKeepAliveWorker := managers.NewE2TKeepAliveWorker(Log, rmrSender, e2tInstancesManager, e2tShutdownManager, config, leaderElector)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(Log, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	ricServiceQueryManager := managers.NewRicServiceQueryManager(Log, config, rmrSender, rnibDataService, ranProcedureTracker)
+	ricServiceQueryWorker := managers.NewRicServiceQueryWorker(Log, config, ranListManager, ricServiceQueryManager, leaderElector)
+	healthCheckJobManager := managers.NewHealthCheckJobManager(Log)
+	x2ResetTransactionManager := managers.NewX2ResetTransactionManager(Log, config, ranProcedureTracker)
+	ranDisconnectionManager := managers.NewRanDisconnectionManager(Log, config, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager)
+	ranResetManager := managers.NewRanResetManager(Log, rnibDataService, ranConnectStatusChangeManager)
+	changeStatusToConnectedRanManager := managers.NewChangeStatusToConnectedRanManager(Log, rnibDataService, ranConnectStatusChangeManager)
+	ricE2ResetManager := managers.NewRicE2ResetManager(Log, rmrSender, rnibDataService, ranResetManager, changeStatusToConnectedRanManager, e2ResetTransactionManager)
+	ranListSynchronizer := managers.NewRanListSynchronizer(Log, config, sdl, ranListManager, publishedNotifications)
+	ranLivenessMonitor := managers.NewRanLivenessMonitor(Log, config, ranListManager, ranAlarmService, ricE2ResetManager, ranDisconnectionManager, leaderElector)
+	notificationDispatcher := notificationmanager.NewNotificationDispatcher(Log, config.NotificationWorkers, config.NotificationResponseBuffer)
 	rmrNotificationHandlerProvider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
-	rmrNotificationHandlerProvider.Init(Log, config, rnibDataService, rmrSender, e2tInstancesManager, routingManagerClient, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, RicServiceUpdateManager)
+	rmrNotificationHandlerProvider.Init(Log, config, rnibDataService, rmrSender, e2tInstancesManager, routingManagerClient, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, RicServiceUpdateManager, e2ResetTransactionManager, ranAlarmService, ranProcedureTracker, errorIndicationStore, ricServiceQueryManager, healthCheckJobManager, x2ResetTransactionManager, notificationDispatcher)
 
-	notificationManager := notificationmanager.NewNotificationManager(Log, rmrNotificationHandlerProvider)
+	notificationDispatcher.Start()
+	notificationManager := notificationmanager.NewNotificationManager(Log, rmrNotificationHandlerProvider, notificationDispatcher, leaderElector)
 	rmrReceiver := rmrreceiver.NewRmrReceiver(Log, rmrMessenger, notificationManager)
 	nodebValidator := managers.NewNodebValidator()
 	updateEnbManager := managers.NewUpdateEnbManager(Log, rnibDataService, nodebValidator)
 	updateGnbManager := managers.NewUpdateGnbManager(Log, rnibDataService, nodebValidator)
 
-	e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	// with leader election the keep alive worker resets the timestamps once this replica becomes the leader
+	if !config.LeaderElection.Enabled {
+		e2tInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+	}
 
 	defer rmrMessenger.Close()
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
+	leaderElectorDone := make(chan struct{})
+	go func() {
+		leaderElector.Execute(workersCtx)
+		close(leaderElectorDone)
+	}()
 	go rmrReceiver.ListenAndHandle()
-	go e2tKeepAliveWorker.Execute()
+	go e2tKeepAliveWorker.Execute(workersCtx)
+	go ricServiceQueryWorker.Execute(workersCtx)
+	go ranLivenessMonitor.Execute(workersCtx)
+	go ranListSynchronizer.Execute(workersCtx)
 
-	httpMsgHandlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(Log, rmrSender, config, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager)
-	rootController := controllers.NewRootController(rnibDataService)
+	httpMsgHandlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(Log, rmrSender, config, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager, e2ResetTransactionManager, ranProcedureTracker, errorIndicationStore, ricServiceQueryManager, ranLivenessMonitor, healthCheckJobManager, x2ResetTransactionManager, leaderElector)
+	rootController := controllers.NewRootController(rnibDataService, rmrMessenger, routingManagerClient, ranListManager)
 	nodebController := controllers.NewNodebController(Log, httpMsgHandlerProvider)
 	e2tController := controllers.NewE2TController(Log, httpMsgHandlerProvider)
 	symptomController := controllers.NewSymptomdataController(Log, httpMsgHandlerProvider, rnibDataService, ranListManager)
+	adminController := controllers.NewAdminController(Log)
         //fmt.Println("loadconfig called at last")
         //loadConfig()
-	_ = httpserver.Run(Log, config.Http.Port, rootController, nodebController, e2tController, symptomController)
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stopSignals()
+
+	httpCtx, stopHttp := context.WithCancel(context.Background())
+	httpDone := make(chan error, 1)
+	go func() {
+		httpDone <- httpserver.Run(httpCtx, Log, config.Http.Port, time.Duration(config.ShutdownTimeoutSec)*time.Second, rootController, nodebController, e2tController, symptomController, adminController)
+	}()
+
+	select {
+	case <-signalCtx.Done():
+		Log.Infof("#app.main - termination signal received, shutting down")
+	case <-httpDone:
+		Log.Errorf("#app.main - HTTP server stopped unexpectedly, shutting down")
+		httpDone <- nil
+	}
+
+	// stop taking new work first, then drain what is in progress, the RMR context and the SDL connection are closed last by the deferred calls
+	rootController.SetShuttingDown()
+	rmrReceiver.Stop()
+	notificationDispatcher.Stop()
+	stopWorkers()
+	stopHttp()
+	<-httpDone
+	// the leader lease is released through SDL, which must stay open until it is
+	<-leaderElectorDone
+
+	Log.Infof("#app.main - shutdown completed")
 	//fmt.Println("loadconfig called at last")
 	//loadConfig()
 }
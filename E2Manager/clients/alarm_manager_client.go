@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package clients
+
+import (
+	"bytes"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const AlarmsApiSuffix = "alarms"
+
+type IAlarmManagerClient interface {
+	Raise(alarm models.Alarm) error
+	Clear(alarm models.Alarm) error
+}
+
+// alarmQueueSize is the number of alarms waiting to be sent beyond which Raise and Clear fail
+const alarmQueueSize = 100
+
+type alarmKey struct {
+	specificProblem models.AlarmId
+	identifyingInfo string
+}
+
+type alarmRequest struct {
+	alarm  models.Alarm
+	action models.AlarmAction
+}
+
+// AlarmManagerClient de-duplicates alarms: an alarm that is already raised is not raised again, and an alarm
+// that is known to be cleared is not cleared again. Alarms never seen since startup are always forwarded.
+// Raise and Clear only queue the alarm, a single sender posts the queued alarms in order, so that the callers
+// never wait for the Alarm Manager
+type AlarmManagerClient struct {
+	logger      *logger.Logger
+	config      *configuration.Configuration
+	httpClient  IHttpClient
+	mutex       sync.Mutex
+	alarmStates map[alarmKey]bool
+	requests    chan alarmRequest
+}
+
+func NewAlarmManagerClient(logger *logger.Logger, config *configuration.Configuration, httpClient IHttpClient) *AlarmManagerClient {
+	c := &AlarmManagerClient{
+		logger:      logger,
+		config:      config,
+		httpClient:  httpClient,
+		alarmStates: make(map[alarmKey]bool),
+		requests:    make(chan alarmRequest, alarmQueueSize),
+	}
+
+	go c.send()
+
+	return c
+}
+
+func (c *AlarmManagerClient) Raise(alarm models.Alarm) error {
+	return c.enqueue(alarm, models.AlarmActionRaise)
+}
+
+func (c *AlarmManagerClient) Clear(alarm models.Alarm) error {
+	return c.enqueue(alarm, models.AlarmActionClear)
+}
+
+func (c *AlarmManagerClient) enqueue(alarm models.Alarm, action models.AlarmAction) error {
+	select {
+	case c.requests <- alarmRequest{alarm: alarm, action: action}:
+		return nil
+	default:
+		c.logger.Errorf("#AlarmManagerClient.enqueue - alarm queue is full, dropping %s of alarm %d (%s)", action, alarm.SpecificProblem, alarm.IdentifyingInfo)
+		return e2managererrors.NewAlarmManagerError()
+	}
+}
+
+func (c *AlarmManagerClient) send() {
+	for request := range c.requests {
+		_ = c.sendAlarm(request.alarm, request.action)
+	}
+}
+
+func (c *AlarmManagerClient) isInState(key alarmKey, isRaise bool) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	isRaised, ok := c.alarmStates[key]
+	return ok && isRaised == isRaise
+}
+
+func (c *AlarmManagerClient) setState(key alarmKey, isRaise bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.alarmStates[key] = isRaise
+}
+
+func (c *AlarmManagerClient) sendAlarm(alarm models.Alarm, action models.AlarmAction) error {
+	key := alarmKey{specificProblem: alarm.SpecificProblem, identifyingInfo: alarm.IdentifyingInfo}
+	isRaise := action == models.AlarmActionRaise
+
+	if c.isInState(key, isRaise) {
+		c.logger.Debugf("#AlarmManagerClient.sendAlarm - alarm %d (%s) already in state %s, skipping", alarm.SpecificProblem, alarm.IdentifyingInfo, action)
+		return nil
+	}
+
+	if len(c.config.AlarmManager.BaseUrl) == 0 {
+		c.logger.Infof("#AlarmManagerClient.sendAlarm - alarm manager is not configured. %s alarm %d (%s): %s", action, alarm.SpecificProblem, alarm.IdentifyingInfo, alarm.AdditionalInfo)
+		c.setState(key, isRaise)
+		return nil
+	}
+
+	alarmMessage := models.AlarmMessage{Alarm: alarm, AlarmAction: action, AlarmTime: time.Now().UnixNano()}
+	marshaled, err := json.Marshal(alarmMessage)
+
+	if err != nil {
+		return e2managererrors.NewAlarmManagerError()
+	}
+
+	url := c.config.AlarmManager.BaseUrl + AlarmsApiSuffix
+	body := bytes.NewBuffer(marshaled)
+	c.logger.Infof("[E2 Manager -> Alarm Manager] #AlarmManagerClient.sendAlarm - %s url: %s, request body: %+v", action, url, body)
+
+	var resp *http.Response
+
+	if isRaise {
+		resp, err = c.httpClient.Post(url, "application/json", body)
+	} else {
+		resp, err = c.httpClient.Delete(url, "application/json", body)
+	}
+
+	if err != nil {
+		c.logger.Errorf("#AlarmManagerClient.sendAlarm - failed sending request. error: %s", err)
+		return e2managererrors.NewAlarmManagerError()
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		c.logger.Infof("[Alarm Manager -> E2 Manager] #AlarmManagerClient.sendAlarm - success. http status code: %d", resp.StatusCode)
+		c.setState(key, isRaise)
+		return nil
+	}
+
+	c.logger.Errorf("[Alarm Manager -> E2 Manager] #AlarmManagerClient.sendAlarm - failure. http status code: %d", resp.StatusCode)
+	return e2managererrors.NewAlarmManagerError()
+}
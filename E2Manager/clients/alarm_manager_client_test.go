@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package clients
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/tests/alarmmanagerstub"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initAlarmManagerClientTest(t *testing.T) (*AlarmManagerClient, *alarmmanagerstub.AlarmManagerStub) {
+	logger := initLog(t)
+	stub := alarmmanagerstub.NewAlarmManagerStub()
+	t.Cleanup(stub.Close)
+	config := &configuration.Configuration{}
+	config.AlarmManager.BaseUrl = stub.BaseUrl()
+	return NewAlarmManagerClient(logger, config, NewHttpClient()), stub
+}
+
+func TestAlarmManagerClientRaiseAndClear(t *testing.T) {
+	client, stub := initAlarmManagerClientTest(t)
+	alarm := models.NewAlarm(models.E2ConnectivityLostToGnbAlarmId, models.AlarmSeverityMajor, RanName, "connection status: DISCONNECTED")
+
+	err := client.Raise(alarm)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return stub.IsActive(models.E2ConnectivityLostToGnbAlarmId, RanName)
+	}, time.Second, 10*time.Millisecond)
+
+	messages := stub.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, models.AlarmManagedObjectId, messages[0].ManagedObjectId)
+	assert.Equal(t, models.AlarmApplicationId, messages[0].ApplicationId)
+	assert.Equal(t, models.AlarmSeverityMajor, messages[0].PerceivedSeverity)
+	assert.Equal(t, "connection status: DISCONNECTED", messages[0].AdditionalInfo)
+
+	err = client.Clear(alarm)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return !stub.IsActive(models.E2ConnectivityLostToGnbAlarmId, RanName)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAlarmManagerClientDeduplication(t *testing.T) {
+	client, stub := initAlarmManagerClientTest(t)
+	alarm := models.NewAlarm(models.E2TKeepAliveLostAlarmId, models.AlarmSeverityCritical, E2TAddress, "")
+
+	_ = client.sendAlarm(alarm, models.AlarmActionRaise)
+	_ = client.sendAlarm(alarm, models.AlarmActionRaise)
+	assert.Len(t, stub.Messages(), 1)
+
+	_ = client.sendAlarm(alarm, models.AlarmActionClear)
+	_ = client.sendAlarm(alarm, models.AlarmActionClear)
+	assert.Len(t, stub.Messages(), 2)
+}
+
+func TestAlarmManagerClientFailureStatus(t *testing.T) {
+	client, stub := initAlarmManagerClientTest(t)
+	stub.SetStatusCode(http.StatusServiceUnavailable)
+	alarm := models.NewAlarm(models.E2TKeepAliveLostAlarmId, models.AlarmSeverityCritical, E2TAddress, "")
+
+	err := client.sendAlarm(alarm, models.AlarmActionRaise)
+	assert.IsType(t, &e2managererrors.AlarmManagerError{}, err)
+
+	stub.SetStatusCode(http.StatusOK)
+	err = client.sendAlarm(alarm, models.AlarmActionRaise)
+	assert.Nil(t, err)
+	assert.True(t, stub.IsActive(models.E2TKeepAliveLostAlarmId, E2TAddress))
+}
+
+func TestAlarmManagerClientPostFailure(t *testing.T) {
+	logger := initLog(t)
+	config := &configuration.Configuration{}
+	config.AlarmManager.BaseUrl = "http://localhost:8080/ric/v1/"
+	httpClientMock := &mocks.HttpClientMock{}
+	client := NewAlarmManagerClient(logger, config, httpClientMock)
+
+	url := config.AlarmManager.BaseUrl + AlarmsApiSuffix
+	httpClientMock.On("Post", url, "application/json", mock.Anything).Return(&http.Response{}, errors.New("error"))
+	err := client.sendAlarm(models.NewAlarm(models.E2TKeepAliveLostAlarmId, models.AlarmSeverityCritical, E2TAddress, ""), models.AlarmActionRaise)
+	assert.IsType(t, &e2managererrors.AlarmManagerError{}, err)
+}
+
+func TestAlarmManagerClientNotConfigured(t *testing.T) {
+	logger := initLog(t)
+	httpClientMock := &mocks.HttpClientMock{}
+	client := NewAlarmManagerClient(logger, &configuration.Configuration{}, httpClientMock)
+
+	err := client.sendAlarm(models.NewAlarm(models.E2TKeepAliveLostAlarmId, models.AlarmSeverityCritical, E2TAddress, ""), models.AlarmActionRaise)
+	assert.Nil(t, err)
+	httpClientMock.AssertNotCalled(t, "Post", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAlarmManagerClientDoesNotBlockOnSlowAlarmManager(t *testing.T) {
+	logger := initLog(t)
+	config := &configuration.Configuration{}
+	config.AlarmManager.BaseUrl = "http://localhost:8080/ric/v1/"
+	httpClientMock := &mocks.HttpClientMock{}
+	client := NewAlarmManagerClient(logger, config, httpClientMock)
+
+	posting := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	httpClientMock.On("Post", mock.Anything, "application/json", mock.Anything).Run(func(mock.Arguments) {
+		select {
+		case posting <- struct{}{}:
+		default:
+		}
+		<-release
+	}).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	alarm := models.NewAlarm(models.E2TKeepAliveLostAlarmId, models.AlarmSeverityCritical, E2TAddress, "")
+	assert.Nil(t, client.Raise(alarm))
+	<-posting
+
+	// the alarm states stay available while the sender waits for the Alarm Manager
+	key := alarmKey{specificProblem: alarm.SpecificProblem, identifyingInfo: alarm.IdentifyingInfo}
+	assert.False(t, client.isInState(key, true))
+
+	for i := 0; i < alarmQueueSize; i++ {
+		assert.Nil(t, client.Raise(alarm))
+	}
+	assert.IsType(t, &e2managererrors.AlarmManagerError{}, client.Raise(alarm))
+}
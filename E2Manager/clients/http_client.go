@@ -22,11 +22,17 @@ package clients
 import (
 	"io"
 	"net/http"
+	"time"
 )
 
+// HttpClientTimeout bounds every request, so that an unresponsive Routing Manager or Alarm Manager never blocks a flow
+const HttpClientTimeout = 10 * time.Second
+
 type IHttpClient interface {
+	Get(url string) (resp *http.Response, err error)
 	Post(url, contentType string, body io.Reader) (resp *http.Response, err error)
 	Delete(url, contentType string, body io.Reader) (resp *http.Response, err error)
+	Do(req *http.Request) (resp *http.Response, err error)
 }
 
 type HttpClient struct {
@@ -35,7 +41,7 @@ type HttpClient struct {
 
 func NewHttpClient() *HttpClient {
 	return &HttpClient{
-		&http.Client{},
+		&http.Client{Timeout: HttpClientTimeout},
 	}
 }
 
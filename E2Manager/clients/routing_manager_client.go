@@ -21,12 +21,18 @@ package clients
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
+	"e2mgr/metrics"
 	"e2mgr/models"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 const (
@@ -34,12 +40,21 @@ const (
 	AssociateRanToE2TInstanceApiSuffix = "associate-ran-to-e2t"
 	DissociateRanE2TInstanceApiSuffix  = "dissociate-ran"
 	DeleteE2TInstanceApiSuffix         = "e2t"
+	HealthCheckApiPath                 = "../health"
 )
 
+// RoutingManagerAlarmIdentifyingInfo identifies the routing manager unreachable alarm. It does not change with
+// routingManager.baseUrl, so that the alarm raised before the base url is reloaded is the one cleared after
+const RoutingManagerAlarmIdentifyingInfo = "routing-manager"
+
+// HealthCheckTimeout is much shorter than HttpClientTimeout, so that the readiness probe answers before the probe itself times out
+const HealthCheckTimeout = 2 * time.Second
+
 type RoutingManagerClient struct {
-	logger     *logger.Logger
-	config     *configuration.Configuration
-	httpClient IHttpClient
+	logger             *logger.Logger
+	config             *configuration.Configuration
+	httpClient         IHttpClient
+	alarmManagerClient IAlarmManagerClient
 }
 
 type IRoutingManagerClient interface {
@@ -48,20 +63,22 @@ type IRoutingManagerClient interface {
 	DissociateRanE2TInstance(e2tAddress string, ranName string) error
 	DissociateAllRans(e2tAddresses []string) error
 	DeleteE2TInstance(e2tAddress string, ransToBeDissociated []string) error
+	IsReachable() bool
 }
 
-func NewRoutingManagerClient(logger *logger.Logger, config *configuration.Configuration, httpClient IHttpClient) *RoutingManagerClient {
+func NewRoutingManagerClient(logger *logger.Logger, config *configuration.Configuration, httpClient IHttpClient, alarmManagerClient IAlarmManagerClient) *RoutingManagerClient {
 	return &RoutingManagerClient{
-		logger:     logger,
-		config:     config,
-		httpClient: httpClient,
+		logger:             logger,
+		config:             config,
+		httpClient:         httpClient,
+		alarmManagerClient: alarmManagerClient,
 	}
 }
 
 func (c *RoutingManagerClient) AddE2TInstance(e2tAddress string) error {
 
 	data := models.NewRoutingManagerE2TData(e2tAddress)
-	url := c.config.RoutingManager.BaseUrl + AddE2TInstanceApiSuffix
+	url := c.config.GetRoutingManagerBaseUrl() + AddE2TInstanceApiSuffix
 
 	return c.PostMessage(url, data)
 }
@@ -69,7 +86,7 @@ func (c *RoutingManagerClient) AddE2TInstance(e2tAddress string) error {
 func (c *RoutingManagerClient) AssociateRanToE2TInstance(e2tAddress string, ranName string) error {
 
 	data := models.RoutingManagerE2TDataList{models.NewRoutingManagerE2TData(e2tAddress, ranName)}
-	url := c.config.RoutingManager.BaseUrl + AssociateRanToE2TInstanceApiSuffix
+	url := c.config.GetRoutingManagerBaseUrl() + AssociateRanToE2TInstanceApiSuffix
 
 	return c.PostMessage(url, data)
 }
@@ -77,7 +94,7 @@ func (c *RoutingManagerClient) AssociateRanToE2TInstance(e2tAddress string, ranN
 func (c *RoutingManagerClient) DissociateRanE2TInstance(e2tAddress string, ranName string) error {
 
 	data := models.RoutingManagerE2TDataList{models.NewRoutingManagerE2TData(e2tAddress, ranName)}
-	url := c.config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
+	url := c.config.GetRoutingManagerBaseUrl() + DissociateRanE2TInstanceApiSuffix
 
 	return c.PostMessage(url, data)
 }
@@ -85,17 +102,47 @@ func (c *RoutingManagerClient) DissociateRanE2TInstance(e2tAddress string, ranNa
 func (c *RoutingManagerClient) DissociateAllRans(e2tAddresses []string) error {
 
 	data := mapE2TAddressesToE2DataList(e2tAddresses)
-	url := c.config.RoutingManager.BaseUrl + DissociateRanE2TInstanceApiSuffix
+	url := c.config.GetRoutingManagerBaseUrl() + DissociateRanE2TInstanceApiSuffix
 
 	return c.PostMessage(url, data)
 }
 
 func (c *RoutingManagerClient) DeleteE2TInstance(e2tAddress string, ransTobeDissociated []string) error {
 	data := models.NewRoutingManagerDeleteRequestModel(e2tAddress, ransTobeDissociated, nil)
-	url := c.config.RoutingManager.BaseUrl + DeleteE2TInstanceApiSuffix
+	url := c.config.GetRoutingManagerBaseUrl() + DeleteE2TInstanceApiSuffix
 	return c.DeleteMessage(url, data)
 }
 
+// IsReachable tells whether the routing manager answers its health check URL, whatever the status code
+func (c *RoutingManagerClient) IsReachable() bool {
+	rawBaseUrl := c.config.GetRoutingManagerBaseUrl()
+	baseUrl, err := url.Parse(rawBaseUrl)
+	if err != nil {
+		c.logger.Errorf("#RoutingManagerClient.IsReachable - invalid routing manager base url: %s. Error: %s", rawBaseUrl, err)
+		return false
+	}
+
+	healthUrl := baseUrl.ResolveReference(&url.URL{Path: HealthCheckApiPath}).String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), HealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthUrl, nil)
+	if err != nil {
+		c.logger.Errorf("#RoutingManagerClient.IsReachable - failed creating the health check request, url: %s. Error: %s", healthUrl, err)
+		return false
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logger.Warnf("#RoutingManagerClient.IsReachable - routing manager is unreachable, url: %s. Error: %s", healthUrl, err)
+		return false
+	}
+
+	_ = resp.Body.Close()
+	return true
+}
+
 func (c *RoutingManagerClient) sendMessage(method string, url string, data interface{}) error {
 	marshaled, err := json.Marshal(data)
 
@@ -108,17 +155,26 @@ func (c *RoutingManagerClient) sendMessage(method string, url string, data inter
 
 	var resp *http.Response
 
+	endpoint := strings.TrimPrefix(url, c.config.GetRoutingManagerBaseUrl())
+	start := time.Now()
+
 	if method == http.MethodPost {
 		resp, err = c.httpClient.Post(url, "application/json", body)
 	} else if method == http.MethodDelete {
 		resp, err = c.httpClient.Delete(url, "application/json", body)
 	}
 
+	metrics.RoutingManagerRequestDuration.WithLabelValues(method, endpoint).Observe(time.Since(start).Seconds())
+
 	if err != nil {
 		c.logger.Errorf("#RoutingManagerClient.sendMessage - failed sending request. error: %s", err)
+		metrics.RoutingManagerErrors.WithLabelValues(method, endpoint).Inc()
+		c.setUnreachableAlarm(true, err.Error())
 		return e2managererrors.NewRoutingManagerError()
 	}
 
+	c.setUnreachableAlarm(false, "")
+
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
@@ -129,9 +185,26 @@ func (c *RoutingManagerClient) sendMessage(method string, url string, data inter
 	}
 
 	c.logger.Errorf("[Routing Manager -> E2 Manager] #RoutingManagerClient.sendMessage - failure. http status code: %d", resp.StatusCode)
+	metrics.RoutingManagerErrors.WithLabelValues(method, endpoint).Inc()
 	return e2managererrors.NewRoutingManagerError()
 }
 
+func (c *RoutingManagerClient) setUnreachableAlarm(isUnreachable bool, reason string) {
+	alarm := models.NewAlarm(models.RoutingManagerUnreachableAlarmId, models.AlarmSeverityMajor, RoutingManagerAlarmIdentifyingInfo,
+		fmt.Sprintf("base url: %s, error: %s", c.config.GetRoutingManagerBaseUrl(), reason))
+
+	var err error
+	if isUnreachable {
+		err = c.alarmManagerClient.Raise(alarm)
+	} else {
+		err = c.alarmManagerClient.Clear(alarm)
+	}
+
+	if err != nil {
+		c.logger.Warnf("#RoutingManagerClient.setUnreachableAlarm - failed updating routing manager alarm. error: %s", err)
+	}
+}
+
 func (c *RoutingManagerClient) DeleteMessage(url string, data interface{}) error {
 	return c.sendMessage(http.MethodDelete, url, data)
 }
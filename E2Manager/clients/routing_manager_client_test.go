@@ -29,9 +29,13 @@ import (
 	"encoding/json"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 const E2TAddress = "10.0.2.15:38000"
@@ -43,7 +47,8 @@ func initRoutingManagerClientTest(t *testing.T) (*RoutingManagerClient, *mocks.H
 	config := &configuration.Configuration{}
 	config.RoutingManager.BaseUrl = "http://iltlv740.intl.att.com:8080/ric/v1/handles/"
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := NewRoutingManagerClient(logger, config, httpClientMock)
+	alarmManagerClient := NewAlarmManagerClient(logger, config, httpClientMock)
+	rmClient := NewRoutingManagerClient(logger, config, httpClientMock, alarmManagerClient)
 	return rmClient, httpClientMock, config
 }
 
@@ -110,6 +115,69 @@ func TestAddE2TInstanceHttpPostFailure(t *testing.T) {
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
 }
 
+func TestAddE2TInstanceHttpPostFailureRaisesAlarm(t *testing.T) {
+	rmClient, httpClientMock, config := initRoutingManagerClientTest(t)
+	config.AlarmManager.BaseUrl = "http://alarmmanager:8080/ric/v1/"
+
+	data := models.NewRoutingManagerE2TData(E2TAddress)
+	marshaled, _ := json.Marshal(data)
+	body := bytes.NewBuffer(marshaled)
+	url := config.RoutingManager.BaseUrl + "e2t"
+	alarmUrl := config.AlarmManager.BaseUrl + AlarmsApiSuffix
+	httpClientMock.On("Post", url, "application/json", body).Return(&http.Response{}, errors.New("error"))
+	alarmSent := make(chan struct{})
+	httpClientMock.On("Post", alarmUrl, "application/json", mock.Anything).Run(func(mock.Arguments) {
+		close(alarmSent)
+	}).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+	err := rmClient.AddE2TInstance(E2TAddress)
+	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
+
+	select {
+	case <-alarmSent:
+	case <-time.After(time.Second):
+		t.Errorf("#TestAddE2TInstanceHttpPostFailureRaisesAlarm - alarm was not sent")
+	}
+}
+
+func TestUnreachableAlarmClearedAfterBaseUrlChange(t *testing.T) {
+	rmClient, httpClientMock, config := initRoutingManagerClientTest(t)
+	config.AlarmManager.BaseUrl = "http://alarmmanager:8080/ric/v1/"
+
+	data := models.NewRoutingManagerE2TData(E2TAddress)
+	marshaled, _ := json.Marshal(data)
+	alarmUrl := config.AlarmManager.BaseUrl + AlarmsApiSuffix
+	isRoutingManagerAlarm := mock.MatchedBy(func(body *bytes.Buffer) bool {
+		return strings.Contains(body.String(), `"identifyingInfo":"`+RoutingManagerAlarmIdentifyingInfo+`"`)
+	})
+	httpClientMock.On("Post", config.RoutingManager.BaseUrl+"e2t", "application/json", bytes.NewBuffer(marshaled)).Return(&http.Response{}, errors.New("error"))
+	alarmRaised := make(chan struct{})
+	httpClientMock.On("Post", alarmUrl, "application/json", isRoutingManagerAlarm).Run(func(mock.Arguments) {
+		close(alarmRaised)
+	}).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	_ = rmClient.AddE2TInstance(E2TAddress)
+	select {
+	case <-alarmRaised:
+	case <-time.After(time.Second):
+		t.Errorf("#TestUnreachableAlarmClearedAfterBaseUrlChange - alarm was not raised")
+	}
+
+	config.RoutingManager.BaseUrl = "http://routingmanager:8080/ric/v1/handles/"
+	httpClientMock.On("Post", config.RoutingManager.BaseUrl+"e2t", "application/json", bytes.NewBuffer(marshaled)).Return(&http.Response{StatusCode: http.StatusCreated}, nil)
+	alarmCleared := make(chan struct{})
+	httpClientMock.On("Delete", alarmUrl, "application/json", isRoutingManagerAlarm).Run(func(mock.Arguments) {
+		close(alarmCleared)
+	}).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	err := rmClient.AddE2TInstance(E2TAddress)
+	assert.Nil(t, err)
+	select {
+	case <-alarmCleared:
+	case <-time.After(time.Second):
+		t.Errorf("#TestUnreachableAlarmClearedAfterBaseUrlChange - alarm was not cleared")
+	}
+}
+
 func TestAddE2TInstanceFailure(t *testing.T) {
 	rmClient, httpClientMock, config := initRoutingManagerClientTest(t)
 
@@ -264,3 +332,41 @@ func initLog(t *testing.T) *logger.Logger {
 //	err := rmClient.AddE2TInstance(E2TAddress)
 //	assert.Nil(t, err)
 //}
+
+func TestIsReachableSuccess(t *testing.T) {
+	rmClient, httpClientMock, _ := initRoutingManagerClientTest(t)
+
+	respBody := ioutil.NopCloser(bytes.NewBufferString(""))
+	httpClientMock.On("Do", mock.MatchedBy(isHealthCheckRequest)).Return(&http.Response{StatusCode: http.StatusOK, Body: respBody}, nil)
+	assert.True(t, rmClient.IsReachable())
+}
+
+func TestIsReachableFailure(t *testing.T) {
+	rmClient, httpClientMock, _ := initRoutingManagerClientTest(t)
+
+	httpClientMock.On("Do", mock.MatchedBy(isHealthCheckRequest)).Return(&http.Response{}, errors.New("connection refused"))
+	assert.False(t, rmClient.IsReachable())
+}
+
+func TestIsReachableUnresponsiveRoutingManager(t *testing.T) {
+	rmClient, _, _ := initRoutingManagerClientTest(t)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	rmClient.config.RoutingManager.BaseUrl = server.URL + "/ric/v1/handles/"
+	rmClient.httpClient = NewHttpClient()
+
+	start := time.Now()
+	assert.False(t, rmClient.IsReachable())
+	assert.True(t, time.Since(start) < HttpClientTimeout)
+}
+
+// isHealthCheckRequest matches the health check request, which must be bounded by a deadline
+func isHealthCheckRequest(req *http.Request) bool {
+	_, hasDeadline := req.Context().Deadline()
+	return hasDeadline && req.Method == http.MethodGet && req.URL.String() == "http://iltlv740.intl.att.com:8080/ric/v1/health"
+}
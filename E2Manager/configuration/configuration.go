@@ -23,15 +23,134 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
+const defaultE2SetupTimeToWaitSec = 60
+
+// E2AP TimeToWait supports only these values (in seconds)
+var validE2SetupTimeToWaitSec = map[int]struct{}{1: {}, 2: {}, 5: {}, 10: {}, 20: {}, 60: {}}
+
+const (
+	defaultErrorIndicationAction          = "revert"
+	defaultErrorIndicationMaxStoredPerRan = 10
+)
+
+const defaultRicServiceUpdateTimeToWaitSec = 10
+
+const defaultE2NodeConfigUpdateTimeToWaitSec = 10
+
+const defaultRicServiceQueryResponseDeadlineSec = 10
+
+const (
+	defaultRanLivenessResponseThresholdSec  = 30
+	defaultRanLivenessMaxMissedHealthChecks = 3
+	defaultRanLivenessAction                = "none"
+)
+
+const (
+	defaultLeaderElectionLeaseDurationSec = 15
+	defaultLeaderElectionRenewIntervalSec = 5
+)
+
+const defaultRanListSyncReconcileIntervalSec = 60
+
+const defaultX2ResetTimeoutSec = 10
+
+const defaultE2ResetResponseTimeoutSec = 10
+
+const defaultDuplicateGlobalNbIdAction = "reject"
+
+var validDuplicateGlobalNbIdActions = map[string]struct{}{"reject": {}, "flag": {}}
+
+var validRanLivenessActions = map[string]struct{}{"none": {}, "reset": {}, "disconnect": {}}
+
+var validErrorIndicationActions = map[string]struct{}{"ignore": {}, "log": {}, "revert": {}, "reset": {}, "disconnect": {}}
+
 type RnibWriterConfig struct {
 	StateChangeMessageChannel     string
 	RanManipulationMessageChannel string
 }
 
+type NbIdRange struct {
+	Min uint64
+	Max uint64
+}
+
+type E2SetupAdmissionConfig struct {
+	TimeToWaitSec             int
+	AllowedPlmnIds            []string
+	DeniedPlmnIds             []string
+	AllowedNodeTypes          []string
+	DeniedNodeTypes           []string
+	AllowedNbIdRanges         []NbIdRange
+	AllowedRanFunctionOids    []string
+	DeniedRanFunctionOids     []string
+	MaxNodesPerE2T            int
+	DuplicateGlobalNbIdAction string
+}
+
+// ErrorIndicationConfig : CauseActions maps an E2AP cause ("group/value") or a whole cause group ("group") to an action
+type ErrorIndicationConfig struct {
+	DefaultAction   string
+	CauseActions    map[string]string
+	MaxStoredPerRan int
+}
+
+// RicServiceUpdateConfig : KnownRanFunctionOids restricts the RAN functions accepted in E2 setup and RIC service update, when empty any OID is accepted
+type RicServiceUpdateConfig struct {
+	TimeToWaitSec        int
+	KnownRanFunctionOids []string
+}
+
+type E2NodeConfigUpdateConfig struct {
+	TimeToWaitSec int
+}
+
+// RicServiceQueryConfig : every IntervalSec, delayed by up to JitterSec, the connected RANs are queried, 0 disables the scheduled queries.
+// A RAN which does not answer within ResponseDeadlineSec is reported as unresponsive
+type RicServiceQueryConfig struct {
+	IntervalSec         int
+	JitterSec           int
+	ResponseDeadlineSec int
+}
+
+// RanLivenessConfig : every CheckIntervalSec the health check timestamps of the connected RANs are compared, 0 disables the check.
+// A health check which is not answered within ResponseThresholdSec is missed, after MaxMissedHealthChecks consecutive misses
+// the RAN is unresponsive and Action (none, reset or disconnect) is taken
+type RanLivenessConfig struct {
+	CheckIntervalSec      int
+	ResponseThresholdSec  int
+	MaxMissedHealthChecks int
+	Action                string
+}
+
+// RanListSyncConfig : the in-memory RAN list is compared with rNib every ReconcileIntervalSec, and whenever rNib publishes
+// a RAN change. 0 disables the periodic comparison
+type RanListSyncConfig struct {
+	ReconcileIntervalSec int
+}
+
+// E2ResetConfig : a RAN which does not answer a RIC initiated E2 Reset within ResponseTimeoutSec is disconnected
+type E2ResetConfig struct {
+	ResponseTimeoutSec int
+}
+
+// X2ResetConfig : a RAN which does not answer a RIC initiated X2 Reset within TimeoutSec is marked with a timed out X2 Reset
+type X2ResetConfig struct {
+	TimeoutSec int
+}
+
+// LeaderElectionConfig : when Enabled, the replicas compete for a lease kept in SDL. The leader renews it every RenewIntervalSec,
+// a follower takes over once the lease has not been renewed for LeaseDurationSec
+type LeaderElectionConfig struct {
+	Enabled          bool
+	LeaseDurationSec int
+	RenewIntervalSec int
+}
+
 type Configuration struct {
 	Logging struct {
 		LogLevel string
@@ -46,8 +165,13 @@ type Configuration struct {
 	RoutingManager struct {
 		BaseUrl string
 	}
+	AlarmManager struct {
+		BaseUrl                   string
+		RanUnderResetThresholdSec int
+	}
 
 	NotificationResponseBuffer   int
+	NotificationWorkers          int
 	BigRedButtonTimeoutSec       int
 	MaxRnibConnectionAttempts    int
 	RnibRetryIntervalMs          int
@@ -55,90 +179,534 @@ type Configuration struct {
 	KeepAliveDelayMs             int
 	E2TInstanceDeletionTimeoutMs int
 	E2ResetTimeOutSec            int
+	ProcedureTimeoutSec          int
+	ShutdownTimeoutSec           int
 	GlobalRicId                  struct {
 		RicId string
 		Mcc   string
 		Mnc   string
 	}
-	RnibWriter RnibWriterConfig
+	RnibWriter         RnibWriterConfig
+	E2SetupAdmission   E2SetupAdmissionConfig
+	ErrorIndication    ErrorIndicationConfig
+	RicServiceUpdate   RicServiceUpdateConfig
+	E2NodeConfigUpdate E2NodeConfigUpdateConfig
+	RicServiceQuery    RicServiceQueryConfig
+	RanLiveness        RanLivenessConfig
+	LeaderElection     LeaderElectionConfig
+	RanListSync        RanListSyncConfig
+	X2Reset            X2ResetConfig
+	E2Reset            E2ResetConfig
 }
 
+// ParseConfiguration reads the configuration file, with the environment overrides applied, and panics on the first
+// error found. LoadConfiguration reports every error instead
 func ParseConfiguration() *Configuration {
-	viper.SetConfigType("yaml")
-	viper.SetConfigName("configuration")
-	viper.AddConfigPath("E2Manager/resources/")
-	viper.AddConfigPath("./resources/")     //For production
-	viper.AddConfigPath("../resources/")    //For test under Docker
-	viper.AddConfigPath("../../resources/") //For test under Docker
-	err := viper.ReadInConfig()
+	settings, err := readSettings("")
 	if err != nil {
-		panic(fmt.Sprintf("#configuration.ParseConfiguration - failed to read configuration file: %s\n", err))
+		panic(err.Error())
+	}
+
+	config, errs := populateConfiguration(settings)
+	if len(errs) != 0 {
+		panic(errs[0])
 	}
 
+	return config
+}
+
+// newConfigurationFileViper locates the configuration file. It does not use the global viper, which app.main points
+// to the file given with -f
+func newConfigurationFileViper() *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigName("configuration")
+	v.AddConfigPath("E2Manager/resources/")
+	v.AddConfigPath("./resources/")     //For production
+	v.AddConfigPath("../resources/")    //For test under Docker
+	v.AddConfigPath("../../resources/") //For test under Docker
+	return v
+}
+
+// readSettings reads the given configuration file, or the one found in the search paths when fileName is empty
+func readSettings(fileName string) (*viper.Viper, error) {
+	file := newConfigurationFileViper()
+	if len(fileName) != 0 {
+		file.SetConfigFile(fileName)
+	}
+	err := file.ReadInConfig()
+	if err != nil {
+		return nil, fmt.Errorf("#configuration.ParseConfiguration - failed to read configuration file: %s\n", err)
+	}
+
+	settings := file.AllSettings()
+	applyEnvironmentOverrides(settings)
+
+	v := viper.New()
+	_ = v.MergeConfigMap(settings)
+	return v, nil
+}
+
+// populateConfiguration returns the configuration and the errors found, in the order of the entries
+func populateConfiguration(v *viper.Viper) (*Configuration, []string) {
 	config := Configuration{}
-	config.populateRmrConfig(viper.Sub("rmr"))
-	config.populateHttpConfig(viper.Sub("http"))
-	config.populateLoggingConfig(viper.Sub("logging"))
-	config.populateRoutingManagerConfig(viper.Sub("routingManager"))
-	config.NotificationResponseBuffer = viper.GetInt("notificationResponseBuffer")
-	config.BigRedButtonTimeoutSec = viper.GetInt("bigRedButtonTimeoutSec")
-	config.MaxRnibConnectionAttempts = viper.GetInt("maxRnibConnectionAttempts")
-	config.RnibRetryIntervalMs = viper.GetInt("rnibRetryIntervalMs")
-	config.KeepAliveResponseTimeoutMs = viper.GetInt("keepAliveResponseTimeoutMs")
-	config.KeepAliveDelayMs = viper.GetInt("KeepAliveDelayMs")
-	config.E2TInstanceDeletionTimeoutMs = viper.GetInt("e2tInstanceDeletionTimeoutMs")
+	var errs []string
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	collect(config.populateRmrConfig(v.Sub("rmr")))
+	collect(config.populateHttpConfig(v.Sub("http")))
+	collect(config.populateLoggingConfig(v.Sub("logging")))
+	collect(config.populateRoutingManagerConfig(v.Sub("routingManager")))
+	collect(config.populateAlarmManagerConfig(v.Sub("alarmManager")))
+	config.NotificationResponseBuffer = v.GetInt("notificationResponseBuffer")
+	config.NotificationWorkers = v.GetInt("notificationWorkers")
+	config.BigRedButtonTimeoutSec = v.GetInt("bigRedButtonTimeoutSec")
+	config.MaxRnibConnectionAttempts = v.GetInt("maxRnibConnectionAttempts")
+	config.RnibRetryIntervalMs = v.GetInt("rnibRetryIntervalMs")
+	config.KeepAliveResponseTimeoutMs = v.GetInt("keepAliveResponseTimeoutMs")
+	config.KeepAliveDelayMs = v.GetInt("KeepAliveDelayMs")
+	config.E2TInstanceDeletionTimeoutMs = v.GetInt("e2tInstanceDeletionTimeoutMs")
 	//E2ResetTimeOutSec : timeout expiry threshold required for handling reset and thus the time for which the nodeb is under reset connection state.
-	config.E2ResetTimeOutSec = viper.GetInt("e2ResetTimeOutSec")
-	config.populateGlobalRicIdConfig(viper.Sub("globalRicId"))
-	config.populateRnibWriterConfig(viper.Sub("rnibWriter"))
-	return &config
+	config.E2ResetTimeOutSec = v.GetInt("e2ResetTimeOutSec")
+	config.ProcedureTimeoutSec = v.GetInt("procedureTimeoutSec")
+	//ShutdownTimeoutSec : time given to the HTTP requests in progress to complete once E2 Manager is asked to stop.
+	config.ShutdownTimeoutSec = v.GetInt("shutdownTimeoutSec")
+	collect(config.populateGlobalRicIdConfig(v.Sub("globalRicId")))
+	collect(config.populateRnibWriterConfig(v.Sub("rnibWriter")))
+	collect(config.populateE2SetupAdmissionConfig(v.Sub("e2SetupAdmission")))
+	collect(config.populateErrorIndicationConfig(v.Sub("errorIndication")))
+	collect(config.populateRicServiceUpdateConfig(v.Sub("ricServiceUpdate")))
+	collect(config.populateE2NodeConfigUpdateConfig(v.Sub("e2NodeConfigUpdate")))
+	collect(config.populateRicServiceQueryConfig(v.Sub("ricServiceQuery")))
+	collect(config.populateRanLivenessConfig(v.Sub("ranLiveness")))
+	collect(config.populateLeaderElectionConfig(v.Sub("leaderElection")))
+	collect(config.populateRanListSyncConfig(v.Sub("ranListSync")))
+	collect(config.populateX2ResetConfig(v.Sub("x2Reset")))
+	collect(config.populateE2ResetConfig(v.Sub("e2Reset")))
+	return &config, errs
 }
 
-func (c *Configuration) populateLoggingConfig(logConfig *viper.Viper) {
+func (c *Configuration) populateLoggingConfig(logConfig *viper.Viper) error {
 	if logConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateLoggingConfig - failed to populate logging configuration: The entry 'logging' not found\n"))
+		return errors.New("#configuration.populateLoggingConfig - failed to populate logging configuration: The entry 'logging' not found\n")
 	}
 	c.Logging.LogLevel = logConfig.GetString("logLevel")
+	return nil
 }
 
-func (c *Configuration) populateHttpConfig(httpConfig *viper.Viper) {
+func (c *Configuration) populateHttpConfig(httpConfig *viper.Viper) error {
 	if httpConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateHttpConfig - failed to populate HTTP configuration: The entry 'http' not found\n"))
+		return errors.New("#configuration.populateHttpConfig - failed to populate HTTP configuration: The entry 'http' not found\n")
 	}
 	c.Http.Port = httpConfig.GetInt("port")
+	return nil
 }
 
-func (c *Configuration) populateRmrConfig(rmrConfig *viper.Viper) {
+func (c *Configuration) populateRmrConfig(rmrConfig *viper.Viper) error {
 	if rmrConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateRmrConfig - failed to populate RMR configuration: The entry 'rmr' not found\n"))
+		return errors.New("#configuration.populateRmrConfig - failed to populate RMR configuration: The entry 'rmr' not found\n")
 	}
 	c.Rmr.Port = rmrConfig.GetInt("port")
 	c.Rmr.MaxMsgSize = rmrConfig.GetInt("maxMsgSize")
+	return nil
 }
 
-func (c *Configuration) populateRoutingManagerConfig(rmConfig *viper.Viper) {
+func (c *Configuration) populateRoutingManagerConfig(rmConfig *viper.Viper) error {
 	if rmConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateRoutingManagerConfig - failed to populate Routing Manager configuration: The entry 'routingManager' not found\n"))
+		return errors.New("#configuration.populateRoutingManagerConfig - failed to populate Routing Manager configuration: The entry 'routingManager' not found\n")
 	}
 	c.RoutingManager.BaseUrl = rmConfig.GetString("baseUrl")
+	return nil
+}
+
+// populateAlarmManagerConfig : the 'alarmManager' entry is optional, when missing alarms are only logged.
+func (c *Configuration) populateAlarmManagerConfig(amConfig *viper.Viper) error {
+	if amConfig == nil {
+		return nil
+	}
+	c.AlarmManager.BaseUrl = amConfig.GetString("baseUrl")
+	c.AlarmManager.RanUnderResetThresholdSec = amConfig.GetInt("ranUnderResetThresholdSec")
+	return nil
 }
 
-func (c *Configuration) populateRnibWriterConfig(rnibWriterConfig *viper.Viper) {
+func (c *Configuration) populateRnibWriterConfig(rnibWriterConfig *viper.Viper) error {
 	if rnibWriterConfig == nil {
-		panic(fmt.Sprintf("#configuration.populateRnibWriterConfig - failed to populate Rnib Writer configuration: The entry 'rnibWriter' not found\n"))
+		return errors.New("#configuration.populateRnibWriterConfig - failed to populate Rnib Writer configuration: The entry 'rnibWriter' not found\n")
 	}
 	c.RnibWriter.StateChangeMessageChannel = rnibWriterConfig.GetString("stateChangeMessageChannel")
 	c.RnibWriter.RanManipulationMessageChannel = rnibWriterConfig.GetString("ranManipulationMessageChannel")
+	return nil
+}
+
+// populateE2SetupAdmissionConfig : the 'e2SetupAdmission' entry is optional, when missing every identifiable E2 node is admitted.
+func (c *Configuration) populateE2SetupAdmissionConfig(admissionConfig *viper.Viper) error {
+	c.E2SetupAdmission.TimeToWaitSec = defaultE2SetupTimeToWaitSec
+	c.E2SetupAdmission.DuplicateGlobalNbIdAction = defaultDuplicateGlobalNbIdAction
+
+	if admissionConfig == nil {
+		return nil
+	}
+
+	err := validateE2SetupAdmissionConfig(admissionConfig)
+	if err != nil {
+		return err
+	}
+
+	if admissionConfig.IsSet("timeToWaitSec") {
+		c.E2SetupAdmission.TimeToWaitSec = admissionConfig.GetInt("timeToWaitSec")
+	}
+	c.E2SetupAdmission.AllowedPlmnIds = admissionConfig.GetStringSlice("allowedPlmnIds")
+	c.E2SetupAdmission.DeniedPlmnIds = admissionConfig.GetStringSlice("deniedPlmnIds")
+	c.E2SetupAdmission.AllowedNodeTypes = admissionConfig.GetStringSlice("allowedNodeTypes")
+	c.E2SetupAdmission.DeniedNodeTypes = admissionConfig.GetStringSlice("deniedNodeTypes")
+	c.E2SetupAdmission.AllowedRanFunctionOids = admissionConfig.GetStringSlice("allowedRanFunctionOids")
+	c.E2SetupAdmission.DeniedRanFunctionOids = admissionConfig.GetStringSlice("deniedRanFunctionOids")
+	c.E2SetupAdmission.MaxNodesPerE2T = admissionConfig.GetInt("maxNodesPerE2T")
+	_ = admissionConfig.UnmarshalKey("allowedNbIdRanges", &c.E2SetupAdmission.AllowedNbIdRanges)
+
+	if admissionConfig.IsSet("duplicateGlobalNbIdAction") {
+		c.E2SetupAdmission.DuplicateGlobalNbIdAction = admissionConfig.GetString("duplicateGlobalNbIdAction")
+	}
+	return nil
+}
+
+func validateE2SetupAdmissionConfig(admissionConfig *viper.Viper) error {
+
+	if admissionConfig.IsSet("timeToWaitSec") {
+		timeToWaitSec := admissionConfig.GetInt("timeToWaitSec")
+		if _, ok := validE2SetupTimeToWaitSec[timeToWaitSec]; !ok {
+			return errors.New("#configuration.validateE2SetupAdmissionConfig - timeToWaitSec should be one of 1, 2, 5, 10, 20, 60\n")
+		}
+	}
+
+	if admissionConfig.IsSet("duplicateGlobalNbIdAction") {
+		if _, ok := validDuplicateGlobalNbIdActions[admissionConfig.GetString("duplicateGlobalNbIdAction")]; !ok {
+			return errors.New("#configuration.validateE2SetupAdmissionConfig - duplicateGlobalNbIdAction should be one of reject, flag\n")
+		}
+	}
+
+	if admissionConfig.GetInt("maxNodesPerE2T") < 0 {
+		return errors.New("#configuration.validateE2SetupAdmissionConfig - maxNodesPerE2T is negative\n")
+	}
+
+	var ranges []NbIdRange
+	if err := admissionConfig.UnmarshalKey("allowedNbIdRanges", &ranges); err != nil {
+		return errors.New("#configuration.validateE2SetupAdmissionConfig - allowedNbIdRanges is malformed\n")
+	}
+
+	for _, r := range ranges {
+		if r.Min > r.Max {
+			return errors.New("#configuration.validateE2SetupAdmissionConfig - allowedNbIdRanges min is greater than max\n")
+		}
+	}
+
+	return nil
+}
+
+// populateErrorIndicationConfig : the 'errorIndication' entry is optional, when missing every Error Indication reverts the procedure it refers to.
+func (c *Configuration) populateErrorIndicationConfig(errorIndicationConfig *viper.Viper) error {
+	c.ErrorIndication.DefaultAction = defaultErrorIndicationAction
+	c.ErrorIndication.CauseActions = map[string]string{}
+	c.ErrorIndication.MaxStoredPerRan = defaultErrorIndicationMaxStoredPerRan
+
+	if errorIndicationConfig == nil {
+		return nil
+	}
+
+	err := validateErrorIndicationConfig(errorIndicationConfig)
+	if err != nil {
+		return err
+	}
+
+	if errorIndicationConfig.IsSet("defaultAction") {
+		c.ErrorIndication.DefaultAction = strings.ToLower(errorIndicationConfig.GetString("defaultAction"))
+	}
+	if errorIndicationConfig.IsSet("maxStoredPerRan") {
+		c.ErrorIndication.MaxStoredPerRan = errorIndicationConfig.GetInt("maxStoredPerRan")
+	}
+	for cause, action := range errorIndicationConfig.GetStringMapString("causeActions") {
+		c.ErrorIndication.CauseActions[strings.ToLower(cause)] = strings.ToLower(action)
+	}
+	return nil
+}
+
+func validateErrorIndicationConfig(errorIndicationConfig *viper.Viper) error {
+
+	if errorIndicationConfig.IsSet("defaultAction") && !isValidErrorIndicationAction(errorIndicationConfig.GetString("defaultAction")) {
+		return errors.New("#configuration.validateErrorIndicationConfig - defaultAction should be one of ignore, log, revert, reset, disconnect\n")
+	}
+
+	if errorIndicationConfig.GetInt("maxStoredPerRan") < 0 {
+		return errors.New("#configuration.validateErrorIndicationConfig - maxStoredPerRan is negative\n")
+	}
+
+	for cause, action := range errorIndicationConfig.GetStringMapString("causeActions") {
+		if !isValidErrorIndicationAction(action) {
+			return fmt.Errorf("#configuration.validateErrorIndicationConfig - action of cause %s should be one of ignore, log, revert, reset, disconnect\n", cause)
+		}
+	}
+
+	return nil
+}
+
+// populateRicServiceUpdateConfig : the 'ricServiceUpdate' entry is optional, when missing every RAN function OID is accepted.
+func (c *Configuration) populateRicServiceUpdateConfig(ricServiceUpdateConfig *viper.Viper) error {
+	c.RicServiceUpdate.TimeToWaitSec = defaultRicServiceUpdateTimeToWaitSec
+
+	if ricServiceUpdateConfig == nil {
+		return nil
+	}
+
+	err := validateRicServiceUpdateConfig(ricServiceUpdateConfig)
+	if err != nil {
+		return err
+	}
+
+	if ricServiceUpdateConfig.IsSet("timeToWaitSec") {
+		c.RicServiceUpdate.TimeToWaitSec = ricServiceUpdateConfig.GetInt("timeToWaitSec")
+	}
+	c.RicServiceUpdate.KnownRanFunctionOids = ricServiceUpdateConfig.GetStringSlice("knownRanFunctionOids")
+	return nil
 }
 
-func (c *Configuration) populateGlobalRicIdConfig(globalRicIdConfig *viper.Viper) {
+func validateRicServiceUpdateConfig(ricServiceUpdateConfig *viper.Viper) error {
+
+	if ricServiceUpdateConfig.IsSet("timeToWaitSec") {
+		timeToWaitSec := ricServiceUpdateConfig.GetInt("timeToWaitSec")
+		if _, ok := validE2SetupTimeToWaitSec[timeToWaitSec]; !ok {
+			return errors.New("#configuration.validateRicServiceUpdateConfig - timeToWaitSec should be one of 1, 2, 5, 10, 20, 60\n")
+		}
+	}
+
+	return nil
+}
+
+// populateE2NodeConfigUpdateConfig : the 'e2NodeConfigUpdate' entry is optional, when missing the default time to wait is used.
+func (c *Configuration) populateE2NodeConfigUpdateConfig(e2NodeConfigUpdateConfig *viper.Viper) error {
+	c.E2NodeConfigUpdate.TimeToWaitSec = defaultE2NodeConfigUpdateTimeToWaitSec
+
+	if e2NodeConfigUpdateConfig == nil {
+		return nil
+	}
+
+	err := validateE2NodeConfigUpdateConfig(e2NodeConfigUpdateConfig)
+	if err != nil {
+		return err
+	}
+
+	if e2NodeConfigUpdateConfig.IsSet("timeToWaitSec") {
+		c.E2NodeConfigUpdate.TimeToWaitSec = e2NodeConfigUpdateConfig.GetInt("timeToWaitSec")
+	}
+	return nil
+}
+
+func validateE2NodeConfigUpdateConfig(e2NodeConfigUpdateConfig *viper.Viper) error {
+
+	if e2NodeConfigUpdateConfig.IsSet("timeToWaitSec") {
+		timeToWaitSec := e2NodeConfigUpdateConfig.GetInt("timeToWaitSec")
+		if _, ok := validE2SetupTimeToWaitSec[timeToWaitSec]; !ok {
+			return errors.New("#configuration.validateE2NodeConfigUpdateConfig - timeToWaitSec should be one of 1, 2, 5, 10, 20, 60\n")
+		}
+	}
+
+	return nil
+}
+
+// populateRicServiceQueryConfig : the 'ricServiceQuery' entry is optional, when missing RIC Service Queries are only sent on demand.
+func (c *Configuration) populateRicServiceQueryConfig(ricServiceQueryConfig *viper.Viper) error {
+	c.RicServiceQuery.ResponseDeadlineSec = defaultRicServiceQueryResponseDeadlineSec
+
+	if ricServiceQueryConfig == nil {
+		return nil
+	}
+
+	err := validateRicServiceQueryConfig(ricServiceQueryConfig)
+	if err != nil {
+		return err
+	}
+
+	c.RicServiceQuery.IntervalSec = ricServiceQueryConfig.GetInt("intervalSec")
+	c.RicServiceQuery.JitterSec = ricServiceQueryConfig.GetInt("jitterSec")
+	if ricServiceQueryConfig.IsSet("responseDeadlineSec") {
+		c.RicServiceQuery.ResponseDeadlineSec = ricServiceQueryConfig.GetInt("responseDeadlineSec")
+	}
+	return nil
+}
+
+func validateRicServiceQueryConfig(ricServiceQueryConfig *viper.Viper) error {
+	intervalSec := ricServiceQueryConfig.GetInt("intervalSec")
+	if intervalSec < 0 {
+		return errors.New("#configuration.validateRicServiceQueryConfig - intervalSec should not be negative\n")
+	}
+
+	if ricServiceQueryConfig.GetInt("jitterSec") < 0 {
+		return errors.New("#configuration.validateRicServiceQueryConfig - jitterSec should not be negative\n")
+	}
+
+	if ricServiceQueryConfig.IsSet("responseDeadlineSec") {
+		responseDeadlineSec := ricServiceQueryConfig.GetInt("responseDeadlineSec")
+		if responseDeadlineSec <= 0 || (intervalSec > 0 && responseDeadlineSec > intervalSec) {
+			return errors.New("#configuration.validateRicServiceQueryConfig - responseDeadlineSec should be positive and not greater than intervalSec\n")
+		}
+	}
+
+	return nil
+}
+
+func isValidErrorIndicationAction(action string) bool {
+	_, ok := validErrorIndicationActions[strings.ToLower(action)]
+	return ok
+}
+
+// populateRanLivenessConfig : the 'ranLiveness' entry is optional, when missing the RAN liveness is not monitored.
+func (c *Configuration) populateRanLivenessConfig(ranLivenessConfig *viper.Viper) error {
+	c.RanLiveness.ResponseThresholdSec = defaultRanLivenessResponseThresholdSec
+	c.RanLiveness.MaxMissedHealthChecks = defaultRanLivenessMaxMissedHealthChecks
+	c.RanLiveness.Action = defaultRanLivenessAction
+
+	if ranLivenessConfig == nil {
+		return nil
+	}
+
+	err := validateRanLivenessConfig(ranLivenessConfig)
+	if err != nil {
+		return err
+	}
+
+	c.RanLiveness.CheckIntervalSec = ranLivenessConfig.GetInt("checkIntervalSec")
+	if ranLivenessConfig.IsSet("responseThresholdSec") {
+		c.RanLiveness.ResponseThresholdSec = ranLivenessConfig.GetInt("responseThresholdSec")
+	}
+	if ranLivenessConfig.IsSet("maxMissedHealthChecks") {
+		c.RanLiveness.MaxMissedHealthChecks = ranLivenessConfig.GetInt("maxMissedHealthChecks")
+	}
+	if ranLivenessConfig.IsSet("action") {
+		c.RanLiveness.Action = strings.ToLower(ranLivenessConfig.GetString("action"))
+	}
+	return nil
+}
+
+func validateRanLivenessConfig(ranLivenessConfig *viper.Viper) error {
+
+	if ranLivenessConfig.GetInt("checkIntervalSec") < 0 {
+		return errors.New("#configuration.validateRanLivenessConfig - checkIntervalSec should not be negative\n")
+	}
+
+	if ranLivenessConfig.IsSet("responseThresholdSec") && ranLivenessConfig.GetInt("responseThresholdSec") <= 0 {
+		return errors.New("#configuration.validateRanLivenessConfig - responseThresholdSec should be positive\n")
+	}
+
+	if ranLivenessConfig.IsSet("maxMissedHealthChecks") && ranLivenessConfig.GetInt("maxMissedHealthChecks") <= 0 {
+		return errors.New("#configuration.validateRanLivenessConfig - maxMissedHealthChecks should be positive\n")
+	}
+
+	if ranLivenessConfig.IsSet("action") {
+		if _, ok := validRanLivenessActions[strings.ToLower(ranLivenessConfig.GetString("action"))]; !ok {
+			return errors.New("#configuration.validateRanLivenessConfig - action should be one of none, reset, disconnect\n")
+		}
+	}
+
+	return nil
+}
+
+// populateLeaderElectionConfig : the 'leaderElection' entry is optional, when missing a single replica is expected and it is always the leader.
+func (c *Configuration) populateLeaderElectionConfig(leaderElectionConfig *viper.Viper) error {
+	c.LeaderElection.LeaseDurationSec = defaultLeaderElectionLeaseDurationSec
+	c.LeaderElection.RenewIntervalSec = defaultLeaderElectionRenewIntervalSec
+
+	if leaderElectionConfig == nil {
+		return nil
+	}
+
+	if leaderElectionConfig.IsSet("leaseDurationSec") {
+		c.LeaderElection.LeaseDurationSec = leaderElectionConfig.GetInt("leaseDurationSec")
+	}
+	if leaderElectionConfig.IsSet("renewIntervalSec") {
+		c.LeaderElection.RenewIntervalSec = leaderElectionConfig.GetInt("renewIntervalSec")
+	}
+
+	err := validateLeaderElectionConfig(c.LeaderElection)
+	if err != nil {
+		return err
+	}
+
+	c.LeaderElection.Enabled = leaderElectionConfig.GetBool("enabled")
+	return nil
+}
+
+func validateLeaderElectionConfig(leaderElectionConfig LeaderElectionConfig) error {
+	if leaderElectionConfig.RenewIntervalSec <= 0 {
+		return errors.New("#configuration.validateLeaderElectionConfig - renewIntervalSec should be positive\n")
+	}
+
+	if leaderElectionConfig.LeaseDurationSec <= leaderElectionConfig.RenewIntervalSec {
+		return errors.New("#configuration.validateLeaderElectionConfig - leaseDurationSec should be greater than renewIntervalSec\n")
+	}
+
+	return nil
+}
+
+// populateRanListSyncConfig : the 'ranListSync' entry is optional, when missing the default reconcile interval is used.
+func (c *Configuration) populateRanListSyncConfig(ranListSyncConfig *viper.Viper) error {
+	c.RanListSync.ReconcileIntervalSec = defaultRanListSyncReconcileIntervalSec
+
+	if ranListSyncConfig == nil || !ranListSyncConfig.IsSet("reconcileIntervalSec") {
+		return nil
+	}
+
+	if ranListSyncConfig.GetInt("reconcileIntervalSec") < 0 {
+		return errors.New("#configuration.populateRanListSyncConfig - reconcileIntervalSec should not be negative\n")
+	}
+
+	c.RanListSync.ReconcileIntervalSec = ranListSyncConfig.GetInt("reconcileIntervalSec")
+	return nil
+}
+
+// populateX2ResetConfig : the 'x2Reset' entry is optional, when missing the default timeout is used.
+func (c *Configuration) populateX2ResetConfig(x2ResetConfig *viper.Viper) error {
+	c.X2Reset.TimeoutSec = defaultX2ResetTimeoutSec
+
+	if x2ResetConfig == nil || !x2ResetConfig.IsSet("timeoutSec") {
+		return nil
+	}
+
+	if x2ResetConfig.GetInt("timeoutSec") <= 0 {
+		return errors.New("#configuration.populateX2ResetConfig - timeoutSec should be positive\n")
+	}
+
+	c.X2Reset.TimeoutSec = x2ResetConfig.GetInt("timeoutSec")
+	return nil
+}
+
+// populateE2ResetConfig : the 'e2Reset' entry is optional, when missing the default response timeout is used.
+func (c *Configuration) populateE2ResetConfig(e2ResetConfig *viper.Viper) error {
+	c.E2Reset.ResponseTimeoutSec = defaultE2ResetResponseTimeoutSec
+
+	if e2ResetConfig == nil || !e2ResetConfig.IsSet("responseTimeoutSec") {
+		return nil
+	}
+
+	if e2ResetConfig.GetInt("responseTimeoutSec") <= 0 {
+		return errors.New("#configuration.populateE2ResetConfig - responseTimeoutSec should be positive\n")
+	}
+
+	c.E2Reset.ResponseTimeoutSec = e2ResetConfig.GetInt("responseTimeoutSec")
+	return nil
+}
+
+func (c *Configuration) populateGlobalRicIdConfig(globalRicIdConfig *viper.Viper) error {
 	err := validateGlobalRicIdConfig(globalRicIdConfig)
 	if err != nil {
-		panic(err.Error())
+		return err
 	}
 	c.GlobalRicId.RicId = globalRicIdConfig.GetString("ricId")
 	c.GlobalRicId.Mcc = globalRicIdConfig.GetString("mcc")
 	c.GlobalRicId.Mnc = globalRicIdConfig.GetString("mnc")
+	return nil
 }
 
 func validateGlobalRicIdConfig(globalRicIdConfig *viper.Viper) error {
@@ -186,6 +754,11 @@ func validateMcc(mcc string) error {
 	if mccInt < 0 {
 		return errors.New("#configuration.validateMcc - mcc is negative\n")
 	}
+
+	// Atoi accepts a sign, which would end up in the PLMN id
+	if !isDigits(mcc) {
+		return errors.New("#configuration.validateMcc - mcc is not a number\n")
+	}
 	return nil
 }
 
@@ -209,9 +782,22 @@ func validateMnc(mnc string) error {
 		return errors.New("#configuration.validateMnc - mnc is negative\n")
 	}
 
+	if !isDigits(mnc) {
+		return errors.New("#configuration.validateMnc - mnc is not a number\n")
+	}
+
 	return nil
 }
 
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func validateRicId(ricId string) error {
 
 	if len(ricId) == 0 {
@@ -232,15 +818,30 @@ func validateRicId(ricId string) error {
 
 func (c *Configuration) String() string {
 	return fmt.Sprintf("{logging.logLevel: %s, http.port: %d, rmr: { port: %d, maxMsgSize: %d}, routingManager.baseUrl: %s, "+
-		"notificationResponseBuffer: %d, bigRedButtonTimeoutSec: %d, maxRnibConnectionAttempts: %d, "+
-		"rnibRetryIntervalMs: %d, keepAliveResponseTimeoutMs: %d, keepAliveDelayMs: %d, e2tInstanceDeletionTimeoutMs: %d,e2ResetTimeOutSec: %d,"+
-		"globalRicId: { ricId: %s, mcc: %s, mnc: %s}, rnibWriter: { stateChangeMessageChannel: %s, ranManipulationChannel: %s}",
+		"alarmManager: { baseUrl: %s, ranUnderResetThresholdSec: %d}, "+
+		"notificationResponseBuffer: %d, notificationWorkers: %d, bigRedButtonTimeoutSec: %d, maxRnibConnectionAttempts: %d, "+
+		"rnibRetryIntervalMs: %d, keepAliveResponseTimeoutMs: %d, keepAliveDelayMs: %d, e2tInstanceDeletionTimeoutMs: %d,e2ResetTimeOutSec: %d, procedureTimeoutSec: %d, shutdownTimeoutSec: %d, "+
+		"globalRicId: { ricId: %s, mcc: %s, mnc: %s}, rnibWriter: { stateChangeMessageChannel: %s, ranManipulationChannel: %s}, "+
+		"e2SetupAdmission: { timeToWaitSec: %d, allowedPlmnIds: %v, deniedPlmnIds: %v, allowedNodeTypes: %v, deniedNodeTypes: %v, "+
+		"allowedNbIdRanges: %v, allowedRanFunctionOids: %v, deniedRanFunctionOids: %v, maxNodesPerE2T: %d, duplicateGlobalNbIdAction: %s}, "+
+		"errorIndication: { defaultAction: %s, causeActions: %v, maxStoredPerRan: %d}, "+
+		"ricServiceUpdate: { timeToWaitSec: %d, knownRanFunctionOids: %v}, "+
+		"e2NodeConfigUpdate: { timeToWaitSec: %d}, "+
+		"ricServiceQuery: { intervalSec: %d, jitterSec: %d, responseDeadlineSec: %d}, "+
+		"ranLiveness: { checkIntervalSec: %d, responseThresholdSec: %d, maxMissedHealthChecks: %d, action: %s}, "+
+		"leaderElection: { enabled: %t, leaseDurationSec: %d, renewIntervalSec: %d}, "+
+		"ranListSync: { reconcileIntervalSec: %d}, "+
+		"x2Reset: { timeoutSec: %d}, "+
+		"e2Reset: { responseTimeoutSec: %d}",
 		c.Logging.LogLevel,
 		c.Http.Port,
 		c.Rmr.Port,
 		c.Rmr.MaxMsgSize,
 		c.RoutingManager.BaseUrl,
+		c.AlarmManager.BaseUrl,
+		c.AlarmManager.RanUnderResetThresholdSec,
 		c.NotificationResponseBuffer,
+		c.NotificationWorkers,
 		c.BigRedButtonTimeoutSec,
 		c.MaxRnibConnectionAttempts,
 		c.RnibRetryIntervalMs,
@@ -248,10 +849,41 @@ func (c *Configuration) String() string {
 		c.KeepAliveDelayMs,
 		c.E2TInstanceDeletionTimeoutMs,
 		c.E2ResetTimeOutSec,
+		c.ProcedureTimeoutSec,
+		c.ShutdownTimeoutSec,
 		c.GlobalRicId.RicId,
 		c.GlobalRicId.Mcc,
 		c.GlobalRicId.Mnc,
 		c.RnibWriter.StateChangeMessageChannel,
 		c.RnibWriter.RanManipulationMessageChannel,
+		c.E2SetupAdmission.TimeToWaitSec,
+		c.E2SetupAdmission.AllowedPlmnIds,
+		c.E2SetupAdmission.DeniedPlmnIds,
+		c.E2SetupAdmission.AllowedNodeTypes,
+		c.E2SetupAdmission.DeniedNodeTypes,
+		c.E2SetupAdmission.AllowedNbIdRanges,
+		c.E2SetupAdmission.AllowedRanFunctionOids,
+		c.E2SetupAdmission.DeniedRanFunctionOids,
+		c.E2SetupAdmission.MaxNodesPerE2T,
+		c.E2SetupAdmission.DuplicateGlobalNbIdAction,
+		c.ErrorIndication.DefaultAction,
+		c.ErrorIndication.CauseActions,
+		c.ErrorIndication.MaxStoredPerRan,
+		c.RicServiceUpdate.TimeToWaitSec,
+		c.RicServiceUpdate.KnownRanFunctionOids,
+		c.E2NodeConfigUpdate.TimeToWaitSec,
+		c.RicServiceQuery.IntervalSec,
+		c.RicServiceQuery.JitterSec,
+		c.RicServiceQuery.ResponseDeadlineSec,
+		c.RanLiveness.CheckIntervalSec,
+		c.RanLiveness.ResponseThresholdSec,
+		c.RanLiveness.MaxMissedHealthChecks,
+		c.RanLiveness.Action,
+		c.LeaderElection.Enabled,
+		c.LeaderElection.LeaseDurationSec,
+		c.LeaderElection.RenewIntervalSec,
+		c.RanListSync.ReconcileIntervalSec,
+		c.X2Reset.TimeoutSec,
+		c.E2Reset.ResponseTimeoutSec,
 	)
 }
@@ -34,18 +34,48 @@ func TestParseConfigurationSuccess(t *testing.T) {
 	assert.Equal(t, 3801, config.Rmr.Port)
 	assert.Equal(t, 65536, config.Rmr.MaxMsgSize)
 	assert.Equal(t, "info", config.Logging.LogLevel)
+	assert.Equal(t, "http://service-ricplt-alarmmanager-http.ricplt:8080/ric/v1/", config.AlarmManager.BaseUrl)
+	assert.Equal(t, 30, config.AlarmManager.RanUnderResetThresholdSec)
 	assert.Equal(t, 100, config.NotificationResponseBuffer)
+	assert.Equal(t, 8, config.NotificationWorkers)
 	assert.Equal(t, 5, config.BigRedButtonTimeoutSec)
 	assert.Equal(t, 4500, config.KeepAliveResponseTimeoutMs)
 	assert.Equal(t, 1500, config.KeepAliveDelayMs)
 	assert.Equal(t, 15000, config.E2TInstanceDeletionTimeoutMs)
 	assert.Equal(t, 10, config.E2ResetTimeOutSec)
+	assert.Equal(t, 30, config.ProcedureTimeoutSec)
+	assert.Equal(t, 15, config.ShutdownTimeoutSec)
 	assert.NotNil(t, config.GlobalRicId)
 	assert.Equal(t, "AACCE", config.GlobalRicId.RicId)
 	assert.Equal(t, "310", config.GlobalRicId.Mcc)
 	assert.Equal(t, "411", config.GlobalRicId.Mnc)
 	assert.Equal(t, "RAN_CONNECTION_STATUS_CHANGE", config.RnibWriter.StateChangeMessageChannel)
 	assert.Equal(t, "RAN_MANIPULATION", config.RnibWriter.RanManipulationMessageChannel)
+	assert.Equal(t, 60, config.E2SetupAdmission.TimeToWaitSec)
+	assert.Empty(t, config.E2SetupAdmission.AllowedPlmnIds)
+	assert.Empty(t, config.E2SetupAdmission.AllowedNbIdRanges)
+	assert.Equal(t, 0, config.E2SetupAdmission.MaxNodesPerE2T)
+	assert.Equal(t, "reject", config.E2SetupAdmission.DuplicateGlobalNbIdAction)
+	assert.Equal(t, "revert", config.ErrorIndication.DefaultAction)
+	assert.Equal(t, 10, config.ErrorIndication.MaxStoredPerRan)
+	assert.Equal(t, "log", config.ErrorIndication.CauseActions["misc/hardware-failure"])
+	assert.Equal(t, "log", config.ErrorIndication.CauseActions["transport"])
+	assert.Equal(t, 10, config.RicServiceUpdate.TimeToWaitSec)
+	assert.Empty(t, config.RicServiceUpdate.KnownRanFunctionOids)
+	assert.Equal(t, 10, config.E2NodeConfigUpdate.TimeToWaitSec)
+	assert.Equal(t, 0, config.RicServiceQuery.IntervalSec)
+	assert.Equal(t, 30, config.RicServiceQuery.JitterSec)
+	assert.Equal(t, 10, config.RicServiceQuery.ResponseDeadlineSec)
+	assert.Equal(t, 60, config.RanLiveness.CheckIntervalSec)
+	assert.Equal(t, 30, config.RanLiveness.ResponseThresholdSec)
+	assert.Equal(t, 3, config.RanLiveness.MaxMissedHealthChecks)
+	assert.Equal(t, "none", config.RanLiveness.Action)
+	assert.False(t, config.LeaderElection.Enabled)
+	assert.Equal(t, 15, config.LeaderElection.LeaseDurationSec)
+	assert.Equal(t, 5, config.LeaderElection.RenewIntervalSec)
+	assert.Equal(t, 60, config.RanListSync.ReconcileIntervalSec)
+	assert.Equal(t, 10, config.X2Reset.TimeoutSec)
+	assert.Equal(t, 10, config.E2Reset.ResponseTimeoutSec)
 }
 
 func TestStringer(t *testing.T) {
@@ -734,3 +764,484 @@ func TestMissingMncFailure(t *testing.T) {
 	assert.PanicsWithValue(t, "#configuration.validateMnc - mnc is missing or empty\n",
 		func() { ParseConfiguration() })
 }
+
+func TestE2SetupAdmissionConfigSuccess(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionConfigSuccess - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestE2SetupAdmissionConfigSuccess - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":              map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":          map[string]interface{}{"logLevel": "info"},
+		"http":             map[string]interface{}{"port": 3800},
+		"globalRicId":      map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":   map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":       map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"e2SetupAdmission": map[string]interface{}{"timeToWaitSec": 10, "allowedPlmnIds": []string{"02F829"}, "deniedNodeTypes": []string{"HOME_ENB"}, "allowedNbIdRanges": []map[string]interface{}{{"min": 1, "max": 100}}, "maxNodesPerE2T": 5},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionConfigSuccess - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionConfigSuccess - failed to write configuration file: %s\n", configPath)
+	}
+	config := ParseConfiguration()
+	assert.Equal(t, 10, config.E2SetupAdmission.TimeToWaitSec)
+	assert.Equal(t, []string{"02F829"}, config.E2SetupAdmission.AllowedPlmnIds)
+	assert.Equal(t, []string{"HOME_ENB"}, config.E2SetupAdmission.DeniedNodeTypes)
+	assert.Equal(t, []NbIdRange{{Min: 1, Max: 100}}, config.E2SetupAdmission.AllowedNbIdRanges)
+	assert.Equal(t, 5, config.E2SetupAdmission.MaxNodesPerE2T)
+}
+
+func TestE2SetupAdmissionInvalidTimeToWaitFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidTimeToWaitFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestE2SetupAdmissionInvalidTimeToWaitFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":              map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":          map[string]interface{}{"logLevel": "info"},
+		"http":             map[string]interface{}{"port": 3800},
+		"globalRicId":      map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":   map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":       map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"e2SetupAdmission": map[string]interface{}{"timeToWaitSec": 30},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidTimeToWaitFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidTimeToWaitFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateE2SetupAdmissionConfig - timeToWaitSec should be one of 1, 2, 5, 10, 20, 60\n",
+		func() { ParseConfiguration() })
+}
+
+func TestE2SetupAdmissionInvalidNbIdRangeFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidNbIdRangeFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestE2SetupAdmissionInvalidNbIdRangeFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":              map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":          map[string]interface{}{"logLevel": "info"},
+		"http":             map[string]interface{}{"port": 3800},
+		"globalRicId":      map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":   map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":       map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"e2SetupAdmission": map[string]interface{}{"allowedNbIdRanges": []map[string]interface{}{{"min": 100, "max": 1}}},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidNbIdRangeFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidNbIdRangeFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateE2SetupAdmissionConfig - allowedNbIdRanges min is greater than max\n",
+		func() { ParseConfiguration() })
+}
+
+func TestE2SetupAdmissionInvalidDuplicateGlobalNbIdActionFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidDuplicateGlobalNbIdActionFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestE2SetupAdmissionInvalidDuplicateGlobalNbIdActionFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":              map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":          map[string]interface{}{"logLevel": "info"},
+		"http":             map[string]interface{}{"port": 3800},
+		"globalRicId":      map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":   map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":       map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"e2SetupAdmission": map[string]interface{}{"duplicateGlobalNbIdAction": "ignore"},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidDuplicateGlobalNbIdActionFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestE2SetupAdmissionInvalidDuplicateGlobalNbIdActionFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateE2SetupAdmissionConfig - duplicateGlobalNbIdAction should be one of reject, flag\n",
+		func() { ParseConfiguration() })
+}
+
+func TestErrorIndicationConfigDefaults(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestErrorIndicationConfigDefaults - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestErrorIndicationConfigDefaults - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"globalRicId":    map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager": map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":     map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestErrorIndicationConfigDefaults - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestErrorIndicationConfigDefaults - failed to write configuration file: %s\n", configPath)
+	}
+	config := ParseConfiguration()
+	assert.Equal(t, "revert", config.ErrorIndication.DefaultAction)
+	assert.Empty(t, config.ErrorIndication.CauseActions)
+	assert.Equal(t, 10, config.ErrorIndication.MaxStoredPerRan)
+}
+
+func TestErrorIndicationInvalidActionFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestErrorIndicationInvalidActionFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestErrorIndicationInvalidActionFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":             map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":         map[string]interface{}{"logLevel": "info"},
+		"http":            map[string]interface{}{"port": 3800},
+		"globalRicId":     map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":  map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":      map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"errorIndication": map[string]interface{}{"causeActions": map[string]interface{}{"misc": "reboot"}},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestErrorIndicationInvalidActionFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestErrorIndicationInvalidActionFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateErrorIndicationConfig - action of cause misc should be one of ignore, log, revert, reset, disconnect\n",
+		func() { ParseConfiguration() })
+}
+
+func TestRicServiceUpdateInvalidTimeToWaitFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestRicServiceUpdateInvalidTimeToWaitFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestRicServiceUpdateInvalidTimeToWaitFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":              map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":          map[string]interface{}{"logLevel": "info"},
+		"http":             map[string]interface{}{"port": 3800},
+		"globalRicId":      map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":   map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":       map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"ricServiceUpdate": map[string]interface{}{"timeToWaitSec": 15, "knownRanFunctionOids": []string{"1.3.6.1.4.1.53148.1.2.2.2"}},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestRicServiceUpdateInvalidTimeToWaitFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestRicServiceUpdateInvalidTimeToWaitFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateRicServiceUpdateConfig - timeToWaitSec should be one of 1, 2, 5, 10, 20, 60\n",
+		func() { ParseConfiguration() })
+}
+
+func TestE2NodeConfigUpdateInvalidTimeToWaitFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestE2NodeConfigUpdateInvalidTimeToWaitFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestE2NodeConfigUpdateInvalidTimeToWaitFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":                map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":            map[string]interface{}{"logLevel": "info"},
+		"http":               map[string]interface{}{"port": 3800},
+		"globalRicId":        map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":     map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":         map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"e2NodeConfigUpdate": map[string]interface{}{"timeToWaitSec": 30},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestE2NodeConfigUpdateInvalidTimeToWaitFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestE2NodeConfigUpdateInvalidTimeToWaitFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateE2NodeConfigUpdateConfig - timeToWaitSec should be one of 1, 2, 5, 10, 20, 60\n",
+		func() { ParseConfiguration() })
+}
+
+func TestRicServiceQueryInvalidResponseDeadlineFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestRicServiceQueryInvalidResponseDeadlineFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestRicServiceQueryInvalidResponseDeadlineFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":             map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":         map[string]interface{}{"logLevel": "info"},
+		"http":            map[string]interface{}{"port": 3800},
+		"globalRicId":     map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":  map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":      map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"ricServiceQuery": map[string]interface{}{"intervalSec": 60, "jitterSec": 5, "responseDeadlineSec": 120},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestRicServiceQueryInvalidResponseDeadlineFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestRicServiceQueryInvalidResponseDeadlineFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateRicServiceQueryConfig - responseDeadlineSec should be positive and not greater than intervalSec\n",
+		func() { ParseConfiguration() })
+}
+
+func TestRanLivenessInvalidActionFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestRanLivenessInvalidActionFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestRanLivenessInvalidActionFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"globalRicId":    map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager": map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":     map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"ranLiveness":    map[string]interface{}{"checkIntervalSec": 60, "action": "restart"},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestRanLivenessInvalidActionFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestRanLivenessInvalidActionFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateRanLivenessConfig - action should be one of none, reset, disconnect\n",
+		func() { ParseConfiguration() })
+}
+
+func TestLeaderElectionInvalidLeaseDurationFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestLeaderElectionInvalidLeaseDurationFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestLeaderElectionInvalidLeaseDurationFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"globalRicId":    map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager": map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":     map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"leaderElection": map[string]interface{}{"enabled": true, "leaseDurationSec": 5, "renewIntervalSec": 5},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestLeaderElectionInvalidLeaseDurationFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestLeaderElectionInvalidLeaseDurationFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.validateLeaderElectionConfig - leaseDurationSec should be greater than renewIntervalSec\n",
+		func() { ParseConfiguration() })
+}
+
+func TestRanListSyncNegativeIntervalFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestRanListSyncNegativeIntervalFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestRanListSyncNegativeIntervalFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"globalRicId":    map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager": map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":     map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"ranListSync":    map[string]interface{}{"reconcileIntervalSec": -1},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestRanListSyncNegativeIntervalFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestRanListSyncNegativeIntervalFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.populateRanListSyncConfig - reconcileIntervalSec should not be negative\n",
+		func() { ParseConfiguration() })
+}
+
+func TestX2ResetNonPositiveTimeoutFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestX2ResetNonPositiveTimeoutFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestX2ResetNonPositiveTimeoutFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"globalRicId":    map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager": map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":     map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"x2Reset":        map[string]interface{}{"timeoutSec": 0},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestX2ResetNonPositiveTimeoutFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestX2ResetNonPositiveTimeoutFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.populateX2ResetConfig - timeoutSec should be positive\n",
+		func() { ParseConfiguration() })
+}
+
+func TestE2ResetNonPositiveResponseTimeoutFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestE2ResetNonPositiveResponseTimeoutFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestE2ResetNonPositiveResponseTimeoutFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":            map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":        map[string]interface{}{"logLevel": "info"},
+		"http":           map[string]interface{}{"port": 3800},
+		"globalRicId":    map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager": map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":     map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"e2Reset":        map[string]interface{}{"responseTimeoutSec": 0},
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestE2ResetNonPositiveResponseTimeoutFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestE2ResetNonPositiveResponseTimeoutFailure - failed to write configuration file: %s\n", configPath)
+	}
+	assert.PanicsWithValue(t, "#configuration.populateE2ResetConfig - responseTimeoutSec should be positive\n",
+		func() { ParseConfiguration() })
+}
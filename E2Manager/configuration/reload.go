@@ -0,0 +1,182 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadMux guards the settings which ApplyReloadable changes at runtime, they are read through the getters below
+var reloadMux sync.RWMutex
+
+// reloadGeneration counts the reloads which changed a setting, so components deriving state from the configuration
+// can tell when to rebuild it
+var reloadGeneration uint64
+
+// ReloadConfiguration reads and validates the configuration file E2 Manager was started with again, the one found in
+// the search paths when fileName is empty. An invalid file is reported as an error, E2 Manager keeps running with the
+// current settings
+func ReloadConfiguration(fileName string) (*Configuration, error) {
+	return LoadConfiguration(fileName)
+}
+
+// WatchConfiguration calls onChange whenever the configuration file changes, and returns the path of the file. An
+// empty fileName selects the file found in the search paths
+func WatchConfiguration(fileName string, onChange func()) (string, error) {
+	file := newConfigurationFileViper()
+	if len(fileName) != 0 {
+		file.SetConfigFile(fileName)
+	}
+	err := file.ReadInConfig()
+	if err != nil {
+		return "", fmt.Errorf("#configuration.WatchConfiguration - failed to read configuration file: %s\n", err)
+	}
+
+	file.WatchConfig()
+	file.OnConfigChange(func(e fsnotify.Event) {
+		onChange()
+	})
+
+	return file.ConfigFileUsed(), nil
+}
+
+// ApplyReloadable copies the settings which are safe to change at runtime from the reloaded configuration,
+// and describes each change. Other settings take effect on the next restart
+func (c *Configuration) ApplyReloadable(reloaded *Configuration) []string {
+	reloadMux.Lock()
+	defer reloadMux.Unlock()
+
+	var changes []string
+
+	describe := func(name string, current interface{}, next interface{}) bool {
+		if reflect.DeepEqual(current, next) {
+			return false
+		}
+		changes = append(changes, fmt.Sprintf("%s: %v -> %v", name, current, next))
+		return true
+	}
+
+	if describe("logging.logLevel", c.Logging.LogLevel, reloaded.Logging.LogLevel) {
+		c.Logging.LogLevel = reloaded.Logging.LogLevel
+	}
+	if describe("keepAliveDelayMs", c.KeepAliveDelayMs, reloaded.KeepAliveDelayMs) {
+		c.KeepAliveDelayMs = reloaded.KeepAliveDelayMs
+	}
+	if describe("keepAliveResponseTimeoutMs", c.KeepAliveResponseTimeoutMs, reloaded.KeepAliveResponseTimeoutMs) {
+		c.KeepAliveResponseTimeoutMs = reloaded.KeepAliveResponseTimeoutMs
+	}
+	if describe("maxRnibConnectionAttempts", c.MaxRnibConnectionAttempts, reloaded.MaxRnibConnectionAttempts) {
+		c.MaxRnibConnectionAttempts = reloaded.MaxRnibConnectionAttempts
+	}
+	if describe("rnibRetryIntervalMs", c.RnibRetryIntervalMs, reloaded.RnibRetryIntervalMs) {
+		c.RnibRetryIntervalMs = reloaded.RnibRetryIntervalMs
+	}
+	if describe("e2ResetTimeOutSec", c.E2ResetTimeOutSec, reloaded.E2ResetTimeOutSec) {
+		c.E2ResetTimeOutSec = reloaded.E2ResetTimeOutSec
+	}
+	if describe("x2Reset.timeoutSec", c.X2Reset.TimeoutSec, reloaded.X2Reset.TimeoutSec) {
+		c.X2Reset.TimeoutSec = reloaded.X2Reset.TimeoutSec
+	}
+	if describe("e2Reset.responseTimeoutSec", c.E2Reset.ResponseTimeoutSec, reloaded.E2Reset.ResponseTimeoutSec) {
+		c.E2Reset.ResponseTimeoutSec = reloaded.E2Reset.ResponseTimeoutSec
+	}
+	if describe("routingManager.baseUrl", c.RoutingManager.BaseUrl, reloaded.RoutingManager.BaseUrl) {
+		c.RoutingManager.BaseUrl = reloaded.RoutingManager.BaseUrl
+	}
+	if describe("e2SetupAdmission", c.E2SetupAdmission, reloaded.E2SetupAdmission) {
+		c.E2SetupAdmission = reloaded.E2SetupAdmission
+	}
+
+	if len(changes) != 0 {
+		reloadGeneration++
+	}
+
+	return changes
+}
+
+// ReloadGeneration changes whenever ApplyReloadable changes a setting
+func (c *Configuration) ReloadGeneration() uint64 {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return reloadGeneration
+}
+
+func (c *Configuration) GetLogLevel() string {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.Logging.LogLevel
+}
+
+func (c *Configuration) GetKeepAliveDelayMs() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.KeepAliveDelayMs
+}
+
+func (c *Configuration) GetKeepAliveResponseTimeoutMs() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.KeepAliveResponseTimeoutMs
+}
+
+func (c *Configuration) GetMaxRnibConnectionAttempts() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.MaxRnibConnectionAttempts
+}
+
+func (c *Configuration) GetRnibRetryIntervalMs() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.RnibRetryIntervalMs
+}
+
+func (c *Configuration) GetE2ResetTimeOutSec() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.E2ResetTimeOutSec
+}
+
+func (c *Configuration) GetX2ResetTimeoutSec() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.X2Reset.TimeoutSec
+}
+
+func (c *Configuration) GetE2ResetResponseTimeoutSec() int {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.E2Reset.ResponseTimeoutSec
+}
+
+func (c *Configuration) GetRoutingManagerBaseUrl() string {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.RoutingManager.BaseUrl
+}
+
+func (c *Configuration) GetE2SetupAdmission() E2SetupAdmissionConfig {
+	reloadMux.RLock()
+	defer reloadMux.RUnlock()
+	return c.E2SetupAdmission
+}
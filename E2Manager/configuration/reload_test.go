@@ -0,0 +1,126 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestReloadConfigurationSuccess(t *testing.T) {
+	config, err := ReloadConfiguration("")
+	assert.Nil(t, err)
+	assert.Equal(t, 1500, config.KeepAliveDelayMs)
+}
+
+func TestReloadConfigurationGivenFile(t *testing.T) {
+	buf, err := ioutil.ReadFile("../resources/configuration.yaml")
+	if err != nil {
+		t.Errorf("#TestReloadConfigurationGivenFile - failed to read configuration file\n")
+	}
+	fileName := filepath.Join(t.TempDir(), "e2mgr.yaml")
+	err = ioutil.WriteFile(fileName, []byte(strings.Replace(string(buf), "keepAliveDelayMs: 1500", "keepAliveDelayMs: 2500", 1)), 0644)
+	if err != nil {
+		t.Errorf("#TestReloadConfigurationGivenFile - failed to write configuration file: %s\n", fileName)
+	}
+
+	config, err := ReloadConfiguration(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, 2500, config.KeepAliveDelayMs)
+}
+
+func TestReloadConfigurationInvalidFileFailure(t *testing.T) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#TestReloadConfigurationInvalidFileFailure - failed to rename configuration file: %s\n", configPath)
+	}
+	defer func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#TestReloadConfigurationInvalidFileFailure - failed to rename configuration file: %s\n", configPath)
+		}
+	}()
+	yamlMap := map[string]interface{}{
+		"rmr":                        map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":                    map[string]interface{}{"logLevel": "verbose"},
+		"http":                       map[string]interface{}{"port": 3800},
+		"globalRicId":                map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":             map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriter":                 map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"keepAliveDelayMs":           1500,
+		"keepAliveResponseTimeoutMs": 4500,
+		"maxRnibConnectionAttempts":  3,
+		"e2ResetTimeOutSec":          10,
+	}
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#TestReloadConfigurationInvalidFileFailure - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+	if err != nil {
+		t.Errorf("#TestReloadConfigurationInvalidFileFailure - failed to write configuration file: %s\n", configPath)
+	}
+
+	config, err := ReloadConfiguration("")
+	assert.Nil(t, config)
+	assert.Contains(t, err.Error(), "logging.logLevel")
+
+	delete(yamlMap, "rmr")
+	yamlMap["logging"] = map[string]interface{}{"logLevel": "info"}
+	buf, _ = yaml.Marshal(yamlMap)
+	_ = ioutil.WriteFile("../resources/configuration.yaml", buf, 0644)
+
+	config, err = ReloadConfiguration("")
+	assert.Nil(t, config)
+	assert.Contains(t, err.Error(), "#configuration.populateRmrConfig")
+}
+
+func TestApplyReloadable(t *testing.T) {
+	config := &Configuration{KeepAliveDelayMs: 1500, E2ResetTimeOutSec: 10, RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	config.Logging.LogLevel = "info"
+	config.RoutingManager.BaseUrl = "http://localhost:8080/ric/v1/handles/"
+	config.Rmr.Port = 3801
+
+	reloaded := *config
+	reloaded.Logging.LogLevel = "debug"
+	reloaded.KeepAliveDelayMs = 500
+	reloaded.E2SetupAdmission.DeniedPlmnIds = []string{"02f829"}
+	reloaded.Rmr.Port = 4801
+
+	generation := config.ReloadGeneration()
+	changes := config.ApplyReloadable(&reloaded)
+
+	assert.Equal(t, []string{"logging.logLevel: info -> debug", "keepAliveDelayMs: 1500 -> 500", "e2SetupAdmission: {0 [] [] [] [] [] [] [] 0 } -> {0 [] [02f829] [] [] [] [] [] 0 }"}, changes)
+	assert.Equal(t, generation+1, config.ReloadGeneration())
+	assert.Equal(t, "debug", config.GetLogLevel())
+	assert.Equal(t, 500, config.GetKeepAliveDelayMs())
+	assert.Equal(t, []string{"02f829"}, config.GetE2SetupAdmission().DeniedPlmnIds)
+	assert.Equal(t, 3801, config.Rmr.Port)
+
+	assert.Empty(t, config.ApplyReloadable(&reloaded))
+	assert.Equal(t, generation+1, config.ReloadGeneration())
+}
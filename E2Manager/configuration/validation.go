@@ -0,0 +1,376 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package configuration
+
+import (
+	"e2mgr/logger"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvironmentPrefix starts the name of the environment variables overriding configuration keys.
+// The rest of the name is the key path in upper snake case, e.g. E2MGR_KEEP_ALIVE_DELAY_MS or E2MGR_GLOBAL_RIC_ID_RIC_ID
+const EnvironmentPrefix = "E2MGR_"
+
+type valueKind int
+
+const (
+	intValue valueKind = iota
+	boolValue
+	stringValue
+	stringListValue
+	// mapValue and objectListValue are given as JSON in the environment
+	mapValue
+	objectListValue
+)
+
+var valueKindNames = map[valueKind]string{
+	intValue:        "an integer",
+	boolValue:       "a boolean",
+	stringValue:     "a string",
+	stringListValue: "a list of strings",
+	mapValue:        "a map",
+	objectListValue: "a list of objects",
+}
+
+// configurationSchema lists every key of the configuration file
+var configurationSchema = map[string]valueKind{
+	"logging.logLevel":                           stringValue,
+	"http.port":                                  intValue,
+	"rmr.port":                                   intValue,
+	"rmr.maxMsgSize":                             intValue,
+	"routingManager.baseUrl":                     stringValue,
+	"alarmManager.baseUrl":                       stringValue,
+	"alarmManager.ranUnderResetThresholdSec":     intValue,
+	"notificationResponseBuffer":                 intValue,
+	"notificationWorkers":                        intValue,
+	"bigRedButtonTimeoutSec":                     intValue,
+	"maxRnibConnectionAttempts":                  intValue,
+	"rnibRetryIntervalMs":                        intValue,
+	"keepAliveResponseTimeoutMs":                 intValue,
+	"keepAliveDelayMs":                           intValue,
+	"e2tInstanceDeletionTimeoutMs":               intValue,
+	"e2ResetTimeOutSec":                          intValue,
+	"procedureTimeoutSec":                        intValue,
+	"shutdownTimeoutSec":                         intValue,
+	"globalRicId.ricId":                          stringValue,
+	"globalRicId.mcc":                            stringValue,
+	"globalRicId.mnc":                            stringValue,
+	"rnibWriter.stateChangeMessageChannel":       stringValue,
+	"rnibWriter.ranManipulationMessageChannel":   stringValue,
+	"e2SetupAdmission.timeToWaitSec":             intValue,
+	"e2SetupAdmission.allowedPlmnIds":            stringListValue,
+	"e2SetupAdmission.deniedPlmnIds":             stringListValue,
+	"e2SetupAdmission.allowedNodeTypes":          stringListValue,
+	"e2SetupAdmission.deniedNodeTypes":           stringListValue,
+	"e2SetupAdmission.allowedNbIdRanges":         objectListValue,
+	"e2SetupAdmission.allowedRanFunctionOids":    stringListValue,
+	"e2SetupAdmission.deniedRanFunctionOids":     stringListValue,
+	"e2SetupAdmission.maxNodesPerE2T":            intValue,
+	"e2SetupAdmission.duplicateGlobalNbIdAction": stringValue,
+	"errorIndication.defaultAction":              stringValue,
+	"errorIndication.maxStoredPerRan":            intValue,
+	"errorIndication.causeActions":               mapValue,
+	"ricServiceUpdate.timeToWaitSec":             intValue,
+	"ricServiceUpdate.knownRanFunctionOids":      stringListValue,
+	"e2NodeConfigUpdate.timeToWaitSec":           intValue,
+	"ricServiceQuery.intervalSec":                intValue,
+	"ricServiceQuery.jitterSec":                  intValue,
+	"ricServiceQuery.responseDeadlineSec":        intValue,
+	"ranLiveness.checkIntervalSec":               intValue,
+	"ranLiveness.responseThresholdSec":           intValue,
+	"ranLiveness.maxMissedHealthChecks":          intValue,
+	"ranLiveness.action":                         stringValue,
+	"leaderElection.enabled":                     boolValue,
+	"leaderElection.leaseDurationSec":            intValue,
+	"leaderElection.renewIntervalSec":            intValue,
+	"ranListSync.reconcileIntervalSec":           intValue,
+	"x2Reset.timeoutSec":                         intValue,
+	"e2Reset.responseTimeoutSec":                 intValue,
+}
+
+// ValidationError holds every problem found in the configuration, one per line
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Errors, "")
+}
+
+// LoadConfiguration reads and validates the configuration file, with the environment overrides applied. An empty
+// fileName selects the file found in the search paths.
+// Unlike ParseConfiguration it does not stop at the first problem, every one found is reported in a ValidationError
+func LoadConfiguration(fileName string) (*Configuration, error) {
+	settings, err := readSettings(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := validateSchema(settings.AllSettings())
+
+	config, populateErrs := populateConfiguration(settings)
+	errs = append(errs, populateErrs...)
+	errs = append(errs, config.validate()...)
+
+	if len(errs) != 0 {
+		return nil, &ValidationError{Errors: errs}
+	}
+
+	return config, nil
+}
+
+// EnvironmentVariableName returns the name of the environment variable overriding a key, such as globalRicId.ricId
+func EnvironmentVariableName(key string) string {
+	var b strings.Builder
+	b.WriteString(EnvironmentPrefix)
+
+	for i, part := range strings.Split(key, ".") {
+		if i > 0 {
+			b.WriteByte('_')
+		}
+		runes := []rune(part)
+		for j, r := range runes {
+			// a word starts at an upper case letter following a lower case letter, or a digit which does not
+			// belong to an acronym such as E2T
+			if j > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[j-1]) || unicode.IsDigit(runes[j-1]) && j > 1 && unicode.IsLower(runes[j-2])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+
+	return b.String()
+}
+
+// applyEnvironmentOverrides replaces the values of the settings, keyed in lower case as viper does, with the values
+// of the environment variables which are set
+func applyEnvironmentOverrides(settings map[string]interface{}) {
+	for key, kind := range configurationSchema {
+		value, ok := os.LookupEnv(EnvironmentVariableName(key))
+		if !ok {
+			continue
+		}
+
+		path := strings.Split(strings.ToLower(key), ".")
+		section := settings
+		for _, name := range path[:len(path)-1] {
+			sub, ok := section[name].(map[string]interface{})
+			if !ok {
+				sub = make(map[string]interface{})
+				section[name] = sub
+			}
+			section = sub
+		}
+
+		section[path[len(path)-1]] = parseEnvironmentValue(kind, value)
+	}
+}
+
+// parseEnvironmentValue converts the value to the kind of the key. A value which can not be converted is kept as a string
+// and reported by validateSchema
+func parseEnvironmentValue(kind valueKind, value string) interface{} {
+	switch kind {
+	case intValue:
+		if i, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			return i
+		}
+	case boolValue:
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return b
+		}
+	case stringListValue:
+		list := []interface{}{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); len(item) != 0 {
+				list = append(list, item)
+			}
+		}
+		return list
+	case mapValue:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &m); err == nil {
+			return m
+		}
+	case objectListValue:
+		var list []interface{}
+		if err := json.Unmarshal([]byte(value), &list); err == nil {
+			return list
+		}
+	default:
+		return value
+	}
+
+	return value
+}
+
+// validateSchema reports the unknown keys, and the values which are not of the kind of their key
+func validateSchema(settings map[string]interface{}) []string {
+	kinds := make(map[string]valueKind, len(configurationSchema))
+	names := make(map[string]string, len(configurationSchema))
+	for key, kind := range configurationSchema {
+		kinds[strings.ToLower(key)] = kind
+		names[strings.ToLower(key)] = key
+	}
+
+	var errs []string
+	walkSettings("", settings, func(key string, value interface{}) bool {
+		kind, ok := kinds[key]
+		if !ok {
+			if _, isSection := value.(map[string]interface{}); isSection && isSchemaSection(key, kinds) {
+				return true
+			}
+			errs = append(errs, fmt.Sprintf("#configuration.validateSchema - unknown key %s\n", key))
+			return false
+		}
+
+		if !isOfKind(kind, value) {
+			errs = append(errs, fmt.Sprintf("#configuration.validateSchema - %s should be %s\n", names[key], valueKindNames[kind]))
+		}
+		return false
+	})
+
+	sort.Strings(errs)
+	return errs
+}
+
+// walkSettings calls visit for every key of the nested settings, and descends into a map when visit returns true
+func walkSettings(prefix string, settings map[string]interface{}, visit func(key string, value interface{}) bool) {
+	for name, value := range settings {
+		key := name
+		if len(prefix) != 0 {
+			key = prefix + "." + name
+		}
+
+		if visit(key, value) {
+			walkSettings(key, value.(map[string]interface{}), visit)
+		}
+	}
+}
+
+func isSchemaSection(key string, kinds map[string]valueKind) bool {
+	for schemaKey := range kinds {
+		if strings.HasPrefix(schemaKey, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func isOfKind(kind valueKind, value interface{}) bool {
+	switch kind {
+	case intValue:
+		switch value.(type) {
+		case int, int64:
+			return true
+		}
+	case boolValue:
+		_, ok := value.(bool)
+		return ok
+	case stringValue:
+		// unquoted yaml scalars, such as mcc: 310, are read as numbers
+		switch value.(type) {
+		case string, int, int64:
+			return true
+		}
+	case stringListValue:
+		list, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range list {
+			if !isOfKind(stringValue, item) {
+				return false
+			}
+		}
+		return true
+	case mapValue:
+		switch value.(type) {
+		case map[string]interface{}, map[interface{}]interface{}:
+			return true
+		}
+	case objectListValue:
+		list, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range list {
+			if !isOfKind(mapValue, item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// validate checks the values which ParseConfiguration accepts but E2 Manager can not run with
+func (c *Configuration) validate() []string {
+	var errs []string
+
+	check := func(valid bool, format string, args ...interface{}) {
+		if !valid {
+			errs = append(errs, fmt.Sprintf("#configuration.validate - "+format+"\n", args...))
+		}
+	}
+
+	_, err := logger.ParseLevel(c.Logging.LogLevel)
+	check(err == nil, "logging.logLevel should be one of error, warning, info, debug")
+
+	check(isValidPort(c.Http.Port), "http.port should be between 1 and 65535")
+	check(isValidPort(c.Rmr.Port), "rmr.port should be between 1 and 65535")
+	check(c.Http.Port != c.Rmr.Port, "http.port and rmr.port should differ")
+	check(c.Rmr.MaxMsgSize > 0, "rmr.maxMsgSize should be positive")
+
+	check(isValidBaseUrl(c.RoutingManager.BaseUrl), "routingManager.baseUrl should be an absolute http url")
+	check(len(c.AlarmManager.BaseUrl) == 0 || isValidBaseUrl(c.AlarmManager.BaseUrl), "alarmManager.baseUrl should be an absolute http url")
+	check(c.AlarmManager.RanUnderResetThresholdSec >= 0, "alarmManager.ranUnderResetThresholdSec should not be negative")
+
+	check(c.NotificationResponseBuffer >= 0, "notificationResponseBuffer should not be negative")
+	check(c.NotificationWorkers >= 0, "notificationWorkers should not be negative")
+	check(c.BigRedButtonTimeoutSec > 0, "bigRedButtonTimeoutSec should be positive")
+	check(c.MaxRnibConnectionAttempts > 0, "maxRnibConnectionAttempts should be positive")
+	check(c.RnibRetryIntervalMs >= 0, "rnibRetryIntervalMs should not be negative")
+	check(c.KeepAliveDelayMs > 0, "keepAliveDelayMs should be positive")
+	check(c.KeepAliveResponseTimeoutMs > c.KeepAliveDelayMs, "keepAliveResponseTimeoutMs should be greater than keepAliveDelayMs")
+	check(c.E2TInstanceDeletionTimeoutMs > 0, "e2tInstanceDeletionTimeoutMs should be positive")
+	check(c.E2ResetTimeOutSec > 0, "e2ResetTimeOutSec should be positive")
+	check(c.ProcedureTimeoutSec >= 0, "procedureTimeoutSec should not be negative")
+	check(c.ShutdownTimeoutSec > 0, "shutdownTimeoutSec should be positive")
+
+	check(len(c.RnibWriter.StateChangeMessageChannel) != 0, "rnibWriter.stateChangeMessageChannel should not be empty")
+	check(len(c.RnibWriter.RanManipulationMessageChannel) != 0, "rnibWriter.ranManipulationMessageChannel should not be empty")
+
+	return errs
+}
+
+func isValidPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+func isValidBaseUrl(baseUrl string) bool {
+	u, err := url.ParseRequestURI(baseUrl)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) != 0
+}
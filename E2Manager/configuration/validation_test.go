@@ -0,0 +1,178 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package configuration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func writeTestConfiguration(t *testing.T, yamlMap map[string]interface{}) {
+	configPath := "../resources/configuration.yaml"
+	configPathTmp := "../resources/configuration.yaml_tmp"
+	err := os.Rename(configPath, configPathTmp)
+	if err != nil {
+		t.Errorf("#writeTestConfiguration - failed to rename configuration file: %s\n", configPath)
+	}
+	t.Cleanup(func() {
+		err = os.Rename(configPathTmp, configPath)
+		if err != nil {
+			t.Errorf("#writeTestConfiguration - failed to rename configuration file: %s\n", configPath)
+		}
+	})
+	buf, err := yaml.Marshal(yamlMap)
+	if err != nil {
+		t.Errorf("#writeTestConfiguration - failed to marshal configuration map\n")
+	}
+	err = ioutil.WriteFile(configPath, buf, 0644)
+	if err != nil {
+		t.Errorf("#writeTestConfiguration - failed to write configuration file: %s\n", configPath)
+	}
+}
+
+func TestLoadConfigurationSuccess(t *testing.T) {
+	config, err := LoadConfiguration("")
+	assert.Nil(t, err)
+	assert.Equal(t, 3800, config.Http.Port)
+}
+
+func TestEnvironmentVariableName(t *testing.T) {
+	assert.Equal(t, "E2MGR_KEEP_ALIVE_DELAY_MS", EnvironmentVariableName("keepAliveDelayMs"))
+	assert.Equal(t, "E2MGR_E2_RESET_TIME_OUT_SEC", EnvironmentVariableName("e2ResetTimeOutSec"))
+	assert.Equal(t, "E2MGR_E2T_INSTANCE_DELETION_TIMEOUT_MS", EnvironmentVariableName("e2tInstanceDeletionTimeoutMs"))
+	assert.Equal(t, "E2MGR_GLOBAL_RIC_ID_RIC_ID", EnvironmentVariableName("globalRicId.ricId"))
+	assert.Equal(t, "E2MGR_E2_SETUP_ADMISSION_MAX_NODES_PER_E2T", EnvironmentVariableName("e2SetupAdmission.maxNodesPerE2T"))
+}
+
+func TestEnvironmentOverrides(t *testing.T) {
+	t.Setenv("E2MGR_KEEP_ALIVE_DELAY_MS", "2000")
+	t.Setenv("E2MGR_GLOBAL_RIC_ID_RIC_ID", "BBCCE")
+	t.Setenv("E2MGR_LEADER_ELECTION_ENABLED", "true")
+	t.Setenv("E2MGR_E2_SETUP_ADMISSION_DENIED_PLMN_IDS", "02f829, 131014")
+	t.Setenv("E2MGR_ERROR_INDICATION_CAUSE_ACTIONS", `{"transport":"log"}`)
+
+	config, err := LoadConfiguration("")
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, config.KeepAliveDelayMs)
+	assert.Equal(t, "BBCCE", config.GlobalRicId.RicId)
+	assert.Equal(t, "310", config.GlobalRicId.Mcc)
+	assert.True(t, config.LeaderElection.Enabled)
+	assert.Equal(t, 15, config.LeaderElection.LeaseDurationSec)
+	assert.Equal(t, []string{"02f829", "131014"}, config.E2SetupAdmission.DeniedPlmnIds)
+	assert.Equal(t, map[string]string{"transport": "log"}, config.ErrorIndication.CauseActions)
+}
+
+func TestEnvironmentOverrideInvalidValueFailure(t *testing.T) {
+	t.Setenv("E2MGR_HTTP_PORT", "http")
+
+	config, err := LoadConfiguration("")
+	assert.Nil(t, config)
+	assert.Equal(t, "#configuration.validateSchema - http.port should be an integer\n#configuration.validate - http.port should be between 1 and 65535\n", err.Error())
+}
+
+func TestLoadConfigurationReportsAllErrors(t *testing.T) {
+	writeTestConfiguration(t, map[string]interface{}{
+		"rmr":                          map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":                      map[string]interface{}{"logLevel": "verbose"},
+		"http":                         map[string]interface{}{"port": 3800, "host": "localhost"},
+		"globalRicId":                  map[string]interface{}{"mcc": "327", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":               map[string]interface{}{"baseUrl": "localhost:8080"},
+		"rnibWriter":                   map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE", "ranManipulationMessageChannel": "RAN_MANIPULATION"},
+		"bigRedButtonTimeoutSec":       5,
+		"maxRnibConnectionAttempts":    3,
+		"keepAliveDelayMs":             1500,
+		"keepAliveResponseTimeoutMs":   0,
+		"e2tInstanceDeletionTimeoutMs": 15000,
+		"e2ResetTimeOutSec":            -1,
+		"shutdownTimeoutSec":           15,
+		"leaderElection":               map[string]interface{}{"enabled": "yes"},
+	})
+
+	config, err := LoadConfiguration("")
+	assert.Nil(t, config)
+	validationError, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{
+		"#configuration.validateSchema - leaderElection.enabled should be a boolean\n",
+		"#configuration.validateSchema - unknown key http.host\n",
+		"#configuration.validate - logging.logLevel should be one of error, warning, info, debug\n",
+		"#configuration.validate - routingManager.baseUrl should be an absolute http url\n",
+		"#configuration.validate - keepAliveResponseTimeoutMs should be greater than keepAliveDelayMs\n",
+		"#configuration.validate - e2ResetTimeOutSec should be positive\n",
+	}, validationError.Errors)
+}
+
+func TestLoadConfigurationParseFailure(t *testing.T) {
+	writeTestConfiguration(t, map[string]interface{}{
+		"rmr":                          map[string]interface{}{"port": 3801, "maxMsgSize": 4096},
+		"logging":                      map[string]interface{}{"logLevel": "info"},
+		"http":                         map[string]interface{}{"port": 3800},
+		"globalRicId":                  map[string]interface{}{"mcc": "+27", "mnc": "94", "ricId": "AACCE"},
+		"routingManager":               map[string]interface{}{"baseUrl": "http://localhost:8080/ric/v1/handles/"},
+		"rnibWriters":                  map[string]interface{}{"stateChangeMessageChannel": "RAN_CONNECTION_STATUS_CHANGE"},
+		"bigRedButtonTimeoutSec":       5,
+		"maxRnibConnectionAttempts":    3,
+		"keepAliveDelayMs":             1500,
+		"keepAliveResponseTimeoutMs":   4500,
+		"e2tInstanceDeletionTimeoutMs": 15000,
+		"e2ResetTimeOutSec":            -1,
+		"shutdownTimeoutSec":           15,
+		"ricServiceQuery":              map[string]interface{}{"intervalSec": -1},
+	})
+
+	config, err := LoadConfiguration("")
+	assert.Nil(t, config)
+	validationError, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{
+		"#configuration.validateSchema - unknown key rnibwriters\n",
+		"#configuration.validateMcc - mcc is not a number\n",
+		"#configuration.populateRnibWriterConfig - failed to populate Rnib Writer configuration: The entry 'rnibWriter' not found\n",
+		"#configuration.validateRicServiceQueryConfig - intervalSec should not be negative\n",
+		"#configuration.validate - e2ResetTimeOutSec should be positive\n",
+		"#configuration.validate - rnibWriter.stateChangeMessageChannel should not be empty\n",
+		"#configuration.validate - rnibWriter.ranManipulationMessageChannel should not be empty\n",
+	}, validationError.Errors)
+}
+
+func TestLoadConfigurationGivenFile(t *testing.T) {
+	buf, err := ioutil.ReadFile("../resources/configuration.yaml")
+	assert.Nil(t, err)
+	settings := map[string]interface{}{}
+	assert.Nil(t, yaml.Unmarshal(buf, &settings))
+	settings["http"] = map[string]interface{}{"port": 3811}
+
+	fileName := filepath.Join(t.TempDir(), "e2mgr.yaml")
+	buf, err = yaml.Marshal(settings)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(fileName, buf, 0644))
+
+	config, err := LoadConfiguration(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, 3811, config.Http.Port)
+
+	config, err = LoadConfiguration(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Nil(t, config)
+	assert.Contains(t, err.Error(), "failed to read configuration file")
+}
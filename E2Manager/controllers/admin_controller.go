@@ -0,0 +1,136 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package controllers
+
+import (
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+type IAdminController interface {
+	GetLogLevel(writer http.ResponseWriter, r *http.Request)
+	SetLogLevel(writer http.ResponseWriter, r *http.Request)
+}
+
+type AdminController struct {
+	logger *logger.Logger
+}
+
+func NewAdminController(logger *logger.Logger) *AdminController {
+	return &AdminController{
+		logger: logger,
+	}
+}
+
+func (c *AdminController) GetLogLevel(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #AdminController.GetLogLevel - request received")
+	c.writeLogLevelResponse(writer)
+}
+
+// SetLogLevel changes the global log level, or the level of a single package when the request names one
+func (c *AdminController) SetLogLevel(writer http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	request := models.LogLevelRequest{}
+	err := json.NewDecoder(io.LimitReader(r.Body, LimitRequest)).Decode(&request)
+	if err != nil {
+		c.logger.Errorf("[Client -> E2 Manager] #AdminController.SetLogLevel - unable to extract json body - error: %s", err)
+		c.handleErrorResponse(e2managererrors.NewInvalidJsonError(), writer)
+		return
+	}
+
+	c.logger.Infof("[Client -> E2 Manager] #AdminController.SetLogLevel - request: %+v", request)
+
+	if len(request.Package) != 0 && len(request.LogLevel) == 0 {
+		c.logger.RemovePackageLevel(request.Package)
+		c.logger.Infof("#AdminController.SetLogLevel - log level override of package %s removed", request.Package)
+		c.writeLogLevelResponse(writer)
+		return
+	}
+
+	level, err := logger.ParseLevel(request.LogLevel)
+	if err != nil {
+		c.logger.Errorf("#AdminController.SetLogLevel - %s", err)
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return
+	}
+
+	if len(request.Package) != 0 {
+		c.logger.SetPackageLevel(request.Package, level)
+		c.logger.Infof("#AdminController.SetLogLevel - log level of package %s set to %s", request.Package, logger.LevelName(level))
+	} else {
+		c.logger.SetLevel(level)
+		c.logger.Infof("#AdminController.SetLogLevel - log level set to %s", logger.LevelName(level))
+	}
+
+	c.writeLogLevelResponse(writer)
+}
+
+func (c *AdminController) writeLogLevelResponse(writer http.ResponseWriter) {
+	response := models.LogLevelResponse{LogLevel: logger.LevelName(c.logger.GetLevel())}
+
+	packageLevels := c.logger.GetPackageLevels()
+	if len(packageLevels) != 0 {
+		response.Packages = make(map[string]string, len(packageLevels))
+		for pkg, level := range packageLevels {
+			response.Packages[pkg] = logger.LevelName(level)
+		}
+	}
+
+	result, err := response.Marshal()
+	if err != nil {
+		c.handleErrorResponse(err, writer)
+		return
+	}
+
+	c.logger.Infof("[E2 Manager -> Client] #AdminController.writeLogLevelResponse - response: %s", result)
+	writer.Header().Set(ContentType, ApplicationJson)
+	_, _ = writer.Write(result)
+}
+
+func (c *AdminController) handleErrorResponse(err error, writer http.ResponseWriter) {
+
+	var errorResponseDetails models.ErrorResponse
+	var httpError int
+
+	switch e2Error := err.(type) {
+	case *e2managererrors.InvalidJsonError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusBadRequest
+	case *e2managererrors.RequestValidationError:
+		errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+		httpError = http.StatusBadRequest
+	default:
+		internalError := e2managererrors.NewInternalError()
+		errorResponseDetails = models.ErrorResponse{Code: internalError.Code, Message: internalError.Message}
+		httpError = http.StatusInternalServerError
+	}
+
+	errorResponse, _ := json.Marshal(errorResponseDetails)
+
+	c.logger.Errorf("[E2 Manager -> Client] #AdminController.handleErrorResponse - http status: %d, error response: %+v", httpError, errorResponseDetails)
+
+	writer.Header().Set(ContentType, ApplicationJson)
+	writer.WriteHeader(httpError)
+	_, _ = writer.Write(errorResponse)
+}
@@ -0,0 +1,102 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package controllers
+
+import (
+	"e2mgr/logger"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAdminControllerTest(t *testing.T) (*AdminController, *logger.Logger) {
+	log, err := logger.InitLogger(logger.InfoLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize logger, error: %s", err)
+	}
+	return NewAdminController(log), log
+}
+
+func TestAdminControllerGetLogLevel(t *testing.T) {
+	controller, log := setupAdminControllerTest(t)
+	log.SetPackageLevel("managers", logger.DebugLevel)
+
+	writer := httptest.NewRecorder()
+	controller.GetLogLevel(writer, httptest.NewRequest(http.MethodGet, "/v1/admin/loglevel", nil))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.JSONEq(t, `{"logLevel":"info","packages":{"managers":"debug"}}`, writer.Body.String())
+}
+
+func TestAdminControllerSetLogLevel(t *testing.T) {
+	controller, log := setupAdminControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	controller.SetLogLevel(writer, httptest.NewRequest(http.MethodPut, "/v1/admin/loglevel", strings.NewReader(`{"logLevel":"debug"}`)))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, logger.DebugLevel, log.GetLevel())
+	assert.JSONEq(t, `{"logLevel":"debug"}`, writer.Body.String())
+}
+
+func TestAdminControllerSetPackageLogLevel(t *testing.T) {
+	controller, log := setupAdminControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	controller.SetLogLevel(writer, httptest.NewRequest(http.MethodPut, "/v1/admin/loglevel", strings.NewReader(`{"package":"httpmsghandlers","logLevel":"warning"}`)))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, logger.InfoLevel, log.GetLevel())
+	assert.Equal(t, map[string]int{"httpmsghandlers": logger.WarnLevel}, log.GetPackageLevels())
+
+	writer = httptest.NewRecorder()
+	controller.SetLogLevel(writer, httptest.NewRequest(http.MethodPut, "/v1/admin/loglevel", strings.NewReader(`{"package":"httpmsghandlers"}`)))
+
+	assert.Equal(t, http.StatusOK, writer.Code)
+	assert.Empty(t, log.GetPackageLevels())
+}
+
+func TestAdminControllerSetLogLevelInvalidJson(t *testing.T) {
+	controller, _ := setupAdminControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	controller.SetLogLevel(writer, httptest.NewRequest(http.MethodPut, "/v1/admin/loglevel", strings.NewReader(`{"logLevel":`)))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	var errorResponse map[string]interface{}
+	_ = json.Unmarshal(writer.Body.Bytes(), &errorResponse)
+	assert.Equal(t, float64(401), errorResponse["errorCode"])
+}
+
+func TestAdminControllerSetLogLevelInvalidLevel(t *testing.T) {
+	controller, log := setupAdminControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	controller.SetLogLevel(writer, httptest.NewRequest(http.MethodPut, "/v1/admin/loglevel", strings.NewReader(`{"logLevel":"verbose"}`)))
+
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, logger.InfoLevel, log.GetLevel())
+	var errorResponse map[string]interface{}
+	_ = json.Unmarshal(writer.Body.Bytes(), &errorResponse)
+	assert.Equal(t, float64(402), errorResponse["errorCode"])
+}
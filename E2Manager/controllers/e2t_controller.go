@@ -22,6 +22,7 @@
 package controllers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -50,10 +51,10 @@ func NewE2TController(logger *logger.Logger, handlerProvider *httpmsghandlerprov
 
 func (c *E2TController) GetE2TInstances(writer http.ResponseWriter, r *http.Request) {
 	c.logger.Infof("[Client -> E2 Manager] #E2TController.GetE2TInstances - request: %v", c.prettifyRequest(r))
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.GetE2TInstancesRequest, nil, false)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetE2TInstancesRequest, nil, false)
 }
 
-func (c *E2TController) handleRequest(writer http.ResponseWriter, header *http.Header, requestName httpmsghandlerprovider.IncomingRequest, request models.Request, validateHeader bool) {
+func (c *E2TController) handleRequest(ctx context.Context, writer http.ResponseWriter, header *http.Header, requestName httpmsghandlerprovider.IncomingRequest, request models.Request, validateHeader bool) {
 
 	handler, err := c.handlerProvider.GetHandler(requestName)
 
@@ -62,7 +63,7 @@ func (c *E2TController) handleRequest(writer http.ResponseWriter, header *http.H
 		return
 	}
 
-	response, err := handler.Handle(request)
+	response, err := handler.Handle(ctx, request)
 
 	if err != nil {
 		c.handleErrorResponse(err, writer)
@@ -76,7 +77,7 @@ func (c *E2TController) handleRequest(writer http.ResponseWriter, header *http.H
 		return
 	}
 
-	c.logger.Infof("[E2 Manager -> Client] #E2TController.handleRequest - response: %s", result)
+	c.logger.WithContext(ctx).Infof("[E2 Manager -> Client] #E2TController.handleRequest - response: %s", result)
 	writer.Header().Set("Content-Type", "application/json")
 	writer.Write(result)
 }
@@ -18,6 +18,8 @@
 package controllers
 
 import (
+	"context"
+	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/managers"
 	"e2mgr/mocks"
@@ -29,6 +31,7 @@ import (
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/magiconair/properties/assert"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -56,12 +59,17 @@ func setupE2TControllerTest(t *testing.T) (*E2TController, *mocks.RnibReaderMock
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, log)
 
 	ranListManager := managers.NewRanListManager(log, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(log, config)
+	ranAlarmService := services.NewRanAlarmService(log, config, clients.NewAlarmManagerClient(log, config, clients.NewHttpClient()))
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(log, rnibDataService, ranListManager, ranAlarmService)
 	nodebValidator := managers.NewNodebValidator()
 	updateEnbManager := managers.NewUpdateEnbManager(log, rnibDataService, nodebValidator)
 	updateGnbManager := managers.NewUpdateGnbManager(log, rnibDataService, nodebValidator)
-	handlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(log, nil, config, rnibDataService, e2tInstancesManager, nil, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(log, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	handlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(log, nil, config, rnibDataService, e2tInstancesManager, nil, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager, e2ResetTransactionManager, ranProcedureTracker, &mocks.ErrorIndicationStoreMock{}, &mocks.RicServiceQueryManagerMock{}, &mocks.RanLivenessMonitorMock{}, &mocks.HealthCheckJobManagerMock{}, managers.NewX2ResetTransactionManager(log, config, ranProcedureTracker), managers.NewLeaderElector(log, config, nil, ""))
 	controller := NewE2TController(log, handlerProvider)
 	return controller, readerMock
 }
@@ -135,7 +143,7 @@ func TestInvalidRequestName(t *testing.T) {
 
 	header := &http.Header{}
 
-	controller.handleRequest(writer, header, "", nil, true)
+	controller.handleRequest(context.Background(), writer, header, "", nil, true)
 
 	var errorResponse = parseJsonRequest(t, writer.Body)
 
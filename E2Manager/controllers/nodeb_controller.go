@@ -21,6 +21,7 @@
 package controllers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -34,20 +35,42 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
 const (
 	ParamRanName = "ranName"
+	ParamJobId   = "jobId"
+	ParamCellId  = "cellId"
+	ParamOid     = "oid"
 	LimitRequest = 2000
 )
 const ApplicationJson = "application/json"
+
+var nodebIdListParams = map[string]bool{
+	"connectionStatus": true,
+	"nodeType":         true,
+	"e2tAddress":       true,
+	"plmnId":           true,
+	"namePrefix":       true,
+	"sort":             true,
+	"cursor":           true,
+	"limit":            true,
+	"fields":           true,
+}
+
 const ContentType = "Content-Type"
 
 type INodebController interface {
 	Shutdown(writer http.ResponseWriter, r *http.Request)
 	X2Reset(writer http.ResponseWriter, r *http.Request)
+	E2Reset(writer http.ResponseWriter, r *http.Request)
 	GetNodeb(writer http.ResponseWriter, r *http.Request)
+	GetErrorIndications(writer http.ResponseWriter, r *http.Request)
+	RicServiceQuery(writer http.ResponseWriter, r *http.Request)
+	GetRicServiceQueryReport(writer http.ResponseWriter, r *http.Request)
 	UpdateGnb(writer http.ResponseWriter, r *http.Request)
 	UpdateEnb(writer http.ResponseWriter, r *http.Request)
 	GetNodebIdList(writer http.ResponseWriter, r *http.Request)
@@ -56,6 +79,12 @@ type INodebController interface {
 	AddEnb(writer http.ResponseWriter, r *http.Request)
 	DeleteEnb(writer http.ResponseWriter, r *http.Request)
 	HealthCheckRequest(writer http.ResponseWriter, r *http.Request)
+	GetHealthCheckJob(writer http.ResponseWriter, r *http.Request)
+	GetX2ResetJob(writer http.ResponseWriter, r *http.Request)
+	GetNodebByGlobalNbId(writer http.ResponseWriter, r *http.Request)
+	GetCell(writer http.ResponseWriter, r *http.Request)
+	GetRanFunctions(writer http.ResponseWriter, r *http.Request)
+	GetRanFunctionNodes(writer http.ResponseWriter, r *http.Request)
 }
 
 type NodebController struct {
@@ -73,7 +102,107 @@ func NewNodebController(logger *logger.Logger, handlerProvider *httpmsghandlerpr
 func (c *NodebController) GetNodebIdList(writer http.ResponseWriter, r *http.Request) {
 	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetNodebIdList - request: %v", c.prettifyRequest(r))
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.GetNodebIdListRequest, nil, false, http.StatusOK)
+	query := r.URL.Query()
+
+	if len(query) == 0 {
+		c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebIdListRequest, nil, false, http.StatusOK)
+		return
+	}
+
+	request, err := c.parseGetNodebIdListRequest(query)
+
+	if err != nil {
+		c.handleErrorResponse(err, writer)
+		return
+	}
+
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebIdListRequest, *request, false, http.StatusOK)
+}
+
+func (c *NodebController) parseGetNodebIdListRequest(query url.Values) (*models.GetNodebIdListRequest, error) {
+	request := &models.GetNodebIdListRequest{
+		E2TAddress: query.Get("e2tAddress"),
+		PlmnId:     query.Get("plmnId"),
+		NamePrefix: query.Get("namePrefix"),
+		SortBy:     models.NodebIdListSortByName,
+		Limit:      models.DefaultNodebIdListLimit,
+	}
+
+	for param := range query {
+		if !nodebIdListParams[param] {
+			c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, unknown parameter %s", param)
+			return nil, e2managererrors.NewRequestValidationError()
+		}
+	}
+
+	if connectionStatuses := query.Get("connectionStatus"); connectionStatuses != "" {
+		for _, value := range strings.Split(connectionStatuses, ",") {
+			connectionStatus, ok := entities.ConnectionStatus_value[value]
+
+			if !ok {
+				c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, invalid connection status %s", value)
+				return nil, e2managererrors.NewRequestValidationError()
+			}
+
+			request.ConnectionStatuses = append(request.ConnectionStatuses, entities.ConnectionStatus(connectionStatus))
+		}
+	}
+
+	if nodeType := query.Get("nodeType"); nodeType != "" {
+		value, ok := entities.Node_Type_value[nodeType]
+
+		if !ok || entities.Node_Type(value) == entities.Node_UNKNOWN {
+			c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, invalid node type %s", nodeType)
+			return nil, e2managererrors.NewRequestValidationError()
+		}
+
+		request.NodeType = entities.Node_Type(value)
+	}
+
+	if sortBy := query.Get("sort"); sortBy != "" {
+		request.Descending = strings.HasPrefix(sortBy, "-")
+		request.SortBy = models.NodebIdListSortField(strings.TrimPrefix(sortBy, "-"))
+
+		if !models.NodebIdListSortFields[request.SortBy] {
+			c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, invalid sort %s", sortBy)
+			return nil, e2managererrors.NewRequestValidationError()
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+
+		if err != nil || value <= 0 || value > models.MaxNodebIdListLimit {
+			c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, limit should be between 1 and %d", models.MaxNodebIdListLimit)
+			return nil, e2managererrors.NewRequestValidationError()
+		}
+
+		request.Limit = value
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		value, err := models.DecodeNodebIdListCursor(cursor)
+
+		if err != nil || value.SortBy != request.SortBy || value.Descending != request.Descending {
+			c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, invalid cursor or sort changed between pages")
+			return nil, e2managererrors.NewRequestValidationError()
+		}
+
+		request.Cursor = value
+	}
+
+	if fields := query.Get("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if !models.NodebIdListFields[field] {
+				c.logger.Errorf("#NodebController.parseGetNodebIdListRequest - validation failure, invalid field %s", field)
+				return nil, e2managererrors.NewRequestValidationError()
+			}
+
+			request.Fields = append(request.Fields, field)
+		}
+	}
+
+	return request, nil
 }
 
 func (c *NodebController) GetNodebId(writer http.ResponseWriter, r *http.Request) {
@@ -82,15 +211,102 @@ func (c *NodebController) GetNodebId(writer http.ResponseWriter, r *http.Request
 	ranName := vars["ranName"]
 	request := models.GetNodebIdRequest{RanName: ranName}
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.GetNodebIdRequest, request, false, http.StatusOK)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebIdRequest, request, false, http.StatusOK)
 }
 
+// GetNodeb returns the RAN from rNib, the optional decodeRanFunctions query parameter adds its RAN function
+// definitions decoded by the E2SM decoders
 func (c *NodebController) GetNodeb(writer http.ResponseWriter, r *http.Request) {
 	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetNodeb - request: %v", c.prettifyRequest(r))
 	vars := mux.Vars(r)
 	ranName := vars["ranName"]
 	request := models.GetNodebRequest{RanName: ranName}
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.GetNodebRequest, request, false, http.StatusOK)
+
+	if decodeRanFunctions := r.URL.Query().Get("decodeRanFunctions"); decodeRanFunctions != "" {
+		decode, err := strconv.ParseBool(decodeRanFunctions)
+
+		if err != nil {
+			c.logger.Errorf("#NodebController.GetNodeb - validation failure, invalid decodeRanFunctions %s", decodeRanFunctions)
+			c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+			return
+		}
+
+		request.DecodeRanFunctions = decode
+	}
+
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebRequest, request, false, http.StatusOK)
+}
+
+// GetNodebByGlobalNbId resolves the RAN owning a global nb id, both the plmnId and the nbId query parameters are required
+func (c *NodebController) GetNodebByGlobalNbId(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetNodebByGlobalNbId - request: %v", c.prettifyRequest(r))
+	query := r.URL.Query()
+	request := models.GetNodebByGlobalNbIdRequest{PlmnId: query.Get("plmnId"), NbId: query.Get("nbId")}
+
+	if request.PlmnId == "" || request.NbId == "" {
+		c.logger.Errorf("#NodebController.GetNodebByGlobalNbId - validation failure, plmnId and nbId are required")
+		c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+		return
+	}
+
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetNodebByGlobalNbIdRequest, request, false, http.StatusOK)
+}
+
+func (c *NodebController) GetCell(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetCell - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	request := models.GetCellRequest{CellId: vars[ParamCellId]}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetCellRequest, request, false, http.StatusOK)
+}
+
+func (c *NodebController) GetRanFunctions(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetRanFunctions - request: %v", c.prettifyRequest(r))
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetRanFunctionsRequest, nil, false, http.StatusOK)
+}
+
+// GetRanFunctionNodes lists the nodes exposing a RAN function oid, the optional status query parameter is a comma
+// separated list of connection statuses to keep
+func (c *NodebController) GetRanFunctionNodes(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetRanFunctionNodes - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	request := models.GetRanFunctionNodesRequest{RanFunctionOid: vars[ParamOid]}
+
+	if statuses := r.URL.Query().Get("status"); statuses != "" {
+		for _, value := range strings.Split(statuses, ",") {
+			connectionStatus, ok := entities.ConnectionStatus_value[value]
+
+			if !ok {
+				c.logger.Errorf("#NodebController.GetRanFunctionNodes - validation failure, invalid connection status %s", value)
+				c.handleErrorResponse(e2managererrors.NewRequestValidationError(), writer)
+				return
+			}
+
+			request.ConnectionStatuses = append(request.ConnectionStatuses, entities.ConnectionStatus(connectionStatus))
+		}
+	}
+
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetRanFunctionNodesRequest, request, false, http.StatusOK)
+}
+
+func (c *NodebController) GetErrorIndications(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetErrorIndications - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+	request := models.GetErrorIndicationsRequest{RanName: ranName}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetErrorIndicationsRequest, request, false, http.StatusOK)
+}
+
+func (c *NodebController) RicServiceQuery(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.RicServiceQuery - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+	request := models.RicServiceQueryRequest{RanName: ranName}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.RicServiceQueryRequest, request, false, http.StatusAccepted)
+}
+
+func (c *NodebController) GetRicServiceQueryReport(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetRicServiceQueryReport - request: %v", c.prettifyRequest(r))
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.RicServiceQueryReportRequest, models.GetRicServiceQueryReportRequest{}, false, http.StatusOK)
 }
 
 func (c *NodebController) UpdateGnb(writer http.ResponseWriter, r *http.Request) {
@@ -108,7 +324,7 @@ func (c *NodebController) UpdateGnb(writer http.ResponseWriter, r *http.Request)
 
 	request.Gnb = &gnb
 	request.RanName = ranName
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.UpdateGnbRequest, &request, true, http.StatusOK)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.UpdateGnbRequest, &request, true, http.StatusOK)
 }
 
 func (c *NodebController) UpdateEnb(writer http.ResponseWriter, r *http.Request) {
@@ -137,7 +353,7 @@ func (c *NodebController) UpdateEnb(writer http.ResponseWriter, r *http.Request)
 
 	updateEnbRequest.RanName = ranName
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.UpdateEnbRequest, &updateEnbRequest, true, http.StatusOK)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.UpdateEnbRequest, &updateEnbRequest, true, http.StatusOK)
 }
 
 func (c *NodebController) AddEnb(writer http.ResponseWriter, r *http.Request) {
@@ -161,7 +377,7 @@ func (c *NodebController) AddEnb(writer http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.AddEnbRequest, &addEnbRequest, true, http.StatusCreated)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.AddEnbRequest, &addEnbRequest, true, http.StatusCreated)
 }
 
 func (c *NodebController) DeleteEnb(writer http.ResponseWriter, r *http.Request) {
@@ -169,7 +385,7 @@ func (c *NodebController) DeleteEnb(writer http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	ranName := vars["ranName"]
 	request := &models.DeleteEnbRequest{RanName: ranName}
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.DeleteEnbRequest, request, true, http.StatusNoContent)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.DeleteEnbRequest, request, true, http.StatusNoContent)
 }
 
 func (c *NodebController) SetGeneralConfiguration(writer http.ResponseWriter, r *http.Request) {
@@ -180,12 +396,12 @@ func (c *NodebController) SetGeneralConfiguration(writer http.ResponseWriter, r
 	if !c.extractJsonBodyDisallowUnknownFields(r, &request, writer) {
 		return
 	}
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.SetGeneralConfigurationRequest, request, false, http.StatusOK)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.SetGeneralConfigurationRequest, request, false, http.StatusOK)
 }
 
 func (c *NodebController) Shutdown(writer http.ResponseWriter, r *http.Request) {
 	c.logger.Infof("[Client -> E2 Manager] #NodebController.Shutdown - request: %v", c.prettifyRequest(r))
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.ShutdownRequest, nil, false, http.StatusNoContent)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.ShutdownRequest, nil, false, http.StatusNoContent)
 }
 
 func (c *NodebController) X2Reset(writer http.ResponseWriter, r *http.Request) {
@@ -199,7 +415,26 @@ func (c *NodebController) X2Reset(writer http.ResponseWriter, r *http.Request) {
 		return
 	}
 	request.RanName = ranName
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.ResetRequest, request, false, http.StatusNoContent)
+
+	successStatusCode := http.StatusNoContent
+	if request.Async {
+		successStatusCode = http.StatusAccepted
+	}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.ResetRequest, request, false, successStatusCode)
+}
+
+func (c *NodebController) E2Reset(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.E2Reset - request: %v", c.prettifyRequest(r))
+	request := models.ResetRequest{}
+	vars := mux.Vars(r)
+	ranName := vars[ParamRanName]
+
+	if err := c.extractJsonBody(r, &request); err != nil {
+		c.handleErrorResponse(err, writer)
+		return
+	}
+	request.RanName = ranName
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.E2ResetRequest, request, false, http.StatusNoContent)
 }
 
 func (c *NodebController) HealthCheckRequest(writer http.ResponseWriter, r *http.Request) {
@@ -212,7 +447,23 @@ func (c *NodebController) HealthCheckRequest(writer http.ResponseWriter, r *http
 		return
 	}
 
-	c.handleRequest(writer, &r.Header, httpmsghandlerprovider.HealthCheckRequest, request, true, http.StatusAccepted)
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.HealthCheckRequest, request, true, http.StatusAccepted)
+}
+
+func (c *NodebController) GetHealthCheckJob(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetHealthCheckJob - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	jobId := vars[ParamJobId]
+	request := models.GetHealthCheckJobRequest{JobId: jobId}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetHealthCheckJobRequest, request, false, http.StatusOK)
+}
+
+func (c *NodebController) GetX2ResetJob(writer http.ResponseWriter, r *http.Request) {
+	c.logger.Infof("[Client -> E2 Manager] #NodebController.GetX2ResetJob - request: %v", c.prettifyRequest(r))
+	vars := mux.Vars(r)
+	jobId := vars[ParamJobId]
+	request := models.GetX2ResetJobRequest{JobId: jobId}
+	c.handleRequest(r.Context(), writer, &r.Header, httpmsghandlerprovider.GetX2ResetJobRequest, request, false, http.StatusOK)
 }
 
 func (c *NodebController) extractRequestBodyToProto(r *http.Request, pb proto.Message, writer http.ResponseWriter) bool {
@@ -262,7 +513,7 @@ func (c *NodebController) extractJsonBody(r *http.Request, request models.Reques
 	return nil
 }
 
-func (c *NodebController) handleRequest(writer http.ResponseWriter, header *http.Header, requestName httpmsghandlerprovider.IncomingRequest, request models.Request, validateRequestHeaders bool, successStatusCode int) {
+func (c *NodebController) handleRequest(ctx context.Context, writer http.ResponseWriter, header *http.Header, requestName httpmsghandlerprovider.IncomingRequest, request models.Request, validateRequestHeaders bool, successStatusCode int) {
 
 	if validateRequestHeaders {
 
@@ -280,7 +531,7 @@ func (c *NodebController) handleRequest(writer http.ResponseWriter, header *http
 		return
 	}
 
-	response, err := handler.Handle(request)
+	response, err := handler.Handle(ctx, request)
 
 	if err != nil {
 		c.handleErrorResponse(err, writer)
@@ -289,7 +540,7 @@ func (c *NodebController) handleRequest(writer http.ResponseWriter, header *http
 
 	if successStatusCode == http.StatusNoContent {
 		writer.WriteHeader(successStatusCode)
-		c.logger.Infof("[E2 Manager -> Client] #NodebController.handleRequest - status response: %v", http.StatusNoContent)
+		c.logger.WithContext(ctx).Infof("[E2 Manager -> Client] #NodebController.handleRequest - status response: %v", http.StatusNoContent)
 		return
 	}
 
@@ -300,7 +551,7 @@ func (c *NodebController) handleRequest(writer http.ResponseWriter, header *http
 		return
 	}
 
-	c.logger.Infof("[E2 Manager -> Client] #NodebController.handleRequest - response: %s", result)
+	c.logger.WithContext(ctx).Infof("[E2 Manager -> Client] #NodebController.handleRequest - response: %s", result)
 	writer.Header().Set(ContentType, ApplicationJson)
 	writer.WriteHeader(successStatusCode)
 	writer.Write(result)
@@ -363,6 +614,10 @@ func (c *NodebController) handleErrorResponse(err error, writer http.ResponseWri
 			e2Error, _ := err.(*e2managererrors.RoutingManagerError)
 			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
 			httpError = http.StatusServiceUnavailable
+		case *e2managererrors.NotLeaderError:
+			e2Error, _ := err.(*e2managererrors.NotLeaderError)
+			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+			httpError = http.StatusServiceUnavailable
 		case *e2managererrors.NodebExistsError:
 			e2Error, _ := err.(*e2managererrors.NodebExistsError)
 			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
@@ -371,6 +626,14 @@ func (c *NodebController) handleErrorResponse(err error, writer http.ResponseWri
 			e2Error, _ := err.(*e2managererrors.NoConnectedRanError)
 			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
 			httpError = http.StatusNotFound
+		case *e2managererrors.RanResponseTimeoutError:
+			e2Error, _ := err.(*e2managererrors.RanResponseTimeoutError)
+			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+			httpError = http.StatusGatewayTimeout
+		case *e2managererrors.RanRejectedRequestError:
+			e2Error, _ := err.(*e2managererrors.RanRejectedRequestError)
+			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
+			httpError = http.StatusBadGateway
 		default:
 			e2Error := e2managererrors.NewInternalError()
 			errorResponseDetails = models.ErrorResponse{Code: e2Error.Code, Message: e2Error.Message}
@@ -22,6 +22,7 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -83,6 +84,7 @@ type controllerGetNodebTestContext struct {
 }
 
 type controllerGetNodebIdListTestContext struct {
+	query                string
 	nodebIdList          []*entities.NbIdentity
 	rnibError            error
 	expectedStatusCode   int
@@ -314,14 +316,24 @@ func setupControllerTest(t *testing.T) (*NodebController, *mocks.RnibReaderMock,
 	rmrSender := getRmrSender(rmrMessengerMock, log)
 	e2tInstancesManager := &mocks.E2TInstancesManagerMock{}
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(log, config, httpClientMock)
+	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock, alarmManagerClient)
 	ranListManager := managers.NewRanListManager(log, rnibDataService)
 	ranAlarmService := &mocks.RanAlarmServiceMock{}
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(log, rnibDataService, ranListManager, ranAlarmService)
 	nodebValidator := managers.NewNodebValidator()
 	updateEnbManager := managers.NewUpdateEnbManager(log, rnibDataService, nodebValidator)
 	updateGnbManager := managers.NewUpdateGnbManager(log, rnibDataService, nodebValidator)
-	handlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(log, rmrSender, config, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(log, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	errorIndicationStoreMock := &mocks.ErrorIndicationStoreMock{}
+	errorIndicationStoreMock.On("Get", mock.Anything).Return([]*models.ErrorIndicationRecord{{TransactionId: "1", Cause: "misc/om-intervention", Action: models.ErrorIndicationActionRevert}}, nil)
+	ricServiceQueryManager := managers.NewRicServiceQueryManager(log, config, rmrSender, rnibDataService, ranProcedureTracker)
+	ranLivenessMonitor := managers.NewRanLivenessMonitor(log, config, ranListManager, ranAlarmService, nil, nil, nil)
+	handlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(log, rmrSender, config, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager, e2ResetTransactionManager, ranProcedureTracker, errorIndicationStoreMock, ricServiceQueryManager, ranLivenessMonitor, managers.NewHealthCheckJobManager(log), managers.NewX2ResetTransactionManager(log, config, ranProcedureTracker), managers.NewLeaderElector(log, config, nil, ""))
 	controller := NewNodebController(log, handlerProvider)
 	return controller, readerMock, writerMock, rmrMessengerMock, e2tInstancesManager, ranListManager
 }
@@ -339,7 +351,8 @@ func setupDeleteEnbControllerTest(t *testing.T, preAddNbIdentity bool) (*NodebCo
 	rmrSender := getRmrSender(rmrMessengerMock, log)
 	e2tInstancesManager := &mocks.E2TInstancesManagerMock{}
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(log, config, httpClientMock)
+	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock, alarmManagerClient)
 	ranListManager := managers.NewRanListManager(log, rnibDataService)
 	var nbIdentity *entities.NbIdentity
 	if preAddNbIdentity {
@@ -353,7 +366,12 @@ func setupDeleteEnbControllerTest(t *testing.T, preAddNbIdentity bool) (*NodebCo
 	updateEnbManager := managers.NewUpdateEnbManager(log, rnibDataService, nodebValidator)
 	updateGnbManager := managers.NewUpdateGnbManager(log, rnibDataService, nodebValidator)
 
-	handlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(log, rmrSender, config, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(log, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	handlerProvider := httpmsghandlerprovider.NewIncomingRequestHandlerProvider(log, rmrSender, config, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager, e2ResetTransactionManager, ranProcedureTracker, &mocks.ErrorIndicationStoreMock{}, &mocks.RicServiceQueryManagerMock{}, &mocks.RanLivenessMonitorMock{}, &mocks.HealthCheckJobManagerMock{}, managers.NewX2ResetTransactionManager(log, config, ranProcedureTracker), managers.NewLeaderElector(log, config, nil, ""))
 	controller := NewNodebController(log, handlerProvider)
 	return controller, readerMock, writerMock, nbIdentity
 }
@@ -417,6 +435,72 @@ func controllerGetNodebTestExecuter(t *testing.T, context *controllerGetNodebTes
 	assert.Equal(t, context.expectedJsonResponse, string(bodyBytes))
 }
 
+func TestControllerGetErrorIndicationsSuccess(t *testing.T) {
+	controller, readerMock, _, _, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	readerMock.On("GetNodeb", RanName).Return(&entities.NodebInfo{RanName: RanName}, nil)
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/"+RanName+"/errorindications", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetErrorIndications(writer, req)
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "[{\"receivedAt\":0,\"transactionId\":\"1\",\"cause\":\"misc/om-intervention\",\"action\":\"revert\"}]", string(bodyBytes))
+}
+
+func TestControllerRicServiceQuerySuccess(t *testing.T) {
+	controller, readerMock, _, rmrMessengerMock, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	nodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED, Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{}}}
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/nodeb/"+RanName+"/servicequery", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.RicServiceQuery(writer, req)
+	assert.Equal(t, http.StatusAccepted, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Contains(t, string(bodyBytes), "\"ranName\":\""+RanName+"\"")
+}
+
+func TestControllerRicServiceQueryWrongState(t *testing.T) {
+	controller, readerMock, _, rmrMessengerMock, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	nodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	req, _ := http.NewRequest(http.MethodPost, "/nodeb/"+RanName+"/servicequery", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.RicServiceQuery(writer, req)
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
+}
+
+func TestControllerGetRicServiceQueryReportSuccess(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/servicequery/report", nil)
+	controller.GetRicServiceQueryReport(writer, req)
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "[]", string(bodyBytes))
+}
+
+func TestControllerGetHealthCheckJobNotFound(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/health/1", nil)
+	req = mux.SetURLVars(req, map[string]string{"jobId": "1"})
+	controller.GetHealthCheckJob(writer, req)
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+}
+
+func TestControllerGetX2ResetJobNotFound(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/x2reset/1", nil)
+	req = mux.SetURLVars(req, map[string]string{"jobId": "1"})
+	controller.GetX2ResetJob(writer, req)
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+}
+
 func controllerGetNodebIdListTestExecuter(t *testing.T, context *controllerGetNodebIdListTestContext) {
 	controller, readerMock, _, _, _, ranListManager := setupControllerTest(t)
 	writer := httptest.NewRecorder()
@@ -427,7 +511,7 @@ func controllerGetNodebIdListTestExecuter(t *testing.T, context *controllerGetNo
 		t.Errorf("Error cannot init identity")
 	}
 
-	req, _ := http.NewRequest(http.MethodGet, "/nodeb/states", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/states"+context.query, nil)
 	controller.GetNodebIdList(writer, req)
 	assert.Equal(t, context.expectedStatusCode, writer.Result().StatusCode)
 	bodyBytes, _ := ioutil.ReadAll(writer.Body)
@@ -1565,6 +1649,32 @@ func TestControllerGetNodebInternal(t *testing.T) {
 	controllerGetNodebTestExecuter(t, &context)
 }
 
+func TestControllerGetNodebDecodeRanFunctions(t *testing.T) {
+	controller, readerMock, _, _, _, _ := setupControllerTest(t)
+	nodebInfo := &entities.NodebInfo{RanName: RanName, Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{RanFunctions: []*entities.RanFunction{{RanFunctionId: 1, RanFunctionOid: "1.2.3", RanFunctionDefinition: "334455"}}}}}
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/"+RanName+"?decodeRanFunctions=true", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetNodeb(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Contains(t, string(bodyBytes), "\"ranFunctionDefinitions\":[{\"ranFunctionId\":1,\"ranFunctionRevision\":0,\"ranFunctionOid\":\"1.2.3\",\"rawDefinition\":\"334455\"}]")
+}
+
+func TestControllerGetNodebInvalidDecodeRanFunctions(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb/"+RanName+"?decodeRanFunctions=maybe", nil)
+	req = mux.SetURLVars(req, map[string]string{"ranName": RanName})
+	controller.GetNodeb(writer, req)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+}
+
 func TestControllerGetNodebIdListSuccess(t *testing.T) {
 	var rnibError error
 	nodebIdList := []*entities.NbIdentity{
@@ -1596,6 +1706,176 @@ func TestControllerGetNodebIdListEmptySuccess(t *testing.T) {
 	controllerGetNodebIdListTestExecuter(t, &context)
 }
 
+func TestControllerGetNodebIdListPageSuccess(t *testing.T) {
+	var rnibError error
+	nodebIdList := []*entities.NbIdentity{
+		{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}},
+		{InventoryName: "test2", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId2", NbId: "nbId2"}},
+		{InventoryName: "other", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId3"}},
+	}
+
+	context := controllerGetNodebIdListTestContext{
+		query:                "?connectionStatus=CONNECTED&namePrefix=test&sort=-nbId&fields=inventoryName",
+		nodebIdList:          nodebIdList,
+		rnibError:            rnibError,
+		expectedStatusCode:   http.StatusOK,
+		expectedJsonResponse: "{\"nodebs\":[{\"inventoryName\":\"test2\"},{\"inventoryName\":\"test1\"}],\"totalCount\":2}",
+	}
+
+	controllerGetNodebIdListTestExecuter(t, &context)
+}
+
+func TestControllerGetNodebIdListInvalidParameters(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+	queries := []string{
+		"?connectionStatus=UP",
+		"?nodeType=UNKNOWN",
+		"?sort=address",
+		"?limit=0",
+		"?limit=1001",
+		"?cursor=abc",
+		"?cursor=" + (&models.NodebIdListCursor{SortBy: models.NodebIdListSortByName, SortKey: "a", InventoryName: "a"}).Encode() + "&sort=plmnId",
+		"?fields=inventoryName,ip",
+		"?status=CONNECTED",
+	}
+
+	for _, query := range queries {
+		writer := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/nodeb/states"+query, nil)
+		controller.GetNodebIdList(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode, query)
+	}
+}
+
+func TestControllerGetNodebByGlobalNbIdSuccess(t *testing.T) {
+	controller, readerMock, writerMock, _, _, ranListManager := setupControllerTest(t)
+	nbIdentity := &entities.NbIdentity{InventoryName: "test1", GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"}}
+	writerMock.On("AddNbIdentity", entities.Node_ENB, nbIdentity).Return(nil)
+	_ = ranListManager.AddNbIdentity(entities.Node_ENB, nbIdentity)
+	var rnibError error
+	readerMock.On("GetNodeb", "test1").Return(&entities.NodebInfo{RanName: "test1", Ip: "10.0.2.15", Port: 1234}, rnibError)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb?plmnId=02f829&nbId=4a952a0a", nil)
+	controller.GetNodebByGlobalNbId(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "{\"ranName\":\"test1\",\"ip\":\"10.0.2.15\",\"port\":1234}", string(bodyBytes))
+}
+
+func TestControllerGetNodebByGlobalNbIdNotFound(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/nodeb?plmnId=02f829&nbId=4a952a0a", nil)
+	controller.GetNodebByGlobalNbId(writer, req)
+
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, ResourceNotFoundJson, string(bodyBytes))
+}
+
+func TestControllerGetNodebByGlobalNbIdMissingParameters(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	for _, query := range []string{"", "?plmnId=02f829", "?nbId=4a952a0a"} {
+		writer := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/nodeb"+query, nil)
+		controller.GetNodebByGlobalNbId(writer, req)
+		assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode, query)
+	}
+}
+
+func TestControllerGetCellSuccess(t *testing.T) {
+	controller, _, writerMock, _, _, ranListManager := setupControllerTest(t)
+	nbIdentity := &entities.NbIdentity{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+	writerMock.On("AddNbIdentity", entities.Node_ENB, nbIdentity).Return(nil)
+	_ = ranListManager.AddNbIdentity(entities.Node_ENB, nbIdentity)
+	ranListManager.UpdateNbIdentityCells(&entities.NodebInfo{
+		RanName:       "test1",
+		Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: []*entities.ServedCellInfo{{CellId: "02f829:0007ab50"}}}},
+	})
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cells/02f829:0007ab50", nil)
+	req = mux.SetURLVars(req, map[string]string{"cellId": "02f829:0007ab50"})
+	controller.GetCell(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "{\"cellId\":\"02f829:0007ab50\",\"ranName\":\"test1\",\"connectionStatus\":\"DISCONNECTED\"}", string(bodyBytes))
+}
+
+func TestControllerGetCellNotFound(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/cells/02f829:0007ab50", nil)
+	req = mux.SetURLVars(req, map[string]string{"cellId": "02f829:0007ab50"})
+	controller.GetCell(writer, req)
+
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+}
+
+func TestControllerGetRanFunctionsSuccess(t *testing.T) {
+	controller, _, writerMock, _, _, ranListManager := setupControllerTest(t)
+	nbIdentity := &entities.NbIdentity{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	writerMock.On("AddNbIdentity", entities.Node_GNB, nbIdentity).Return(nil)
+	_ = ranListManager.AddNbIdentity(entities.Node_GNB, nbIdentity)
+	ranListManager.UpdateNbIdentityRanFunctions("test1", []*entities.RanFunction{{RanFunctionId: 2, RanFunctionRevision: 1, RanFunctionOid: "1.3.6.1.4.1.53148.1.2.2.2"}})
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ranfunctions", nil)
+	controller.GetRanFunctions(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "[{\"ranFunctionOid\":\"1.3.6.1.4.1.53148.1.2.2.2\",\"nodes\":[{\"ranName\":\"test1\",\"ranFunctionId\":2,\"ranFunctionRevision\":1,\"connectionStatus\":\"CONNECTED\"}]}]", string(bodyBytes))
+}
+
+func TestControllerGetRanFunctionNodesFilteredByStatus(t *testing.T) {
+	controller, _, writerMock, _, _, ranListManager := setupControllerTest(t)
+	oid := "1.3.6.1.4.1.53148.1.2.2.2"
+	for name, status := range map[string]entities.ConnectionStatus{"test1": entities.ConnectionStatus_CONNECTED, "test2": entities.ConnectionStatus_DISCONNECTED} {
+		nbIdentity := &entities.NbIdentity{InventoryName: name, ConnectionStatus: status}
+		writerMock.On("AddNbIdentity", entities.Node_GNB, nbIdentity).Return(nil)
+		_ = ranListManager.AddNbIdentity(entities.Node_GNB, nbIdentity)
+		ranListManager.UpdateNbIdentityRanFunctions(name, []*entities.RanFunction{{RanFunctionId: 2, RanFunctionOid: oid}})
+	}
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ranfunctions/"+oid+"/nodes?status=CONNECTED", nil)
+	req = mux.SetURLVars(req, map[string]string{"oid": oid})
+	controller.GetRanFunctionNodes(writer, req)
+
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	bodyBytes, _ := ioutil.ReadAll(writer.Body)
+	assert.Equal(t, "{\"ranFunctionOid\":\"1.3.6.1.4.1.53148.1.2.2.2\",\"nodes\":[{\"ranName\":\"test1\",\"ranFunctionId\":2,\"ranFunctionRevision\":0,\"connectionStatus\":\"CONNECTED\"}]}", string(bodyBytes))
+}
+
+func TestControllerGetRanFunctionNodesNotFound(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ranfunctions/1.2.3/nodes", nil)
+	req = mux.SetURLVars(req, map[string]string{"oid": "1.2.3"})
+	controller.GetRanFunctionNodes(writer, req)
+
+	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
+}
+
+func TestControllerGetRanFunctionNodesInvalidStatus(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ranfunctions/1.2.3/nodes?status=CONNECTED,UP", nil)
+	req = mux.SetURLVars(req, map[string]string{"oid": "1.2.3"})
+	controller.GetRanFunctionNodes(writer, req)
+
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+}
+
 func TestHeaderValidationFailed(t *testing.T) {
 	controller, _, _, _, _, _ := setupControllerTest(t)
 
@@ -1603,7 +1883,7 @@ func TestHeaderValidationFailed(t *testing.T) {
 
 	header := &http.Header{}
 
-	controller.handleRequest(writer, header, httpmsghandlerprovider.ShutdownRequest, nil, true, 0)
+	controller.handleRequest(context.Background(), writer, header, httpmsghandlerprovider.ShutdownRequest, nil, true, 0)
 
 	var errorResponse = parseJsonRequest(t, writer.Body)
 	err := e2managererrors.NewHeaderValidationError()
@@ -1678,6 +1958,20 @@ func TestHandleE2TInstanceAbsenceError(t *testing.T) {
 	assert.Equal(t, errorResponse.Message, err.Message)
 }
 
+func TestHandleNotLeaderError(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	writer := httptest.NewRecorder()
+	err := e2managererrors.NewNotLeaderError()
+
+	controller.handleErrorResponse(err, writer)
+	var errorResponse = parseJsonRequest(t, writer.Body)
+
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Result().StatusCode)
+	assert.Equal(t, errorResponse.Code, err.Code)
+	assert.Equal(t, errorResponse.Message, err.Message)
+}
+
 func TestValidateHeaders(t *testing.T) {
 	controller, _, _, _, _, _ := setupControllerTest(t)
 
@@ -1724,14 +2018,24 @@ func TestX2ResetHandleSuccessfulRequestedCause(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	data4Req := map[string]interface{}{"cause": "protocol:transfer-syntax-error"}
+	data4Req := map[string]interface{}{"cause": "protocol:transfer-syntax-error", "async": true}
 	b := new(bytes.Buffer)
 	_ = json.NewEncoder(b).Encode(data4Req)
 	req, _ := http.NewRequest("PUT", "https://localhost:3800/nodeb-reset", b)
 	req = mux.SetURLVars(req, map[string]string{"ranName": ranName})
 
 	controller.X2Reset(writer, req)
-	assert.Equal(t, http.StatusNoContent, writer.Result().StatusCode)
+	assert.Equal(t, http.StatusAccepted, writer.Result().StatusCode)
+	job := models.X2ResetJob{}
+	_ = json.NewDecoder(writer.Body).Decode(&job)
+	assert.NotEmpty(t, job.JobId)
+	assert.Equal(t, models.X2ResetInProgress, job.Status)
+
+	writer = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/nodeb/x2reset/"+job.JobId, nil)
+	req = mux.SetURLVars(req, map[string]string{"jobId": job.JobId})
+	controller.GetX2ResetJob(writer, req)
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
 
 }
 
@@ -1751,15 +2055,15 @@ func TestX2ResetHandleSuccessfulRequestedDefault(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	// no body
+	// no cause
 	b := new(bytes.Buffer)
-	data4Req := map[string]interface{}{}
+	data4Req := map[string]interface{}{"async": true}
 	_ = json.NewEncoder(b).Encode(data4Req)
 	req, _ := http.NewRequest("PUT", "https://localhost:3800/nodeb-reset", b)
 	req = mux.SetURLVars(req, map[string]string{"ranName": ranName})
 
 	controller.X2Reset(writer, req)
-	assert.Equal(t, http.StatusNoContent, writer.Result().StatusCode)
+	assert.Equal(t, http.StatusAccepted, writer.Result().StatusCode)
 }
 
 func TestX2ResetHandleFailureBodyReadError(t *testing.T) {
@@ -1794,6 +2098,39 @@ func TestX2ResetHandleFailureInvalidBody(t *testing.T) {
 
 }
 
+func TestE2ResetHandleFailureInvalidBody(t *testing.T) {
+	controller, _, _, _, _, _ := setupControllerTest(t)
+
+	ranName := "test1"
+
+	writer := httptest.NewRecorder()
+
+	// Invalid json: attribute name without quotes (should be "cause":).
+	b := strings.NewReader("{cause:\"misc:om-intervention\"")
+	req, _ := http.NewRequest("PUT", "https://localhost:3800/v1/nodeb/test1/reset", b)
+	req = mux.SetURLVars(req, map[string]string{"ranName": ranName})
+
+	controller.E2Reset(writer, req)
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+}
+
+func TestE2ResetHandleFailureWrongState(t *testing.T) {
+	controller, readerMock, _, rmrMessengerMock, _, _ := setupControllerTest(t)
+
+	ranName := "test1"
+	var nodeb = &entities.NodebInfo{RanName: ranName, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
+
+	writer := httptest.NewRecorder()
+	b := strings.NewReader("{}")
+	req, _ := http.NewRequest("PUT", "https://localhost:3800/v1/nodeb/test1/reset", b)
+	req = mux.SetURLVars(req, map[string]string{"ranName": ranName})
+
+	controller.E2Reset(writer, req)
+	assert.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
+}
+
 /*
 func TestControllerHealthCheckRequestSuccess(t *testing.T) {
 	controller, readerMock, _, rmrMessengerMock, _, _ := setupControllerTest(t)
@@ -1857,6 +2194,10 @@ func TestHandleErrorResponse(t *testing.T) {
 	controller.handleErrorResponse(e2managererrors.NewResourceNotFoundError(), writer)
 	assert.Equal(t, http.StatusNotFound, writer.Result().StatusCode)
 
+	writer = httptest.NewRecorder()
+	controller.handleErrorResponse(e2managererrors.NewRanResponseTimeoutError(), writer)
+	assert.Equal(t, http.StatusGatewayTimeout, writer.Result().StatusCode)
+
 	writer = httptest.NewRecorder()
 	controller.handleErrorResponse(fmt.Errorf("ErrorError"), writer)
 	assert.Equal(t, http.StatusInternalServerError, writer.Result().StatusCode)
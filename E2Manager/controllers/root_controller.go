@@ -21,21 +21,35 @@
 package controllers
 
 import (
+	"e2mgr/clients"
+	"e2mgr/managers"
+	"e2mgr/models"
+	"e2mgr/rmrCgo"
 	"e2mgr/services"
 	"net/http"
+	"sync/atomic"
 )
 
 type IRootController interface {
 	HandleHealthCheckRequest(writer http.ResponseWriter, request *http.Request)
+	HandleReadinessRequest(writer http.ResponseWriter, request *http.Request)
+	HandleLivenessRequest(writer http.ResponseWriter, request *http.Request)
 }
 
 type RootController struct {
-	rnibDataService services.RNibDataService
+	rnibDataService      services.RNibDataService
+	rmrMessenger         rmrCgo.RmrMessenger
+	routingManagerClient clients.IRoutingManagerClient
+	ranListManager       managers.RanListManager
+	shuttingDown         int32
 }
 
-func NewRootController(rnibDataService services.RNibDataService) *RootController {
+func NewRootController(rnibDataService services.RNibDataService, rmrMessenger rmrCgo.RmrMessenger, routingManagerClient clients.IRoutingManagerClient, ranListManager managers.RanListManager) *RootController {
 	return &RootController{
-		rnibDataService: rnibDataService,
+		rnibDataService:      rnibDataService,
+		rmrMessenger:         rmrMessenger,
+		routingManagerClient: routingManagerClient,
+		ranListManager:       ranListManager,
 	}
 }
 
@@ -48,3 +62,39 @@ func (rc *RootController) HandleHealthCheckRequest(writer http.ResponseWriter, r
 
 	writer.WriteHeader(httpStatus)
 }
+
+// HandleReadinessRequest answers 200 only when E2 Manager can serve traffic: RNIB and the routing manager are reachable,
+// the RMR routing table is loaded, the RAN list is loaded and no shutdown is in progress
+func (rc *RootController) HandleReadinessRequest(writer http.ResponseWriter, request *http.Request) {
+	response := models.ReadinessResponse{
+		Rnib:           rc.rnibDataService.PingRnib(),
+		Rmr:            rc.rmrMessenger.IsReady(),
+		RoutingManager: rc.routingManagerClient.IsReachable(),
+		RanList:        rc.ranListManager.IsInitialized(),
+		ShuttingDown:   atomic.LoadInt32(&rc.shuttingDown) == 1,
+	}
+
+	httpStatus := http.StatusOK
+	if !response.IsReady() {
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	body, err := response.Marshal()
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set(ContentType, ApplicationJson)
+	writer.WriteHeader(httpStatus)
+	_, _ = writer.Write(body)
+}
+
+func (rc *RootController) HandleLivenessRequest(writer http.ResponseWriter, request *http.Request) {
+	writer.WriteHeader(http.StatusOK)
+}
+
+// SetShuttingDown makes the readiness check fail, so that no new traffic is routed to E2 Manager while it stops
+func (rc *RootController) SetShuttingDown() {
+	atomic.StoreInt32(&rc.shuttingDown, 1)
+}
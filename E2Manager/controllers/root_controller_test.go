@@ -52,7 +52,7 @@ func setupNodebControllerTest(t *testing.T) (services.RNibDataService, *mocks.Rn
 
 func TestNewRequestController(t *testing.T) {
 	rnibDataService, _ := setupNodebControllerTest(t)
-	assert.NotNil(t, NewRootController(rnibDataService))
+	assert.NotNil(t, NewRootController(rnibDataService, &mocks.RmrMessengerMock{}, &mocks.RoutingManagerClientMock{}, &mocks.RanListManagerMock{}))
 }
 
 func TestHandleHealthCheckRequestGood(t *testing.T) {
@@ -61,7 +61,7 @@ func TestHandleHealthCheckRequestGood(t *testing.T) {
 	var nbList []*entities.NbIdentity
 	rnibReaderMock.On("GetListNodebIds").Return(nbList, nil)
 
-	rc := NewRootController(rnibDataService)
+	rc := NewRootController(rnibDataService, &mocks.RmrMessengerMock{}, &mocks.RoutingManagerClientMock{}, &mocks.RanListManagerMock{})
 	writer := httptest.NewRecorder()
 	rc.HandleHealthCheckRequest(writer, nil)
 	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
@@ -74,7 +74,7 @@ func TestHandleHealthCheckRequestOtherError(t *testing.T) {
 	var nbList []*entities.NbIdentity
 	rnibReaderMock.On("GetListNodebIds").Return(nbList, mockOtherErr)
 
-	rc := NewRootController(rnibDataService)
+	rc := NewRootController(rnibDataService, &mocks.RmrMessengerMock{}, &mocks.RoutingManagerClientMock{}, &mocks.RanListManagerMock{})
 	writer := httptest.NewRecorder()
 	rc.HandleHealthCheckRequest(writer, nil)
 	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
@@ -88,8 +88,69 @@ func TestHandleHealthCheckRequestConnError(t *testing.T) {
 	rnibReaderMock.On("GetListNodebIds").Return(nbList, mockConnErr)
 
 
-	rc := NewRootController(rnibDataService)
+	rc := NewRootController(rnibDataService, &mocks.RmrMessengerMock{}, &mocks.RoutingManagerClientMock{}, &mocks.RanListManagerMock{})
 	writer := httptest.NewRecorder()
 	rc.HandleHealthCheckRequest(writer, nil)
 	assert.Equal(t, http.StatusInternalServerError, writer.Result().StatusCode)
 }
+
+func setupReadinessTest(t *testing.T, rmrReady bool, routingManagerReachable bool, ranListInitialized bool) *RootController {
+	rnibDataService, rnibReaderMock := setupNodebControllerTest(t)
+	var nbList []*entities.NbIdentity
+	rnibReaderMock.On("GetListNodebIds").Return(nbList, nil)
+
+	rmrMessengerMock := &mocks.RmrMessengerMock{}
+	rmrMessengerMock.On("IsReady").Return(rmrReady)
+	routingManagerClientMock := &mocks.RoutingManagerClientMock{}
+	routingManagerClientMock.On("IsReachable").Return(routingManagerReachable)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ranListManagerMock.On("IsInitialized").Return(ranListInitialized)
+
+	return NewRootController(rnibDataService, rmrMessengerMock, routingManagerClientMock, ranListManagerMock)
+}
+
+func TestHandleReadinessRequestReady(t *testing.T) {
+	rc := setupReadinessTest(t, true, true, true)
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+	assert.Equal(t, `{"rnib":true,"rmr":true,"routingManager":true,"ranList":true,"shuttingDown":false}`, writer.Body.String())
+}
+
+func TestHandleReadinessRequestRmrNotReady(t *testing.T) {
+	rc := setupReadinessTest(t, false, true, true)
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Result().StatusCode)
+	assert.Contains(t, writer.Body.String(), `"rmr":false`)
+}
+
+func TestHandleReadinessRequestRoutingManagerUnreachable(t *testing.T) {
+	rc := setupReadinessTest(t, true, false, true)
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Result().StatusCode)
+}
+
+func TestHandleReadinessRequestRanListNotLoaded(t *testing.T) {
+	rc := setupReadinessTest(t, true, true, false)
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Result().StatusCode)
+}
+
+func TestHandleReadinessRequestShuttingDown(t *testing.T) {
+	rc := setupReadinessTest(t, true, true, true)
+	rc.SetShuttingDown()
+	writer := httptest.NewRecorder()
+	rc.HandleReadinessRequest(writer, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Result().StatusCode)
+	assert.Contains(t, writer.Body.String(), `"shuttingDown":true`)
+}
+
+func TestHandleLivenessRequest(t *testing.T) {
+	rc := setupReadinessTest(t, false, false, false)
+	writer := httptest.NewRecorder()
+	rc.HandleLivenessRequest(writer, nil)
+	assert.Equal(t, http.StatusOK, writer.Result().StatusCode)
+}
@@ -21,6 +21,7 @@
 package controllers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -53,7 +54,7 @@ func NewSymptomdataController(l *logger.Logger, hp *httpmsghandlerprovider.Incom
 }
 
 func (s *SymptomdataController) GetSymptomData(w http.ResponseWriter, r *http.Request) {
-	e2TList := s.handleRequest(httpmsghandlerprovider.GetE2TInstancesRequest)
+	e2TList := s.handleRequest(r.Context(), httpmsghandlerprovider.GetE2TInstancesRequest)
 	nodeBList := s.ranListManager.GetNbIdentityList()
 
 	s.logger.Infof("nodeBList=%+v, e2TList=%+v", nodeBList, e2TList)
@@ -83,12 +84,12 @@ func (s *SymptomdataController) GetSymptomData(w http.ResponseWriter, r *http.Re
 	w.Write(resp)
 }
 
-func (s *SymptomdataController) handleRequest(requestName httpmsghandlerprovider.IncomingRequest) models.IResponse {
+func (s *SymptomdataController) handleRequest(ctx context.Context, requestName httpmsghandlerprovider.IncomingRequest) models.IResponse {
 	handler, err := s.handlerProvider.GetHandler(requestName)
 	if err != nil {
 		return nil
 	}
 
-	resp, err := handler.Handle(nil)
+	resp, err := handler.Handle(ctx, nil)
 	return resp
 }
@@ -1,6 +1,5 @@
 //
-// Copyright 2019 AT&T Intellectual Property
-// Copyright 2019 Nokia
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -13,30 +12,25 @@
 // WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 // See the License for the specific language governing permissions and
 // limitations under the License.
-//
 
 //  This source code is part of the near-RT RIC (RAN Intelligent Controller)
 //  platform project (RICP).
 
 package e2managererrors
 
-import "fmt"
-
-type UnknownSetupRequestRanNameError struct {
+type RanResponseTimeoutError struct {
 	*BaseError
-	ranName string
 }
 
-func NewUnknownSetupRequestRanNameError(ranName string) *UnknownSetupRequestRanNameError {
-	return &UnknownSetupRequestRanNameError{
+func NewRanResponseTimeoutError() *RanResponseTimeoutError {
+	return &RanResponseTimeoutError{
 		&BaseError{
-			Code:    599,
-			Message: fmt.Sprintf("Can't extract node-type out of RAN name %s", ranName),
+			Code:    512,
+			Message: "RAN did not respond within the configured timeout",
 		},
-		ranName,
 	}
 }
 
-func (e *UnknownSetupRequestRanNameError) Error() string {
+func (e *RanResponseTimeoutError) Error() string {
 	return e.Message
 }
@@ -0,0 +1,283 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// aperReader reads the subset of ASN.1 aligned PER needed by the E2SM RAN function definitions
+type aperReader struct {
+	data      []byte
+	bitOffset int
+}
+
+func newAperReader(data []byte) *aperReader {
+	return &aperReader{data: data}
+}
+
+func (r *aperReader) readBits(count int) (uint64, error) {
+	if count > 64 {
+		return 0, fmt.Errorf("cannot read %d bits at once", count)
+	}
+
+	if r.bitOffset+count > len(r.data)*8 {
+		return 0, fmt.Errorf("unexpected end of data at bit %d", r.bitOffset)
+	}
+
+	var value uint64
+	for i := 0; i < count; i++ {
+		bit := (r.data[r.bitOffset/8] >> (7 - uint(r.bitOffset%8))) & 1
+		value = value<<1 | uint64(bit)
+		r.bitOffset++
+	}
+
+	return value, nil
+}
+
+func (r *aperReader) readBool() (bool, error) {
+	bit, err := r.readBits(1)
+	return bit == 1, err
+}
+
+func (r *aperReader) align() {
+	if rest := r.bitOffset % 8; rest != 0 {
+		r.bitOffset += 8 - rest
+	}
+}
+
+// readOctets reads count octets from the current position, callers align first when the encoding requires it
+func (r *aperReader) readOctets(count int) ([]byte, error) {
+	if r.bitOffset+count*8 > len(r.data)*8 {
+		return nil, fmt.Errorf("unexpected end of data reading %d octets", count)
+	}
+
+	octets := make([]byte, count)
+	for i := range octets {
+		octet, _ := r.readBits(8)
+		octets[i] = byte(octet)
+	}
+
+	return octets, nil
+}
+
+// readPreamble reads the extension bit of an extensible SEQUENCE followed by the presence bitmap of its optional
+// root components
+func (r *aperReader) readPreamble(extensible bool, optionalCount int) (bool, []bool, error) {
+	extended := false
+
+	if extensible {
+		var err error
+		if extended, err = r.readBool(); err != nil {
+			return false, nil, err
+		}
+	}
+
+	present := make([]bool, optionalCount)
+	for i := range present {
+		var err error
+		if present[i], err = r.readBool(); err != nil {
+			return false, nil, err
+		}
+	}
+
+	return extended, present, nil
+}
+
+// skipExtensions skips the extension additions of a SEQUENCE whose extension bit was set, each addition being
+// an open type
+func (r *aperReader) skipExtensions() error {
+	large, err := r.readBool()
+	if err != nil {
+		return err
+	}
+
+	var count uint64
+	if large {
+		length, err := r.readLengthDeterminant()
+		if err != nil {
+			return err
+		}
+		count = uint64(length)
+	} else {
+		if count, err = r.readBits(6); err != nil {
+			return err
+		}
+		count++
+	}
+
+	present := 0
+	for i := uint64(0); i < count; i++ {
+		bit, err := r.readBool()
+		if err != nil {
+			return err
+		}
+		if bit {
+			present++
+		}
+	}
+
+	for i := 0; i < present; i++ {
+		length, err := r.readLengthDeterminant()
+		if err != nil {
+			return err
+		}
+		if _, err = r.readOctets(length); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *aperReader) readLengthDeterminant() (int, error) {
+	r.align()
+
+	first, err := r.readBits(8)
+	if err != nil {
+		return 0, err
+	}
+
+	if first&0x80 == 0 {
+		return int(first), nil
+	}
+
+	if first&0xc0 == 0x80 {
+		second, err := r.readBits(8)
+		if err != nil {
+			return 0, err
+		}
+		return int(first&0x3f)<<8 | int(second), nil
+	}
+
+	return 0, fmt.Errorf("fragmented length determinant is not supported")
+}
+
+func (r *aperReader) readConstrainedWholeNumber(lb int64, ub int64) (int64, error) {
+	valueRange := uint64(ub-lb) + 1
+
+	var value uint64
+	var err error
+
+	switch {
+	case valueRange == 1:
+		return lb, nil
+	case valueRange <= 255:
+		value, err = r.readBits(bits.Len64(valueRange - 1))
+	case valueRange == 256:
+		r.align()
+		value, err = r.readBits(8)
+	case valueRange <= 65536:
+		r.align()
+		value, err = r.readBits(16)
+	default:
+		maxOctets := (bits.Len64(valueRange-1) + 7) / 8
+		var octets uint64
+		if octets, err = r.readBits(bits.Len64(uint64(maxOctets - 1))); err != nil {
+			return 0, err
+		}
+		r.align()
+		value, err = r.readBits(int(octets+1) * 8)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return lb + int64(value), nil
+}
+
+// readInteger reads an INTEGER without constraint
+func (r *aperReader) readInteger() (int64, error) {
+	length, err := r.readLengthDeterminant()
+	if err != nil {
+		return 0, err
+	}
+
+	if length < 1 || length > 8 {
+		return 0, fmt.Errorf("unsupported integer length %d", length)
+	}
+
+	octets, err := r.readOctets(length)
+	if err != nil {
+		return 0, err
+	}
+
+	value := int64(int8(octets[0]))
+	for _, octet := range octets[1:] {
+		value = value<<8 | int64(octet)
+	}
+
+	return value, nil
+}
+
+// readExtensibleInteger reads an INTEGER (lb..ub, ...)
+func (r *aperReader) readExtensibleInteger(lb int64, ub int64) (int64, error) {
+	extended, err := r.readBool()
+	if err != nil {
+		return 0, err
+	}
+
+	if extended {
+		return r.readInteger()
+	}
+
+	return r.readConstrainedWholeNumber(lb, ub)
+}
+
+// readCount reads the number of items of a SEQUENCE (SIZE(lb..ub)) OF
+func (r *aperReader) readCount(lb int64, ub int64) (int, error) {
+	if ub >= 65536 {
+		return r.readLengthDeterminant()
+	}
+
+	count, err := r.readConstrainedWholeNumber(lb, ub)
+	return int(count), err
+}
+
+// readPrintableString reads a PrintableString (SIZE(lb..ub, ...))
+func (r *aperReader) readPrintableString(lb int64, ub int64) (string, error) {
+	extended, err := r.readBool()
+	if err != nil {
+		return "", err
+	}
+
+	var length int
+	if extended {
+		length, err = r.readLengthDeterminant()
+	} else {
+		length, err = r.readCount(lb, ub)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if extended || ub*8 > 16 {
+		r.align()
+	}
+
+	bytes, err := r.readOctets(length)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,245 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// aperWriter builds the aligned PER encodings read back by the decoders under test
+type aperWriter struct {
+	data      []byte
+	bitOffset int
+}
+
+func (w *aperWriter) writeBits(value uint64, count int) {
+	for i := count - 1; i >= 0; i-- {
+		if w.bitOffset%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		if (value>>uint(i))&1 == 1 {
+			w.data[w.bitOffset/8] |= 1 << (7 - uint(w.bitOffset%8))
+		}
+		w.bitOffset++
+	}
+}
+
+func (w *aperWriter) writeBool(value bool) {
+	if value {
+		w.writeBits(1, 1)
+	} else {
+		w.writeBits(0, 1)
+	}
+}
+
+func (w *aperWriter) align() {
+	if rest := w.bitOffset % 8; rest != 0 {
+		w.bitOffset += 8 - rest
+	}
+}
+
+func (w *aperWriter) writeOctets(octets []byte) {
+	for _, octet := range octets {
+		w.writeBits(uint64(octet), 8)
+	}
+}
+
+func (w *aperWriter) writePreamble(extended bool, present ...bool) {
+	w.writeBool(extended)
+	for _, p := range present {
+		w.writeBool(p)
+	}
+}
+
+func (w *aperWriter) writeExtensions(additions ...[]byte) {
+	w.writeBool(false)
+	w.writeBits(uint64(len(additions)-1), 6)
+	for range additions {
+		w.writeBool(true)
+	}
+	for _, addition := range additions {
+		w.writeLength(len(addition))
+		w.writeOctets(addition)
+	}
+}
+
+func (w *aperWriter) writeLength(length int) {
+	w.align()
+	if length < 128 {
+		w.writeBits(uint64(length), 8)
+	} else {
+		w.writeBits(uint64(0x8000|length), 16)
+	}
+}
+
+func (w *aperWriter) writeConstrainedWholeNumber(value int64, lb int64, ub int64) {
+	valueRange := uint64(ub-lb) + 1
+	offset := uint64(value - lb)
+
+	switch {
+	case valueRange == 1:
+	case valueRange <= 255:
+		w.writeBits(offset, bits.Len64(valueRange-1))
+	case valueRange == 256:
+		w.align()
+		w.writeBits(offset, 8)
+	case valueRange <= 65536:
+		w.align()
+		w.writeBits(offset, 16)
+	default:
+		maxOctets := (bits.Len64(valueRange-1) + 7) / 8
+		octets := (bits.Len64(offset) + 7) / 8
+		if octets == 0 {
+			octets = 1
+		}
+		w.writeBits(uint64(octets-1), bits.Len64(uint64(maxOctets-1)))
+		w.align()
+		w.writeBits(offset, octets*8)
+	}
+}
+
+func (w *aperWriter) writeInteger(value int64) {
+	octets := 1
+	for value < -(1<<(uint(octets)*8-1)) || value >= 1<<(uint(octets)*8-1) {
+		octets++
+	}
+	w.writeLength(octets)
+	w.writeBits(uint64(value), octets*8)
+}
+
+func (w *aperWriter) writeExtensibleInteger(value int64, lb int64, ub int64) {
+	w.writeBool(false)
+	w.writeConstrainedWholeNumber(value, lb, ub)
+}
+
+func (w *aperWriter) writeCount(count int, lb int64, ub int64) {
+	if ub >= 65536 {
+		w.writeLength(count)
+		return
+	}
+	w.writeConstrainedWholeNumber(int64(count), lb, ub)
+}
+
+func (w *aperWriter) writePrintableString(value string, lb int64, ub int64) {
+	w.writeBool(false)
+	w.writeCount(len(value), lb, ub)
+	if ub*8 > 16 {
+		w.align()
+	}
+	w.writeOctets([]byte(value))
+}
+
+func TestReadPrintableStringIsAlignedAfterItsLength(t *testing.T) {
+	r := newAperReader([]byte{0x01, 0x00, 0x4b, 0x50, 0x4d})
+
+	value, err := r.readPrintableString(1, 150)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "KPM", value)
+}
+
+func TestReadOidPrintableStringHasTwoOctetsLength(t *testing.T) {
+	r := newAperReader([]byte{0x00, 0x00, 0x02, 0x31, 0x2e, 0x33})
+
+	value, err := r.readPrintableString(1, 1000)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.3", value)
+}
+
+func TestReadInteger(t *testing.T) {
+	for _, value := range []int64{0, 1, 127, 128, -1, -129, 65536, 1 << 40} {
+		w := &aperWriter{}
+		w.writeInteger(value)
+
+		actual, err := newAperReader(w.data).readInteger()
+
+		assert.Nil(t, err)
+		assert.Equal(t, value, actual)
+	}
+}
+
+func TestReadExtensibleInteger(t *testing.T) {
+	for _, value := range []int64{1, 255, 65535, 4294967295} {
+		w := &aperWriter{}
+		w.writeBits(0x5, 3)
+		w.writeExtensibleInteger(value, 1, 4294967295)
+
+		r := newAperReader(w.data)
+		_, _ = r.readBits(3)
+		actual, err := r.readExtensibleInteger(1, 4294967295)
+
+		assert.Nil(t, err)
+		assert.Equal(t, value, actual)
+	}
+}
+
+func TestReadExtensibleIntegerOutOfRoot(t *testing.T) {
+	w := &aperWriter{}
+	w.writeBool(true)
+	w.writeInteger(70000)
+
+	actual, err := newAperReader(w.data).readExtensibleInteger(1, 65536)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(70000), actual)
+}
+
+func TestReadLengthDeterminant(t *testing.T) {
+	for _, length := range []int{0, 127, 128, 16383} {
+		w := &aperWriter{}
+		w.writeLength(length)
+
+		actual, err := newAperReader(w.data).readLengthDeterminant()
+
+		assert.Nil(t, err)
+		assert.Equal(t, length, actual)
+	}
+}
+
+func TestReadLengthDeterminantFragmented(t *testing.T) {
+	_, err := newAperReader([]byte{0xc1}).readLengthDeterminant()
+
+	assert.NotNil(t, err)
+}
+
+func TestSkipExtensions(t *testing.T) {
+	w := &aperWriter{}
+	w.writeExtensions([]byte{0x01, 0x02}, []byte{0x03})
+	w.writeInteger(42)
+
+	r := newAperReader(w.data)
+	err := r.skipExtensions()
+	assert.Nil(t, err)
+
+	value, err := r.readInteger()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), value)
+}
+
+func TestReadPastEndOfData(t *testing.T) {
+	r := newAperReader([]byte{0x02, 0x80, 0x4b})
+
+	_, err := r.readPrintableString(1, 150)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,117 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import "e2mgr/models"
+
+const (
+	maxnoofRicStyles              = 63
+	maxnoofMeasurementInfo        = 65535
+	maxnoofAssociatedRanParams    = 65535
+	maxnoofCallProcessTypes       = 65535
+	maxnoofCallProcessBreakpoints = 65535
+	maxPrintableStringLength      = 150
+	maxOidLength                  = 1000
+	maxRanParameterId             = 4294967295
+)
+
+// IDecoder decodes the APER encoded RAN function definition of one E2 service model
+type IDecoder interface {
+	Decode(definition []byte) (*models.E2smRanFunctionDefinition, error)
+}
+
+// decodeRanFunctionName decodes the RANfunction-Name SEQUENCE shared by the O-RAN service models
+func decodeRanFunctionName(r *aperReader, definition *models.E2smRanFunctionDefinition) error {
+	extended, present, err := r.readPreamble(true, 1)
+	if err != nil {
+		return err
+	}
+
+	if definition.ShortName, err = r.readPrintableString(1, maxPrintableStringLength); err != nil {
+		return err
+	}
+
+	if definition.Oid, err = r.readPrintableString(1, maxOidLength); err != nil {
+		return err
+	}
+
+	if definition.Description, err = r.readPrintableString(1, maxPrintableStringLength); err != nil {
+		return err
+	}
+
+	if present[0] {
+		instance, err := r.readInteger()
+		if err != nil {
+			return err
+		}
+		definition.Instance = &instance
+	}
+
+	if extended {
+		return r.skipExtensions()
+	}
+
+	return nil
+}
+
+// decodeStyle decodes the type and the name which start every RIC style item
+func decodeStyle(r *aperReader) (int64, string, error) {
+	styleType, err := r.readInteger()
+	if err != nil {
+		return 0, "", err
+	}
+
+	name, err := r.readPrintableString(1, maxPrintableStringLength)
+	return styleType, name, err
+}
+
+// decodeEventTriggerStyles decodes a list of RIC-EventTriggerStyle-Item, identical in E2SM-KPM and E2SM-RC
+func decodeEventTriggerStyles(r *aperReader) ([]*models.E2smEventTriggerStyle, error) {
+	count, err := r.readCount(1, maxnoofRicStyles)
+	if err != nil {
+		return nil, err
+	}
+
+	styles := make([]*models.E2smEventTriggerStyle, 0, count)
+	for i := 0; i < count; i++ {
+		extended, _, err := r.readPreamble(true, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		style := &models.E2smEventTriggerStyle{}
+		if style.Type, style.Name, err = decodeStyle(r); err != nil {
+			return nil, err
+		}
+
+		if style.FormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if extended {
+			if err = r.skipExtensions(); err != nil {
+				return nil, err
+			}
+		}
+
+		styles = append(styles, style)
+	}
+
+	return styles, nil
+}
@@ -0,0 +1,120 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import (
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	KpmV2Oid = "1.3.6.1.4.1.53148.1.2.2.2"
+	KpmV3Oid = "1.3.6.1.4.1.53148.1.3.2.2"
+	RcV1Oid  = "1.3.6.1.4.1.53148.1.1.2.3"
+)
+
+// IDecoderRegistry decodes RAN function definitions with the decoder registered for their OID
+type IDecoderRegistry interface {
+	Register(oid string, decoder IDecoder)
+	Decode(ranName string, ranFunctions []*entities.RanFunction) []*models.DecodedRanFunction
+}
+
+type DecoderRegistry struct {
+	logger   *logger.Logger
+	decoders map[string]IDecoder
+	mux      sync.RWMutex
+}
+
+// NewDecoderRegistry returns a registry holding the built-in E2SM-KPM and E2SM-RC decoders
+func NewDecoderRegistry(logger *logger.Logger) *DecoderRegistry {
+	registry := &DecoderRegistry{
+		logger:   logger,
+		decoders: map[string]IDecoder{},
+	}
+
+	registry.Register(KpmV2Oid, NewKpmDecoder("2.0"))
+	registry.Register(KpmV3Oid, NewKpmDecoder("3.0"))
+	registry.Register(RcV1Oid, NewRcDecoder("1.03"))
+
+	return registry
+}
+
+// Register adds a decoder for a RAN function OID, replacing any decoder already registered for it
+func (r *DecoderRegistry) Register(oid string, decoder IDecoder) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.decoders[oid] = decoder
+}
+
+// Decode decodes each RAN function definition, falling back to the raw definition when no decoder is registered
+// for the OID or when decoding fails
+func (r *DecoderRegistry) Decode(ranName string, ranFunctions []*entities.RanFunction) []*models.DecodedRanFunction {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	decodedRanFunctions := make([]*models.DecodedRanFunction, 0, len(ranFunctions))
+
+	for _, ranFunction := range ranFunctions {
+		decodedRanFunction := &models.DecodedRanFunction{
+			RanFunctionId:       ranFunction.GetRanFunctionId(),
+			RanFunctionRevision: ranFunction.GetRanFunctionRevision(),
+			RanFunctionOid:      ranFunction.GetRanFunctionOid(),
+		}
+
+		decodedRanFunction.Definition = r.decode(ranName, ranFunction)
+
+		if decodedRanFunction.Definition == nil {
+			decodedRanFunction.RawDefinition = ranFunction.GetRanFunctionDefinition()
+		}
+
+		decodedRanFunctions = append(decodedRanFunctions, decodedRanFunction)
+	}
+
+	return decodedRanFunctions
+}
+
+func (r *DecoderRegistry) decode(ranName string, ranFunction *entities.RanFunction) *models.E2smRanFunctionDefinition {
+	decoder, ok := r.decoders[ranFunction.GetRanFunctionOid()]
+
+	if !ok {
+		return nil
+	}
+
+	data, err := hex.DecodeString(strings.Join(strings.Fields(ranFunction.GetRanFunctionDefinition()), ""))
+
+	if err != nil {
+		r.logger.Warnf("#DecoderRegistry.decode - RAN name: %s - RAN function id: %d - definition is not hex encoded: %s", ranName, ranFunction.GetRanFunctionId(), err)
+		return nil
+	}
+
+	definition, err := decoder.Decode(data)
+
+	if err != nil {
+		r.logger.Warnf("#DecoderRegistry.decode - RAN name: %s - RAN function id: %d - failed decoding definition of OID %s: %s", ranName, ranFunction.GetRanFunctionId(), ranFunction.GetRanFunctionOid(), err)
+		return nil
+	}
+
+	return definition
+}
@@ -0,0 +1,115 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import (
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingDecoder struct{}
+
+func (d *failingDecoder) Decode(data []byte) (*models.E2smRanFunctionDefinition, error) {
+	return nil, errors.New("unsupported definition")
+}
+
+type nameDecoder struct{}
+
+func (d *nameDecoder) Decode(data []byte) (*models.E2smRanFunctionDefinition, error) {
+	return &models.E2smRanFunctionDefinition{ServiceModelName: "E2SM-TEST", ShortName: string(data)}, nil
+}
+
+func initLog(t *testing.T) *logger.Logger {
+	DebugLevel := int8(4)
+	log, err := logger.InitLogger(DebugLevel)
+	if err != nil {
+		t.Errorf("#initLog test - failed to initialize logger, error: %s", err)
+	}
+	return log
+}
+
+func TestDecoderRegistryDecodesBuiltInServiceModels(t *testing.T) {
+	registry := NewDecoderRegistry(initLog(t))
+	kpmHex := hex.EncodeToString(buildKpmDefinition())
+	ranFunctions := []*entities.RanFunction{
+		{RanFunctionId: 2, RanFunctionRevision: 1, RanFunctionOid: KpmV2Oid, RanFunctionDefinition: kpmHex[:6] + " " + kpmHex[6:]},
+		{RanFunctionId: 3, RanFunctionOid: RcV1Oid, RanFunctionDefinition: hex.EncodeToString(buildRcDefinition())},
+	}
+
+	decodedRanFunctions := registry.Decode("gnb_1", ranFunctions)
+
+	assert.Len(t, decodedRanFunctions, 2)
+	assert.Equal(t, uint32(2), decodedRanFunctions[0].RanFunctionId)
+	assert.Equal(t, uint32(1), decodedRanFunctions[0].RanFunctionRevision)
+	assert.Equal(t, KpmServiceModelName, decodedRanFunctions[0].Definition.ServiceModelName)
+	assert.Empty(t, decodedRanFunctions[0].RawDefinition)
+	assert.Equal(t, RcServiceModelName, decodedRanFunctions[1].Definition.ServiceModelName)
+}
+
+func TestDecoderRegistryUnknownOidFallsBackToRaw(t *testing.T) {
+	registry := NewDecoderRegistry(initLog(t))
+	ranFunctions := []*entities.RanFunction{{RanFunctionId: 1, RanFunctionOid: "1.3.6.1.4.1.1.1", RanFunctionDefinition: "20 6D 6F 6E"}}
+
+	decodedRanFunctions := registry.Decode("gnb_1", ranFunctions)
+
+	assert.Nil(t, decodedRanFunctions[0].Definition)
+	assert.Equal(t, "20 6D 6F 6E", decodedRanFunctions[0].RawDefinition)
+}
+
+func TestDecoderRegistryDecodeFailureFallsBackToRaw(t *testing.T) {
+	registry := NewDecoderRegistry(initLog(t))
+	registry.Register("1.2.3", &failingDecoder{})
+	ranFunctions := []*entities.RanFunction{
+		{RanFunctionId: 1, RanFunctionOid: "1.2.3", RanFunctionDefinition: "334455"},
+		{RanFunctionId: 2, RanFunctionOid: KpmV2Oid, RanFunctionDefinition: "not hex"},
+	}
+
+	decodedRanFunctions := registry.Decode("gnb_1", ranFunctions)
+
+	assert.Nil(t, decodedRanFunctions[0].Definition)
+	assert.Equal(t, "334455", decodedRanFunctions[0].RawDefinition)
+	assert.Nil(t, decodedRanFunctions[1].Definition)
+	assert.Equal(t, "not hex", decodedRanFunctions[1].RawDefinition)
+}
+
+func TestDecoderRegistryRegister(t *testing.T) {
+	registry := NewDecoderRegistry(initLog(t))
+	registry.Register("1.2.3", &nameDecoder{})
+	ranFunctions := []*entities.RanFunction{{RanFunctionId: 1, RanFunctionOid: "1.2.3", RanFunctionDefinition: "414243"}}
+
+	decodedRanFunctions := registry.Decode("gnb_1", ranFunctions)
+
+	assert.Equal(t, "E2SM-TEST", decodedRanFunctions[0].Definition.ServiceModelName)
+	assert.Equal(t, "ABC", decodedRanFunctions[0].Definition.ShortName)
+}
+
+func TestDecoderRegistryNoRanFunctions(t *testing.T) {
+	registry := NewDecoderRegistry(initLog(t))
+
+	decodedRanFunctions := registry.Decode("enb_1", nil)
+
+	assert.NotNil(t, decodedRanFunctions)
+	assert.Empty(t, decodedRanFunctions)
+}
@@ -0,0 +1,145 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import "e2mgr/models"
+
+const KpmServiceModelName = "E2SM-KPM"
+
+// KpmDecoder decodes the E2SM-KPM-RANfunction-Description, which has the same root in versions 2 and 3
+type KpmDecoder struct {
+	version string
+}
+
+func NewKpmDecoder(version string) *KpmDecoder {
+	return &KpmDecoder{
+		version: version,
+	}
+}
+
+func (d *KpmDecoder) Decode(data []byte) (*models.E2smRanFunctionDefinition, error) {
+	r := newAperReader(data)
+	definition := &models.E2smRanFunctionDefinition{ServiceModelName: KpmServiceModelName, ServiceModelVersion: d.version}
+
+	_, present, err := r.readPreamble(true, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decodeRanFunctionName(r, definition); err != nil {
+		return nil, err
+	}
+
+	if present[0] {
+		if definition.EventTriggerStyles, err = decodeEventTriggerStyles(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if present[1] {
+		if definition.ReportStyles, err = decodeKpmReportStyles(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return definition, nil
+}
+
+func decodeKpmReportStyles(r *aperReader) ([]*models.E2smReportStyle, error) {
+	count, err := r.readCount(1, maxnoofRicStyles)
+	if err != nil {
+		return nil, err
+	}
+
+	styles := make([]*models.E2smReportStyle, 0, count)
+	for i := 0; i < count; i++ {
+		extended, _, err := r.readPreamble(true, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		style := &models.E2smReportStyle{}
+		if style.Type, style.Name, err = decodeStyle(r); err != nil {
+			return nil, err
+		}
+
+		if style.ActionFormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if style.MeasurementNames, err = decodeKpmMeasurementNames(r); err != nil {
+			return nil, err
+		}
+
+		if style.IndicationHeaderFormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if style.IndicationMessageFormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if extended {
+			if err = r.skipExtensions(); err != nil {
+				return nil, err
+			}
+		}
+
+		styles = append(styles, style)
+	}
+
+	return styles, nil
+}
+
+// decodeKpmMeasurementNames decodes a MeasurementInfo-Action-List, keeping the measurement names
+func decodeKpmMeasurementNames(r *aperReader) ([]string, error) {
+	count, err := r.readCount(1, maxnoofMeasurementInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		extended, present, err := r.readPreamble(true, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := r.readPrintableString(1, maxPrintableStringLength)
+		if err != nil {
+			return nil, err
+		}
+
+		if present[0] {
+			if _, err = r.readExtensibleInteger(1, 65536); err != nil {
+				return nil, err
+			}
+		}
+
+		if extended {
+			if err = r.skipExtensions(); err != nil {
+				return nil, err
+			}
+		}
+
+		names = append(names, name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,128 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRanFunctionName(w *aperWriter, shortName string, oid string, description string) {
+	w.writePreamble(false, false)
+	w.writePrintableString(shortName, 1, maxPrintableStringLength)
+	w.writePrintableString(oid, 1, maxOidLength)
+	w.writePrintableString(description, 1, maxPrintableStringLength)
+}
+
+func writeEventTriggerStyle(w *aperWriter, styleType int64, name string, formatType int64) {
+	w.writePreamble(false)
+	w.writeInteger(styleType)
+	w.writePrintableString(name, 1, maxPrintableStringLength)
+	w.writeInteger(formatType)
+}
+
+func buildKpmDefinition() []byte {
+	w := &aperWriter{}
+	w.writePreamble(false, true, true)
+	writeRanFunctionName(w, "ORAN-E2SM-KPM", KpmV2Oid, "KPM Monitor")
+
+	w.writeCount(1, 1, maxnoofRicStyles)
+	writeEventTriggerStyle(w, 1, "Periodic Report", 1)
+
+	w.writeCount(2, 1, maxnoofRicStyles)
+
+	w.writePreamble(false)
+	w.writeInteger(1)
+	w.writePrintableString("E2 Node Measurement", 1, maxPrintableStringLength)
+	w.writeInteger(1)
+	w.writeCount(2, 1, maxnoofMeasurementInfo)
+	w.writePreamble(false, true)
+	w.writePrintableString("DRB.UEThpDl", 1, maxPrintableStringLength)
+	w.writeExtensibleInteger(1, 1, 65536)
+	w.writePreamble(true, false)
+	w.writePrintableString("RRU.PrbTotDl", 1, maxPrintableStringLength)
+	w.writeExtensions([]byte{0x20})
+	w.writeInteger(1)
+	w.writeInteger(1)
+
+	w.writePreamble(false)
+	w.writeInteger(4)
+	w.writePrintableString("Common Condition-based, UE-level Measurement", 1, maxPrintableStringLength)
+	w.writeInteger(4)
+	w.writeCount(1, 1, maxnoofMeasurementInfo)
+	w.writePreamble(false, false)
+	w.writePrintableString("DRB.RlcSduDelayDl", 1, maxPrintableStringLength)
+	w.writeInteger(1)
+	w.writeInteger(2)
+
+	return w.data
+}
+
+func TestKpmDecoderDecode(t *testing.T) {
+	definition, err := NewKpmDecoder("2.0").Decode(buildKpmDefinition())
+
+	assert.Nil(t, err)
+	assert.Equal(t, KpmServiceModelName, definition.ServiceModelName)
+	assert.Equal(t, "2.0", definition.ServiceModelVersion)
+	assert.Equal(t, "ORAN-E2SM-KPM", definition.ShortName)
+	assert.Equal(t, KpmV2Oid, definition.Oid)
+	assert.Equal(t, "KPM Monitor", definition.Description)
+	assert.Nil(t, definition.Instance)
+
+	assert.Len(t, definition.EventTriggerStyles, 1)
+	assert.Equal(t, "Periodic Report", definition.EventTriggerStyles[0].Name)
+
+	assert.Len(t, definition.ReportStyles, 2)
+	assert.Equal(t, int64(1), definition.ReportStyles[0].Type)
+	assert.Equal(t, "E2 Node Measurement", definition.ReportStyles[0].Name)
+	assert.Equal(t, int64(1), definition.ReportStyles[0].ActionFormatType)
+	assert.Equal(t, []string{"DRB.UEThpDl", "RRU.PrbTotDl"}, definition.ReportStyles[0].MeasurementNames)
+	assert.Equal(t, int64(4), definition.ReportStyles[1].Type)
+	assert.Equal(t, int64(4), definition.ReportStyles[1].ActionFormatType)
+	assert.Equal(t, int64(1), definition.ReportStyles[1].IndicationHeaderFormatType)
+	assert.Equal(t, int64(2), definition.ReportStyles[1].IndicationMessageFormatType)
+	assert.Equal(t, []string{"DRB.RlcSduDelayDl"}, definition.ReportStyles[1].MeasurementNames)
+	assert.Nil(t, definition.ReportStyles[1].SupportedEventTriggerStyleType)
+}
+
+func TestKpmDecoderDecodeNameOnly(t *testing.T) {
+	w := &aperWriter{}
+	w.writePreamble(false, false, false)
+	w.writePreamble(false, true)
+	w.writePrintableString("ORAN-E2SM-KPM", 1, maxPrintableStringLength)
+	w.writePrintableString(KpmV3Oid, 1, maxOidLength)
+	w.writePrintableString("KPM Monitor", 1, maxPrintableStringLength)
+	w.writeInteger(3)
+
+	definition, err := NewKpmDecoder("3.0").Decode(w.data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, KpmV3Oid, definition.Oid)
+	assert.Equal(t, int64(3), *definition.Instance)
+	assert.Nil(t, definition.ReportStyles)
+}
+
+func TestKpmDecoderDecodeTruncated(t *testing.T) {
+	data := buildKpmDefinition()
+
+	_, err := NewKpmDecoder("2.0").Decode(data[:len(data)/2])
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,276 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import "e2mgr/models"
+
+const RcServiceModelName = "E2SM-RC"
+
+// RcDecoder decodes the E2SM-RC-RANFunctionDefinition up to its report styles, the insert, control and policy
+// definitions which follow are not part of the structured view
+type RcDecoder struct {
+	version string
+}
+
+func NewRcDecoder(version string) *RcDecoder {
+	return &RcDecoder{
+		version: version,
+	}
+}
+
+func (d *RcDecoder) Decode(data []byte) (*models.E2smRanFunctionDefinition, error) {
+	r := newAperReader(data)
+	definition := &models.E2smRanFunctionDefinition{ServiceModelName: RcServiceModelName, ServiceModelVersion: d.version}
+
+	_, present, err := r.readPreamble(true, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = decodeRanFunctionName(r, definition); err != nil {
+		return nil, err
+	}
+
+	if present[0] {
+		if definition.EventTriggerStyles, err = decodeRcEventTrigger(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if present[1] {
+		if definition.ReportStyles, err = decodeRcReportStyles(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return definition, nil
+}
+
+// decodeRcEventTrigger decodes the RANFunctionDefinition-EventTrigger, keeping its styles. The parameter lists
+// are read only to reach the report definition
+func decodeRcEventTrigger(r *aperReader) ([]*models.E2smEventTriggerStyle, error) {
+	extended, present, err := r.readPreamble(true, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	styles, err := decodeEventTriggerStyles(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if present[0] {
+		if _, err = decodeRcRanParameters(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if present[1] {
+		if err = skipRcCallProcessTypes(r); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, listPresent := range present[2:] {
+		if listPresent {
+			if _, err = decodeRcRanParameters(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if extended {
+		if err = r.skipExtensions(); err != nil {
+			return nil, err
+		}
+	}
+
+	return styles, nil
+}
+
+func skipRcCallProcessTypes(r *aperReader) error {
+	count, err := r.readCount(1, maxnoofCallProcessTypes)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < count; i++ {
+		extended, _, err := r.readPreamble(true, 0)
+		if err != nil {
+			return err
+		}
+
+		if _, err = r.readExtensibleInteger(1, maxnoofCallProcessTypes); err != nil {
+			return err
+		}
+
+		if _, err = r.readPrintableString(1, maxPrintableStringLength); err != nil {
+			return err
+		}
+
+		if err = skipRcCallProcessBreakpoints(r); err != nil {
+			return err
+		}
+
+		if extended {
+			if err = r.skipExtensions(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func skipRcCallProcessBreakpoints(r *aperReader) error {
+	count, err := r.readCount(1, maxnoofCallProcessBreakpoints)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < count; i++ {
+		extended, present, err := r.readPreamble(true, 1)
+		if err != nil {
+			return err
+		}
+
+		if _, err = r.readExtensibleInteger(1, maxnoofCallProcessBreakpoints); err != nil {
+			return err
+		}
+
+		if _, err = r.readPrintableString(1, maxPrintableStringLength); err != nil {
+			return err
+		}
+
+		if present[0] {
+			if _, err = decodeRcRanParameters(r); err != nil {
+				return err
+			}
+		}
+
+		if extended {
+			if err = r.skipExtensions(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func decodeRcReportStyles(r *aperReader) ([]*models.E2smReportStyle, error) {
+	extended, _, err := r.readPreamble(true, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.readCount(1, maxnoofRicStyles)
+	if err != nil {
+		return nil, err
+	}
+
+	styles := make([]*models.E2smReportStyle, 0, count)
+	for i := 0; i < count; i++ {
+		itemExtended, present, err := r.readPreamble(true, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		style := &models.E2smReportStyle{}
+		if style.Type, style.Name, err = decodeStyle(r); err != nil {
+			return nil, err
+		}
+
+		eventTriggerStyleType, err := r.readInteger()
+		if err != nil {
+			return nil, err
+		}
+		style.SupportedEventTriggerStyleType = &eventTriggerStyleType
+
+		if style.ActionFormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if style.IndicationHeaderFormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if style.IndicationMessageFormatType, err = r.readInteger(); err != nil {
+			return nil, err
+		}
+
+		if present[0] {
+			if style.RanParameters, err = decodeRcRanParameters(r); err != nil {
+				return nil, err
+			}
+		}
+
+		if itemExtended {
+			if err = r.skipExtensions(); err != nil {
+				return nil, err
+			}
+		}
+
+		styles = append(styles, style)
+	}
+
+	if extended {
+		if err = r.skipExtensions(); err != nil {
+			return nil, err
+		}
+	}
+
+	return styles, nil
+}
+
+// decodeRcRanParameters decodes a list of RAN parameter items, the recursive RANParameter-Definition being an
+// extension addition it is skipped
+func decodeRcRanParameters(r *aperReader) ([]*models.E2smRanParameter, error) {
+	count, err := r.readCount(1, maxnoofAssociatedRanParams)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := make([]*models.E2smRanParameter, 0, count)
+	for i := 0; i < count; i++ {
+		extended, _, err := r.readPreamble(true, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		parameter := &models.E2smRanParameter{}
+		if parameter.Id, err = r.readExtensibleInteger(1, maxRanParameterId); err != nil {
+			return nil, err
+		}
+
+		if parameter.Name, err = r.readPrintableString(1, maxPrintableStringLength); err != nil {
+			return nil, err
+		}
+
+		if extended {
+			if err = r.skipExtensions(); err != nil {
+				return nil, err
+			}
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	return parameters, nil
+}
@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package e2sm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRanParameters(w *aperWriter, parameters map[int64]string, ids ...int64) {
+	w.writeCount(len(ids), 1, maxnoofAssociatedRanParams)
+	for _, id := range ids {
+		w.writePreamble(false)
+		w.writeExtensibleInteger(id, 1, maxRanParameterId)
+		w.writePrintableString(parameters[id], 1, maxPrintableStringLength)
+	}
+}
+
+func buildRcDefinition() []byte {
+	parameters := map[int64]string{1: "Message Copy", 2: "UE ID", 21528: "List of Neighbor cells", 4294967295: "Cell Global ID"}
+
+	w := &aperWriter{}
+	w.writePreamble(false, true, true, true, false, false)
+	writeRanFunctionName(w, "ORAN-E2SM-RC", RcV1Oid, "RAN Control")
+
+	w.writePreamble(true, true, true, false, true)
+	w.writeCount(2, 1, maxnoofRicStyles)
+	writeEventTriggerStyle(w, 1, "Message Event", 1)
+	writeEventTriggerStyle(w, 2, "Call Process Breakpoint", 2)
+	writeRanParameters(w, parameters, 1)
+	w.writeCount(1, 1, maxnoofCallProcessTypes)
+	w.writePreamble(false)
+	w.writeExtensibleInteger(3, 1, maxnoofCallProcessTypes)
+	w.writePrintableString("Handover", 1, maxPrintableStringLength)
+	w.writeCount(1, 1, maxnoofCallProcessBreakpoints)
+	w.writePreamble(false, true)
+	w.writeExtensibleInteger(1, 1, maxnoofCallProcessBreakpoints)
+	w.writePrintableString("Handover Preparation", 1, maxPrintableStringLength)
+	writeRanParameters(w, parameters, 2, 21528)
+	writeRanParameters(w, parameters, 4294967295)
+	w.writeExtensions([]byte{0x00, 0x01})
+
+	w.writePreamble(false)
+	w.writeCount(1, 1, maxnoofRicStyles)
+	w.writePreamble(true, true)
+	w.writeInteger(2)
+	w.writePrintableString("Call Process Outcome", 1, maxPrintableStringLength)
+	w.writeInteger(2)
+	w.writeInteger(1)
+	w.writeInteger(1)
+	w.writeInteger(2)
+	writeRanParameters(w, parameters, 2, 21528)
+	w.writeExtensions([]byte{0xff})
+
+	// the insert definition, which the decoder does not read
+	w.writeOctets([]byte{0xde, 0xad})
+
+	return w.data
+}
+
+func TestRcDecoderDecode(t *testing.T) {
+	definition, err := NewRcDecoder("1.03").Decode(buildRcDefinition())
+
+	assert.Nil(t, err)
+	assert.Equal(t, RcServiceModelName, definition.ServiceModelName)
+	assert.Equal(t, "1.03", definition.ServiceModelVersion)
+	assert.Equal(t, "ORAN-E2SM-RC", definition.ShortName)
+	assert.Equal(t, RcV1Oid, definition.Oid)
+	assert.Equal(t, "RAN Control", definition.Description)
+
+	assert.Len(t, definition.EventTriggerStyles, 2)
+	assert.Equal(t, "Call Process Breakpoint", definition.EventTriggerStyles[1].Name)
+	assert.Equal(t, int64(2), definition.EventTriggerStyles[1].FormatType)
+
+	assert.Len(t, definition.ReportStyles, 1)
+	style := definition.ReportStyles[0]
+	assert.Equal(t, int64(2), style.Type)
+	assert.Equal(t, "Call Process Outcome", style.Name)
+	assert.Equal(t, int64(2), *style.SupportedEventTriggerStyleType)
+	assert.Equal(t, int64(1), style.ActionFormatType)
+	assert.Equal(t, int64(1), style.IndicationHeaderFormatType)
+	assert.Equal(t, int64(2), style.IndicationMessageFormatType)
+	assert.Len(t, style.RanParameters, 2)
+	assert.Equal(t, int64(21528), style.RanParameters[1].Id)
+	assert.Equal(t, "List of Neighbor cells", style.RanParameters[1].Name)
+	assert.Nil(t, style.MeasurementNames)
+}
+
+func TestRcDecoderDecodeTruncated(t *testing.T) {
+	data := buildRcDefinition()
+
+	_, err := NewRcDecoder("1.03").Decode(data[:len(data)/2])
+
+	assert.NotNil(t, err)
+}
@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
@@ -46,35 +47,36 @@ func NewAddEnbRequestHandler(logger *logger.Logger, rNibDataService services.RNi
 	}
 }
 
-func (h *AddEnbRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *AddEnbRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
 
 	addEnbRequest := request.(*models.AddEnbRequest)
 
-	h.logger.Infof("#AddEnbRequestHandler.Handle - Ran name: %s", addEnbRequest.RanName)
+	log.Infof("#AddEnbRequestHandler.Handle - Ran name: %s", addEnbRequest.RanName)
 
 	err := h.validateRequestBody(addEnbRequest)
 
 	if err != nil {
-		h.logger.Errorf("#AddEnbRequestHandler.Handle - validation failure: %s is a mandatory field and cannot be empty", err)
+		log.Errorf("#AddEnbRequestHandler.Handle - validation failure: %s is a mandatory field and cannot be empty", err)
 		return nil, e2managererrors.NewRequestValidationError()
 	}
 
 	enbType := addEnbRequest.Enb.GetEnbType()
 	if h.nodebValidator.IsNgEnbType(enbType){
-		h.logger.Errorf("#AddEnbRequestHandler.Handle - validation failure: enb type is not supported. enb type: %s", enbType)
+		log.Errorf("#AddEnbRequestHandler.Handle - validation failure: enb type is not supported. enb type: %s", enbType)
 		return nil, e2managererrors.NewRequestValidationError()
 	}
 
 	_, err = h.rNibDataService.GetNodeb(addEnbRequest.RanName)
 
 	if err == nil {
-		h.logger.Errorf("#AddEnbRequestHandler.Handle - RAN name: %s - RAN already exists. quit", addEnbRequest.RanName)
+		log.Errorf("#AddEnbRequestHandler.Handle - RAN name: %s - RAN already exists. quit", addEnbRequest.RanName)
 		return nil, e2managererrors.NewNodebExistsError()
 	}
 
 	_, ok := err.(*common.ResourceNotFoundError)
 	if !ok {
-		h.logger.Errorf("#AddEnbRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", addEnbRequest.RanName, err)
+		log.Errorf("#AddEnbRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", addEnbRequest.RanName, err)
 		return nil, e2managererrors.NewRnibDbError()
 	}
 
@@ -82,7 +84,7 @@ func (h *AddEnbRequestHandler) Handle(request models.Request) (models.IResponse,
 	err = h.rNibDataService.AddEnb(nodebInfo)
 
 	if err != nil {
-		h.logger.Errorf("#AddEnbRequestHandler.Handle - RAN name: %s - failed to add eNB entity in RNIB. Error: %s", addEnbRequest.RanName, err)
+		log.Errorf("#AddEnbRequestHandler.Handle - RAN name: %s - failed to add eNB entity in RNIB. Error: %s", addEnbRequest.RanName, err)
 		return nil, e2managererrors.NewRnibDbError()
 	}
 
@@ -93,6 +95,8 @@ func (h *AddEnbRequestHandler) Handle(request models.Request) (models.IResponse,
 		return nil, e2managererrors.NewRnibDbError()
 	}
 
+	h.ranListManager.UpdateNbIdentityCells(nodebInfo)
+
 	return models.NewNodebResponse(nodebInfo), nil
 }
 
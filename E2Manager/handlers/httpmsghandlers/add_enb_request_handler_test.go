@@ -1,6 +1,7 @@
 package httpmsghandlers
 
 import (
+	"context"
     "e2mgr/configuration"
     "e2mgr/managers"
     "e2mgr/mocks"
@@ -62,7 +63,7 @@ func TestHandleAddEnbSuccess(t *testing.T) {
         writerMock.On("AddEnb", nodebInfo).Return(nil)
         writerMock.On("AddNbIdentity", entities.Node_ENB, &entities.NbIdentity{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}).Return(nil)
         addEnbRequest := &models.AddEnbRequest{RanName: ranName}
-        result, err := handler.Handle(addEnbRequest)
+        result, err := handler.Handle(context.Background(), addEnbRequest)
         assert.NotNil(t, err)
         assert.Nil(t, result)
 }
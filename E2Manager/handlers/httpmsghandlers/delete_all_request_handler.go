@@ -21,6 +21,7 @@ package httpmsghandlers
 
 import "C"
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -61,8 +62,9 @@ func NewDeleteAllRequestHandler(logger *logger.Logger, rmrSender *rmrsender.RmrS
 	}
 }
 
-func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IResponse, error) {
-	h.logger.Infof("#DeleteAllRequestHandler.Handle - handling shutdown request")
+func (h *DeleteAllRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
+	log.Infof("#DeleteAllRequestHandler.Handle - handling shutdown request")
 
 	e2tAddresses, err := h.e2tInstancesManager.GetE2TAddresses()
 
@@ -71,17 +73,17 @@ func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IRespon
 	}
 
 	if len(e2tAddresses) == 0 {
-		err, _ = h.updateNodebs(h.updateNodebInfoForceShutdown)
+		err, _ = h.updateNodebs(ctx, h.updateNodebInfoForceShutdown)
 		return nil, err
 	}
 
 	dissocErr := h.rmClient.DissociateAllRans(e2tAddresses)
 
 	if dissocErr != nil {
-		h.logger.Warnf("#DeleteAllRequestHandler.Handle - routing manager failure. continue flow.")
+		log.Warnf("#DeleteAllRequestHandler.Handle - routing manager failure. continue flow.")
 	}
 
-	err, updatedAtLeastOnce := h.updateNodebs(h.updateNodebInfoShuttingDown)
+	err, updatedAtLeastOnce := h.updateNodebs(ctx, h.updateNodebInfoShuttingDown)
 
 	if err != nil {
 		return nil, err
@@ -98,12 +100,12 @@ func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IRespon
 	err = h.rmrSender.Send(&rmrMessage)
 
 	if err != nil {
-		h.logger.Errorf("#DeleteAllRequestHandler.Handle - failed to send sctp clear all message to RMR: %s", err)
+		log.Errorf("#DeleteAllRequestHandler.Handle - failed to send sctp clear all message to RMR: %s", err)
 		return nil, e2managererrors.NewRmrError()
 	}
 
 	if !updatedAtLeastOnce {
-		h.logger.Infof("#DeleteAllRequestHandler.Handle - DB wasn't updated, not activating timer")
+		log.Infof("#DeleteAllRequestHandler.Handle - DB wasn't updated, not activating timer")
 
 		if dissocErr != nil {
 			return models.NewRedButtonPartialSuccessResponseModel(PartialSuccessDueToRmErrorMessage), nil
@@ -113,9 +115,9 @@ func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IRespon
 	}
 
 	time.Sleep(time.Duration(h.config.BigRedButtonTimeoutSec) * time.Second)
-	h.logger.Infof("#DeleteAllRequestHandler.Handle - timer expired")
+	log.Infof("#DeleteAllRequestHandler.Handle - timer expired")
 
-	err, _ = h.updateNodebs(h.updateNodebInfoShutDown)
+	err, _ = h.updateNodebs(ctx, h.updateNodebInfoShutDown)
 
 	if err != nil {
 		return nil, err
@@ -128,7 +130,7 @@ func (h *DeleteAllRequestHandler) Handle(request models.Request) (models.IRespon
 	return nil, nil
 }
 
-func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.NodebInfo) (error, bool)) (error, bool) {
+func (h *DeleteAllRequestHandler) updateNodebs(ctx context.Context, updateCb func(ctx context.Context, node *entities.NodebInfo) (error, bool)) (error, bool) {
 	nbIdentityList := h.ranListManager.GetNbIdentityList()
 	updatedAtLeastOnce := false
 
@@ -145,7 +147,7 @@ func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.Node
 			continue
 		}
 
-		err, updated := updateCb(node)
+		err, updated := updateCb(ctx, node)
 
 		if err != nil {
 			return err, false
@@ -159,8 +161,8 @@ func (h *DeleteAllRequestHandler) updateNodebs(updateCb func(node *entities.Node
 	return nil, updatedAtLeastOnce
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfoForceShutdown(node *entities.NodebInfo) (error, bool) {
-	err := h.updateNodebInfo(node, entities.ConnectionStatus_SHUT_DOWN, true)
+func (h *DeleteAllRequestHandler) updateNodebInfoForceShutdown(ctx context.Context, node *entities.NodebInfo) (error, bool) {
+	err := h.updateNodebInfo(ctx, node, entities.ConnectionStatus_SHUT_DOWN, true)
 
 	if err != nil {
 		return err, false
@@ -169,12 +171,12 @@ func (h *DeleteAllRequestHandler) updateNodebInfoForceShutdown(node *entities.No
 	return nil, true
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfoShuttingDown(node *entities.NodebInfo) (error, bool) {
+func (h *DeleteAllRequestHandler) updateNodebInfoShuttingDown(ctx context.Context, node *entities.NodebInfo) (error, bool) {
 	if node.ConnectionStatus == entities.ConnectionStatus_SHUT_DOWN {
 		return nil, false
 	}
 
-	err := h.updateNodebInfo(node, entities.ConnectionStatus_SHUTTING_DOWN, true)
+	err := h.updateNodebInfo(ctx, node, entities.ConnectionStatus_SHUTTING_DOWN, true)
 
 	if err != nil {
 		return err, false
@@ -183,7 +185,7 @@ func (h *DeleteAllRequestHandler) updateNodebInfoShuttingDown(node *entities.Nod
 	return nil, true
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(node *entities.NodebInfo) (error, bool) {
+func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(ctx context.Context, node *entities.NodebInfo) (error, bool) {
 	if node.ConnectionStatus == entities.ConnectionStatus_SHUT_DOWN {
 		return nil, false
 	}
@@ -193,7 +195,7 @@ func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(node *entities.NodebIn
 		return nil, false
 	}
 
-	err := h.updateNodebInfo(node, entities.ConnectionStatus_SHUT_DOWN, false)
+	err := h.updateNodebInfo(ctx, node, entities.ConnectionStatus_SHUT_DOWN, false)
 
 	if err != nil {
 		return err, false
@@ -202,9 +204,9 @@ func (h *DeleteAllRequestHandler) updateNodebInfoShutDown(node *entities.NodebIn
 	return nil, true
 }
 
-func (h *DeleteAllRequestHandler) updateNodebInfo(node *entities.NodebInfo, connectionStatus entities.ConnectionStatus, resetAssociatedE2TAddress bool) error {
+func (h *DeleteAllRequestHandler) updateNodebInfo(ctx context.Context, node *entities.NodebInfo, connectionStatus entities.ConnectionStatus, resetAssociatedE2TAddress bool) error {
 
-	_, err := h.ranConnectStatusChangeManager.ChangeStatus(node, connectionStatus)
+	_, err := h.ranConnectStatusChangeManager.ChangeStatus(ctx, node, connectionStatus)
 	if err != nil {
 		return e2managererrors.NewRnibDbError()
 	}
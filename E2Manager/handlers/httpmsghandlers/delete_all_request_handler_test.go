@@ -21,6 +21,7 @@ package httpmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -63,10 +64,11 @@ func setupDeleteAllRequestHandlerTest(t *testing.T) (*DeleteAllRequestHandler, *
 
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, log)
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(log, config, httpClientMock)
+	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock, alarmManagerClient)
 
 	ranListManager := managers.NewRanListManager(log, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(log, config)
+	ranAlarmService := services.NewRanAlarmService(log, config, alarmManagerClient)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(log, rnibDataService, ranListManager, ranAlarmService)
 
 	handler := NewDeleteAllRequestHandler(log, rmrSender, config, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, ranListManager)
@@ -102,7 +104,7 @@ func mockHttpClientDissociateAllRans(httpClientMock *mocks.HttpClientMock, e2tAd
 func TestGetE2TAddressesFailure(t *testing.T) {
 	h, readerMock, _, _, _, _ := setupDeleteAllRequestHandlerTest(t)
 	readerMock.On("GetE2TAddresses").Return([]string{}, common.NewInternalError(errors.New("error")))
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 }
@@ -127,7 +129,7 @@ func TestOneRanGetE2TAddressesEmptyList(t *testing.T) {
 	newNbIdentity := &entities.NbIdentity{InventoryName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}
 	writerMock.On("UpdateNbIdentities", updatedNb1.GetNodeType(), []*entities.NbIdentity{oldNbIdentity}, []*entities.NbIdentity{newNbIdentity}).Return(nil)
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -157,7 +159,7 @@ func TestTwoRansGetE2TAddressesEmptyListOneGetNodebFailure(t *testing.T) {
 
 	var nb2 *entities.NodebInfo
 	readerMock.On("GetNodeb", "RanName_2").Return(nb2, common.NewInternalError(errors.New("error")))
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	writerMock.AssertNotCalled(t, "UpdateNodebInfo", nb2)
 	readerMock.AssertCalled(t, "GetE2TAddresses")
@@ -193,7 +195,7 @@ func TestUpdateNodebInfoOnConnectionStatusInversionFailure(t *testing.T) {
 	newNbIdentity2 := &entities.NbIdentity{InventoryName: "RanName_2", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId2", NbId: "nbId2"}}
 	writerMock.On("UpdateNbIdentities", updatedNb1.GetNodeType(), []*entities.NbIdentity{oldNbIdentity2}, []*entities.NbIdentity{newNbIdentity2}).Return(nil)
 
-	_, err := h.Handle(nil)
+	_, err := h.Handle(context.Background(), nil)
 
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	writerMock.AssertCalled(t, "UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, "RanName_1_DISCONNECTED")
@@ -228,7 +230,7 @@ func TestTwoRansGetE2TAddressesEmptyListOneUpdateNodebInfoFailure(t *testing.T)
 	readerMock.On("GetNodeb", "RanName_2").Return(nb2, nil)
 	updatedNb2 := &entities.NodebInfo{RanName: "RanName_2", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN}
 	writerMock.On("UpdateNodebInfo", updatedNb2).Return(common.NewInternalError(errors.New("error")))
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	//assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertCalled(t, "GetE2TAddresses")
 	readerMock.AssertCalled(t, "GetListNodebIds")
@@ -263,7 +265,7 @@ func TestOneRanWithStateShutDown(t *testing.T) {
 	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 
 	assert.Nil(t, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
@@ -296,7 +298,7 @@ func TestOneRanShutDown(t *testing.T) {
 
 	readerMock.On("GetE2TAddresses").Return([]string{E2TAddress}, nil)
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -333,7 +335,7 @@ func TestOneRanTryShuttingDownSucceedsClearFails(t *testing.T) {
 
 	readerMock.On("GetE2TAddresses").Return([]string{E2TAddress}, nil)
 	readerMock.On("GetE2TInstances", []string{E2TAddress}).Return([]*entities.E2TInstance{}, common.NewInternalError(errors.New("error")))
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -367,7 +369,7 @@ func TestOneRanTryShuttingDownUpdateNodebError(t *testing.T) {
 	newNbIdentity := &entities.NbIdentity{InventoryName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUTTING_DOWN, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}
 	writerMock.On("UpdateNbIdentities", updatedNb1.GetNodeType(), []*entities.NbIdentity{oldNbIdentity}, []*entities.NbIdentity{newNbIdentity}).Return(nil)
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
 	readerMock.AssertExpectations(t)
@@ -412,7 +414,7 @@ func TestOneRanTryShuttingDownSucceedsClearSucceedsRmrSendFails(t *testing.T) {
 	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
 	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, e2managererrors.NewRmrError())
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	assert.IsType(t, &e2managererrors.RmrError{}, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
@@ -447,7 +449,7 @@ func testTwoRansTryShuttingDownSucceedsClearSucceedsRmrSucceedsAllRansAreShutdow
 	rmrMessage := models.RmrMessage{MsgType: rmrCgo.RIC_SCTP_CLEAR_ALL}
 	mbuf := rmrCgo.NewMBuf(rmrMessage.MsgType, len(rmrMessage.Payload), rmrMessage.RanName, &rmrMessage.Payload, &rmrMessage.XAction, rmrMessage.GetMsgSrc())
 	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
-	resp, err := h.Handle(nil)
+	resp, err := h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 
 	if partial {
@@ -515,7 +517,7 @@ func TestOneRanTryShuttingDownSucceedsClearSucceedsRmrSucceedsRanStatusIsShuttin
 	updatedNb2.AssociatedE2TInstanceAddress = ""
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(common.NewInternalError(errors.New("error")))
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
@@ -560,7 +562,7 @@ func testOneRanTryShuttingDownSucceedsClearSucceedsRmrSucceedsRanStatusIsShuttin
 	newNbIdentityShutDown := &entities.NbIdentity{InventoryName: "RanName_1", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}
 	writerMock.On("UpdateNbIdentities", updatedNb1.GetNodeType(), []*entities.NbIdentity{oldNbIdentity}, []*entities.NbIdentity{newNbIdentityShutDown}).Return(nil)
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
@@ -677,7 +679,7 @@ func TestSuccessTwoE2TInstancesSixRans(t *testing.T) {
 	newNbIdentity6ShutDown := &entities.NbIdentity{InventoryName: "RanName_6", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId6", NbId: "nbId6"}}
 	writerMock.On("UpdateNbIdentities", updatedNb1.GetNodeType(), []*entities.NbIdentity{oldNbIdentity6}, []*entities.NbIdentity{newNbIdentity6ShutDown}).Return(nil)
 
-	_, err = h.Handle(nil)
+	_, err = h.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
 	readerMock.AssertExpectations(t)
@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
@@ -30,60 +31,74 @@ import (
 )
 
 type DeleteEnbRequestHandler struct {
-	logger          *logger.Logger
-	rNibDataService services.RNibDataService
-	ranListManager  managers.RanListManager
+	logger               *logger.Logger
+	rNibDataService      services.RNibDataService
+	ranListManager       managers.RanListManager
+	ranProcedureTracker  managers.IRanProcedureTracker
+	errorIndicationStore services.ErrorIndicationStore
 }
 
-func NewDeleteEnbRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager) *DeleteEnbRequestHandler {
+func NewDeleteEnbRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager, ranProcedureTracker managers.IRanProcedureTracker, errorIndicationStore services.ErrorIndicationStore) *DeleteEnbRequestHandler {
 	return &DeleteEnbRequestHandler{
-		logger:          logger,
-		rNibDataService: rNibDataService,
-		ranListManager:  ranListManager,
+		logger:               logger,
+		rNibDataService:      rNibDataService,
+		ranListManager:       ranListManager,
+		ranProcedureTracker:  ranProcedureTracker,
+		errorIndicationStore: errorIndicationStore,
 	}
 }
 
-func (h *DeleteEnbRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *DeleteEnbRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
 
 	deleteEnbRequest := request.(*models.DeleteEnbRequest)
 
-	h.logger.Infof("#DeleteEnbRequestHandler.Handle - RAN name: %s", deleteEnbRequest.RanName)
+	log.Infof("#DeleteEnbRequestHandler.Handle - RAN name: %s", deleteEnbRequest.RanName)
 
 	nodebInfo, err := h.rNibDataService.GetNodeb(deleteEnbRequest.RanName)
 
 	if err != nil {
 		_, ok := err.(*common.ResourceNotFoundError)
 		if !ok {
-			h.logger.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", deleteEnbRequest.RanName, err)
+			log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", deleteEnbRequest.RanName, err)
 			return nil, e2managererrors.NewRnibDbError()
 		}
 
-		h.logger.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - RAN not found on RNIB. Error: %s", deleteEnbRequest.RanName, err)
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - RAN not found on RNIB. Error: %s", deleteEnbRequest.RanName, err)
 		return nil, e2managererrors.NewResourceNotFoundError()
 	}
 
 	if nodebInfo.NodeType != entities.Node_ENB {
-		h.logger.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - RAN is not eNB.", deleteEnbRequest.RanName)
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - RAN is not eNB.", deleteEnbRequest.RanName)
 		return nil, e2managererrors.NewRequestValidationError()
 	}
 
 	if nodebInfo.GetSetupFromNetwork() {
-		h.logger.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - can't delete RAN which was created from network.", deleteEnbRequest.RanName)
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - can't delete RAN which was created from network.", deleteEnbRequest.RanName)
 		return nil, e2managererrors.NewRequestValidationError()
 	}
 
 	err = h.rNibDataService.RemoveEnb(nodebInfo)
 	if err != nil {
-		h.logger.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to delete nodeb entity in RNIB. Error: %s", deleteEnbRequest.RanName, err)
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to delete nodeb entity in RNIB. Error: %s", deleteEnbRequest.RanName, err)
 		return nil, e2managererrors.NewRnibDbError()
 	}
 
 	err = h.ranListManager.RemoveNbIdentity(entities.Node_ENB, deleteEnbRequest.RanName)
 	if err != nil {
-		h.logger.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to delete nbIdentity in RNIB. Error: %s", deleteEnbRequest.RanName, err)
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to delete nbIdentity in RNIB. Error: %s", deleteEnbRequest.RanName, err)
 		return nil, e2managererrors.NewRnibDbError()
 	}
 
-	h.logger.Infof("#DeleteEnbRequestHandler.Handle - RAN name: %s - deleted successfully.", deleteEnbRequest.RanName)
+	// The RAN itself is deleted, so failing to delete its procedures or Error Indications leaves orphan keys only and does not fail the request
+	if err = h.ranProcedureTracker.Remove(deleteEnbRequest.RanName); err != nil {
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to delete RAN procedures in RNIB. Error: %s", deleteEnbRequest.RanName, err)
+	}
+
+	if err = h.errorIndicationStore.Delete(deleteEnbRequest.RanName); err != nil {
+		log.Errorf("#DeleteEnbRequestHandler.Handle - RAN name: %s - failed to delete Error Indications in RNIB. Error: %s", deleteEnbRequest.RanName, err)
+	}
+
+	log.Infof("#DeleteEnbRequestHandler.Handle - RAN name: %s - deleted successfully.", deleteEnbRequest.RanName)
 	return models.NewNodebResponse(nodebInfo), nil
 }
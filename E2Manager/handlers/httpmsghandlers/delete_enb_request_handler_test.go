@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/managers"
 	"e2mgr/mocks"
@@ -33,7 +34,7 @@ import (
 	"testing"
 )
 
-func setupDeleteEnbRequestHandlerTest(t *testing.T, emptyList bool) (*DeleteEnbRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock) {
+func setupDeleteEnbRequestHandlerTest(t *testing.T, emptyList bool) (*DeleteEnbRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.RanProcedureStoreMock, *mocks.ErrorIndicationStoreMock) {
 	log := initLog(t)
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 	readerMock := &mocks.RnibReaderMock{}
@@ -47,51 +48,78 @@ func setupDeleteEnbRequestHandlerTest(t *testing.T, emptyList bool) (*DeleteEnbR
 			t.Errorf("#setupDeleteEnbRequestHandlerTest - Failed to add nbIdentity prior to DeleteEnb test")
 		}
 	}
-	handler := NewDeleteEnbRequestHandler(log, rnibDataService, ranListManager)
-	return handler, readerMock, writerMock
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureTracker := managers.NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	errorIndicationStoreMock := &mocks.ErrorIndicationStoreMock{}
+	handler := NewDeleteEnbRequestHandler(log, rnibDataService, ranListManager, ranProcedureTracker, errorIndicationStoreMock)
+	return handler, readerMock, writerMock, ranProcedureStoreMock, errorIndicationStoreMock
 }
 
 func TestHandleDeleteEnbSuccess(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, writerMock, ranProcedureStoreMock, errorIndicationStoreMock := setupDeleteEnbRequestHandlerTest(t, false)
 
 	ranName := "ran1"
 	var rnibError error
 	nodebInfo := &entities.NodebInfo{RanName: ranName, NodeType: entities.Node_ENB}
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, rnibError)
 	writerMock.On("RemoveEnb", nodebInfo).Return(nil)
+	ranProcedureStoreMock.On("Delete", ranName).Return(nil)
+	errorIndicationStoreMock.On("Delete", ranName).Return(nil)
 	writerMock.On("RemoveNbIdentity", entities.Node_ENB, &entities.NbIdentity{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}).Return(nil)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.Nil(t, err)
 	assert.NotNil(t, result)
 	assert.IsType(t, &models.NodebResponse{}, result)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
+	ranProcedureStoreMock.AssertExpectations(t)
+	errorIndicationStoreMock.AssertExpectations(t)
+}
+
+func TestHandleDeleteEnbRemoveRanDataError(t *testing.T) {
+	handler, readerMock, writerMock, ranProcedureStoreMock, errorIndicationStoreMock := setupDeleteEnbRequestHandlerTest(t, true)
+
+	ranName := "ran1"
+	nodebInfo := &entities.NodebInfo{RanName: ranName, NodeType: entities.Node_ENB}
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, nil)
+	writerMock.On("RemoveEnb", nodebInfo).Return(nil)
+	ranProcedureStoreMock.On("Delete", ranName).Return(common.NewInternalError(errors.New("for test")))
+	errorIndicationStoreMock.On("Delete", ranName).Return(common.NewInternalError(errors.New("for test")))
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
+	assert.Nil(t, err)
+	assert.IsType(t, &models.NodebResponse{}, result)
+	ranProcedureStoreMock.AssertExpectations(t)
+	errorIndicationStoreMock.AssertExpectations(t)
 }
 
 func TestHandleDeleteEnbSuccessNoEnb(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, true)
+	handler, readerMock, writerMock, ranProcedureStoreMock, errorIndicationStoreMock := setupDeleteEnbRequestHandlerTest(t, true)
 
 	ranName := "ran1"
 	var rnibError error
 	nodebInfo := &entities.NodebInfo{RanName: ranName, NodeType: entities.Node_ENB}
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, rnibError)
 	writerMock.On("RemoveEnb", nodebInfo).Return(nil)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	ranProcedureStoreMock.On("Delete", ranName).Return(nil)
+	errorIndicationStoreMock.On("Delete", ranName).Return(nil)
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.Nil(t, err)
 	assert.NotNil(t, result)
 	assert.IsType(t, &models.NodebResponse{}, result)
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
+	ranProcedureStoreMock.AssertExpectations(t)
+	errorIndicationStoreMock.AssertExpectations(t)
 }
 
 func TestHandleDeleteEnbInternalGetNodebError(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, writerMock, _, _ := setupDeleteEnbRequestHandlerTest(t, false)
 
 	ranName := "ran1"
 	rnibError := errors.New("for test")
 	var nodebInfo *entities.NodebInfo
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, rnibError)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, result)
 	readerMock.AssertExpectations(t)
@@ -99,14 +127,14 @@ func TestHandleDeleteEnbInternalGetNodebError(t *testing.T) {
 }
 
 func TestHandleDeleteEnbInternalRemoveEnbError(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, writerMock, _, _ := setupDeleteEnbRequestHandlerTest(t, false)
 
 	ranName := "ran1"
 	rnibError := errors.New("for test")
 	nodebInfo  := &entities.NodebInfo{RanName: ranName, NodeType: entities.Node_ENB}
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, nil)
 	writerMock.On("RemoveEnb", nodebInfo).Return(rnibError)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, result)
 	readerMock.AssertExpectations(t)
@@ -114,18 +142,18 @@ func TestHandleDeleteEnbInternalRemoveEnbError(t *testing.T) {
 }
 
 func TestHandleDeleteEnbFromNetworkError(t *testing.T) {
-	handler, readerMock, _ := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, _, _, _ := setupDeleteEnbRequestHandlerTest(t, false)
 	ranName := "ran1"
 	nodebInfo  := &entities.NodebInfo{RanName: ranName, NodeType: entities.Node_ENB, SetupFromNetwork: true}
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, nil)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, result)
 	readerMock.AssertExpectations(t)
 }
 
 func TestHandleDeleteEnbInternalRemoveNbIdentityError(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, writerMock, _, _ := setupDeleteEnbRequestHandlerTest(t, false)
 
 	ranName := "ran1"
 	rnibError := errors.New("for test")
@@ -133,7 +161,7 @@ func TestHandleDeleteEnbInternalRemoveNbIdentityError(t *testing.T) {
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, nil)
 	writerMock.On("RemoveEnb", nodebInfo).Return(nil)
 	writerMock.On("RemoveNbIdentity", entities.Node_ENB, &entities.NbIdentity{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}).Return(rnibError)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, result)
 	readerMock.AssertExpectations(t)
@@ -141,13 +169,13 @@ func TestHandleDeleteEnbInternalRemoveNbIdentityError(t *testing.T) {
 }
 
 func TestHandleDeleteEnbResourceNotFoundError(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, writerMock, _, _ := setupDeleteEnbRequestHandlerTest(t, false)
 
 	ranName := "ran1"
 	rnibError := common.NewResourceNotFoundError("for test")
 	var nodebInfo *entities.NodebInfo
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, rnibError)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, result)
 	readerMock.AssertExpectations(t)
@@ -155,12 +183,12 @@ func TestHandleDeleteEnbResourceNotFoundError(t *testing.T) {
 }
 
 func TestHandleDeleteEnbNodeTypeNotEnbError(t *testing.T) {
-	handler, readerMock, writerMock := setupDeleteEnbRequestHandlerTest(t, false)
+	handler, readerMock, writerMock, _, _ := setupDeleteEnbRequestHandlerTest(t, false)
 
 	ranName := "ran1"
 	nodebInfo  := &entities.NodebInfo{RanName: ranName, NodeType: entities.Node_GNB}
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, nil)
-	result, err := handler.Handle(&models.DeleteEnbRequest{RanName: ranName})
+	result, err := handler.Handle(context.Background(), &models.DeleteEnbRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, result)
 	readerMock.AssertExpectations(t)
@@ -1,55 +1,206 @@
-//
-// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//      http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
-//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
-//  platform project (RICP).
-
-package httpmsghandlers
-
-import (
-	"e2mgr/configuration"
-	"e2mgr/managers"
-	"e2mgr/mocks"
-	"e2mgr/services"
-	"e2mgr/tests"
-	"testing"
-)
-
-const (
-	E2ResetXmlPath            = "../../tests/resources/reset/reset-request.xml"
-	StateChangeMessageChannel = "RAN_CONNECTION_STATUS_CHANGE"
-)
-
-func initE2ResetMocks(t *testing.T) (*E2ResetRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.RmrMessengerMock) {
-	logger := tests.InitLog(t)
-	config := &configuration.Configuration{
-		RnibRetryIntervalMs:       10,
-		MaxRnibConnectionAttempts: 3,
-		E2ResetTimeOutSec:         10,
-		RnibWriter: configuration.RnibWriterConfig{
-			StateChangeMessageChannel: StateChangeMessageChannel,
-		}}
-	rmrMessengerMock := &mocks.RmrMessengerMock{}
-	rmrSender := tests.InitRmrSender(rmrMessengerMock, logger)
-	readerMock := &mocks.RnibReaderMock{}
-	writerMock := &mocks.RnibWriterMock{}
-	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
-	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := &mocks.RanAlarmServiceMock{}
-	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
-	ranResetManager := managers.NewRanResetManager(logger, rnibDataService, ranConnectStatusChangeManager)
-	handler := NewE2ResetRequestHandler(logger, rmrSender, rnibDataService, ranResetManager)
-	return handler, readerMock, writerMock, rmrMessengerMock
-}
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/managers"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/rmrCgo"
+	"e2mgr/services"
+	"e2mgr/tests"
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	E2ResetRanName            = "gnb:208-092-303030"
+	StateChangeMessageChannel = "RAN_CONNECTION_STATUS_CHANGE"
+)
+
+func initE2ResetMocks(t *testing.T, responseTimeoutSec int) (*E2ResetRequestHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.RmrMessengerMock, *managers.E2ResetTransactionManager) {
+	logger := tests.InitLog(t)
+	config := &configuration.Configuration{
+		RnibRetryIntervalMs:       10,
+		MaxRnibConnectionAttempts: 3,
+		E2Reset:                   configuration.E2ResetConfig{ResponseTimeoutSec: responseTimeoutSec},
+		RnibWriter: configuration.RnibWriterConfig{
+			StateChangeMessageChannel: StateChangeMessageChannel,
+		}}
+	rmrMessengerMock := &mocks.RmrMessengerMock{}
+	rmrSender := tests.InitRmrSender(rmrMessengerMock, logger)
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
+	ranListManager := managers.NewRanListManager(logger, rnibDataService)
+	ranAlarmService := &mocks.RanAlarmServiceMock{}
+	ranAlarmService.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
+	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
+	ranResetManager := managers.NewRanResetManager(logger, rnibDataService, ranConnectStatusChangeManager)
+	changeStatusToConnectedRanManager := managers.NewChangeStatusToConnectedRanManager(logger, rnibDataService, ranConnectStatusChangeManager)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(logger, config, ranProcedureStoreMock)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(logger, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	ricE2ResetManager := managers.NewRicE2ResetManager(logger, rmrSender, rnibDataService, ranResetManager, changeStatusToConnectedRanManager, e2ResetTransactionManager)
+	handler := NewE2ResetRequestHandler(logger, ricE2ResetManager)
+	return handler, readerMock, writerMock, rmrMessengerMock, e2ResetTransactionManager
+}
+
+func createE2ResetRequestMbuf(t *testing.T, transactionId string, cause string) *rmrCgo.MBuf {
+	e2Cause, ok := models.GetE2ResetCause(cause)
+	if !ok {
+		t.Fatalf("unknown cause %s", cause)
+	}
+
+	payload, err := managers.BuildRicE2ResetRequest(transactionId, e2Cause)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var xAction []byte
+	var msgSrc unsafe.Pointer
+	return rmrCgo.NewMBuf(rmrCgo.RIC_E2_RESET_REQ, len(payload), E2ResetRanName, &payload, &xAction, msgSrc)
+}
+
+func TestE2ResetRequestHandlerSuccess(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, e2ResetTransactionManager := initE2ResetMocks(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: E2ResetRanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	readerMock.On("GetNodeb", E2ResetRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, mock.Anything).Return(nil)
+
+	mbuf := createE2ResetRequestMbuf(t, "0", models.E2ResetOmInterventionCause)
+	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil).Run(func(args mock.Arguments) {
+		go e2ResetTransactionManager.Complete(E2ResetRanName, "0")
+	})
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodebInfo.ConnectionStatus)
+	writerMock.AssertNumberOfCalls(t, "UpdateNodebInfoOnConnectionStatusInversion", 2)
+	rmrMessengerMock.AssertExpectations(t)
+}
+
+func TestE2ResetRequestHandlerTimeout(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, _ := initE2ResetMocks(t, 1)
+
+	nodebInfo := &entities.NodebInfo{RanName: E2ResetRanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	readerMock.On("GetNodeb", E2ResetRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, mock.Anything).Return(nil)
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+
+	mbuf := createE2ResetRequestMbuf(t, "0", "protocol:semantic-error")
+	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil)
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName, Cause: "protocol:semantic-error"})
+
+	assert.IsType(t, &e2managererrors.RanResponseTimeoutError{}, err)
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, nodebInfo.ConnectionStatus)
+}
+
+func TestE2ResetRequestHandlerRequestCancelled(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, e2ResetTransactionManager := initE2ResetMocks(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: E2ResetRanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	readerMock.On("GetNodeb", E2ResetRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, mock.Anything).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mbuf := createE2ResetRequestMbuf(t, "0", models.E2ResetOmInterventionCause)
+	rmrMessengerMock.On("SendMsg", mbuf, true).Return(mbuf, nil).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	_, err := handler.Handle(ctx, models.ResetRequest{RanName: E2ResetRanName})
+
+	assert.IsType(t, &e2managererrors.InternalError{}, err)
+	assert.True(t, e2ResetTransactionManager.Complete(E2ResetRanName, "0"))
+}
+
+func TestE2ResetRequestHandlerRmrFailure(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, _ := initE2ResetMocks(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: E2ResetRanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	readerMock.On("GetNodeb", E2ResetRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, mock.Anything).Return(nil)
+
+	mbuf := createE2ResetRequestMbuf(t, "0", models.E2ResetOmInterventionCause)
+	rmrMessengerMock.On("SendMsg", mbuf, true).Return(&rmrCgo.MBuf{}, fmt.Errorf("rmr error"))
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName})
+
+	assert.IsType(t, &e2managererrors.RmrError{}, err)
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodebInfo.ConnectionStatus)
+}
+
+func TestE2ResetRequestHandlerAlreadyInProgress(t *testing.T) {
+	handler, readerMock, _, rmrMessengerMock, e2ResetTransactionManager := initE2ResetMocks(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: E2ResetRanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	readerMock.On("GetNodeb", E2ResetRanName).Return(nodebInfo, nil)
+	_, _ = e2ResetTransactionManager.Start(E2ResetRanName)
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName})
+
+	assert.IsType(t, &e2managererrors.CommandAlreadyInProgressError{}, err)
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
+}
+
+func TestE2ResetRequestHandlerUnknownCause(t *testing.T) {
+	handler, readerMock, _, _, _ := initE2ResetMocks(t, 10)
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName, Cause: "XXX"})
+
+	assert.IsType(t, &e2managererrors.RequestValidationError{}, err)
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+}
+
+func TestE2ResetRequestHandlerWrongState(t *testing.T) {
+	handler, readerMock, _, rmrMessengerMock, _ := initE2ResetMocks(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: E2ResetRanName, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+	readerMock.On("GetNodeb", E2ResetRanName).Return(nodebInfo, nil)
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName})
+
+	assert.IsType(t, &e2managererrors.WrongStateError{}, err)
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
+}
+
+func TestE2ResetRequestHandlerRanNotFound(t *testing.T) {
+	handler, readerMock, _, _, _ := initE2ResetMocks(t, 10)
+
+	readerMock.On("GetNodeb", E2ResetRanName).Return(&entities.NodebInfo{}, common.NewResourceNotFoundError("nodeb not found"))
+
+	_, err := handler.Handle(context.Background(), models.ResetRequest{RanName: E2ResetRanName})
+
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
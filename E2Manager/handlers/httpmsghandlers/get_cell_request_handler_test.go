@@ -0,0 +1,57 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGetCellRequestHandlerTest(t *testing.T) (*GetCellRequestHandler, *mocks.RanListManagerMock) {
+	log := initLog(t)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	handler := NewGetCellRequestHandler(log, ranListManagerMock)
+	return handler, ranListManagerMock
+}
+
+func TestHandleGetCellSuccess(t *testing.T) {
+	handler, ranListManagerMock := setupGetCellRequestHandlerTest(t)
+	nbIdentity := &entities.NbIdentity{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	ranListManagerMock.On("GetRanNameByCellId", "02f8294a952a00").Return("test1", nil)
+	ranListManagerMock.On("GetNbIdentity", "test1").Return(nbIdentity, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetCellRequest{CellId: "02f8294a952a00"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test1", response.(*models.CellResponse).RanName)
+}
+
+func TestHandleGetCellNotFound(t *testing.T) {
+	handler, ranListManagerMock := setupGetCellRequestHandlerTest(t)
+	ranListManagerMock.On("GetRanNameByCellId", "02f8294a952a00").Return("", e2managererrors.NewResourceNotFoundError())
+
+	response, err := handler.Handle(context.Background(), models.GetCellRequest{CellId: "02f8294a952a00"})
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -38,12 +39,13 @@ func NewGetE2TInstancesRequestHandler(logger *logger.Logger, e2tInstancesManager
 	}
 }
 
-func (h *GetE2TInstancesRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *GetE2TInstancesRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
 
 	e2tInstances, err := h.e2tInstancesManager.GetE2TInstances()
 
 	if err != nil {
-		h.logger.Errorf("#GetE2TInstancesRequestHandler.Handle - Error fetching E2T instances from rNib: %s", err)
+		log.Errorf("#GetE2TInstancesRequestHandler.Handle - Error fetching E2T instances from rNib: %s", err)
 		return nil, err
 	}
 
@@ -0,0 +1,60 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+)
+
+type GetErrorIndicationsRequestHandler struct {
+	logger               *logger.Logger
+	rNibDataService      services.RNibDataService
+	errorIndicationStore services.ErrorIndicationStore
+}
+
+func NewGetErrorIndicationsRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, errorIndicationStore services.ErrorIndicationStore) *GetErrorIndicationsRequestHandler {
+	return &GetErrorIndicationsRequestHandler{
+		logger:               logger,
+		rNibDataService:      rNibDataService,
+		errorIndicationStore: errorIndicationStore,
+	}
+}
+
+func (handler *GetErrorIndicationsRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := handler.logger.WithContext(ctx)
+	ranName := request.(models.GetErrorIndicationsRequest).RanName
+
+	_, err := handler.rNibDataService.GetNodeb(ranName)
+	if err != nil {
+		log.Errorf("#GetErrorIndicationsRequestHandler.Handle - RAN name: %s - Error fetching RAN from rNib: %v", ranName, err)
+		return nil, rnibErrorToE2ManagerError(err)
+	}
+
+	records, err := handler.errorIndicationStore.Get(ranName)
+	if err != nil {
+		log.Errorf("#GetErrorIndicationsRequestHandler.Handle - RAN name: %s - Error fetching Error Indications: %v", ranName, err)
+		return nil, e2managererrors.NewRnibDbError()
+	}
+
+	return models.ErrorIndicationsResponse(records), nil
+}
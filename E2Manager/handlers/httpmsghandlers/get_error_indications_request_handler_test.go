@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGetErrorIndicationsRequestHandlerTest(t *testing.T) (*GetErrorIndicationsRequestHandler, *mocks.RnibReaderMock, *mocks.ErrorIndicationStoreMock) {
+	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, nil)
+	errorIndicationStoreMock := &mocks.ErrorIndicationStoreMock{}
+	handler := NewGetErrorIndicationsRequestHandler(log, rnibDataService, errorIndicationStoreMock)
+	return handler, readerMock, errorIndicationStoreMock
+}
+
+func TestHandleGetErrorIndicationsSuccess(t *testing.T) {
+	handler, readerMock, errorIndicationStoreMock := setupGetErrorIndicationsRequestHandlerTest(t)
+	ranName := "test1"
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	records := []*models.ErrorIndicationRecord{{TransactionId: "1", Cause: "misc/om-intervention", Action: models.ErrorIndicationActionRevert}}
+	errorIndicationStoreMock.On("Get", ranName).Return(records, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetErrorIndicationsRequest{RanName: ranName})
+
+	assert.Nil(t, err)
+	assert.Equal(t, models.ErrorIndicationsResponse(records), response)
+}
+
+func TestHandleGetErrorIndicationsRanNotFound(t *testing.T) {
+	handler, readerMock, errorIndicationStoreMock := setupGetErrorIndicationsRequestHandlerTest(t)
+	ranName := "test1"
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	response, err := handler.Handle(context.Background(), models.GetErrorIndicationsRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+	errorIndicationStoreMock.AssertNotCalled(t, "Get", ranName)
+}
+
+func TestHandleGetErrorIndicationsStoreFailure(t *testing.T) {
+	handler, readerMock, errorIndicationStoreMock := setupGetErrorIndicationsRequestHandlerTest(t)
+	ranName := "test1"
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, nil)
+	errorIndicationStoreMock.On("Get", ranName).Return(nil, common.NewInternalError(errors.New("#sdl.Get - Internal Error")))
+
+	response, err := handler.Handle(context.Background(), models.GetErrorIndicationsRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
+}
@@ -0,0 +1,57 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGetHealthCheckJobRequestHandlerTest(t *testing.T) (*GetHealthCheckJobRequestHandler, *mocks.HealthCheckJobManagerMock) {
+	log := initLog(t)
+	healthCheckJobManagerMock := &mocks.HealthCheckJobManagerMock{}
+	handler := NewGetHealthCheckJobRequestHandler(log, healthCheckJobManagerMock)
+	return handler, healthCheckJobManagerMock
+}
+
+func TestHandleGetHealthCheckJobSuccess(t *testing.T) {
+	handler, healthCheckJobManagerMock := setupGetHealthCheckJobRequestHandlerTest(t)
+	job := &models.HealthCheckJob{JobId: "1", CreatedAt: 1, Rans: []*models.HealthCheckRanResult{{RanName: "test1", Status: models.HealthCheckUpdateReceived, TransactionId: "7", SentAt: 1, ReceivedAt: 2}}}
+	healthCheckJobManagerMock.On("GetJob", "1").Return(job, nil)
+
+	response, err := handler.Handle(context.Background(), models.GetHealthCheckJobRequest{JobId: "1"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, job, response)
+}
+
+func TestHandleGetHealthCheckJobNotFound(t *testing.T) {
+	handler, healthCheckJobManagerMock := setupGetHealthCheckJobRequestHandlerTest(t)
+	healthCheckJobManagerMock.On("GetJob", "1").Return(nil, e2managererrors.NewResourceNotFoundError())
+
+	response, err := handler.Handle(context.Background(), models.GetHealthCheckJobRequest{JobId: "1"})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/models"
+	"e2mgr/services"
+)
+
+type GetNodebByGlobalNbIdRequestHandler struct {
+	logger          *logger.Logger
+	rNibDataService services.RNibDataService
+	ranListManager  managers.RanListManager
+}
+
+func NewGetNodebByGlobalNbIdRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager) *GetNodebByGlobalNbIdRequestHandler {
+	return &GetNodebByGlobalNbIdRequestHandler{
+		logger:          logger,
+		rNibDataService: rNibDataService,
+		ranListManager:  ranListManager,
+	}
+}
+
+func (h *GetNodebByGlobalNbIdRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
+	getNodebRequest := request.(models.GetNodebByGlobalNbIdRequest)
+
+	ranName, err := h.ranListManager.GetRanNameByGlobalNbId(getNodebRequest.PlmnId, getNodebRequest.NbId)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeb, err := h.rNibDataService.GetNodeb(ranName)
+	if err != nil {
+		log.Errorf("#GetNodebByGlobalNbIdRequestHandler.Handle - RAN name: %s - Error fetching RAN from rNib: %v", ranName, err)
+		return nil, rnibErrorToE2ManagerError(err)
+	}
+
+	return models.NewNodebResponse(nodeb), nil
+}
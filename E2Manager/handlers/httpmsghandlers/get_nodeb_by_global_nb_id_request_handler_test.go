@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGetNodebByGlobalNbIdRequestHandlerTest(t *testing.T) (*GetNodebByGlobalNbIdRequestHandler, *mocks.RnibReaderMock, *mocks.RanListManagerMock) {
+	log := initLog(t)
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	readerMock := &mocks.RnibReaderMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, nil)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	handler := NewGetNodebByGlobalNbIdRequestHandler(log, rnibDataService, ranListManagerMock)
+	return handler, readerMock, ranListManagerMock
+}
+
+func TestHandleGetNodebByGlobalNbIdSuccess(t *testing.T) {
+	handler, readerMock, ranListManagerMock := setupGetNodebByGlobalNbIdRequestHandlerTest(t)
+	ranName := "test1"
+	var rnibError error
+	ranListManagerMock.On("GetRanNameByGlobalNbId", "02f829", "4a952a0a").Return(ranName, nil)
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName: ranName}, rnibError)
+
+	response, err := handler.Handle(context.Background(), models.GetNodebByGlobalNbIdRequest{PlmnId: "02f829", NbId: "4a952a0a"})
+	assert.Nil(t, err)
+	assert.IsType(t, &models.NodebResponse{}, response)
+}
+
+func TestHandleGetNodebByGlobalNbIdNotIndexed(t *testing.T) {
+	handler, readerMock, ranListManagerMock := setupGetNodebByGlobalNbIdRequestHandlerTest(t)
+	ranListManagerMock.On("GetRanNameByGlobalNbId", "02f829", "4a952a0a").Return("", e2managererrors.NewResourceNotFoundError())
+
+	response, err := handler.Handle(context.Background(), models.GetNodebByGlobalNbIdRequest{PlmnId: "02f829", NbId: "4a952a0a"})
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+	readerMock.AssertNotCalled(t, "GetNodeb", "test1")
+}
+
+func TestHandleGetNodebByGlobalNbIdNodebNotFound(t *testing.T) {
+	handler, readerMock, ranListManagerMock := setupGetNodebByGlobalNbIdRequestHandlerTest(t)
+	ranName := "test1"
+	var nodebInfo *entities.NodebInfo
+	ranListManagerMock.On("GetRanNameByGlobalNbId", "02f829", "4a952a0a").Return(ranName, nil)
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+
+	response, err := handler.Handle(context.Background(), models.GetNodebByGlobalNbIdRequest{PlmnId: "02f829", NbId: "4a952a0a"})
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
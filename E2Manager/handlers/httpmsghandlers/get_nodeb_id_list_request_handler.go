@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -27,22 +28,32 @@ import (
 )
 
 type GetNodebIdListRequestHandler struct {
-	rNibDataService services.RNibDataService
-	logger          *logger.Logger
-	ranListManager  managers.RanListManager
+	rNibDataService    services.RNibDataService
+	logger             *logger.Logger
+	ranListManager     managers.RanListManager
+	ranLivenessMonitor managers.IRanLivenessMonitor
 }
 
-func NewGetNodebIdListRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager) *GetNodebIdListRequestHandler {
+func NewGetNodebIdListRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager, ranLivenessMonitor managers.IRanLivenessMonitor) *GetNodebIdListRequestHandler {
 	return &GetNodebIdListRequestHandler{
-		logger:          logger,
-		rNibDataService: rNibDataService,
-		ranListManager:  ranListManager,
+		logger:             logger,
+		rNibDataService:    rNibDataService,
+		ranListManager:     ranListManager,
+		ranLivenessMonitor: ranLivenessMonitor,
 	}
 }
 
-func (handler *GetNodebIdListRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+// Handle : without query parameters the whole RAN list is returned, otherwise a page of it
+func (handler *GetNodebIdListRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 
-	nodebIdList := handler.ranListManager.GetNbIdentityList()
+	if request == nil {
+		nodebIdList := handler.ranListManager.GetNbIdentityList()
 
-	return models.NewGetNodebIdListResponse(nodebIdList), nil
+		return models.NewGetNodebIdListResponse(nodebIdList, handler.ranLivenessMonitor.GetHealthStates()), nil
+	}
+
+	listRequest := request.(models.GetNodebIdListRequest)
+	page := handler.ranListManager.GetNbIdentityPage(&listRequest)
+
+	return models.NewGetNodebIdListPageResponse(page, handler.ranLivenessMonitor.GetHealthStates(), listRequest.Fields), nil
 }
@@ -21,6 +21,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/managers"
 	"e2mgr/mocks"
@@ -31,7 +32,7 @@ import (
 	"testing"
 )
 
-func setupGetNodebIdListRequestHandlerTest(t *testing.T) (*GetNodebIdListRequestHandler, *mocks.RnibReaderMock, managers.RanListManager) {
+func setupGetNodebIdListRequestHandlerTest(t *testing.T) (*GetNodebIdListRequestHandler, *mocks.RnibReaderMock, managers.RanListManager, *mocks.RanLivenessMonitorMock) {
 	log := initLog(t)
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 	readerMock := &mocks.RnibReaderMock{}
@@ -39,22 +40,74 @@ func setupGetNodebIdListRequestHandlerTest(t *testing.T) (*GetNodebIdListRequest
 	rnibDataService := services.NewRnibDataService(log, config, readerMock, nil)
 	ranListManager := managers.NewRanListManager(log, rnibDataService)
 
-	handler := NewGetNodebIdListRequestHandler(log, rnibDataService, ranListManager)
-	return handler, readerMock, ranListManager
+	ranLivenessMonitorMock := &mocks.RanLivenessMonitorMock{}
+
+	handler := NewGetNodebIdListRequestHandler(log, rnibDataService, ranListManager, ranLivenessMonitorMock)
+	return handler, readerMock, ranListManager, ranLivenessMonitorMock
 }
 
 func TestHandleGetNodebIdListSuccess(t *testing.T) {
-	handler, readerMock, ranListManager := setupGetNodebIdListRequestHandlerTest(t)
+	handler, readerMock, ranListManager, ranLivenessMonitorMock := setupGetNodebIdListRequestHandlerTest(t)
 	var rnibError error
 	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{}, rnibError)
+	ranLivenessMonitorMock.On("GetHealthStates").Return(map[string]models.RanHealthState{})
 
 	err := ranListManager.InitNbIdentityMap()
 	if err != nil {
 		t.Errorf("Error cannot init identity")
 	}
 
-	response, err := handler.Handle(nil)
+	response, err := handler.Handle(context.Background(), nil)
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 	assert.IsType(t, &models.GetNodebIdListResponse{}, response)
 }
+
+func TestHandleGetNodebIdListWithHealthState(t *testing.T) {
+	handler, readerMock, ranListManager, ranLivenessMonitorMock := setupGetNodebIdListRequestHandlerTest(t)
+	var rnibError error
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED}}, rnibError)
+	ranLivenessMonitorMock.On("GetHealthStates").Return(map[string]models.RanHealthState{"test1": models.RanHealthStateUnresponsive})
+
+	err := ranListManager.InitNbIdentityMap()
+	if err != nil {
+		t.Errorf("Error cannot init identity")
+	}
+
+	response, err := handler.Handle(context.Background(), nil)
+	assert.Nil(t, err)
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "[{\"inventoryName\":\"test1\",\"connectionStatus\":\"CONNECTED\",\"healthState\":\"UNRESPONSIVE\"}]", string(data))
+}
+
+func TestHandleGetNodebIdListPage(t *testing.T) {
+	handler, readerMock, ranListManager, ranLivenessMonitorMock := setupGetNodebIdListRequestHandlerTest(t)
+	var rnibError error
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "test2", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "test3", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED},
+	}, rnibError)
+	ranLivenessMonitorMock.On("GetHealthStates").Return(map[string]models.RanHealthState{"test1": models.RanHealthStateUnresponsive})
+
+	err := ranListManager.InitNbIdentityMap()
+	if err != nil {
+		t.Errorf("Error cannot init identity")
+	}
+
+	request := models.GetNodebIdListRequest{
+		ConnectionStatuses: []entities.ConnectionStatus{entities.ConnectionStatus_CONNECTED},
+		SortBy:             models.NodebIdListSortByName,
+		Limit:              1,
+		Fields:             []string{"inventoryName", "healthState"},
+	}
+
+	response, err := handler.Handle(context.Background(), request)
+	assert.Nil(t, err)
+	assert.IsType(t, &models.GetNodebIdListPageResponse{}, response)
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	nextCursor := (&models.NodebIdListCursor{SortBy: models.NodebIdListSortByName, SortKey: "test1", InventoryName: "test1"}).Encode()
+	assert.Equal(t, "{\"nodebs\":[{\"healthState\":\"UNRESPONSIVE\",\"inventoryName\":\"test1\"}],\"totalCount\":2,\"nextCursor\":\""+nextCursor+"\"}", string(data))
+}
@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -37,7 +38,7 @@ func NewGetNodebIdRequestHandler(logger *logger.Logger, ranListManager managers.
 	}
 }
 
-func (h *GetNodebIdRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *GetNodebIdRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
 	getNodebIdRequest := request.(models.GetNodebIdRequest)
 	ranName := getNodebIdRequest.RanName
 
@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/mocks"
 	"e2mgr/models"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -45,7 +46,7 @@ func TestHandleGetNodebIdSuccess(t *testing.T) {
 		HealthCheckTimestampReceived: 12346548,
 	}
 	ranListManagerMock.On("GetNbIdentity",nbIdentity.InventoryName).Return(nbIdentity, nil)
-	response, err := handler.Handle(models.GetNodebIdRequest{RanName: nbIdentity.InventoryName})
+	response, err := handler.Handle(context.Background(), models.GetNodebIdRequest{RanName: nbIdentity.InventoryName})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
@@ -59,7 +60,7 @@ func TestHandleGetNodebIdNotFoundFailure(t *testing.T) {
 	}
 
 	ranListManagerMock.On("GetNbIdentity",nbIdentity.InventoryName).Return(nbIdentity, e2managererrors.NewResourceNotFoundError())
-	_, err := handler.Handle(models.GetNodebIdRequest{RanName: nbIdentity.InventoryName})
+	_, err := handler.Handle(context.Background(), models.GetNodebIdRequest{RanName: nbIdentity.InventoryName})
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
@@ -21,7 +21,9 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
+	"e2mgr/e2sm"
 	"e2mgr/logger"
 	"e2mgr/models"
 	"e2mgr/services"
@@ -31,25 +33,33 @@ import (
 type GetNodebRequestHandler struct {
 	rNibDataService services.RNibDataService
 	logger          *logger.Logger
+	decoderRegistry e2sm.IDecoderRegistry
 }
 
-func NewGetNodebRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService) *GetNodebRequestHandler {
+func NewGetNodebRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, decoderRegistry e2sm.IDecoderRegistry) *GetNodebRequestHandler {
 	return &GetNodebRequestHandler{
 		logger:          logger,
 		rNibDataService: rNibDataService,
+		decoderRegistry: decoderRegistry,
 	}
 }
 
-func (handler *GetNodebRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (handler *GetNodebRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := handler.logger.WithContext(ctx)
 	getNodebRequest := request.(models.GetNodebRequest)
 	ranName:= getNodebRequest.RanName
 	nodeb, err := handler.rNibDataService.GetNodeb(ranName)
 
 	if err != nil {
-		handler.logger.Errorf("#GetNodebRequestHandler.Handle - RAN name: %s - Error fetching RAN from rNib: %v",  ranName, err)
+		log.Errorf("#GetNodebRequestHandler.Handle - RAN name: %s - Error fetching RAN from rNib: %v",  ranName, err)
 		return nil, rnibErrorToE2ManagerError(err)
 	}
 
+	if getNodebRequest.DecodeRanFunctions {
+		ranFunctions := handler.decoderRegistry.Decode(ranName, nodeb.GetGnb().GetRanFunctions())
+		return models.NewNodebResponseWithRanFunctions(nodeb, ranFunctions), nil
+	}
+
 	return models.NewNodebResponse(nodeb), nil
 }
 
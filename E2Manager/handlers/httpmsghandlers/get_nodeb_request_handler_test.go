@@ -21,7 +21,9 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
+	"e2mgr/e2sm"
 	"e2mgr/mocks"
 	"e2mgr/models"
 	"e2mgr/services"
@@ -37,7 +39,7 @@ func setupGetNodebRequestHandlerTest(t *testing.T) (*GetNodebRequestHandler, *mo
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 	readerMock := &mocks.RnibReaderMock{}
 	rnibDataService := services.NewRnibDataService(log, config, readerMock, nil)
-	handler := NewGetNodebRequestHandler(log, rnibDataService)
+	handler := NewGetNodebRequestHandler(log, rnibDataService, e2sm.NewDecoderRegistry(log))
 	return handler, readerMock
 }
 
@@ -47,7 +49,7 @@ func TestHandleGetNodebSuccess(t *testing.T) {
 	ranName := "test1"
 	var rnibError error
 	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{RanName:ranName}, rnibError)
-	response, err := handler.Handle(models.GetNodebRequest{RanName: ranName})
+	response, err := handler.Handle(context.Background(), models.GetNodebRequest{RanName: ranName})
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 	assert.IsType(t, &models.NodebResponse{}, response)
@@ -58,7 +60,40 @@ func TestHandleGetNodebFailure(t *testing.T) {
 	ranName := "test1"
 	var nodebInfo *entities.NodebInfo
 	readerMock.On("GetNodeb", ranName).Return(nodebInfo, common.NewInternalError(errors.New("#reader.GetNodeb - Internal Error")))
-	response, err := handler.Handle(models.GetNodebRequest{RanName: ranName})
+	response, err := handler.Handle(context.Background(), models.GetNodebRequest{RanName: ranName})
 	assert.NotNil(t, err)
 	assert.Nil(t, response)
 }
+
+func TestHandleGetNodebDecodeRanFunctions(t *testing.T) {
+	handler, readerMock := setupGetNodebRequestHandlerTest(t)
+
+	ranName := "test1"
+	kpmDefinition := "20304f52414e2d4532534d2d4b504d000018312e332e362e312e342e312e35333134382e312e322e322e3205004b504d204d6f6e69746f7200010109004532204e6f6465204d6561737572656d656e740101000001404452422e5545546870446c01010101"
+	nodebInfo := &entities.NodebInfo{RanName: ranName, Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{RanFunctions: []*entities.RanFunction{
+		{RanFunctionId: 2, RanFunctionOid: e2sm.KpmV2Oid, RanFunctionDefinition: kpmDefinition},
+		{RanFunctionId: 3, RanFunctionOid: "1.3.6.1.4.1.1.1", RanFunctionDefinition: "334455"},
+	}}}}
+	var rnibError error
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, rnibError)
+	response, err := handler.Handle(context.Background(), models.GetNodebRequest{RanName: ranName, DecodeRanFunctions: true})
+	assert.Nil(t, err)
+
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"ranFunctionDefinitions":[{"ranFunctionId":2,"ranFunctionRevision":0,"ranFunctionOid":"1.3.6.1.4.1.53148.1.2.2.2","definition":{"serviceModelName":"E2SM-KPM","serviceModelVersion":"2.0","shortName":"ORAN-E2SM-KPM","oid":"1.3.6.1.4.1.53148.1.2.2.2","description":"KPM Monitor","reportStyles":[{"type":1,"name":"E2 Node Measurement","actionFormatType":1,"indicationHeaderFormatType":1,"indicationMessageFormatType":1,"measurementNames":["DRB.UEThpDl"]}]}},{"ranFunctionId":3,"ranFunctionRevision":0,"ranFunctionOid":"1.3.6.1.4.1.1.1","rawDefinition":"334455"}]`)
+}
+
+func TestHandleGetNodebWithoutDecoding(t *testing.T) {
+	handler, readerMock := setupGetNodebRequestHandlerTest(t)
+
+	ranName := "test1"
+	nodebInfo := &entities.NodebInfo{RanName: ranName, Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{RanFunctions: []*entities.RanFunction{{RanFunctionId: 3, RanFunctionDefinition: "334455"}}}}}
+	var rnibError error
+	readerMock.On("GetNodeb", ranName).Return(nodebInfo, rnibError)
+	response, err := handler.Handle(context.Background(), models.GetNodebRequest{RanName: ranName})
+	assert.Nil(t, err)
+
+	data, _ := response.Marshal()
+	assert.NotContains(t, string(data), "ranFunctionDefinitions")
+}
@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+const ranFunctionKpmOid = "1.3.6.1.4.1.53148.1.2.2.2"
+
+func TestHandleGetRanFunctionsSuccess(t *testing.T) {
+	log := initLog(t)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	handler := NewGetRanFunctionsRequestHandler(log, ranListManagerMock)
+	capabilities := []*models.RanFunctionCapability{{RanFunctionOid: ranFunctionKpmOid, Nodes: []*models.RanFunctionNode{{RanName: "gnb_1", RanFunctionId: 1, ConnectionStatus: "CONNECTED"}}}}
+	ranListManagerMock.On("GetRanFunctionCapabilities").Return(capabilities)
+
+	response, err := handler.Handle(context.Background(), nil)
+	assert.Nil(t, err)
+
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"ranFunctionOid":"1.3.6.1.4.1.53148.1.2.2.2","nodes":[{"ranName":"gnb_1","ranFunctionId":1,"ranFunctionRevision":0,"connectionStatus":"CONNECTED"}]}]`, string(data))
+}
+
+func TestHandleGetRanFunctionsEmpty(t *testing.T) {
+	log := initLog(t)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	handler := NewGetRanFunctionsRequestHandler(log, ranListManagerMock)
+	ranListManagerMock.On("GetRanFunctionCapabilities").Return([]*models.RanFunctionCapability{})
+
+	response, err := handler.Handle(context.Background(), nil)
+	assert.Nil(t, err)
+
+	data, _ := response.Marshal()
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestHandleGetRanFunctionNodesSuccess(t *testing.T) {
+	log := initLog(t)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	handler := NewGetRanFunctionNodesRequestHandler(log, ranListManagerMock)
+	request := models.GetRanFunctionNodesRequest{RanFunctionOid: ranFunctionKpmOid, ConnectionStatuses: []entities.ConnectionStatus{entities.ConnectionStatus_CONNECTED}}
+	capability := &models.RanFunctionCapability{RanFunctionOid: ranFunctionKpmOid, Nodes: []*models.RanFunctionNode{}}
+	ranListManagerMock.On("GetRanFunctionNodes", &request).Return(capability, nil)
+
+	response, err := handler.Handle(context.Background(), request)
+	assert.Nil(t, err)
+	assert.Equal(t, capability, response)
+}
+
+func TestHandleGetRanFunctionNodesNotFound(t *testing.T) {
+	log := initLog(t)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	handler := NewGetRanFunctionNodesRequestHandler(log, ranListManagerMock)
+	request := models.GetRanFunctionNodesRequest{RanFunctionOid: ranFunctionKpmOid}
+	var capability *models.RanFunctionCapability
+	ranListManagerMock.On("GetRanFunctionNodes", &request).Return(capability, e2managererrors.NewResourceNotFoundError())
+
+	response, err := handler.Handle(context.Background(), request)
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
@@ -0,0 +1,42 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/models"
+)
+
+type GetRicServiceQueryReportRequestHandler struct {
+	logger                 *logger.Logger
+	ricServiceQueryManager managers.IRicServiceQueryManager
+}
+
+func NewGetRicServiceQueryReportRequestHandler(logger *logger.Logger, ricServiceQueryManager managers.IRicServiceQueryManager) *GetRicServiceQueryReportRequestHandler {
+	return &GetRicServiceQueryReportRequestHandler{
+		logger:                 logger,
+		ricServiceQueryManager: ricServiceQueryManager,
+	}
+}
+
+func (handler *GetRicServiceQueryReportRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	return models.RicServiceQueryReportResponse(handler.ricServiceQueryManager.GetUnresponsiveRans()), nil
+}
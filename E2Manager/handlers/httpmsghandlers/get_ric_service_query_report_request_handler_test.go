@@ -0,0 +1,55 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetRicServiceQueryReportSuccess(t *testing.T) {
+	log := initLog(t)
+	ricServiceQueryManagerMock := &mocks.RicServiceQueryManagerMock{}
+	handler := NewGetRicServiceQueryReportRequestHandler(log, ricServiceQueryManagerMock)
+	records := []*models.RicServiceQueryRecord{{RanName: "test1", TransactionId: "7", SentAt: 1, Deadline: 2}}
+	ricServiceQueryManagerMock.On("GetUnresponsiveRans").Return(records)
+
+	response, err := handler.Handle(context.Background(), models.GetRicServiceQueryReportRequest{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, models.RicServiceQueryReportResponse(records), response)
+}
+
+func TestHandleGetRicServiceQueryReportEmpty(t *testing.T) {
+	log := initLog(t)
+	ricServiceQueryManagerMock := &mocks.RicServiceQueryManagerMock{}
+	handler := NewGetRicServiceQueryReportRequestHandler(log, ricServiceQueryManagerMock)
+	ricServiceQueryManagerMock.On("GetUnresponsiveRans").Return([]*models.RicServiceQueryRecord{})
+
+	response, err := handler.Handle(context.Background(), models.GetRicServiceQueryReportRequest{})
+
+	assert.Nil(t, err)
+	data, err := response.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(data))
+}
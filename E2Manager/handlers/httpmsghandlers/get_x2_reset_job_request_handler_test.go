@@ -0,0 +1,52 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleGetX2ResetJobSuccess(t *testing.T) {
+	_, _, _, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+	handler := NewGetX2ResetJobRequestHandler(initLog(t), x2ResetTransactionManager)
+	_, _ = x2ResetTransactionManager.Start("test1", "misc:om-intervention")
+
+	response, err := handler.Handle(context.Background(), models.GetX2ResetJobRequest{JobId: "1"})
+
+	assert.Nil(t, err)
+	job := response.(*models.X2ResetJob)
+	assert.Equal(t, "test1", job.RanName)
+	assert.Equal(t, models.X2ResetInProgress, job.Status)
+	x2ResetTransactionManager.Abort("test1")
+}
+
+func TestHandleGetX2ResetJobNotFound(t *testing.T) {
+	_, _, _, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+	handler := NewGetX2ResetJobRequestHandler(initLog(t), x2ResetTransactionManager)
+
+	response, err := handler.Handle(context.Background(), models.GetX2ResetJobRequest{JobId: "1"})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
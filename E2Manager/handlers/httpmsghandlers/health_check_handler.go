@@ -19,44 +19,43 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
-	"e2mgr/rmrCgo"
 	"e2mgr/services"
-	"e2mgr/services/rmrsender"
-	"e2mgr/utils"
-	"encoding/xml"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
-	"unsafe"
 )
 
 var(
 	healthCheckSuccessResponse          = "Request Accepted"
-	healthCheckEmptyTagsToReplaceToSelfClosingTags = []string{"reject", "ignore", "protocolIEs", "procedureCode"}
 )
 
 type HealthCheckRequestHandler struct {
-	logger          *logger.Logger
-	rNibDataService services.RNibDataService
-	ranListManager  managers.RanListManager
-	rmrsender       *rmrsender.RmrSender
+	logger                 *logger.Logger
+	rNibDataService        services.RNibDataService
+	ranListManager         managers.RanListManager
+	ricServiceQueryManager managers.IRicServiceQueryManager
+	healthCheckJobManager  managers.IHealthCheckJobManager
 }
 
-func NewHealthCheckRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager, rmrsender *rmrsender.RmrSender) *HealthCheckRequestHandler {
+func NewHealthCheckRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, ranListManager managers.RanListManager, ricServiceQueryManager managers.IRicServiceQueryManager, healthCheckJobManager managers.IHealthCheckJobManager) *HealthCheckRequestHandler {
 	return &HealthCheckRequestHandler{
-		logger:          logger,
-		rNibDataService: rNibDataService,
-		ranListManager:  ranListManager,
-		rmrsender:       rmrsender,
+		logger:                 logger,
+		rNibDataService:        rNibDataService,
+		ranListManager:         ranListManager,
+		ricServiceQueryManager: ricServiceQueryManager,
+		healthCheckJobManager:  healthCheckJobManager,
 	}
 }
 
-func (h *HealthCheckRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *HealthCheckRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
 	ranNameList := h.getRanNameList(request)
 	isAtleastOneRanConnected := false
+	jobId := ""
 
 	nodetypeToNbIdentityMapOld := make(map[entities.Node_Type][]*entities.NbIdentity)
 	nodetypeToNbIdentityMapNew := make(map[entities.Node_Type][]*entities.NbIdentity)
@@ -66,7 +65,7 @@ func (h *HealthCheckRequestHandler) Handle(request models.Request) (models.IResp
 		if err != nil {
 			_, ok := err.(*common.ResourceNotFoundError)
 			if !ok {
-				h.logger.Errorf("#HealthCheckRequest.Handle - failed to get nodeBInfo entity for ran name: %v from RNIB. Error: %s", ranName, err)
+				log.Errorf("#HealthCheckRequest.Handle - failed to get nodeBInfo entity for ran name: %v from RNIB. Error: %s", ranName, err)
 				return nil, e2managererrors.NewRnibDbError()
 			}
 			continue
@@ -75,11 +74,19 @@ func (h *HealthCheckRequestHandler) Handle(request models.Request) (models.IResp
 		if nodebInfo.ConnectionStatus == entities.ConnectionStatus_CONNECTED {
 			isAtleastOneRanConnected = true
 
-			err := h.sendRICServiceQuery(nodebInfo)
+			if jobId == "" {
+				jobId = h.healthCheckJobManager.CreateJob()
+			}
+
+			record, err := h.ricServiceQueryManager.SendQuery(nodebInfo)
 			if err != nil {
-				return nil,err
+				log.Errorf("#HealthCheckRequest.Handle - RAN name: %s - RIC_SERVICE_QUERY was not sent. Error: %s", ranName, err)
+				h.healthCheckJobManager.QueryFailed(jobId, ranName)
+				continue
 			}
 
+			h.healthCheckJobManager.QuerySent(jobId, record)
+
 			oldnbIdentity, newnbIdentity := h.ranListManager.UpdateHealthcheckTimeStampSent(ranName)
 			nodetypeToNbIdentityMapOld[nodebInfo.NodeType] = append(nodetypeToNbIdentityMapOld[nodebInfo.NodeType], oldnbIdentity)
 			nodetypeToNbIdentityMapNew[nodebInfo.NodeType] = append(nodetypeToNbIdentityMapNew[nodebInfo.NodeType], newnbIdentity)
@@ -97,36 +104,9 @@ func (h *HealthCheckRequestHandler) Handle(request models.Request) (models.IResp
 		return nil, e2managererrors.NewNoConnectedRanError()
 	}
 
-	h.logger.Infof("#HealthcheckRequest.Handle - HealthcheckTimeStampSent Update completed to RedisDB")
-
-	return models.NewHealthCheckSuccessResponse(healthCheckSuccessResponse), nil
-}
-
-func (h *HealthCheckRequestHandler) sendRICServiceQuery(nodebInfo *entities.NodebInfo) error {
-
-	serviceQuery := models.NewRicServiceQueryMessage(nodebInfo.GetGnb().RanFunctions)
-	payLoad, err := xml.Marshal(serviceQuery.E2APPDU)
-	if err != nil {
-		h.logger.Errorf("#HealthCHeckRequest.Handle- RAN name: %s - Error marshalling RIC_SERVICE_QUERY. Payload: %s", nodebInfo.RanName, payLoad)
-		//return nil, e2managererrors.NewInternalError()
-	}
-
-	payLoad = utils.ReplaceEmptyTagsWithSelfClosing(payLoad,healthCheckEmptyTagsToReplaceToSelfClosingTags)
-
-	var xAction []byte
-	var msgSrc unsafe.Pointer
-	msg := models.NewRmrMessage(rmrCgo.RIC_SERVICE_QUERY, nodebInfo.RanName, payLoad, xAction, msgSrc)
-
-	err = h.rmrsender.Send(msg)
-
-	if err != nil {
-		h.logger.Errorf("#HealthCHeckRequest.Handle - failed to send RIC_SERVICE_QUERY message to RMR for %s. Error: %s", nodebInfo.RanName, err)
-		//return nil, e2managererrors.NewRmrError()
-	} else {
-		h.logger.Infof("#HealthCHeckRequest.Handle - RAN name : %s - Successfully built and sent RIC_SERVICE_QUERY. Message: %x", nodebInfo.RanName, msg)
-	}
+	log.Infof("#HealthcheckRequest.Handle - HealthcheckTimeStampSent Update completed to RedisDB, health check job id: %s", jobId)
 
-	return nil
+	return models.NewHealthCheckSuccessResponse(healthCheckSuccessResponse, jobId), nil
 }
 
 func (h *HealthCheckRequestHandler) getRanNameList(request models.Request) []string {
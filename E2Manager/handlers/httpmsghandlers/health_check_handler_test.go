@@ -20,8 +20,10 @@ package httpmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
+	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
@@ -29,6 +31,7 @@ import (
 	"e2mgr/utils"
 	"encoding/xml"
 	"errors"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -46,6 +49,7 @@ const (
 func setupHealthCheckHandlerTest(t *testing.T) (*HealthCheckRequestHandler, services.RNibDataService, *mocks.RnibReaderMock, *mocks.RanListManagerMock, *mocks.RmrMessengerMock) {
 	logger := initLog(t)
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	config.RicServiceQuery.ResponseDeadlineSec = 10
 
 	readerMock := &mocks.RnibReaderMock{}
 	writerMock := &mocks.RnibWriterMock{}
@@ -55,7 +59,12 @@ func setupHealthCheckHandlerTest(t *testing.T) (*HealthCheckRequestHandler, serv
 	ranListManagerMock := &mocks.RanListManagerMock{}
 
 	rmrSender := getRmrSender(rmrMessengerMock, logger)
-	handler := NewHealthCheckRequestHandler(logger, rnibDataService, ranListManagerMock, rmrSender)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(logger, config, ranProcedureStoreMock)
+	ricServiceQueryManager := managers.NewRicServiceQueryManager(logger, config, rmrSender, rnibDataService, ranProcedureTracker)
+	handler := NewHealthCheckRequestHandler(logger, rnibDataService, ranListManagerMock, ricServiceQueryManager, managers.NewHealthCheckJobManager(logger))
 
 	return handler, rnibDataService, readerMock, ranListManagerMock, rmrMessengerMock
 }
@@ -75,11 +84,17 @@ func TestHealthCheckRequestHandlerArguementHasRanNameSuccess(t *testing.T) {
 	ranListManagerMock.On("UpdateHealthcheckTimeStampSent",nb1.RanName).Return(oldnbIdentity, newnbIdentity)
 	ranListManagerMock.On("UpdateNbIdentities",nb1.NodeType, []*entities.NbIdentity{oldnbIdentity}, []*entities.NbIdentity{newnbIdentity}).Return(nil)
 
-	resp, err := handler.Handle(models.HealthCheckRequest{ranNames})
+	resp, err := handler.Handle(context.Background(), models.HealthCheckRequest{ranNames})
 
 	assert.IsType(t, &models.HealthCheckSuccessResponse{}, resp)
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
+
+	job, err := handler.healthCheckJobManager.GetJob(resp.(*models.HealthCheckSuccessResponse).JobId)
+	assert.Nil(t, err)
+	assert.Len(t, job.Rans, 1)
+	assert.Equal(t, nb1.RanName, job.Rans[0].RanName)
+	assert.Equal(t, models.HealthCheckQuerySent, job.Rans[0].Status)
 }
 
 func TestHealthCheckRequestHandlerArguementHasNoRanNameSuccess(t *testing.T) {
@@ -104,7 +119,7 @@ func TestHealthCheckRequestHandlerArguementHasNoRanNameSuccess(t *testing.T) {
 	nb2 := &entities.NodebInfo{RanName: "RanName_2", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
 	readerMock.On("GetNodeb", "RanName_2").Return(nb2, nil)
 
-	resp, err := handler.Handle(models.HealthCheckRequest{[]string{}})
+	resp, err := handler.Handle(context.Background(), models.HealthCheckRequest{[]string{}})
 
 	assert.Nil(t, err)
 	assert.IsType(t, &models.HealthCheckSuccessResponse{}, resp)
@@ -124,7 +139,7 @@ func TestHealthCheckRequestHandlerArguementHasNoRanConnectedFailure(t *testing.T
 	nb2 := &entities.NodebInfo{RanName: "RanName_2", ConnectionStatus: entities.ConnectionStatus_SHUT_DOWN}
 	readerMock.On("GetNodeb", "RanName_2").Return(nb2, nil)
 
-	_, err := handler.Handle(models.HealthCheckRequest{[]string{}})
+	_, err := handler.Handle(context.Background(), models.HealthCheckRequest{[]string{}})
 
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
 	ranListManagerMock.AssertNotCalled(t,"UpdateHealthcheckTimeStampSent",mock.Anything)
@@ -133,13 +148,34 @@ func TestHealthCheckRequestHandlerArguementHasNoRanConnectedFailure(t *testing.T
 
 }
 
+func TestHealthCheckRequestHandlerSendFailureSkipsTimeStampUpdate(t *testing.T) {
+	handler, _, readerMock, ranListManagerMock, rmrMessengerMock := setupHealthCheckHandlerTest(t)
+
+	nb1 := createNbIdentity(t, "RanName_1", entities.ConnectionStatus_CONNECTED)
+	readerMock.On("GetNodeb", nb1.RanName).Return(nb1, nil)
+
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, errors.New("rmr failure"))
+
+	resp, err := handler.Handle(context.Background(), models.HealthCheckRequest{[]string{nb1.RanName}})
+
+	assert.Nil(t, err)
+	assert.IsType(t, &models.HealthCheckSuccessResponse{}, resp)
+	ranListManagerMock.AssertNotCalled(t, "UpdateHealthcheckTimeStampSent", mock.Anything)
+	ranListManagerMock.AssertNotCalled(t, "UpdateNbIdentities", mock.Anything, mock.Anything, mock.Anything)
+
+	job, err := handler.healthCheckJobManager.GetJob(resp.(*models.HealthCheckSuccessResponse).JobId)
+	assert.Nil(t, err)
+	assert.Len(t, job.Rans, 1)
+	assert.Equal(t, models.HealthCheckSendFailed, job.Rans[0].Status)
+}
+
 func TestHealthCheckRequestHandlerArguementHasRanNameDBErrorFailure(t *testing.T) {
 	handler, _, readerMock, ranListManagerMock, rmrMessengerMock := setupHealthCheckHandlerTest(t)
 
 	ranNames := []string{"RanName_1"}
 	readerMock.On("GetNodeb", "RanName_1").Return(&entities.NodebInfo{}, errors.New("error"))
 
-	_, err := handler.Handle(models.HealthCheckRequest{ranNames})
+	_, err := handler.Handle(context.Background(), models.HealthCheckRequest{ranNames})
 
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
 	ranListManagerMock.AssertNotCalled(t,"UpdateHealthcheckTimeStampSent",mock.Anything)
@@ -149,7 +185,7 @@ func TestHealthCheckRequestHandlerArguementHasRanNameDBErrorFailure(t *testing.T
 }
 
 func createRMRMbuf(t *testing.T, nodebInfo *entities.NodebInfo) *rmrCgo.MBuf{
-	serviceQuery := models.NewRicServiceQueryMessage(nodebInfo.GetGnb().RanFunctions)
+	serviceQuery := models.NewRicServiceQueryMessage(nodebInfo.GetGnb().RanFunctions, "0")
 	payLoad, err := xml.Marshal(&serviceQuery.E2APPDU)
 	payLoad = utils.NormalizeXml(payLoad)
 	tagsToReplace := []string{"reject","ignore","protocolIEs"}
@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package httpmsghandlers
+
+import (
+	"context"
+	"e2mgr/e2managererrors"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRicServiceQueryRequestHandlerTest(t *testing.T) (*RicServiceQueryRequestHandler, *mocks.RicServiceQueryManagerMock) {
+	log := initLog(t)
+	ricServiceQueryManagerMock := &mocks.RicServiceQueryManagerMock{}
+	handler := NewRicServiceQueryRequestHandler(log, ricServiceQueryManagerMock)
+	return handler, ricServiceQueryManagerMock
+}
+
+func TestHandleRicServiceQuerySuccess(t *testing.T) {
+	handler, ricServiceQueryManagerMock := setupRicServiceQueryRequestHandlerTest(t)
+	ranName := "test1"
+	record := &models.RicServiceQueryRecord{RanName: ranName, TransactionId: "7", SentAt: 1, Deadline: 2}
+	ricServiceQueryManagerMock.On("Query", ranName).Return(record, nil)
+
+	response, err := handler.Handle(context.Background(), models.RicServiceQueryRequest{RanName: ranName})
+
+	assert.Nil(t, err)
+	assert.Equal(t, record, response)
+}
+
+func TestHandleRicServiceQueryWrongState(t *testing.T) {
+	handler, ricServiceQueryManagerMock := setupRicServiceQueryRequestHandlerTest(t)
+	ranName := "test1"
+	ricServiceQueryManagerMock.On("Query", ranName).Return(nil, e2managererrors.NewWrongStateError("RIC_SERVICE_QUERY", "DISCONNECTED"))
+
+	response, err := handler.Handle(context.Background(), models.RicServiceQueryRequest{RanName: ranName})
+
+	assert.Nil(t, response)
+	assert.IsType(t, &e2managererrors.WrongStateError{}, err)
+}
@@ -21,6 +21,7 @@ package httpmsghandlers
 
 import "C"
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/models"
@@ -39,30 +40,31 @@ func NewSetGeneralConfigurationHandler(logger *logger.Logger, rnibDataService se
 	}
 }
 
-func (h *SetGeneralConfigurationHandler) Handle(request models.Request) (models.IResponse, error) {
-	h.logger.Infof("#SetGeneralConfigurationHandler.Handle - handling set general parameters")
+func (h *SetGeneralConfigurationHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
+	log.Infof("#SetGeneralConfigurationHandler.Handle - handling set general parameters")
 
 	configuration := request.(models.GeneralConfigurationRequest)
 
 	existingConfig, err := h.rnibDataService.GetGeneralConfiguration()
 
 	if err != nil {
-		h.logger.Errorf("#SetGeneralConfigurationHandler.Handle - Error fetching general configuration from rNib: %s", err)
+		log.Errorf("#SetGeneralConfigurationHandler.Handle - Error fetching general configuration from rNib: %s", err)
 		return nil, e2managererrors.NewRnibDbError()
 	}
 
-	h.logger.Infof("#SetGeneralConfigurationHandler.Handle - got general configuration from rnib - enableRic: %t", existingConfig.EnableRic)
+	log.Infof("#SetGeneralConfigurationHandler.Handle - got general configuration from rnib - enableRic: %t", existingConfig.EnableRic)
 
 	if existingConfig.EnableRic != configuration.EnableRic {
 
 		existingConfig.EnableRic = configuration.EnableRic
 
-		h.logger.Infof("#SetGeneralConfigurationHandler.Handle - save general configuration to rnib: %+v", *existingConfig)
+		log.Infof("#SetGeneralConfigurationHandler.Handle - save general configuration to rnib: %+v", *existingConfig)
 
 		err := h.rnibDataService.SaveGeneralConfiguration(existingConfig)
 
 		if err != nil {
-			h.logger.Errorf("#SetGeneralConfigurationHandler.Handle - failed to save general configuration in RNIB. error: %s", err)
+			log.Errorf("#SetGeneralConfigurationHandler.Handle - failed to save general configuration in RNIB. error: %s", err)
 			return nil, e2managererrors.NewRnibDbError()
 		}
 
@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/mocks"
 	"e2mgr/models"
@@ -50,7 +51,7 @@ func TestSetGeneralConfigurationFalse_Success(t *testing.T) {
 	updated := &entities.GeneralConfiguration{EnableRic: false}
 	writerMock.On("SaveGeneralConfiguration", updated).Return(nil)
 
-	response, err := handler.Handle(models.GeneralConfigurationRequest{EnableRic: false})
+	response, err := handler.Handle(context.Background(), models.GeneralConfigurationRequest{EnableRic: false})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
@@ -66,7 +67,7 @@ func TestSetGeneralConfigurationTrue_Success(t *testing.T) {
 	updated := &entities.GeneralConfiguration{EnableRic: true}
 	writerMock.On("SaveGeneralConfiguration", updated).Return(nil)
 
-	response, err := handler.Handle(models.GeneralConfigurationRequest{EnableRic: true})
+	response, err := handler.Handle(context.Background(), models.GeneralConfigurationRequest{EnableRic: true})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
@@ -79,7 +80,7 @@ func TestSetGeneralConfigurationIgnore_Success(t *testing.T) {
 	configuration := &entities.GeneralConfiguration{EnableRic: false}
 	readerMock.On("GetGeneralConfiguration").Return(configuration, nil)
 
-	response, err := handler.Handle(models.GeneralConfigurationRequest{EnableRic: false})
+	response, err := handler.Handle(context.Background(), models.GeneralConfigurationRequest{EnableRic: false})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
@@ -95,7 +96,7 @@ func TestSetGeneralConfigurationHandlerRnibError(t *testing.T) {
 	updated := &entities.GeneralConfiguration{EnableRic: true}
 	writerMock.On("SaveGeneralConfiguration", updated).Return(common.NewInternalError(errors.New("error")))
 
-	response, err := handler.Handle(models.GeneralConfigurationRequest{EnableRic: true})
+	response, err := handler.Handle(context.Background(), models.GeneralConfigurationRequest{EnableRic: true})
 
 	assert.NotNil(t, err)
 	assert.Nil(t, response)
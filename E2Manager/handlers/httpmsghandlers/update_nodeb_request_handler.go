@@ -20,6 +20,7 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
@@ -32,21 +33,24 @@ type UpdateNodebRequestHandler struct {
 	logger             *logger.Logger
 	rNibDataService    services.RNibDataService
 	updateNodebManager managers.IUpdateNodebManager
+	ranListManager     managers.RanListManager
 }
 
-func NewUpdateNodebRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, updateNodebManager managers.IUpdateNodebManager) *UpdateNodebRequestHandler {
+func NewUpdateNodebRequestHandler(logger *logger.Logger, rNibDataService services.RNibDataService, updateNodebManager managers.IUpdateNodebManager, ranListManager managers.RanListManager) *UpdateNodebRequestHandler {
 	return &UpdateNodebRequestHandler{
 		logger:             logger,
 		rNibDataService:    rNibDataService,
 		updateNodebManager: updateNodebManager,
+		ranListManager:     ranListManager,
 	}
 }
 
-func (h *UpdateNodebRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (h *UpdateNodebRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := h.logger.WithContext(ctx)
 
 	ranName := h.getRanName(request)
 
-	h.logger.Infof("#UpdateNodebRequestHandler.Handle - Ran name: %s", ranName)
+	log.Infof("#UpdateNodebRequestHandler.Handle - Ran name: %s", ranName)
 
 	err := h.updateNodebManager.Validate(request)
 	if err != nil {
@@ -57,11 +61,11 @@ func (h *UpdateNodebRequestHandler) Handle(request models.Request) (models.IResp
 	if err != nil {
 		_, ok := err.(*common.ResourceNotFoundError)
 		if !ok {
-			h.logger.Errorf("#UpdateNodebRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", ranName, err)
+			log.Errorf("#UpdateNodebRequestHandler.Handle - RAN name: %s - failed to get nodeb entity from RNIB. Error: %s", ranName, err)
 			return nil, e2managererrors.NewRnibDbError()
 		}
 
-		h.logger.Errorf("#UpdateNodebRequestHandler.Handle - RAN name: %s - RAN not found on RNIB. Error: %s", ranName, err)
+		log.Errorf("#UpdateNodebRequestHandler.Handle - RAN name: %s - RAN not found on RNIB. Error: %s", ranName, err)
 		return nil, e2managererrors.NewResourceNotFoundError()
 	}
 
@@ -82,6 +86,8 @@ func (h *UpdateNodebRequestHandler) Handle(request models.Request) (models.IResp
 		return nil, err
 	}
 
+	h.ranListManager.UpdateNbIdentityCells(nodebInfo)
+
 	return models.NewNodebResponse(nodebInfo), nil
 }
 
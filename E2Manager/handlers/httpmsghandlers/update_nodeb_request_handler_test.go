@@ -23,7 +23,8 @@ func setupUpdateNodebRequestHandlerTest(t *testing.T) ( *UpdateNodebRequestHandl
         writerMock := &mocks.RnibWriterMock{}
         rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
         updateNodebManager := managers.IUpdateNodebManager(nil)
-        handler := NewUpdateNodebRequestHandler(logger,rnibDataService,updateNodebManager)
+        ranListManager := managers.NewRanListManager(logger, rnibDataService)
+        handler := NewUpdateNodebRequestHandler(logger,rnibDataService,updateNodebManager,ranListManager)
         return handler,readerMock, writerMock
 
 }
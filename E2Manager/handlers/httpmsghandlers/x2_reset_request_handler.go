@@ -21,9 +21,11 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/e2managererrors"
 	"e2mgr/e2pdus"
 	"e2mgr/logger"
+	"e2mgr/managers"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
 	"e2mgr/services"
@@ -38,23 +40,26 @@ const (
 )
 
 type X2ResetRequestHandler struct {
-	rNibDataService services.RNibDataService
-	rmrSender       *rmrsender.RmrSender
-	logger          *logger.Logger
+	rNibDataService           services.RNibDataService
+	rmrSender                 *rmrsender.RmrSender
+	logger                    *logger.Logger
+	x2ResetTransactionManager managers.IX2ResetTransactionManager
 }
 
-func NewX2ResetRequestHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService) *X2ResetRequestHandler {
+func NewX2ResetRequestHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, x2ResetTransactionManager managers.IX2ResetTransactionManager) *X2ResetRequestHandler {
 	return &X2ResetRequestHandler{
-		rNibDataService: rNibDataService,
-		rmrSender:       rmrSender,
-		logger:          logger,
+		rNibDataService:           rNibDataService,
+		rmrSender:                 rmrSender,
+		logger:                    logger,
+		x2ResetTransactionManager: x2ResetTransactionManager,
 	}
 }
 
-func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IResponse, error) {
+func (handler *X2ResetRequestHandler) Handle(ctx context.Context, request models.Request) (models.IResponse, error) {
+	log := handler.logger.WithContext(ctx)
 
 	resetRequest := request.(models.ResetRequest)
-	handler.logger.Infof("#X2ResetRequestHandler.Handle - Ran name: %s", resetRequest.RanName)
+	log.Infof("#X2ResetRequestHandler.Handle - Ran name: %s", resetRequest.RanName)
 
 	if len(resetRequest.Cause) == 0 {
 		resetRequest.Cause = e2pdus.OmInterventionCause
@@ -63,13 +68,13 @@ func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IRe
 	payload, ok := e2pdus.KnownCausesToX2ResetPDU(resetRequest.Cause)
 
 	if !ok {
-		handler.logger.Errorf("#X2ResetRequestHandler.Handle - Unknown cause (%s)", resetRequest.Cause)
+		log.Errorf("#X2ResetRequestHandler.Handle - Unknown cause (%s)", resetRequest.Cause)
 		return nil, e2managererrors.NewRequestValidationError()
 	}
 
 	nodeb, err := handler.rNibDataService.GetNodeb(resetRequest.RanName)
 	if err != nil {
-		handler.logger.Errorf("#X2ResetRequestHandler.Handle - failed to get status of RAN: %s from RNIB. Error: %s", resetRequest.RanName, err.Error())
+		log.Errorf("#X2ResetRequestHandler.Handle - failed to get status of RAN: %s from RNIB. Error: %s", resetRequest.RanName, err.Error())
 		_, ok := err.(*common.ResourceNotFoundError)
 		if ok {
 			return nil, e2managererrors.NewResourceNotFoundError()
@@ -78,10 +83,15 @@ func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IRe
 	}
 
 	if nodeb.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
-		handler.logger.Errorf("#X2ResetRequestHandler.Handle - RAN: %s in wrong state (%s)", resetRequest.RanName, entities.ConnectionStatus_name[int32(nodeb.ConnectionStatus)])
+		log.Errorf("#X2ResetRequestHandler.Handle - RAN: %s in wrong state (%s)", resetRequest.RanName, entities.ConnectionStatus_name[int32(nodeb.ConnectionStatus)])
 		return nil, e2managererrors.NewWrongStateError(X2_RESET_ACTIVITY_NAME, entities.ConnectionStatus_name[int32(nodeb.ConnectionStatus)])
 	}
 
+	transaction, err := handler.x2ResetTransactionManager.Start(resetRequest.RanName, resetRequest.Cause)
+	if err != nil {
+		return nil, err
+	}
+
 	var xAction []byte
 	var msgSrc unsafe.Pointer
 	msg := models.NewRmrMessage(rmrCgo.RIC_X2_RESET, resetRequest.RanName, payload, xAction, msgSrc)
@@ -89,11 +99,38 @@ func (handler *X2ResetRequestHandler) Handle(request models.Request) (models.IRe
 	err = handler.rmrSender.Send(msg)
 
 	if err != nil {
-		handler.logger.Errorf("#X2ResetRequestHandler.Handle - failed to send reset message to RMR: %s", err)
+		log.Errorf("#X2ResetRequestHandler.Handle - failed to send reset message to RMR: %s", err)
+		handler.x2ResetTransactionManager.Abort(resetRequest.RanName)
 		return nil, e2managererrors.NewRmrError()
 	}
 
-	handler.logger.Infof("#X2ResetRequestHandler.Handle - sent x2 reset to RAN: %s with cause: %s", resetRequest.RanName, resetRequest.Cause)
+	log.Infof("#X2ResetRequestHandler.Handle - sent x2 reset to RAN: %s with cause: %s, job id: %s", resetRequest.RanName, resetRequest.Cause, transaction.JobId)
 
-	return nil, nil
+	if resetRequest.Async {
+		job, err := handler.x2ResetTransactionManager.GetJob(transaction.JobId)
+		if err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+
+	var status models.X2ResetStatus
+	select {
+	case status = <-transaction.Done():
+	case <-ctx.Done():
+		log.Warnf("#X2ResetRequestHandler.Handle - RAN name: %s - request ended before X2 Reset job %s completed, the job goes on. Error: %s", resetRequest.RanName, transaction.JobId, ctx.Err())
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	switch status {
+	case models.X2ResetSucceeded:
+		log.Infof("#X2ResetRequestHandler.Handle - RAN name: %s - X2 Reset completed successfully", resetRequest.RanName)
+		return nil, nil
+	case models.X2ResetFailed:
+		log.Errorf("#X2ResetRequestHandler.Handle - RAN name: %s - X2 Reset job %s failed", resetRequest.RanName, transaction.JobId)
+		return nil, e2managererrors.NewRanRejectedRequestError()
+	default:
+		log.Errorf("#X2ResetRequestHandler.Handle - RAN name: %s - X2 Reset job %s did not complete, status: %s", resetRequest.RanName, transaction.JobId, status)
+		return nil, e2managererrors.NewRanResponseTimeoutError()
+	}
 }
@@ -21,8 +21,10 @@
 package httpmsghandlers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
+	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
@@ -31,24 +33,56 @@ import (
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"unsafe"
 )
 
 func setupX2ResetRequestHandlerTest(t *testing.T) (*X2ResetRequestHandler, *mocks.RmrMessengerMock, *mocks.RnibReaderMock) {
+	handler, rmrMessengerMock, readerMock, _ := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+	return handler, rmrMessengerMock, readerMock
+}
+
+func setupX2ResetRequestHandlerWithTimeoutTest(t *testing.T, timeoutSec int) (*X2ResetRequestHandler, *mocks.RmrMessengerMock, *mocks.RnibReaderMock, *managers.X2ResetTransactionManager) {
 	log := initLog(t)
-	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, X2Reset: configuration.X2ResetConfig{TimeoutSec: timeoutSec}}
 	readerMock := &mocks.RnibReaderMock{}
 	writerMock := &mocks.RnibWriterMock{}
 	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrSender := getRmrSender(rmrMessengerMock, log)
-	handler := NewX2ResetRequestHandler(log, rmrSender, rnibDataService)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	x2ResetTransactionManager := managers.NewX2ResetTransactionManager(log, config, ranProcedureTracker)
+	handler := NewX2ResetRequestHandler(log, rmrSender, rnibDataService, x2ResetTransactionManager)
+
+	return handler, rmrMessengerMock, readerMock, x2ResetTransactionManager
+}
 
-	return handler, rmrMessengerMock, readerMock
+// startedJobIds records the ids of the X2 Reset jobs started through the handler, which are random
+type startedJobIds struct {
+	managers.IX2ResetTransactionManager
+	jobIds []string
+}
+
+func recordStartedJobIds(handler *X2ResetRequestHandler) *startedJobIds {
+	recorder := &startedJobIds{IX2ResetTransactionManager: handler.x2ResetTransactionManager}
+	handler.x2ResetTransactionManager = recorder
+	return recorder
+}
+
+func (r *startedJobIds) Start(ranName string, cause string) (*managers.X2ResetTransaction, error) {
+	transaction, err := r.IX2ResetTransactionManager.Start(ranName, cause)
+	if err == nil {
+		r.jobIds = append(r.jobIds, transaction.JobId)
+	}
+	return transaction, err
 }
+
 func TestHandleSuccessfulDefaultCause(t *testing.T) {
-	handler, rmrMessengerMock, readerMock := setupX2ResetRequestHandlerTest(t)
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
 
 	ranName := "test1"
 	// o&m intervention
@@ -57,30 +91,34 @@ func TestHandleSuccessfulDefaultCause(t *testing.T) {
 	var msgSrc unsafe.Pointer
 	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
 
-	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil).Run(func(mock.Arguments) {
+		x2ResetTransactionManager.Complete(ranName, true)
+	})
 
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.Nil(t, actual)
 }
 
 func TestHandleSuccessfulRequestedCause(t *testing.T) {
-	handler, rmrMessengerMock, readerMock := setupX2ResetRequestHandlerTest(t)
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
 
 	ranName := "test1"
 	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x40}
 	var xAction[]byte
 	var msgSrc unsafe.Pointer
 	msg := rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
-	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil).Run(func(mock.Arguments) {
+		x2ResetTransactionManager.Complete(ranName, true)
+	})
 
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName, Cause: "protocol:transfer-syntax-error"})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName, Cause: "protocol:transfer-syntax-error"})
 
 	assert.Nil(t, actual)
 }
@@ -92,7 +130,7 @@ func TestHandleFailureUnknownCause(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName, Cause: "XXX"})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName, Cause: "XXX"})
 
 	assert.IsType(t, e2managererrors.NewRequestValidationError(), actual)
 
@@ -105,7 +143,7 @@ func TestHandleFailureWrongState(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewWrongStateError(X2_RESET_ACTIVITY_NAME, entities.ConnectionStatus_name[int32(nodeb.ConnectionStatus)]), actual)
 }
@@ -117,7 +155,7 @@ func TestHandleFailureRanNotFound(t *testing.T) {
 
 	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{}, common.NewResourceNotFoundError("nodeb not found"))
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewResourceNotFoundError(), actual)
 }
@@ -129,7 +167,7 @@ func TestHandleFailureRnibError(t *testing.T) {
 
 	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{}, common.NewInternalError(fmt.Errorf("internal error")))
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewRnibDbError(), actual)
 }
@@ -148,7 +186,120 @@ func TestHandleFailureRmrError(t *testing.T) {
 	var nodeb = &entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}
 	readerMock.On("GetNodeb", ranName).Return(nodeb, nil)
 
-	_, actual := handler.Handle(models.ResetRequest{RanName: ranName})
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
 
 	assert.IsType(t, e2managererrors.NewRmrError(), actual)
 }
+
+func createX2ResetMbuf(ranName string) *rmrCgo.MBuf {
+	// o&m intervention
+	payload := []byte{0x00, 0x07, 0x00, 0x08, 0x00, 0x00, 0x01, 0x00, 0x05, 0x40, 0x01, 0x64}
+	var xAction []byte
+	var msgSrc unsafe.Pointer
+	return rmrCgo.NewMBuf(rmrCgo.RIC_X2_RESET, len(payload), ranName, &payload, &xAction, msgSrc)
+}
+
+func TestHandleFailureRmrErrorAbortsJob(t *testing.T) {
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+
+	ranName := "test1"
+	msg := createX2ResetMbuf(ranName)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(&rmrCgo.MBuf{}, fmt.Errorf("rmr error"))
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}, nil)
+	startedJobIds := recordStartedJobIds(handler)
+
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
+
+	assert.IsType(t, e2managererrors.NewRmrError(), actual)
+	assert.Len(t, startedJobIds.jobIds, 1)
+	job, err := x2ResetTransactionManager.GetJob(startedJobIds.jobIds[0])
+	assert.Nil(t, err)
+	assert.Equal(t, models.X2ResetFailed, job.Status)
+}
+
+func TestHandleAsyncReturnsJob(t *testing.T) {
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+
+	ranName := "test1"
+	msg := createX2ResetMbuf(ranName)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}, nil)
+
+	response, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName, Async: true})
+
+	assert.Nil(t, actual)
+	job := response.(*models.X2ResetJob)
+	assert.NotEmpty(t, job.JobId)
+	assert.Equal(t, ranName, job.RanName)
+	assert.Equal(t, models.X2ResetInProgress, job.Status)
+
+	assert.True(t, x2ResetTransactionManager.Complete(ranName, true))
+	job, _ = x2ResetTransactionManager.GetJob(job.JobId)
+	assert.Equal(t, models.X2ResetSucceeded, job.Status)
+}
+
+func TestHandleFailureAlreadyInProgress(t *testing.T) {
+	handler, rmrMessengerMock, readerMock, _ := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+
+	ranName := "test1"
+	msg := createX2ResetMbuf(ranName)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}, nil)
+
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName, Async: true})
+	assert.Nil(t, actual)
+
+	_, actual = handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
+	assert.IsType(t, &e2managererrors.CommandAlreadyInProgressError{}, actual)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestHandleFailureUnsuccessfulResponse(t *testing.T) {
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+
+	ranName := "test1"
+	msg := createX2ResetMbuf(ranName)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil).Run(func(mock.Arguments) {
+		x2ResetTransactionManager.Complete(ranName, false)
+	})
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}, nil)
+
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
+
+	assert.IsType(t, &e2managererrors.RanRejectedRequestError{}, actual)
+}
+
+func TestHandleFailureTimeout(t *testing.T) {
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 1)
+
+	ranName := "test1"
+	msg := createX2ResetMbuf(ranName)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil)
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}, nil)
+	startedJobIds := recordStartedJobIds(handler)
+
+	_, actual := handler.Handle(context.Background(), models.ResetRequest{RanName: ranName})
+
+	assert.IsType(t, &e2managererrors.RanResponseTimeoutError{}, actual)
+	assert.Len(t, startedJobIds.jobIds, 1)
+	job, _ := x2ResetTransactionManager.GetJob(startedJobIds.jobIds[0])
+	assert.Equal(t, models.X2ResetTimedOut, job.Status)
+	assert.False(t, x2ResetTransactionManager.Complete(ranName, true))
+}
+
+func TestHandleFailureRequestCancelled(t *testing.T) {
+	handler, rmrMessengerMock, readerMock, x2ResetTransactionManager := setupX2ResetRequestHandlerWithTimeoutTest(t, 10)
+
+	ranName := "test1"
+	ctx, cancel := context.WithCancel(context.Background())
+	msg := createX2ResetMbuf(ranName)
+	rmrMessengerMock.On("SendMsg", msg, true).Return(msg, nil).Run(func(mock.Arguments) {
+		cancel()
+	})
+	readerMock.On("GetNodeb", ranName).Return(&entities.NodebInfo{ConnectionStatus: entities.ConnectionStatus_CONNECTED}, nil)
+
+	_, actual := handler.Handle(ctx, models.ResetRequest{RanName: ranName})
+
+	assert.IsType(t, &e2managererrors.InternalError{}, actual)
+	assert.True(t, x2ResetTransactionManager.Complete(ranName, true))
+}
@@ -19,7 +19,9 @@
 package rmrmsghandlers
 
 import (
+	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/managers"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
 	"e2mgr/services"
@@ -32,58 +34,96 @@ import (
 )
 
 var (
-	toReplaceTags = []string{"reject", "ignore", "procedureCode", "id", "RANfunctionID-Item", "RANfunctionsID-List", "success", "s1", "ng", "e1", "f1", "w1", "x1", "xn"}
+	toReplaceTags = []string{"reject", "ignore", "procedureCode", "id", "RANfunctionID-Item", "RANfunctionsID-List", "success", "failure", "s1", "ng", "e1", "f1", "w1", "x1", "xn"}
 )
 
 type E2nodeConfigUpdateNotificationHandler struct {
-	logger          *logger.Logger
-	rNibDataService services.RNibDataService
-	rmrSender       *rmrsender.RmrSender
+	logger              *logger.Logger
+	config              *configuration.Configuration
+	rNibDataService     services.RNibDataService
+	rmrSender           *rmrsender.RmrSender
+	ranProcedureTracker managers.IRanProcedureTracker
 }
 
-func NewE2nodeConfigUpdateNotificationHandler(logger *logger.Logger, rNibDataService services.RNibDataService, rmrSender *rmrsender.RmrSender) *E2nodeConfigUpdateNotificationHandler {
+func NewE2nodeConfigUpdateNotificationHandler(logger *logger.Logger, config *configuration.Configuration, rNibDataService services.RNibDataService, rmrSender *rmrsender.RmrSender, ranProcedureTracker managers.IRanProcedureTracker) *E2nodeConfigUpdateNotificationHandler {
 	return &E2nodeConfigUpdateNotificationHandler{
-		logger:          logger,
-		rNibDataService: rNibDataService,
-		rmrSender:       rmrSender,
+		logger:              logger,
+		config:              config,
+		rNibDataService:     rNibDataService,
+		rmrSender:           rmrSender,
+		ranProcedureTracker: ranProcedureTracker,
 	}
 }
 
 func (e *E2nodeConfigUpdateNotificationHandler) Handle(request *models.NotificationRequest) {
-	e.logger.Infof("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - received E2_Config_Update. Payload: %x", request.RanName, request.Payload)
+	log := e.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
+	log.Infof("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - received E2_Config_Update. Payload: %x", request.RanName, request.Payload)
 	e2NodeConfig, err := e.parseE2NodeConfigurationUpdate(request.Payload)
 	if err != nil {
-		e.logger.Errorf(err.Error())
+		log.Errorf(err.Error())
+		sendErrorIndication(e.rmrSender, request.RanName, models.ProcedureCode_id_E2nodeConfigurationUpdate, "", models.NewTransferSyntaxErrorCause())
 		return
 	}
 
 	if len(e2NodeConfig.E2APPDU.InitiatingMessage.Value.E2nodeConfigurationUpdate.ProtocolIEs.E2nodeConfigurationUpdateIEs) == 0 {
-		e.logger.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - E2nodeConfigurationUpdateIEs is empty")
+		log.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - E2nodeConfigurationUpdateIEs is empty")
+		sendErrorIndication(e.rmrSender, request.RanName, models.ProcedureCode_id_E2nodeConfigurationUpdate, "", models.NewAbstractSyntaxErrorRejectCause())
 		return
 	}
 
-	e.logger.Debugf("#E2nodeConfigUpdateNotificationHandler.Handle - RIC_E2_Node_Config_Update parsed successfully %+v", e2NodeConfig)
+	log.Debugf("#E2nodeConfigUpdateNotificationHandler.Handle - RIC_E2_Node_Config_Update parsed successfully %+v", e2NodeConfig)
+	transactionId := e2NodeConfig.E2APPDU.InitiatingMessage.Value.E2nodeConfigurationUpdate.ProtocolIEs.E2nodeConfigurationUpdateIEs[0].Value.TransactionID
+	e.ranProcedureTracker.Start(request.RanName, models.E2NodeConfigUpdateProcedure, transactionId)
 
 	nodebInfo, err := e.rNibDataService.GetNodeb(request.RanName)
 
 	if err != nil {
 		switch v := err.(type) {
 		case *common.ResourceNotFoundError:
-			e.logger.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - failed to get nodeB, E2nodeConfigUpdate will not be processed further.")
+			log.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - nobeB entity absent in RNIB, E2nodeConfigUpdate will not be processed further.", request.RanName)
+			e.rejectUpdate(request, transactionId, models.NewMessageNotCompatibleWithReceiverStateCause())
 		default:
-			e.logger.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - nobeB entity of RanName:%s absent in RNIB. Error: %s", request.RanName, v)
+			log.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - failed to get nodeB entity. Error: %s", request.RanName, v)
+			e.rejectUpdate(request, transactionId, models.Cause{Misc: &models.CauseMisc{Unspecified: &struct{}{}}})
 		}
 		return
 	}
-	e.updateE2nodeConfig(e2NodeConfig, nodebInfo)
-	e.handleSuccessfulResponse(e2NodeConfig, request, nodebInfo)
+
+	if nodebInfo.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
+		log.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - E2_Config_Update received while RAN is %s", request.RanName, nodebInfo.ConnectionStatus)
+		e.rejectUpdate(request, transactionId, models.NewMessageNotCompatibleWithReceiverStateCause())
+		return
+	}
+
+	if nodebInfo.GetGnb() == nil && nodebInfo.GetEnb() == nil {
+		log.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - E2_Config_Update received for a %s node without configuration", request.RanName, nodebInfo.NodeType)
+		e.rejectUpdate(request, transactionId, models.Cause{Misc: &models.CauseMisc{Unspecified: &struct{}{}}})
+		return
+	}
+
+	additions, updates, removals := e.updateE2nodeConfig(e2NodeConfig, nodebInfo)
+
+	err = e.rNibDataService.UpdateNodebInfoAndPublish(nodebInfo)
+	if err != nil {
+		log.Errorf("#E2nodeConfigUpdateNotificationHandler.Handle - RAN name: %s - Failed at UpdateNodebInfoAndPublish. error: %s", request.RanName, err)
+		e.rejectUpdate(request, transactionId, models.Cause{Misc: &models.CauseMisc{Unspecified: &struct{}{}}})
+		return
+	}
+
+	e2nodeConfigUpdateResp := models.NewE2nodeConfigurationUpdateAcknowledgeMessage(transactionId, additions, updates, removals)
+	if err = e.handleSuccessfulResponse(e2nodeConfigUpdateResp, failureCauseTags(additions, updates, removals), request, nodebInfo); err != nil {
+		e.ranProcedureTracker.Fail(request.RanName, models.E2NodeConfigUpdateProcedure)
+		return
+	}
+	e.ranProcedureTracker.Complete(request.RanName, models.E2NodeConfigUpdateProcedure)
 }
 
-func (e *E2nodeConfigUpdateNotificationHandler) updateE2nodeConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) {
-	e.handleAddConfig(e2nodeConfig, nodebInfo)
-	e.handleUpdateConfig(e2nodeConfig, nodebInfo)
-	e.handleDeleteConfig(e2nodeConfig, nodebInfo)
-	e.rNibDataService.UpdateNodebInfoAndPublish(nodebInfo)
+// updateE2nodeConfig applies the accepted items to the stored node configuration and returns the outcome of every item
+func (e *E2nodeConfigUpdateNotificationHandler) updateE2nodeConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) (additions, updates, removals []models.E2nodeComponentConfigOutcome) {
+	additions = e.handleAddConfig(e2nodeConfig, nodebInfo)
+	updates = e.handleUpdateConfig(e2nodeConfig, nodebInfo)
+	removals = e.handleDeleteConfig(e2nodeConfig, nodebInfo)
+	return additions, updates, removals
 }
 
 func (e *E2nodeConfigUpdateNotificationHandler) compareConfigIDs(n1, n2 entities.E2NodeComponentConfig) bool {
@@ -115,79 +155,102 @@ func (e *E2nodeConfigUpdateNotificationHandler) compareConfigIDs(n1, n2 entities
 	return false
 }
 
-func (e *E2nodeConfigUpdateNotificationHandler) handleAddConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) {
-	var result []*entities.E2NodeComponentConfig
-
-	additionList := e2nodeConfig.ExtractConfigAdditionList()
-	for i, _ := range additionList {
-		result = append(result, &additionList[i])
+func (e *E2nodeConfigUpdateNotificationHandler) findConfig(nodeConfigs []*entities.E2NodeComponentConfig, component entities.E2NodeComponentConfig) int {
+	for i, v := range nodeConfigs {
+		if e.compareConfigIDs(component, *v) {
+			return i
+		}
 	}
+	return -1
+}
 
+func getNodeConfigs(nodebInfo *entities.NodebInfo) []*entities.E2NodeComponentConfig {
 	if nodebInfo.NodeType == entities.Node_ENB {
-		nodebInfo.GetEnb().NodeConfigs = append(result, nodebInfo.GetEnb().NodeConfigs...)
+		return nodebInfo.GetEnb().NodeConfigs
+	}
+	return nodebInfo.GetGnb().NodeConfigs
+}
 
+func setNodeConfigs(nodebInfo *entities.NodebInfo, nodeConfigs []*entities.E2NodeComponentConfig) {
+	if nodebInfo.NodeType == entities.Node_ENB {
+		nodebInfo.GetEnb().NodeConfigs = nodeConfigs
 	} else {
-		nodebInfo.GetGnb().NodeConfigs = append(result, nodebInfo.GetGnb().NodeConfigs...)
+		nodebInfo.GetGnb().NodeConfigs = nodeConfigs
 	}
 }
 
-func (e *E2nodeConfigUpdateNotificationHandler) handleUpdateConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) {
-	updateList := e2nodeConfig.ExtractConfigUpdateList()
-	if nodebInfo.GetNodeType() == entities.Node_GNB {
-		for i := 0; i < len(updateList); i++ {
-			u := updateList[i]
-			if nodebInfo.GetNodeType() == entities.Node_GNB {
-				for j := 0; j < len(nodebInfo.GetGnb().NodeConfigs); j++ {
-					if e.compareConfigIDs(u, *nodebInfo.GetGnb().NodeConfigs[j]) {
-						e.logger.Debugf("#E2nodeConfigUpdateNotificationHandler.Handle - item at position [%d] should be updated", i)
-						nodebInfo.GetGnb().NodeConfigs[i] = &u
-						break
-					} else {
-						e.logger.Debugf("#E2nodeConfigUpdateNotificationHandler.Handle - dint match")
-					}
-				}
-			}
+// handleAddConfig rejects the addition of a component which is already configured
+func (e *E2nodeConfigUpdateNotificationHandler) handleAddConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) []models.E2nodeComponentConfigOutcome {
+	var result []*entities.E2NodeComponentConfig
+
+	nodeConfigs := getNodeConfigs(nodebInfo)
+	additionList := e2nodeConfig.ExtractConfigAdditionList()
+	outcomes := make([]models.E2nodeComponentConfigOutcome, len(additionList))
+	for i := range additionList {
+		outcomes[i].Config = additionList[i]
+		if e.findConfig(nodeConfigs, additionList[i]) >= 0 || e.findConfig(result, additionList[i]) >= 0 {
+			e.logger.Warnf("#E2nodeConfigUpdateNotificationHandler.handleAddConfig - RAN name: %s - %s component is already configured", nodebInfo.RanName, additionList[i].E2NodeComponentInterfaceType)
+			outcomes[i].FailureCause = &models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}}
+			continue
 		}
-	} else {
-		for i := 0; i < len(updateList); i++ {
-			u := updateList[i]
-			if nodebInfo.GetNodeType() == entities.Node_ENB {
-				for j := 0; j < len(nodebInfo.GetEnb().NodeConfigs); j++ {
-					v := nodebInfo.GetEnb().NodeConfigs[j]
-					if e.compareConfigIDs(u, *v) {
-						e.logger.Debugf("#E2nodeConfigUpdateNotificationHandler.Handle - item at position [%d] should be updated", i)
-						nodebInfo.GetEnb().NodeConfigs[i] = &u
-						break
-					}
-				}
-			}
+		result = append(result, &additionList[i])
+	}
 
+	setNodeConfigs(nodebInfo, append(result, nodeConfigs...))
+	return outcomes
+}
+
+// handleUpdateConfig rejects the update of a component which is not configured
+func (e *E2nodeConfigUpdateNotificationHandler) handleUpdateConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) []models.E2nodeComponentConfigOutcome {
+	nodeConfigs := getNodeConfigs(nodebInfo)
+	updateList := e2nodeConfig.ExtractConfigUpdateList()
+	outcomes := make([]models.E2nodeComponentConfigOutcome, len(updateList))
+	for i := range updateList {
+		outcomes[i].Config = updateList[i]
+		j := e.findConfig(nodeConfigs, updateList[i])
+		if j < 0 {
+			e.logger.Warnf("#E2nodeConfigUpdateNotificationHandler.handleUpdateConfig - RAN name: %s - %s component to update is unknown", nodebInfo.RanName, updateList[i].E2NodeComponentInterfaceType)
+			outcomes[i].FailureCause = newE2nodeComponentUnknownCause()
+			continue
 		}
+		e.logger.Debugf("#E2nodeConfigUpdateNotificationHandler.handleUpdateConfig - item at position [%d] should be updated", j)
+		nodeConfigs[j] = &updateList[i]
 	}
+	return outcomes
 }
 
-func (e *E2nodeConfigUpdateNotificationHandler) handleDeleteConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) {
+// handleDeleteConfig rejects the removal of a component which is not configured
+func (e *E2nodeConfigUpdateNotificationHandler) handleDeleteConfig(e2nodeConfig *models.E2nodeConfigurationUpdateMessage, nodebInfo *entities.NodebInfo) []models.E2nodeComponentConfigOutcome {
 	deleteList := e2nodeConfig.ExtractConfigDeletionList()
-
-	for _, u := range deleteList {
-		if nodebInfo.GetNodeType() == entities.Node_ENB {
-			for i, v := range nodebInfo.GetEnb().NodeConfigs {
-				if e.compareConfigIDs(u, *v) {
-					nodebInfo.GetEnb().NodeConfigs = removeIndex(nodebInfo.GetEnb().GetNodeConfigs(), i)
-					break
-				}
-			}
+	outcomes := make([]models.E2nodeComponentConfigOutcome, len(deleteList))
+	for i, u := range deleteList {
+		outcomes[i].Config = u
+		nodeConfigs := getNodeConfigs(nodebInfo)
+		j := e.findConfig(nodeConfigs, u)
+		if j < 0 {
+			e.logger.Warnf("#E2nodeConfigUpdateNotificationHandler.handleDeleteConfig - RAN name: %s - %s component to remove is unknown", nodebInfo.RanName, u.E2NodeComponentInterfaceType)
+			outcomes[i].FailureCause = newE2nodeComponentUnknownCause()
+			continue
 		}
+		setNodeConfigs(nodebInfo, removeIndex(nodeConfigs, j))
+	}
+	return outcomes
+}
 
-		if nodebInfo.GetNodeType() == entities.Node_GNB {
-			for i, v := range nodebInfo.GetGnb().NodeConfigs {
-				if e.compareConfigIDs(u, *v) {
-					nodebInfo.GetGnb().NodeConfigs = removeIndex(nodebInfo.GetGnb().GetNodeConfigs(), i)
-					break
-				}
+func newE2nodeComponentUnknownCause() *models.Cause {
+	return &models.Cause{E2Node: &models.CauseE2Node{E2nodeComponentUnknown: &struct{}{}}}
+}
+
+func failureCauseTags(outcomeLists ...[]models.E2nodeComponentConfigOutcome) []string {
+	var tags []string
+	for _, outcomes := range outcomeLists {
+		for _, outcome := range outcomes {
+			if outcome.FailureCause != nil {
+				tags = append(tags, outcome.FailureCause.ValueName())
 			}
 		}
 	}
+	return tags
 }
 
 func removeIndex(s []*entities.E2NodeComponentConfig, index int) []*entities.E2NodeComponentConfig {
@@ -209,16 +272,40 @@ func (e *E2nodeConfigUpdateNotificationHandler) parseE2NodeConfigurationUpdate(p
 	return &e2nodeConfig, nil
 }
 
-func (e *E2nodeConfigUpdateNotificationHandler) handleSuccessfulResponse(e2NodeConfigUpdate *models.E2nodeConfigurationUpdateMessage, request *models.NotificationRequest, nodebInfo *entities.NodebInfo) error {
-	e2nodeConfigUpdateResp := models.NewE2nodeConfigurationUpdateSuccessResponseMessage(e2NodeConfigUpdate)
+func (e *E2nodeConfigUpdateNotificationHandler) handleSuccessfulResponse(e2nodeConfigUpdateResp *models.E2nodeConfigurationUpdateAcknowledgeE2APPDU, failureCauseTags []string, request *models.NotificationRequest, nodebInfo *entities.NodebInfo) error {
 	payLoad, err := xml.Marshal(e2nodeConfigUpdateResp)
 	if err != nil {
 		e.logger.Errorf("#E2nodeConfigUpdateNotificationHandler.sendUpdateAck - Error marshalling RIC_SERVICE_UPDATE_ACK. Payload: %s", payLoad)
 	}
 
-	payLoad = utils.ReplaceEmptyTagsWithSelfClosing(payLoad, toReplaceTags)
+	payLoad = utils.ReplaceEmptyTagsWithSelfClosing(payLoad, append(toReplaceTags, failureCauseTags...))
 	e.logger.Infof("#E2nodeConfigUpdateNotificationHandler.sendUpdateAck - Sending RIC_E2nodeConfigUpdate_ACK to RAN name: %s with payload %s", nodebInfo.RanName, payLoad)
 	msg := models.NewRmrMessage(rmrCgo.RIC_E2NODE_CONFIG_UPDATE_ACK, nodebInfo.RanName, payLoad, request.TransactionId, request.GetMsgSrc())
 	err = e.rmrSender.Send(msg)
 	return err
 }
+
+// rejectUpdate answers with a RIC_E2NODE_CONFIG_UPDATE_FAILURE, the E2 node may retry after the configured time to wait
+func (e *E2nodeConfigUpdateNotificationHandler) rejectUpdate(request *models.NotificationRequest, transactionId string, cause models.Cause) {
+	updateFailure := models.NewE2nodeConfigurationUpdateFailureMessage(transactionId, cause, models.TimeToWait(e.config.E2NodeConfigUpdate.TimeToWaitSec))
+	err := e.sendUpdateFailure(updateFailure, cause, request)
+	if err != nil {
+		e.logger.Errorf("#E2nodeConfigUpdateNotificationHandler.rejectUpdate - RAN name: %s - failed to send RIC_E2NODE_CONFIG_UPDATE_FAILURE message to RMR: %s", request.RanName, err)
+	}
+	e.ranProcedureTracker.Fail(request.RanName, models.E2NodeConfigUpdateProcedure)
+}
+
+func (e *E2nodeConfigUpdateNotificationHandler) sendUpdateFailure(updateFailure models.E2nodeConfigurationUpdateFailureE2APPDU, cause models.Cause, request *models.NotificationRequest) error {
+	payLoad, err := xml.Marshal(updateFailure)
+	if err != nil {
+		e.logger.Errorf("#E2nodeConfigUpdateNotificationHandler.sendUpdateFailure - RAN name: %s - Error marshalling RIC_E2NODE_CONFIG_UPDATE_FAILURE. Payload: %s", request.RanName, payLoad)
+		return err
+	}
+
+	failureTags := []string{"reject", "ignore", "v60s", "v20s", "v10s", "v5s", "v2s", "v1s", cause.ValueName()}
+	payLoad = utils.ReplaceEmptyTagsWithSelfClosing(payLoad, failureTags)
+
+	e.logger.Infof("#E2nodeConfigUpdateNotificationHandler.sendUpdateFailure - Sending RIC_E2NODE_CONFIG_UPDATE_FAILURE to RAN name: %s with payload %s", request.RanName, payLoad)
+	msg := models.NewRmrMessage(rmrCgo.RIC_E2NODE_CONFIG_UPDATE_FAILURE, request.RanName, payLoad, request.TransactionId, request.GetMsgSrc())
+	return e.rmrSender.Send(msg)
+}
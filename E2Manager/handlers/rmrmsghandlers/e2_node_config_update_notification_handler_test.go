@@ -19,6 +19,7 @@
 package rmrmsghandlers
 
 import (
+	"bytes"
 	"e2mgr/configuration"
 	"e2mgr/mocks"
 	"e2mgr/models"
@@ -26,8 +27,10 @@ import (
 	"e2mgr/services"
 	"e2mgr/tests"
 	"e2mgr/utils"
+	"errors"
 	"testing"
 
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +50,7 @@ func initE2nodeConfigMocks(t *testing.T) (*E2nodeConfigUpdateNotificationHandler
 		RnibWriter: configuration.RnibWriterConfig{
 			StateChangeMessageChannel: StateChangeMessageChannel,
 		},
+		E2NodeConfigUpdate: configuration.E2NodeConfigUpdateConfig{TimeToWaitSec: 10},
 		GlobalRicId: struct {
 			RicId string
 			Mcc   string
@@ -57,7 +61,7 @@ func initE2nodeConfigMocks(t *testing.T) (*E2nodeConfigUpdateNotificationHandler
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrSender := tests.InitRmrSender(rmrMessengerMock, logger)
-	handler := NewE2nodeConfigUpdateNotificationHandler(logger, rnibDataService, rmrSender)
+	handler := NewE2nodeConfigUpdateNotificationHandler(logger, config, rnibDataService, rmrSender, initRanProcedureTracker(logger, config))
 	return handler, readerMock, writerMock, rmrMessengerMock
 }
 
@@ -67,7 +71,7 @@ func TestE2nodeConfigUpdatetNotificationHandler(t *testing.T) {
 	var nodebInfo = &entities.NodebInfo{
 		RanName:                      gnbNodebRanName,
 		AssociatedE2TInstanceAddress: e2tInstanceFullAddress,
-		ConnectionStatus:             entities.ConnectionStatus_DISCONNECTED,
+		ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
 		NodeType:                     entities.Node_GNB,
 		Configuration: &entities.NodebInfo_Gnb{
 			Gnb: &entities.Gnb{},
@@ -100,7 +104,7 @@ func TestHandleAddAndUpdateConfig(t *testing.T) {
 	var nodebInfo = &entities.NodebInfo{
 		RanName:                      gnbNodebRanName,
 		AssociatedE2TInstanceAddress: e2tInstanceFullAddress,
-		ConnectionStatus:             entities.ConnectionStatus_DISCONNECTED,
+		ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
 		NodeType:                     entities.Node_GNB,
 		Configuration: &entities.NodebInfo_Gnb{
 			Gnb: &entities.Gnb{},
@@ -133,7 +137,7 @@ func TestHandleAddandDeleteConfig(t *testing.T) {
 	var nodebInfo = &entities.NodebInfo{
 		RanName:                      gnbNodebRanName,
 		AssociatedE2TInstanceAddress: e2tInstanceFullAddress,
-		ConnectionStatus:             entities.ConnectionStatus_DISCONNECTED,
+		ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
 		NodeType:                     entities.Node_GNB,
 		Configuration: &entities.NodebInfo_Gnb{
 			Gnb: &entities.Gnb{},
@@ -155,3 +159,121 @@ func TestHandleAddandDeleteConfig(t *testing.T) {
 	writerMock.AssertExpectations(t)
 	readerMock.AssertExpectations(t)
 }
+
+func TestE2nodeConfigUpdateWrongStateSendsFailure(t *testing.T) {
+	e2NodeConfigUpdateXml := utils.ReadXmlFile(t, E2nodeConfigUpdateOnlyAdditionXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock := initE2nodeConfigMocks(t)
+	var nodebInfo = &entities.NodebInfo{
+		RanName:                      gnbNodebRanName,
+		AssociatedE2TInstanceAddress: e2tInstanceFullAddress,
+		ConnectionStatus:             entities.ConnectionStatus_UNDER_RESET,
+		NodeType:                     entities.Node_GNB,
+		Configuration: &entities.NodebInfo_Gnb{
+			Gnb: &entities.Gnb{},
+		},
+	}
+	readerMock.On("GetNodeb", gnbNodebRanName).Return(nodebInfo, nil)
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isE2nodeConfigUpdateFailureMbuf("message-not-compatible-with-receiver-state")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: append([]byte(""), e2NodeConfigUpdateXml...)}
+
+	handler.Handle(notificationRequest)
+
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoAndPublish", mock.Anything)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(gnbNodebRanName, models.E2NodeConfigUpdateProcedure).State)
+}
+
+func TestE2nodeConfigUpdateUnknownRanSendsFailure(t *testing.T) {
+	e2NodeConfigUpdateXml := utils.ReadXmlFile(t, E2nodeConfigUpdateOnlyAdditionXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock := initE2nodeConfigMocks(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", gnbNodebRanName).Return(nodebInfo, common.NewResourceNotFoundError("#test - not found"))
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isE2nodeConfigUpdateFailureMbuf("message-not-compatible-with-receiver-state")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: append([]byte(""), e2NodeConfigUpdateXml...)}
+
+	handler.Handle(notificationRequest)
+
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoAndPublish", mock.Anything)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(gnbNodebRanName, models.E2NodeConfigUpdateProcedure).State)
+}
+
+func TestE2nodeConfigUpdateRnibWriteFailureSendsFailure(t *testing.T) {
+	e2NodeConfigUpdateXml := utils.ReadXmlFile(t, E2nodeConfigUpdateOnlyAdditionXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock := initE2nodeConfigMocks(t)
+	var nodebInfo = &entities.NodebInfo{
+		RanName:                      gnbNodebRanName,
+		AssociatedE2TInstanceAddress: e2tInstanceFullAddress,
+		ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
+		NodeType:                     entities.Node_GNB,
+		Configuration: &entities.NodebInfo_Gnb{
+			Gnb: &entities.Gnb{},
+		},
+	}
+	readerMock.On("GetNodeb", gnbNodebRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(common.NewInternalError(errors.New("#test - internal error")))
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isE2nodeConfigUpdateFailureMbuf("unspecified")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: append([]byte(""), e2NodeConfigUpdateXml...)}
+
+	handler.Handle(notificationRequest)
+
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(gnbNodebRanName, models.E2NodeConfigUpdateProcedure).State)
+}
+
+func TestE2nodeConfigUpdateRejectsDuplicateAndUnknownComponents(t *testing.T) {
+	e2NodeConfigUpdateXml := utils.ReadXmlFile(t, E2nodeConfigUpdateOnlyAdditionAndUpdateXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock := initE2nodeConfigMocks(t)
+	storedNgConfig := &entities.E2NodeComponentConfig{
+		E2NodeComponentInterfaceType: entities.E2NodeComponentInterfaceType_ng,
+		E2NodeComponentID: &entities.E2NodeComponentConfig_E2NodeComponentInterfaceTypeNG{
+			E2NodeComponentInterfaceTypeNG: &entities.E2NodeComponentInterfaceNG{AmfName: "nginterf"},
+		},
+	}
+	var nodebInfo = &entities.NodebInfo{
+		RanName:                      gnbNodebRanName,
+		AssociatedE2TInstanceAddress: e2tInstanceFullAddress,
+		ConnectionStatus:             entities.ConnectionStatus_CONNECTED,
+		NodeType:                     entities.Node_GNB,
+		Configuration: &entities.NodebInfo_Gnb{
+			Gnb: &entities.Gnb{NodeConfigs: []*entities.E2NodeComponentConfig{storedNgConfig}},
+		},
+	}
+	readerMock.On("GetNodeb", gnbNodebRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	var ackPayload []byte
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(func(mbuf *rmrCgo.MBuf) bool {
+		ackPayload = *mbuf.Payload
+		return mbuf.MType == rmrCgo.RIC_E2NODE_CONFIG_UPDATE_ACK
+	}), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: append([]byte(""), e2NodeConfigUpdateXml...)}
+
+	handler.Handle(notificationRequest)
+
+	// the NG addition duplicates the stored component, the E1, F1 and W1 updates refer to unknown components
+	assert.Equal(t, 5, len(nodebInfo.GetGnb().NodeConfigs))
+	assert.Equal(t, "72 65 71 70 61 72 73", string(nodebInfo.GetGnb().NodeConfigs[4].E2NodeComponentRequestPart))
+	assert.Equal(t, 1, bytes.Count(ackPayload, []byte("<semantic-error/>")))
+	assert.Equal(t, 3, bytes.Count(ackPayload, []byte("<e2node-component-unknown/>")))
+	assert.Equal(t, 4, bytes.Count(ackPayload, []byte("<failure/>")))
+	assert.Equal(t, models.RanProcedureCompleted, handler.ranProcedureTracker.GetProcedure(gnbNodebRanName, models.E2NodeConfigUpdateProcedure).State)
+	writerMock.AssertExpectations(t)
+}
+
+func TestE2nodeConfigUpdateParseFailureSendsErrorIndication(t *testing.T) {
+	handler, readerMock, _, rmrMessengerMock := initE2nodeConfigMocks(t)
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isErrorIndicationMbuf("transfer-syntax-error")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: []byte("abc")}
+
+	handler.Handle(notificationRequest)
+
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+}
+
+func isE2nodeConfigUpdateFailureMbuf(cause string) func(*rmrCgo.MBuf) bool {
+	return func(mbuf *rmrCgo.MBuf) bool {
+		return mbuf.MType == rmrCgo.RIC_E2NODE_CONFIG_UPDATE_FAILURE && bytes.Contains(*mbuf.Payload, []byte("<"+cause+"/>")) &&
+			bytes.Contains(*mbuf.Payload, []byte("<TimeToWait><v10s/></TimeToWait>")) && bytes.Contains(*mbuf.Payload, []byte("<TransactionID>1234</TransactionID>"))
+	}
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrmsghandlers
+
+import (
+	"bytes"
+	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/models"
+	"e2mgr/utils"
+	"encoding/xml"
+)
+
+const E2ResetResponseLogInfoElapsedTime = "#E2ResetResponseNotificationHandler.Handle - Summary: elapsed time for receiving and handling reset response message from E2 terminator: %f ms"
+
+type E2ResetResponseNotificationHandler struct {
+	logger                    *logger.Logger
+	e2ResetTransactionManager managers.IE2ResetTransactionManager
+}
+
+func NewE2ResetResponseNotificationHandler(logger *logger.Logger, e2ResetTransactionManager managers.IE2ResetTransactionManager) *E2ResetResponseNotificationHandler {
+	return &E2ResetResponseNotificationHandler{
+		logger:                    logger,
+		e2ResetTransactionManager: e2ResetTransactionManager,
+	}
+}
+
+func (h *E2ResetResponseNotificationHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
+	log.Infof("#E2ResetResponseNotificationHandler.Handle - RAN name: %s - received RIC_E2_RESET_RESP. Payload: %s", request.RanName, request.Payload)
+
+	resetResponse, err := h.parseE2ResetResponse(request.Payload)
+	if err != nil {
+		log.Errorf("#E2ResetResponseNotificationHandler.Handle - RAN name: %s - failed to parse RIC_E2_RESET_RESP. Error: %s", request.RanName, err)
+		log.Infof(E2ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+		return
+	}
+
+	transactionId := resetResponse.GetTransactionId()
+	if !h.e2ResetTransactionManager.Complete(request.RanName, transactionId) {
+		log.Warnf("#E2ResetResponseNotificationHandler.Handle - RAN name: %s - RIC_E2_RESET_RESP with transaction id %s does not match an outstanding E2 Reset, ignoring", request.RanName, transactionId)
+	}
+
+	log.Infof(E2ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+}
+
+func (h *E2ResetResponseNotificationHandler) parseE2ResetResponse(payload []byte) (*models.E2ResetResponseMessage, error) {
+	resetResponse := &models.E2ResetResponseMessage{}
+
+	pipInd := bytes.IndexByte(payload, '|')
+	err := xml.Unmarshal(utils.NormalizeXml(payload[pipInd+1:]), &resetResponse.E2ApPdu)
+	if err != nil {
+		return nil, err
+	}
+
+	return resetResponse, nil
+}
@@ -0,0 +1,92 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrmsghandlers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/managers"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"e2mgr/tests"
+	"e2mgr/utils"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	E2ResetResponseXmlPath = "../../tests/resources/reset/reset-response.xml"
+)
+
+func initE2ResetResponseMocks(t *testing.T) (*E2ResetResponseNotificationHandler, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *managers.E2ResetTransactionManager) {
+	logger := tests.InitLog(t)
+	config := &configuration.Configuration{
+		RnibRetryIntervalMs:       10,
+		MaxRnibConnectionAttempts: 3,
+		E2Reset:                   configuration.E2ResetConfig{ResponseTimeoutSec: 10},
+		RnibWriter: configuration.RnibWriterConfig{
+			StateChangeMessageChannel: StateChangeMessageChannel,
+		}}
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
+	ranListManager := managers.NewRanListManager(logger, rnibDataService)
+	ranAlarmService := &mocks.RanAlarmServiceMock{}
+	ranAlarmService.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
+	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(logger, config, rnibDataService, ranConnectStatusChangeManager, initRanProcedureTracker(logger, config))
+	handler := NewE2ResetResponseNotificationHandler(logger, e2ResetTransactionManager)
+	return handler, readerMock, writerMock, e2ResetTransactionManager
+}
+
+func TestE2ResetResponseNotificationHandlerSuccess(t *testing.T) {
+	e2ResetResponseXml := utils.ReadXmlFile(t, E2ResetResponseXmlPath)
+	handler, readerMock, writerMock, e2ResetTransactionManager := initE2ResetResponseMocks(t)
+
+	nodebInfo := &entities.NodebInfo{RanName: gnbNodebRanName, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	readerMock.On("GetNodeb", gnbNodebRanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, gnbNodebRanName+"_"+managers.CONNECTED_RAW_EVENT).Return(nil)
+
+	// The RAN responds with TransactionID 1, so the first transaction id is consumed beforehand
+	_, _ = e2ResetTransactionManager.Start(gnbNodebRanName)
+	e2ResetTransactionManager.Abort(gnbNodebRanName)
+	transaction, err := e2ResetTransactionManager.Start(gnbNodebRanName)
+	assert.Nil(t, err)
+
+	payload := append([]byte(e2SetupMsgPrefix), e2ResetResponseXml...)
+	handler.Handle(&models.NotificationRequest{RanName: gnbNodebRanName, Payload: payload, StartTime: time.Now()})
+
+	assert.True(t, transaction.Wait())
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodebInfo.ConnectionStatus)
+	writerMock.AssertExpectations(t)
+}
+
+func TestE2ResetResponseNotificationHandlerNoOutstandingTransaction(t *testing.T) {
+	e2ResetResponseXml := utils.ReadXmlFile(t, E2ResetResponseXmlPath)
+	handler, readerMock, writerMock, _ := initE2ResetResponseMocks(t)
+
+	handler.Handle(&models.NotificationRequest{RanName: gnbNodebRanName, Payload: e2ResetResponseXml, StartTime: time.Now()})
+
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, mock.Anything)
+}
@@ -23,10 +23,12 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
+	"e2mgr/metrics"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
 	"e2mgr/services"
@@ -48,20 +50,8 @@ const cleanUpDurationNanoSec uint64 = 10000000000 // cleanUpDuration = 10sec (va
 var (
 	emptyTagsToReplaceToSelfClosingTags = []string{"reject", "ignore", "transport-resource-unavailable", "om-intervention", "request-id-unknown",
 		"unspecified", "message-not-compatible-with-receiver-state", "control-processing-overload",
+		"semantic-error", "function-not-required", "ric-resource-limit", "abstract-syntax-error-falsely-constructed-message",
 		"v60s", "v20s", "v10s", "v5s", "v2s", "v1s", "ng", "xn", "e1", "f1", "w1", "s1", "x2", "success", "failure"}
-	gnbTypesMap = map[string]entities.GnbType{
-		"gnb":    entities.GnbType_GNB,
-		"en_gnb": entities.GnbType_EN_GNB,
-	}
-	enbTypesMap = map[string]entities.EnbType{
-		"enB_macro":         entities.EnbType_MACRO_ENB,
-		"enB_home":          entities.EnbType_HOME_ENB,
-		"enB_shortmacro":    entities.EnbType_SHORT_MACRO_ENB,
-		"enB_longmacro":     entities.EnbType_LONG_MACRO_ENB,
-		"ng_enB_macro":      entities.EnbType_MACRO_NG_ENB,
-		"ng_enB_shortmacro": entities.EnbType_SHORT_MACRO_NG_ENB,
-		"ng_enB_longmacro":  entities.EnbType_LONG_MACRO_NG_ENB,
-	}
 )
 
 type E2SetupRequestNotificationHandler struct {
@@ -73,9 +63,20 @@ type E2SetupRequestNotificationHandler struct {
 	e2tAssociationManager         *managers.E2TAssociationManager
 	ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager
 	ranListManager                managers.RanListManager
+	admissionPolicy               managers.IE2SetupAdmissionPolicy
+	ranProcedureTracker           managers.IRanProcedureTracker
+	ranFunctionValidator          managers.IRanFunctionValidator
+}
+
+// e2SetupContent holds the RAN functions and component configurations accepted out of an E2 Setup Request,
+// a nil list means the request did not carry the matching IE
+type e2SetupContent struct {
+	ranFunctions []*entities.RanFunction
+	nodeConfigs  []*entities.E2NodeComponentConfig
+	rejections   *models.E2SetupRejections
 }
 
-func NewE2SetupRequestNotificationHandler(logger *logger.Logger, config *configuration.Configuration, e2tInstancesManager managers.IE2TInstancesManager, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, e2tAssociationManager *managers.E2TAssociationManager, ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, ranListManager managers.RanListManager) *E2SetupRequestNotificationHandler {
+func NewE2SetupRequestNotificationHandler(logger *logger.Logger, config *configuration.Configuration, e2tInstancesManager managers.IE2TInstancesManager, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, e2tAssociationManager *managers.E2TAssociationManager, ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, ranListManager managers.RanListManager, admissionPolicy managers.IE2SetupAdmissionPolicy, ranProcedureTracker managers.IRanProcedureTracker, ranFunctionValidator managers.IRanFunctionValidator) *E2SetupRequestNotificationHandler {
 	return &E2SetupRequestNotificationHandler{
 		logger:                        logger,
 		config:                        config,
@@ -85,43 +86,65 @@ func NewE2SetupRequestNotificationHandler(logger *logger.Logger, config *configu
 		e2tAssociationManager:         e2tAssociationManager,
 		ranConnectStatusChangeManager: ranConnectStatusChangeManager,
 		ranListManager:                ranListManager,
+		admissionPolicy:               admissionPolicy,
+		ranProcedureTracker:           ranProcedureTracker,
+		ranFunctionValidator:          ranFunctionValidator,
 	}
 }
 
 func (h *E2SetupRequestNotificationHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	ranName := request.RanName
-	models.UpdateProcedureType(ranName, models.E2SetupProcedureNotInitiated)
-	h.logger.Infof("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - received E2_SETUP_REQUEST. Payload: %x", ranName, request.Payload)
+	log.Infof("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - received E2_SETUP_REQUEST. Payload: %x", ranName, request.Payload)
 
 	generalConfiguration, err := h.rNibDataService.GetGeneralConfiguration()
 
 	if err != nil {
-		h.logger.Errorf("#E2SetupRequestNotificationHandler.Handle - Failed retrieving e2m general configuration - quitting e2 setup flow. error: %s", err)
+		log.Errorf("#E2SetupRequestNotificationHandler.Handle - Failed retrieving e2m general configuration - quitting e2 setup flow. error: %s", err)
 		return
 	}
 
 	setupRequest, e2tIpAddress, err := h.parseSetupRequest(request.Payload)
 	if err != nil {
-		h.logger.Errorf(err.Error())
+		log.Errorf(err.Error())
 		return
 	}
 
-	h.logger.Infof("#E2SetupRequestNotificationHandler.Handle - E2T Address: %s - handling E2_SETUP_REQUEST", e2tIpAddress)
-	h.logger.Debugf("#E2SetupRequestNotificationHandler.Handle - E2_SETUP_REQUEST has been parsed successfully %+v", setupRequest)
+	log.Infof("#E2SetupRequestNotificationHandler.Handle - E2T Address: %s - handling E2_SETUP_REQUEST", e2tIpAddress)
+	log.Debugf("#E2SetupRequestNotificationHandler.Handle - E2_SETUP_REQUEST has been parsed successfully %+v", setupRequest)
+	h.ranProcedureTracker.Start(ranName, models.E2SetupProcedure, setupRequest.GetTransactionId())
 
-	h.logger.Infof("#E2SetupRequestNotificationHandler.Handle - got general configuration from rnib - enableRic: %t", generalConfiguration.EnableRic)
+	log.Infof("#E2SetupRequestNotificationHandler.Handle - got general configuration from rnib - enableRic: %t", generalConfiguration.EnableRic)
 
 	if !generalConfiguration.EnableRic {
 		cause := models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}}
 		h.handleUnsuccessfulResponse(ranName, request, cause, setupRequest)
-		models.UpdateProcedureType(ranName, models.E2SetupProcedureFailure)
+		h.ranProcedureTracker.Fail(ranName, models.E2SetupProcedure)
 		return
 	}
 
-	_, err = h.e2tInstancesManager.GetE2TInstance(e2tIpAddress)
+	e2tInstance, err := h.e2tInstancesManager.GetE2TInstance(e2tIpAddress)
 
 	if err != nil {
-		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Failed retrieving E2TInstance. error: %s", err)
+		log.Errorf("#E2TermInitNotificationHandler.Handle - Failed retrieving E2TInstance. error: %s", err)
+		return
+	}
+
+	candidate := managers.NewE2SetupAdmissionCandidate(ranName, setupRequest, e2tInstance)
+
+	if rejection := h.admissionPolicy.Admit(candidate); rejection != nil {
+		log.Warnf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - E2 setup is not admitted: %s", ranName, rejection.Reason)
+		h.handleUnsuccessfulResponse(ranName, request, rejection.Cause, setupRequest)
+		h.ranProcedureTracker.Fail(ranName, models.E2SetupProcedure)
+		return
+	}
+
+	content := h.validateSetupContent(ranName, setupRequest)
+
+	if content.nodeConfigs != nil && len(content.nodeConfigs) == 0 && len(content.rejections.ComponentConfigs) != 0 {
+		log.Warnf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - all E2 node component configurations are rejected", ranName)
+		h.handleUnsuccessfulResponse(ranName, request, content.rejections.ComponentConfigs[0], setupRequest)
+		h.ranProcedureTracker.Fail(ranName, models.E2SetupProcedure)
 		return
 	}
 
@@ -132,36 +155,30 @@ func (h *E2SetupRequestNotificationHandler) Handle(request *models.NotificationR
 	if err != nil {
 
 		if _, ok := err.(*common.ResourceNotFoundError); !ok {
-			h.logger.Errorf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - failed to retrieve nodebInfo entity. Error: %s", ranName, err)
+			log.Errorf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - failed to retrieve nodebInfo entity. Error: %s", ranName, err)
 			return
 		}
 
-		if nodebInfo, err = h.handleNewRan(ranName, e2tIpAddress, setupRequest); err != nil {
-			if _, ok := err.(*e2managererrors.UnknownSetupRequestRanNameError); ok {
-				cause := models.Cause{RicRequest: &models.CauseRic{RequestIdUnknown: &struct{}{}}}
-				h.handleUnsuccessfulResponse(ranName, request, cause, setupRequest)
-				models.UpdateProcedureType(ranName, models.E2SetupProcedureFailure)
-			}
+		if nodebInfo, err = h.handleNewRan(ranName, e2tIpAddress, setupRequest, content); err != nil {
 			return
 		}
 
 	} else {
 
-		functionsModified, err = h.handleExistingRan(ranName, nodebInfo, setupRequest)
+		functionsModified, err = h.handleExistingRan(ranName, nodebInfo, content)
 
 		if err != nil {
 			h.fillCauseAndSendUnsuccessfulResponse(nodebInfo, request, setupRequest)
-			models.UpdateProcedureType(ranName, models.E2SetupProcedureFailure)
+			h.ranProcedureTracker.Fail(ranName, models.E2SetupProcedure)
 			return
 		}
 	}
-	models.UpdateProcedureType(ranName, models.E2SetupProcedureOngoing)
 
-	ranStatusChangePublished, err := h.e2tAssociationManager.AssociateRan(e2tIpAddress, nodebInfo)
+	ranStatusChangePublished, err := h.e2tAssociationManager.AssociateRan(logger.ContextWithRequestId(context.Background(), request.RequestId), e2tIpAddress, nodebInfo)
 
 	if err != nil {
 
-		h.logger.Errorf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - failed to associate E2T to nodeB entity. Error: %s", ranName, err)
+		log.Errorf("#E2SetupRequestNotificationHandler.Handle - RAN name: %s - failed to associate E2T to nodeB entity. Error: %s", ranName, err)
 		if _, ok := err.(*e2managererrors.RoutingManagerError); ok {
 
 			if err = h.handleUpdateAndPublishNodebInfo(functionsModified, ranStatusChangePublished, nodebInfo); err != nil {
@@ -170,7 +187,7 @@ func (h *E2SetupRequestNotificationHandler) Handle(request *models.NotificationR
 
 			cause := models.Cause{Transport: &models.CauseTransport{TransportResourceUnavailable: &struct{}{}}}
 			h.handleUnsuccessfulResponse(nodebInfo.RanName, request, cause, setupRequest)
-			models.UpdateProcedureType(ranName, models.E2SetupProcedureFailure)
+			h.ranProcedureTracker.Fail(ranName, models.E2SetupProcedure)
 		}
 		return
 	}
@@ -179,9 +196,9 @@ func (h *E2SetupRequestNotificationHandler) Handle(request *models.NotificationR
 		return
 	}
 
-	h.handleSuccessfulResponse(ranName, request, setupRequest)
-	models.UpdateProcedureType(ranName, models.E2SetupProcedureCompleted)
-	h.logger.Debugf("#E2SetupRequestNotificationHandler.Handle - updating the enum value to e2setup request completed")
+	h.ranListManager.UpdateNbIdentityRanFunctions(ranName, nodebInfo.GetGnb().GetRanFunctions())
+	h.handleSuccessfulResponse(ranName, request, setupRequest, content.rejections)
+	h.ranProcedureTracker.Complete(ranName, models.E2SetupProcedure)
 }
 
 func (h *E2SetupRequestNotificationHandler) handleUpdateAndPublishNodebInfo(functionsModified bool, ranStatusChangePublished bool, nodebInfo *entities.NodebInfo) error {
@@ -202,9 +219,107 @@ func (h *E2SetupRequestNotificationHandler) handleUpdateAndPublishNodebInfo(func
 
 }
 
-func (h *E2SetupRequestNotificationHandler) handleNewRan(ranName string, e2tIpAddress string, setupRequest *models.E2SetupRequestMessage) (*entities.NodebInfo, error) {
+// validateSetupContent checks every offered RAN function and component configuration, only the accepted ones are stored
+func (h *E2SetupRequestNotificationHandler) validateSetupContent(ranName string, setupRequest *models.E2SetupRequestMessage) *e2SetupContent {
+	content := &e2SetupContent{rejections: &models.E2SetupRejections{ComponentConfigs: map[int]models.Cause{}}}
+
+	if ranFunctions := setupRequest.ExtractRanFunctionsList(); ranFunctions != nil {
+		content.ranFunctions = h.validateRanFunctions(ranName, ranFunctions, content.rejections)
+	}
+
+	if nodeConfigs := setupRequest.ExtractE2NodeConfigList(); nodeConfigs != nil {
+		content.nodeConfigs = h.validateNodeConfigs(ranName, nodeConfigs, content.rejections)
+	}
+
+	return content
+}
+
+func (h *E2SetupRequestNotificationHandler) validateRanFunctions(ranName string, ranFunctions []*entities.RanFunction, rejections *models.E2SetupRejections) []*entities.RanFunction {
+	occurrences := make(map[uint32]int, len(ranFunctions))
+	for _, ranFunction := range ranFunctions {
+		occurrences[ranFunction.RanFunctionId]++
+	}
+
+	accepted := make([]*entities.RanFunction, 0, len(ranFunctions))
+	rejectedDuplicates := make(map[uint32]bool)
+
+	for _, ranFunction := range ranFunctions {
+		var rejection *managers.RanFunctionRejection
+
+		if occurrences[ranFunction.RanFunctionId] > 1 {
+			if rejectedDuplicates[ranFunction.RanFunctionId] {
+				continue
+			}
+			rejectedDuplicates[ranFunction.RanFunctionId] = true
+			rejection = h.ranFunctionValidator.RejectDuplicate(ranFunction.RanFunctionId)
+		} else {
+			rejection = h.ranFunctionValidator.Validate(ranFunction, nil)
+		}
+
+		if rejection != nil {
+			h.logger.Warnf("#E2SetupRequestNotificationHandler.validateRanFunctions - RAN name: %s - RAN function %d is rejected: %s", ranName, rejection.RanFunctionId, rejection.Reason)
+			rejections.RanFunctions = append(rejections.RanFunctions, models.RicServiceRejectedRANFunctionIDItem{RanFunctionID: rejection.RanFunctionId, Cause: rejection.Cause})
+			continue
+		}
+
+		accepted = append(accepted, ranFunction)
+	}
+
+	return accepted
+}
+
+// validateNodeConfigs rejects component configurations of an unsupported interface type, without a configuration or offered twice
+func (h *E2SetupRequestNotificationHandler) validateNodeConfigs(ranName string, nodeConfigs []*entities.E2NodeComponentConfig, rejections *models.E2SetupRejections) []*entities.E2NodeComponentConfig {
+	accepted := make([]*entities.E2NodeComponentConfig, 0, len(nodeConfigs))
+	componentIds := make(map[string]bool, len(nodeConfigs))
 
-	nodebInfo, err := h.buildNodebInfo(ranName, e2tIpAddress, setupRequest)
+	for i, nodeConfig := range nodeConfigs {
+		if nodeConfig == nil || len(strings.TrimSpace(nodeConfig.E2NodeComponentRequestPart)) == 0 {
+			h.logger.Warnf("#E2SetupRequestNotificationHandler.validateNodeConfigs - RAN name: %s - component configuration #%d is malformed", ranName, i)
+			rejections.ComponentConfigs[i] = models.Cause{Protocol: &models.CauseProtocol{AbstractSyntaxErrorFalselyConstructedMessage: &struct{}{}}}
+			continue
+		}
+
+		if componentId, ok := e2NodeComponentId(nodeConfig); ok {
+			if componentIds[componentId] {
+				h.logger.Warnf("#E2SetupRequestNotificationHandler.validateNodeConfigs - RAN name: %s - component %s is offered more than once", ranName, componentId)
+				rejections.ComponentConfigs[i] = models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}}
+				continue
+			}
+			componentIds[componentId] = true
+		}
+
+		accepted = append(accepted, nodeConfig)
+	}
+
+	return accepted
+}
+
+// e2NodeComponentId identifies a component by its interface type and id, Xn and X2 components are not compared
+func e2NodeComponentId(nodeConfig *entities.E2NodeComponentConfig) (string, bool) {
+	var id string
+
+	switch nodeConfig.E2NodeComponentInterfaceType {
+	case entities.E2NodeComponentInterfaceType_ng:
+		id = nodeConfig.GetE2NodeComponentInterfaceTypeNG().GetAmfName()
+	case entities.E2NodeComponentInterfaceType_e1:
+		id = fmt.Sprint(nodeConfig.GetE2NodeComponentInterfaceTypeE1().GetGNBCuCpId())
+	case entities.E2NodeComponentInterfaceType_f1:
+		id = fmt.Sprint(nodeConfig.GetE2NodeComponentInterfaceTypeF1().GetGNBDuId())
+	case entities.E2NodeComponentInterfaceType_w1:
+		id = fmt.Sprint(nodeConfig.GetE2NodeComponentInterfaceTypeW1().GetNgenbDuId())
+	case entities.E2NodeComponentInterfaceType_s1:
+		id = nodeConfig.GetE2NodeComponentInterfaceTypeS1().GetMmeName()
+	default:
+		return "", false
+	}
+
+	return nodeConfig.E2NodeComponentInterfaceType.String() + "/" + id, true
+}
+
+func (h *E2SetupRequestNotificationHandler) handleNewRan(ranName string, e2tIpAddress string, setupRequest *models.E2SetupRequestMessage, content *e2SetupContent) (*entities.NodebInfo, error) {
+
+	nodebInfo, err := h.buildNodebInfo(ranName, e2tIpAddress, setupRequest, content)
 	if err != nil {
 		h.logger.Errorf("#E2SetupRequestNotificationHandler.handleNewRan - RAN name: %s - failed building nodebInfo. Error: %s", ranName, err)
 		return nil, err
@@ -227,7 +342,7 @@ func (h *E2SetupRequestNotificationHandler) handleNewRan(ranName string, e2tIpAd
 	return nodebInfo, nil
 }
 
-func (h *E2SetupRequestNotificationHandler) handleExistingRan(ranName string, nodebInfo *entities.NodebInfo, setupRequest *models.E2SetupRequestMessage) (bool, error) {
+func (h *E2SetupRequestNotificationHandler) handleExistingRan(ranName string, nodebInfo *entities.NodebInfo, content *e2SetupContent) (bool, error) {
 	if nodebInfo.GetConnectionStatus() == entities.ConnectionStatus_DISCONNECTED {
 		delta_in_nano := uint64(time.Now().UnixNano()) - nodebInfo.StatusUpdateTimeStamp
 		//The duration from last Disconnection for which a new request is to be rejected (currently 10 sec)
@@ -243,7 +358,7 @@ func (h *E2SetupRequestNotificationHandler) handleExistingRan(ranName string, no
 
 	nodebInfo.SetupFromNetwork = true
 
-	e2NodeConfig := setupRequest.ExtractE2NodeConfigList()
+	e2NodeConfig := content.nodeConfigs
 	if e2NodeConfig == nil {
 		return false, errors.New("Empty E2nodeComponentConfigAddition-List")
 	}
@@ -262,7 +377,7 @@ func (h *E2SetupRequestNotificationHandler) handleExistingRan(ranName string, no
 	}
 	nodebInfo.GetGnb().NodeConfigs = e2NodeConfig
 
-	setupMessageRanFuncs := setupRequest.ExtractRanFunctionsList()
+	setupMessageRanFuncs := content.ranFunctions
 
 	if setupMessageRanFuncs == nil || (len(setupMessageRanFuncs) == 0 && len(nodebInfo.GetGnb().RanFunctions) == 0) {
 		return false, nil
@@ -273,7 +388,7 @@ func (h *E2SetupRequestNotificationHandler) handleExistingRan(ranName string, no
 }
 
 func (h *E2SetupRequestNotificationHandler) handleUnsuccessfulResponse(ranName string, req *models.NotificationRequest, cause models.Cause, setupRequest *models.E2SetupRequestMessage) {
-	failureResponse := models.NewE2SetupFailureResponseMessage(models.TimeToWaitEnum.V60s, cause, setupRequest)
+	failureResponse := models.NewE2SetupFailureResponseMessage(h.admissionPolicy.GetTimeToWait(), cause, setupRequest)
 	h.logger.Debugf("#E2SetupRequestNotificationHandler.handleUnsuccessfulResponse - E2_SETUP_RESPONSE has been built successfully %+v", failureResponse)
 
 	responsePayload, err := xml.Marshal(&failureResponse.E2APPDU)
@@ -287,18 +402,20 @@ func (h *E2SetupRequestNotificationHandler) handleUnsuccessfulResponse(ranName s
 	msg := models.NewRmrMessage(rmrCgo.RIC_E2_SETUP_FAILURE, ranName, responsePayload, req.TransactionId, req.GetMsgSrc())
 	h.logger.Infof("#E2SetupRequestNotificationHandler.handleUnsuccessfulResponse - RAN name: %s - RIC_E2_SETUP_RESP message has been built successfully. Message: %x", ranName, msg)
 	_ = h.rmrSender.WhSend(msg)
+	metrics.E2SetupOutcomes.WithLabelValues(metrics.E2SetupOutcomeFailure, cause.String()).Inc()
 
 }
 
-func (h *E2SetupRequestNotificationHandler) handleSuccessfulResponse(ranName string, req *models.NotificationRequest, setupRequest *models.E2SetupRequestMessage) {
+func (h *E2SetupRequestNotificationHandler) handleSuccessfulResponse(ranName string, req *models.NotificationRequest, setupRequest *models.E2SetupRequestMessage, rejections *models.E2SetupRejections) {
 
 	plmnId := buildPlmnId(h.config.GlobalRicId.Mcc, h.config.GlobalRicId.Mnc)
 
 	ricNearRtId, err := convertTo20BitString(h.config.GlobalRicId.RicId)
 	if err != nil {
+		h.logger.Errorf("#E2SetupRequestNotificationHandler.handleSuccessfulResponse - RAN name: %s - invalid globalRicId.ricId %s, RIC_E2_SETUP_RESP is not sent. Error: %s", ranName, h.config.GlobalRicId.RicId, err)
 		return
 	}
-	successResponse := models.NewE2SetupSuccessResponseMessage(plmnId, ricNearRtId, setupRequest)
+	successResponse := models.NewE2SetupResponseMessage(plmnId, ricNearRtId, setupRequest, rejections)
 	h.logger.Debugf("#E2SetupRequestNotificationHandler.handleSuccessfulResponse - E2_SETUP_RESPONSE has been built successfully %+v", successResponse)
 
 	responsePayload, err := xml.Marshal(&successResponse.E2APPDU)
@@ -313,6 +430,7 @@ func (h *E2SetupRequestNotificationHandler) handleSuccessfulResponse(ranName str
 	msg := models.NewRmrMessage(rmrCgo.RIC_E2_SETUP_RESP, ranName, responsePayload, req.TransactionId, req.GetMsgSrc())
 	h.logger.Infof("#E2SetupRequestNotificationHandler.handleSuccessfulResponse - RAN name: %s - RIC_E2_SETUP_RESP message has been built successfully. Message: %x", ranName, msg)
 	_ = h.rmrSender.Send(msg)
+	metrics.E2SetupOutcomes.WithLabelValues(metrics.E2SetupOutcomeSuccess, "").Inc()
 }
 
 func buildPlmnId(mmc string, mnc string) string {
@@ -363,19 +481,19 @@ func (h *E2SetupRequestNotificationHandler) parseSetupRequest(payload []byte) (*
 	return setupRequest, e2tIpAddress, nil
 }
 
-func (h *E2SetupRequestNotificationHandler) buildNodebInfo(ranName string, e2tAddress string, request *models.E2SetupRequestMessage) (*entities.NodebInfo, error) {
+func (h *E2SetupRequestNotificationHandler) buildNodebInfo(ranName string, e2tAddress string, request *models.E2SetupRequestMessage, content *e2SetupContent) (*entities.NodebInfo, error) {
 	nodebInfo := &entities.NodebInfo{
 		AssociatedE2TInstanceAddress: e2tAddress,
 		RanName:                      ranName,
 		GlobalNbId:                   h.buildGlobalNbId(request),
 		SetupFromNetwork:             true,
 	}
-	err := h.setNodeTypeAndConfiguration(nodebInfo)
+	err := h.setNodeTypeAndConfiguration(nodebInfo, request)
 	if err != nil {
 		return nil, err
 	}
 
-	e2NodeConfig := request.ExtractE2NodeConfigList()
+	e2NodeConfig := content.nodeConfigs
 	if e2NodeConfig == nil {
 		return nil, errors.New("Empty E2nodeComponentConfigAddition-List")
 	}
@@ -406,7 +524,7 @@ func (h *E2SetupRequestNotificationHandler) buildNodebInfo(ranName string, e2tAd
 		h.logger.Debugf("#E2SetupRequestNotificationHandler buildNodebInfo -duid %s", request.GetDuId())
 	}
 
-	ranFuncs := request.ExtractRanFunctionsList()
+	ranFuncs := content.ranFunctions
 
 	if ranFuncs != nil {
 		nodebInfo.GetGnb().RanFunctions = ranFuncs
@@ -433,23 +551,19 @@ func (h *E2SetupRequestNotificationHandler) setGnbNodeType(setupRequest *models.
 	return gnbNodetype
 }
 
-func (h *E2SetupRequestNotificationHandler) setNodeTypeAndConfiguration(nodebInfo *entities.NodebInfo) error {
-	for k, v := range gnbTypesMap {
-		if strings.HasPrefix(nodebInfo.RanName, k) {
-			nodebInfo.NodeType = entities.Node_GNB
-			nodebInfo.Configuration = &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{GnbType: v}}
-			return nil
-		}
-	}
-	for k, v := range enbTypesMap {
-		if strings.HasPrefix(nodebInfo.RanName, k) {
-			nodebInfo.NodeType = entities.Node_ENB
-			nodebInfo.Configuration = &entities.NodebInfo_Enb{Enb: &entities.Enb{EnbType: v}}
-			return nil
-		}
+func (h *E2SetupRequestNotificationHandler) setNodeTypeAndConfiguration(nodebInfo *entities.NodebInfo, setupRequest *models.E2SetupRequestMessage) error {
+	switch setupRequest.GetNodeType() {
+	case entities.Node_GNB:
+		nodebInfo.NodeType = entities.Node_GNB
+		nodebInfo.Configuration = &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{GnbType: setupRequest.GetGnbType()}}
+		return nil
+	case entities.Node_ENB:
+		nodebInfo.NodeType = entities.Node_ENB
+		nodebInfo.Configuration = &entities.NodebInfo_Enb{Enb: &entities.Enb{EnbType: setupRequest.GetEnbType()}}
+		return nil
 	}
 
-	return e2managererrors.NewUnknownSetupRequestRanNameError(nodebInfo.RanName)
+	return errors.New("unknown global E2 node ID type")
 }
 
 func (h *E2SetupRequestNotificationHandler) buildGlobalNbId(setupRequest *models.E2SetupRequestMessage) *entities.GlobalNbId {
@@ -467,10 +581,8 @@ func (h *E2SetupRequestNotificationHandler) buildNbIdentity(ranName string, setu
 }
 
 func (h *E2SetupRequestNotificationHandler) fillCauseAndSendUnsuccessfulResponse(nodebInfo *entities.NodebInfo, request *models.NotificationRequest, setupRequest *models.E2SetupRequestMessage) {
-	ranName := request.RanName
 	if nodebInfo.GetConnectionStatus() == entities.ConnectionStatus_DISCONNECTED {
 		cause := models.Cause{Misc: &models.CauseMisc{ControlProcessingOverload: &struct{}{}}}
 		h.handleUnsuccessfulResponse(nodebInfo.RanName, request, cause, setupRequest)
-		models.UpdateProcedureType(ranName, models.E2SetupProcedureFailure)
 	}
 }
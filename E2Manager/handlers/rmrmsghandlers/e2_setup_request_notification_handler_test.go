@@ -23,7 +23,9 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"e2mgr/clients"
 	"e2mgr/configuration"
+	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/mocks"
 	"e2mgr/models"
@@ -51,10 +53,14 @@ const (
 	EnGnbSetupRequestXmlPath                 = "../../tests/resources/setupRequest/setupRequest_en-gNB.xml"
 	NgEnbSetupRequestXmlPath                 = "../../tests/resources/setupRequest/setupRequest_ng-eNB.xml"
 	EnbSetupRequestXmlPath                   = "../../tests/resources/setupRequest/setupRequest_enb.xml"
+	GnbWithOidSetupRequestXmlPath            = "../../tests/resources/setupRequest/setupRequest_with_oid_gnb.xml"
 	GnbWithoutFunctionsSetupRequestXmlPath   = "../../tests/resources/setupRequest/setupRequest_gnb_without_functions.xml"
+	UnknownNodeSetupRequestXmlPath           = "../../tests/resources/setupRequest/setupRequest_unknown_node.xml"
 	E2SetupFailureResponseWithMiscCause      = "<E2AP-PDU><unsuccessfulOutcome><procedureCode>1</procedureCode><criticality><reject/></criticality><value><E2setupFailure><protocolIEs><E2setupFailureIEs><id>49</id><criticality><ignore/></criticality><value><TransactionID>1</TransactionID></value></E2setupFailureIEs><E2setupFailureIEs><id>1</id><criticality><ignore/></criticality><value><Cause><misc><om-intervention/></misc></Cause></value></E2setupFailureIEs><E2setupFailureIEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v60s/></TimeToWait></value></E2setupFailureIEs></protocolIEs></E2setupFailure></value></unsuccessfulOutcome></E2AP-PDU>"
 	E2SetupFailureResponseWithTransportCause = "<E2AP-PDU><unsuccessfulOutcome><procedureCode>1</procedureCode><criticality><reject/></criticality><value><E2setupFailure><protocolIEs><E2setupFailureIEs><id>49</id><criticality><ignore/></criticality><value><TransactionID>1</TransactionID></value></E2setupFailureIEs><E2setupFailureIEs><id>1</id><criticality><ignore/></criticality><value><Cause><transport><transport-resource-unavailable/></transport></Cause></value></E2setupFailureIEs><E2setupFailureIEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v60s/></TimeToWait></value></E2setupFailureIEs></protocolIEs></E2setupFailure></value></unsuccessfulOutcome></E2AP-PDU>"
-	E2SetupFailureResponseWithRicCause       = "<E2AP-PDU><unsuccessfulOutcome><procedureCode>1</procedureCode><criticality><reject/></criticality><value><E2setupFailure><protocolIEs><E2setupFailureIEs><id>49</id><criticality><ignore/></criticality><value><TransactionID>1</TransactionID></value></E2setupFailureIEs><E2setupFailureIEs><id>1</id><criticality><ignore/></criticality><value><Cause><ricRequest><request-id-unknown/></ricRequest></Cause></value></E2setupFailureIEs><E2setupFailureIEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v60s/></TimeToWait></value></E2setupFailureIEs></protocolIEs></E2setupFailure></value></unsuccessfulOutcome></E2AP-PDU>"
+	E2SetupFailureResponseWithProtocolCause  = "<E2AP-PDU><unsuccessfulOutcome><procedureCode>1</procedureCode><criticality><reject/></criticality><value><E2setupFailure><protocolIEs><E2setupFailureIEs><id>49</id><criticality><ignore/></criticality><value><TransactionID>1</TransactionID></value></E2setupFailureIEs><E2setupFailureIEs><id>1</id><criticality><ignore/></criticality><value><Cause><protocol><semantic-error/></protocol></Cause></value></E2setupFailureIEs><E2setupFailureIEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v60s/></TimeToWait></value></E2setupFailureIEs></protocolIEs></E2setupFailure></value></unsuccessfulOutcome></E2AP-PDU>"
+	E2SetupFailureResponseWithServiceCause   = "<E2AP-PDU><unsuccessfulOutcome><procedureCode>1</procedureCode><criticality><reject/></criticality><value><E2setupFailure><protocolIEs><E2setupFailureIEs><id>49</id><criticality><ignore/></criticality><value><TransactionID>1</TransactionID></value></E2setupFailureIEs><E2setupFailureIEs><id>1</id><criticality><ignore/></criticality><value><Cause><ricService><ric-resource-limit/></ricService></Cause></value></E2setupFailureIEs><E2setupFailureIEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v60s/></TimeToWait></value></E2setupFailureIEs></protocolIEs></E2setupFailure></value></unsuccessfulOutcome></E2AP-PDU>"
+	E2SetupFailureResponseWithTimeToWait10s  = "<E2AP-PDU><unsuccessfulOutcome><procedureCode>1</procedureCode><criticality><reject/></criticality><value><E2setupFailure><protocolIEs><E2setupFailureIEs><id>49</id><criticality><ignore/></criticality><value><TransactionID>1</TransactionID></value></E2setupFailureIEs><E2setupFailureIEs><id>1</id><criticality><ignore/></criticality><value><Cause><misc><om-intervention/></misc></Cause></value></E2setupFailureIEs><E2setupFailureIEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v10s/></TimeToWait></value></E2setupFailureIEs></protocolIEs></E2setupFailure></value></unsuccessfulOutcome></E2AP-PDU>"
 	StateChangeMessageChannel                = "RAN_CONNECTION_STATUS_CHANGE"
 )
 
@@ -79,13 +85,20 @@ func initMocks(t *testing.T) (*E2SetupRequestNotificationHandler, *mocks.RnibRea
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManagerMock := &mocks.E2TInstancesManagerMock{}
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, clients.NewAlarmManagerClient(logger, config, clients.NewHttpClient()))
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock, ranConnectStatusChangeManager)
-	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager)
+	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, managers.NewE2SetupAdmissionPolicy(logger, config), initRanProcedureTracker(logger, config), managers.NewRanFunctionValidator(config))
 	return handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock, ranListManager
 }
 
+func initRanProcedureTracker(logger *logger.Logger, config *configuration.Configuration) *managers.RanProcedureTracker {
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	return managers.NewRanProcedureTracker(logger, config, ranProcedureStoreMock)
+}
+
 func getMbuf(ranName string, msgType int, payloadStr string, request *models.NotificationRequest) *rmrCgo.MBuf {
 	payload := []byte(payloadStr)
 	mbuf := rmrCgo.NewMBuf(msgType, len(payload), ranName, &payload, &request.TransactionId, request.GetMsgSrc())
@@ -200,11 +213,11 @@ func TestE2SetupRequestNotificationHandler_ConvertTo20BitStringError(t *testing.
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManagerMock := &mocks.E2TInstancesManagerMock{}
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, clients.NewAlarmManagerClient(logger, config, clients.NewHttpClient()))
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock, ranConnectStatusChangeManager)
-	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager)
+	handler := NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManagerMock, rmrSender, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, managers.NewE2SetupAdmissionPolicy(logger, config), initRanProcedureTracker(logger, config), managers.NewRanFunctionValidator(config))
 	readerMock.On("GetGeneralConfiguration").Return(&entities.GeneralConfiguration{EnableRic: true}, nil)
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(&entities.E2TInstance{}, nil)
 	var gnb *entities.NodebInfo
@@ -231,20 +244,42 @@ func TestE2SetupRequestNotificationHandler_ConvertTo20BitStringError(t *testing.
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
 }
 
-func TestExtractionOfNodeTypeFromRanName(t *testing.T) {
+func TestSetNodeTypeAndConfigurationFromGlobalE2NodeId(t *testing.T) {
 	handler, _, _, _, _, _, _ := initMocks(t)
-	validRanNames := []string{"gnb_P310_410_b5c67788", "en_gnb_P310_410_b5c67788", "ng_enB_macro_P310_410_b5c67788", "ng_enB_shortmacro_P310_410_b5c67788", "ng_enB_longmacro_P310_410_b5c67788", "enB_macro_P310_410_b5c67788", "enB_home_P310_410_b5c67788", "enB_shortmacro_P310_410_b5c67788", "enB_longmacro_P310_410_b5c67788"}
-	for _, v := range validRanNames {
-		nodeb := &entities.NodebInfo{RanName: v}
-		err := handler.setNodeTypeAndConfiguration(nodeb)
+	expectedGnbTypes := map[string]entities.GnbType{
+		GnbSetupRequestXmlPath:   entities.GnbType_GNB,
+		EnGnbSetupRequestXmlPath: entities.GnbType_EN_GNB,
+	}
+	for xmlPath, gnbType := range expectedGnbTypes {
+		setupRequest := getSetupRequest(t, xmlPath)
+		nodeb := &entities.NodebInfo{RanName: gnbNodebRanName}
+		err := handler.setNodeTypeAndConfiguration(nodeb, setupRequest)
 		assert.Nil(t, err)
+		assert.Equal(t, entities.Node_GNB, nodeb.NodeType)
+		assert.Equal(t, gnbType, nodeb.GetGnb().GnbType)
+	}
+	expectedEnbTypes := map[string]entities.EnbType{
+		EnbSetupRequestXmlPath:   entities.EnbType_MACRO_ENB,
+		NgEnbSetupRequestXmlPath: entities.EnbType_SHORT_MACRO_NG_ENB,
 	}
-	inValidRanNames := []string{"P310_410_b5c67788", "blabla_P310_410_b5c67788", "ng_enB-macro_P310_410_b5c67788", "ng_enb_shortmacro_P310_410_b5c67788", "ng_enB-longmacro_P310_410_b5c67788", "enB_new_macro_P310_410_b5c67788"}
-	for _, v := range inValidRanNames {
-		nodeb := &entities.NodebInfo{RanName: v}
-		err := handler.setNodeTypeAndConfiguration(nodeb)
-		assert.NotNil(t, err, v)
+	for xmlPath, enbType := range expectedEnbTypes {
+		setupRequest := getSetupRequest(t, xmlPath)
+		nodeb := &entities.NodebInfo{RanName: enbNodebRanName}
+		err := handler.setNodeTypeAndConfiguration(nodeb, setupRequest)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.Node_ENB, nodeb.NodeType)
+		assert.Equal(t, enbType, nodeb.GetEnb().EnbType)
 	}
+	nodeb := &entities.NodebInfo{RanName: enbNodebRanName}
+	err := handler.setNodeTypeAndConfiguration(nodeb, getSetupRequest(t, UnknownNodeSetupRequestXmlPath))
+	assert.NotNil(t, err)
+}
+
+func getSetupRequest(t *testing.T, xmlPath string) *models.E2SetupRequestMessage {
+	setupRequest := &models.E2SetupRequestMessage{}
+	err := xml.Unmarshal(utils.NormalizeXml(utils.ReadXmlFile(t, xmlPath)), &setupRequest.E2APPDU)
+	assert.Nil(t, err)
+	return setupRequest
 }
 
 func TestE2SetupRequestNotificationHandler_GetGeneralConfigurationFailure(t *testing.T) {
@@ -273,6 +308,7 @@ func TestE2SetupRequestNotificationHandler_EnableRicFalse(t *testing.T) {
 	routingManagerClientMock.AssertNotCalled(t, "AssociateRanToE2TInstance")
 	readerMock.AssertNotCalled(t, "GetNodeb")
 	writerMock.AssertNotCalled(t, "SaveNodeb")
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(gnbNodebRanName, models.E2SetupProcedure).State)
 }
 
 func TestE2SetupRequestNotificationHandler_HandleGetE2TInstanceError(t *testing.T) {
@@ -329,25 +365,62 @@ func TestE2SetupRequestNotificationHandler_HandleNewRanSaveNodebFailure(t *testi
 	e2tInstancesManagerMock.AssertExpectations(t)
 }
 
-func TestE2SetupRequestNotificationHandler_HandleNewRan_invalidRanName(t *testing.T) {
-	xml := utils.ReadXmlFile(t, EnbSetupRequestXmlPath)
+func TestE2SetupRequestNotificationHandler_HandleUnknownGlobalE2NodeId(t *testing.T) {
+	xml := utils.ReadXmlFile(t, UnknownNodeSetupRequestXmlPath)
 	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, _, _ := initMocks(t)
 	readerMock.On("GetGeneralConfiguration").Return(&entities.GeneralConfiguration{EnableRic: true}, nil)
 	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(&entities.E2TInstance{}, nil)
-	var enb *entities.NodebInfo
-	invalidEnbRanName := "enB-macro:P310-410-b5c67788"
-	readerMock.On("GetNodeb", invalidEnbRanName).Return(enb, common.NewResourceNotFoundError("Not found"))
-	notificationRequest := &models.NotificationRequest{RanName: invalidEnbRanName, Payload: append([]byte(e2SetupMsgPrefix), xml...)}
-	mbuf := getMbuf(invalidEnbRanName, rmrCgo.RIC_E2_SETUP_FAILURE, E2SetupFailureResponseWithRicCause, notificationRequest)
+	notificationRequest := &models.NotificationRequest{RanName: enbNodebRanName, Payload: append([]byte(e2SetupMsgPrefix), xml...)}
+	mbuf := getMbuf(enbNodebRanName, rmrCgo.RIC_E2_SETUP_FAILURE, E2SetupFailureResponseWithProtocolCause, notificationRequest)
 	rmrMessengerMock.On("WhSendMsg", mbuf, true).Return(&rmrCgo.MBuf{}, nil)
 	handler.Handle(notificationRequest)
 
-	readerMock.AssertExpectations(t)
-	writerMock.AssertExpectations(t)
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything)
 	rmrMessengerMock.AssertCalled(t, "WhSendMsg", mbuf, true)
 	e2tInstancesManagerMock.AssertExpectations(t)
 }
 
+func TestE2SetupRequestNotificationHandler_HandleDeniedPlmnId(t *testing.T) {
+	xmlGnb := utils.ReadXmlFile(t, GnbSetupRequestXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock, _ := initMocks(t)
+	config := &configuration.Configuration{}
+	config.E2SetupAdmission.TimeToWaitSec = 10
+	config.E2SetupAdmission.DeniedPlmnIds = []string{"02f829"}
+	handler.admissionPolicy = managers.NewE2SetupAdmissionPolicy(handler.logger, config)
+	readerMock.On("GetGeneralConfiguration").Return(&entities.GeneralConfiguration{EnableRic: true}, nil)
+	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(&entities.E2TInstance{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: append([]byte(e2SetupMsgPrefix), xmlGnb...)}
+	mbuf := getMbuf(gnbNodebRanName, rmrCgo.RIC_E2_SETUP_FAILURE, E2SetupFailureResponseWithTimeToWait10s, notificationRequest)
+	rmrMessengerMock.On("WhSendMsg", mbuf, true).Return(&rmrCgo.MBuf{}, nil)
+	handler.Handle(notificationRequest)
+
+	rmrMessengerMock.AssertCalled(t, "WhSendMsg", mbuf, true)
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything)
+	routingManagerClientMock.AssertNotCalled(t, "AssociateRanToE2TInstance", mock.Anything, mock.Anything)
+}
+
+func TestE2SetupRequestNotificationHandler_HandleMaxNodesPerE2TReached(t *testing.T) {
+	xmlGnb := utils.ReadXmlFile(t, GnbSetupRequestXmlPath)
+	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock, _ := initMocks(t)
+	config := &configuration.Configuration{}
+	config.E2SetupAdmission.MaxNodesPerE2T = 1
+	handler.admissionPolicy = managers.NewE2SetupAdmissionPolicy(handler.logger, config)
+	readerMock.On("GetGeneralConfiguration").Return(&entities.GeneralConfiguration{EnableRic: true}, nil)
+	e2tInstance := &entities.E2TInstance{Address: e2tInstanceFullAddress, AssociatedRanList: []string{"gnb:other"}}
+	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(e2tInstance, nil)
+	notificationRequest := &models.NotificationRequest{RanName: gnbNodebRanName, Payload: append([]byte(e2SetupMsgPrefix), xmlGnb...)}
+	mbuf := getMbuf(gnbNodebRanName, rmrCgo.RIC_E2_SETUP_FAILURE, E2SetupFailureResponseWithServiceCause, notificationRequest)
+	rmrMessengerMock.On("WhSendMsg", mbuf, true).Return(&rmrCgo.MBuf{}, nil)
+	handler.Handle(notificationRequest)
+
+	rmrMessengerMock.AssertCalled(t, "WhSendMsg", mbuf, true)
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "SaveNodeb", mock.Anything)
+	routingManagerClientMock.AssertNotCalled(t, "AssociateRanToE2TInstance", mock.Anything, mock.Anything)
+}
+
 func TestE2SetupRequestNotificationHandler_HandleNewRanAddNbIdentityFailure(t *testing.T) {
 	xml := utils.ReadXmlFile(t, GnbSetupRequestXmlPath)
 	handler, readerMock, writerMock, _, e2tInstancesManagerMock, _, _ := initMocks(t)
@@ -438,6 +511,9 @@ func testE2SetupRequestNotificationHandler_HandleNewRanSuccess(t *testing.T, xml
 	readerMock.AssertExpectations(t)
 	writerMock.AssertExpectations(t)
 	e2tInstancesManagerMock.AssertExpectations(t)
+	procedure := handler.ranProcedureTracker.GetLastProcedure(ranName)
+	assert.Equal(t, models.E2SetupProcedure, procedure.Type)
+	assert.Equal(t, models.RanProcedureCompleted, procedure.State)
 }
 
 func TestE2SetupRequestNotificationHandler_HandleNewGnbSuccess(t *testing.T) {
@@ -456,6 +532,68 @@ func TestE2SetupRequestNotificationHandler_HandleNewNgEnbSuccess(t *testing.T) {
 	testE2SetupRequestNotificationHandler_HandleNewRanSuccess(t, NgEnbSetupRequestXmlPath, entities.Node_ENB)
 }
 
+func testE2SetupRequestNotificationHandler_HandleNewGnbWithRejections(t *testing.T, payload []byte, knownOids []string, expectedNodebInfo *entities.NodebInfo, expectedResponseParts []string) {
+	handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock, _ := initMocks(t)
+	config := &configuration.Configuration{}
+	config.RicServiceUpdate.KnownRanFunctionOids = knownOids
+	handler.ranFunctionValidator = managers.NewRanFunctionValidator(config)
+	readerMock.On("GetGeneralConfiguration").Return(&entities.GeneralConfiguration{EnableRic: true}, nil)
+	e2tInstancesManagerMock.On("GetE2TInstance", e2tInstanceFullAddress).Return(&entities.E2TInstance{}, nil)
+	var gnb *entities.NodebInfo
+	readerMock.On("GetNodeb", gnbNodebRanName).Return(gnb, common.NewResourceNotFoundError("Not found"))
+	writerMock.On("SaveNodeb", expectedNodebInfo).Return(nil)
+	writerMock.On("AddNbIdentity", entities.Node_GNB, mock.Anything).Return(nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, gnbNodebRanName+"_CONNECTED").Return(nil)
+	routingManagerClientMock.On("AssociateRanToE2TInstance", e2tInstanceFullAddress, mock.Anything).Return(nil)
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+	writerMock.On("UpdateNbIdentities", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	e2tInstancesManagerMock.On("AddRansToInstance", e2tInstanceFullAddress, []string{gnbNodebRanName}).Return(nil)
+	isSetupResponse := func(mbuf *rmrCgo.MBuf) bool {
+		if mbuf.MType != rmrCgo.RIC_E2_SETUP_RESP {
+			return false
+		}
+		for _, part := range expectedResponseParts {
+			if !bytes.Contains(*mbuf.Payload, []byte(part)) {
+				return false
+			}
+		}
+		return true
+	}
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isSetupResponse), true).Return(&rmrCgo.MBuf{}, nil)
+
+	handler.Handle(&models.NotificationRequest{RanName: gnbNodebRanName, Payload: payload})
+
+	writerMock.AssertExpectations(t)
+	rmrMessengerMock.AssertExpectations(t)
+	procedure := handler.ranProcedureTracker.GetLastProcedure(gnbNodebRanName)
+	assert.Equal(t, models.RanProcedureCompleted, procedure.State)
+}
+
+func TestE2SetupRequestNotificationHandler_HandleNewGnbRejectsUnknownOid(t *testing.T) {
+	payload := append([]byte(e2SetupMsgPrefix), utils.ReadXmlFile(t, GnbWithOidSetupRequestXmlPath)...)
+	expectedNodebInfo := getExpectedGnbNodebForNewRan(payload)
+	ranFunctions := expectedNodebInfo.GetGnb().RanFunctions
+	expectedNodebInfo.GetGnb().RanFunctions = []*entities.RanFunction{ranFunctions[0], ranFunctions[2]}
+
+	testE2SetupRequestNotificationHandler_HandleNewGnbWithRejections(t, payload, []string{"OID123", "OID125"}, expectedNodebInfo, []string{
+		"<RANfunctionsIDcause-List><ProtocolIE-SingleContainer><id>7</id><criticality><ignore/></criticality><value><RANfunctionIDcause-Item><ranFunctionID>2</ranFunctionID><cause><ricService><function-not-required/></ricService></cause></RANfunctionIDcause-Item>",
+		"<ranFunctionID>1</ranFunctionID>",
+		"<ranFunctionID>3</ranFunctionID>",
+	})
+}
+
+func TestE2SetupRequestNotificationHandler_HandleNewGnbRejectsDuplicateComponent(t *testing.T) {
+	xmlGnb := bytes.Replace(utils.ReadXmlFile(t, GnbWithOidSetupRequestXmlPath), []byte("nginterf2"), []byte("nginterf1"), 1)
+	payload := append([]byte(e2SetupMsgPrefix), xmlGnb...)
+	expectedNodebInfo := getExpectedGnbNodebForNewRan(payload)
+	expectedNodebInfo.GetGnb().NodeConfigs = expectedNodebInfo.GetGnb().NodeConfigs[:1]
+
+	testE2SetupRequestNotificationHandler_HandleNewGnbWithRejections(t, payload, nil, expectedNodebInfo, []string{
+		"<updateOutcome><success/></updateOutcome>",
+		"<updateOutcome><failure/></updateOutcome><failureCause><protocol><semantic-error/></protocol></failureCause>",
+	})
+}
+
 func getExpectedGnbNodebForNewRan(payload []byte) *entities.NodebInfo {
 	pipInd := bytes.IndexByte(payload, '|')
 	setupRequest := &models.E2SetupRequestMessage{}
@@ -477,7 +615,7 @@ func getExpectedGnbNodebForNewRan(payload []byte) *entities.NodebInfo {
 		GnbNodeType:                  gnbNodetype,
 		Configuration: &entities.NodebInfo_Gnb{
 			Gnb: &entities.Gnb{
-				GnbType:      entities.GnbType_GNB,
+				GnbType:      setupRequest.GetGnbType(),
 				RanFunctions: setupRequest.ExtractRanFunctionsList(),
 				NodeConfigs:  setupRequest.ExtractE2NodeConfigList(),
 			},
@@ -505,7 +643,7 @@ func getExpectedEnbNodebForNewRan(payload []byte) *entities.NodebInfo {
 		NodeType:                     entities.Node_ENB,
 		Configuration: &entities.NodebInfo_Enb{
 			Enb: &entities.Enb{
-				EnbType:     entities.EnbType_MACRO_ENB,
+				EnbType:     setupRequest.GetEnbType(),
 				NodeConfigs: setupRequest.ExtractE2NodeConfigList(),
 			},
 		},
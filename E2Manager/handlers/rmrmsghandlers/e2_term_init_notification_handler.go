@@ -24,6 +24,7 @@ import (
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
+	"e2mgr/services"
 	"encoding/json"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -34,34 +35,41 @@ type E2TermInitNotificationHandler struct {
 	ranDisconnectionManager *managers.RanDisconnectionManager
 	e2tInstancesManager     managers.IE2TInstancesManager
 	routingManagerClient    clients.IRoutingManagerClient
+	ranAlarmService         services.RanAlarmService
 }
 
-func NewE2TermInitNotificationHandler(logger *logger.Logger, ranDisconnectionManager *managers.RanDisconnectionManager, e2tInstancesManager managers.IE2TInstancesManager, routingManagerClient clients.IRoutingManagerClient) E2TermInitNotificationHandler {
+func NewE2TermInitNotificationHandler(logger *logger.Logger, ranDisconnectionManager *managers.RanDisconnectionManager, e2tInstancesManager managers.IE2TInstancesManager, routingManagerClient clients.IRoutingManagerClient, ranAlarmService services.RanAlarmService) E2TermInitNotificationHandler {
 	return E2TermInitNotificationHandler{
 		logger:                  logger,
 		ranDisconnectionManager: ranDisconnectionManager,
 		e2tInstancesManager:     e2tInstancesManager,
 		routingManagerClient:    routingManagerClient,
+		ranAlarmService:         ranAlarmService,
 	}
 }
 
 func (h E2TermInitNotificationHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	unmarshalledPayload := models.E2TermInitPayload{}
 	err := json.Unmarshal(request.Payload, &unmarshalledPayload)
 
 	if err != nil {
-		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Error unmarshaling E2 Term Init payload: %s", err)
+		log.Errorf("#E2TermInitNotificationHandler.Handle - Error unmarshaling E2 Term Init payload: %s", err)
 		return
 	}
 
 	e2tAddress := unmarshalledPayload.Address
 
 	if len(e2tAddress) == 0 {
-		h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Empty E2T address received")
+		log.Errorf("#E2TermInitNotificationHandler.Handle - Empty E2T address received")
 		return
 	}
 
-	h.logger.Infof("#E2TermInitNotificationHandler.Handle - E2T payload: %s - handling E2_TERM_INIT", unmarshalledPayload)
+	log.Infof("#E2TermInitNotificationHandler.Handle - E2T payload: %s - handling E2_TERM_INIT", unmarshalledPayload)
+
+	if err := h.ranAlarmService.ClearE2TKeepAliveLostAlarm(e2tAddress); err != nil {
+		log.Errorf("#E2TermInitNotificationHandler.Handle - E2T Address: %s - Failed clearing keep alive lost alarm. error: %s", e2tAddress, err)
+	}
 
 	e2tInstance, err := h.e2tInstancesManager.GetE2TInstance(e2tAddress)
 
@@ -69,7 +77,7 @@ func (h E2TermInitNotificationHandler) Handle(request *models.NotificationReques
 		_, ok := err.(*common.ResourceNotFoundError)
 
 		if !ok {
-			h.logger.Errorf("#E2TermInitNotificationHandler.Handle - Failed retrieving E2TInstance. error: %s", err)
+			log.Errorf("#E2TermInitNotificationHandler.Handle - Failed retrieving E2TInstance. error: %s", err)
 			return
 		}
 
@@ -78,19 +86,19 @@ func (h E2TermInitNotificationHandler) Handle(request *models.NotificationReques
 	}
 
 	if len(e2tInstance.AssociatedRanList) == 0 {
-		h.logger.Infof("#E2TermInitNotificationHandler.Handle - E2T Address: %s - E2T instance has no associated RANs", e2tInstance.Address)
+		log.Infof("#E2TermInitNotificationHandler.Handle - E2T Address: %s - E2T instance has no associated RANs", e2tInstance.Address)
                 h.UpdateExistingE2TInstanceToRtmgr(e2tAddress)
 		return
 	}
 
 	if e2tInstance.State == entities.ToBeDeleted {
-		h.logger.Infof("#E2TermInitNotificationHandler.Handle - E2T Address: %s - E2T instance status is: %s, ignore", e2tInstance.Address, e2tInstance.State)
+		log.Infof("#E2TermInitNotificationHandler.Handle - E2T Address: %s - E2T instance status is: %s, ignore", e2tInstance.Address, e2tInstance.State)
 		return
 	}
 
 	h.HandleExistingE2TInstance(e2tInstance)
 
-	h.logger.Infof("#E2TermInitNotificationHandler.Handle - Completed handling of E2_TERM_INIT")
+	log.Infof("#E2TermInitNotificationHandler.Handle - Completed handling of E2_TERM_INIT")
 }
 
 func (h E2TermInitNotificationHandler) HandleExistingE2TInstance(e2tInstance *entities.E2TInstance) {
@@ -67,11 +67,12 @@ func initRanLostConnectionTest(t *testing.T) (*logger.Logger, E2TermInitNotifica
 
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
 	ranAlarmService := &mocks.RanAlarmServiceMock{}
+	ranAlarmService.On("ClearE2TKeepAliveLostAlarm", mock.Anything).Return(nil)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock, ranConnectStatusChangeManager)
 
 	ranDisconnectionManager := managers.NewRanDisconnectionManager(logger, configuration.ParseConfiguration(), rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager)
-	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManagerMock, routingManagerClientMock)
+	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManagerMock, routingManagerClientMock, ranAlarmService)
 
 	return logger, handler, readerMock, writerMock, e2tInstancesManagerMock, routingManagerClientMock
 }
@@ -86,16 +87,17 @@ func initRanLostConnectionTestWithRealE2tInstanceManager(t *testing.T) (*logger.
 	writerMock := &mocks.RnibWriterMock{}
 	httpClientMock := &mocks.HttpClientMock{}
 
-	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(logger, config, httpClientMock)
+	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClientMock, alarmManagerClient)
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, logger)
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, alarmManagerClient)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager)
 	ranDisconnectionManager := managers.NewRanDisconnectionManager(logger, configuration.ParseConfiguration(), rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager)
-	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManager, routingManagerClient)
+	handler := NewE2TermInitNotificationHandler(logger, ranDisconnectionManager, e2tInstancesManager, routingManagerClient, ranAlarmService)
 	return logger, config, handler, readerMock, writerMock, httpClientMock, ranListManager
 }
 
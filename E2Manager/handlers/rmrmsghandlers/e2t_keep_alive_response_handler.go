@@ -43,11 +43,12 @@ func NewE2TKeepAliveResponseHandler(logger *logger.Logger, rnibDataService servi
 }
 
 func (h E2TKeepAliveResponseHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	unmarshalledPayload := models.E2TKeepAlivePayload{}
 	err := json.Unmarshal(request.Payload, &unmarshalledPayload)
 
 	if err != nil {
-		h.logger.Errorf("#E2TKeepAliveResponseHandler.Handle - Error unmarshaling RMR request payload: %v", err)
+		log.Errorf("#E2TKeepAliveResponseHandler.Handle - Error unmarshaling RMR request payload: %v", err)
 		return
 	}
 
@@ -48,23 +48,24 @@ func NewEndcConfigurationUpdateHandler(logger *logger.Logger, rmrSender *rmrsend
 }
 
 func (h EndcConfigurationUpdateHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 
 	refinedMessage, err := converters.UnpackX2apPduAndRefine(h.logger, e2pdus.MaxAsn1CodecAllocationBufferSize /*allocation buffer*/, request.Len, request.Payload, e2pdus.MaxAsn1CodecMessageBufferSize /*message buffer*/)
 
 	if err != nil {
-		h.logger.Errorf("#endc_configuration_update_handler.Handle - unpack failed. Error: %v", err)
+		log.Errorf("#endc_configuration_update_handler.Handle - unpack failed. Error: %v", err)
 
 		msg := models.NewRmrMessage(rmrCgo.RIC_ENDC_CONF_UPDATE_FAILURE, request.RanName, e2pdus.PackedEndcConfigurationUpdateFailure, request.TransactionId, request.GetMsgSrc())
 		_ = h.rmrSender.Send(msg)
 
-		h.logger.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+		log.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
 		return
 	}
 
-	h.logger.Infof("#endc_configuration_update_handler.Handle - Endc configuration update initiating message received")
-	h.logger.Debugf("#endc_configuration_update_handler.Handle - Endc configuration update initiating message payload: %s", refinedMessage.PduPrint)
+	log.Infof("#endc_configuration_update_handler.Handle - Endc configuration update initiating message received")
+	log.Debugf("#endc_configuration_update_handler.Handle - Endc configuration update initiating message payload: %s", refinedMessage.PduPrint)
 	msg := models.NewRmrMessage(rmrCgo.RIC_ENDC_CONF_UPDATE_ACK, request.RanName, e2pdus.PackedEndcConfigurationUpdateAck, request.TransactionId, request.GetMsgSrc())
 	_ = h.rmrSender.Send(msg)
 
-	h.logger.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+	log.Infof("#EndcConfigurationUpdateHandler.Handle - Summary: elapsed time for receiving and handling endc configuration update initiating message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
 }
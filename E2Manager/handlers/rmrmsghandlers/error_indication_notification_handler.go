@@ -19,125 +19,196 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
+	"e2mgr/services"
 	"e2mgr/utils"
 	"encoding/xml"
 	"fmt"
+	"time"
 
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
-	"sync"
 )
 
-var e2ErrorIndicationMessage = models.ErrorIndicationMessage{}
-
-var E2SETUP_PROCEDURE string = "1"
-var RICSERVICEUPDATE_PROCEDURE string = "7"
-
 type ErrorIndicationHandler struct {
-	logger                  *logger.Logger
-	ranDisconnectionManager managers.IRanDisconnectionManager
-	RicServiceUpdateManager managers.IRicServiceUpdateManager
-	procedureMapMutex       sync.RWMutex
+	logger                    *logger.Logger
+	config                    *configuration.Configuration
+	ranDisconnectionManager   managers.IRanDisconnectionManager
+	RicServiceUpdateManager   managers.IRicServiceUpdateManager
+	ranProcedureTracker       managers.IRanProcedureTracker
+	e2ResetTransactionManager managers.IE2ResetTransactionManager
+	ricE2ResetManager         managers.IRicE2ResetManager
+	errorIndicationStore      services.ErrorIndicationStore
+	actionPolicy              *managers.ErrorIndicationActionPolicy
 }
 
-
-func ErrorIndicationNotificationHandler(logger *logger.Logger, ranDisconnectionManager managers.IRanDisconnectionManager, RicServiceUpdateManager managers.IRicServiceUpdateManager) *ErrorIndicationHandler {
+func ErrorIndicationNotificationHandler(logger *logger.Logger, config *configuration.Configuration, ranDisconnectionManager managers.IRanDisconnectionManager, RicServiceUpdateManager managers.IRicServiceUpdateManager, ranProcedureTracker managers.IRanProcedureTracker, e2ResetTransactionManager managers.IE2ResetTransactionManager, ricE2ResetManager managers.IRicE2ResetManager, errorIndicationStore services.ErrorIndicationStore) *ErrorIndicationHandler {
 	return &ErrorIndicationHandler{
-		logger:                  logger,
-		ranDisconnectionManager: ranDisconnectionManager,
-		RicServiceUpdateManager: RicServiceUpdateManager,
+		logger:                    logger,
+		config:                    config,
+		ranDisconnectionManager:   ranDisconnectionManager,
+		RicServiceUpdateManager:   RicServiceUpdateManager,
+		ranProcedureTracker:       ranProcedureTracker,
+		e2ResetTransactionManager: e2ResetTransactionManager,
+		ricE2ResetManager:         ricE2ResetManager,
+		errorIndicationStore:      errorIndicationStore,
+		actionPolicy:              managers.NewErrorIndicationActionPolicy(config),
 	}
 }
-func (errorIndicationHandler *ErrorIndicationHandler) Handle (request *models.NotificationRequest) {
+
+func (errorIndicationHandler *ErrorIndicationHandler) Handle(request *models.NotificationRequest) {
+	log := errorIndicationHandler.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	ranName := request.RanName
-	errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-Received Error Indication from E2Node - %s", ranName)
+	log.Debugf("#ErrorIndicationHandler.Handle - RAN name: %s - Received Error Indication from E2Node, payload: %x", ranName, request.Payload)
 
-	errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-Received ErrorIndication payload at E2M is - %x", request.Payload)
 	errorIndicationMessage, err := errorIndicationHandler.parseErrorIndication(request.Payload)
 	if err != nil {
-		errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.Handle- Parsing is not successful")
+		log.Errorf("#ErrorIndicationHandler.Handle - RAN name: %s - %s", ranName, err)
 		return
 	}
-	errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle ERROR INDICATION from E2Node has been parsed successfully- %+v", errorIndicationMessage)
-	errorIndicationIE := errorIndicationMessage.E2APPDU.InitiatingMessage.Value.ErrorIndication.ProtocolIEs.ErrorIndicationIEs
-	fmt.Printf("errorIndicationIE value is %+v", errorIndicationIE)
-	
-	for i := 0 ; i < len(errorIndicationIE) ; i++ {
-		if errorIndicationIE[i].ID == 2 {
-			errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-CD is: %+v", errorIndicationIE[i].Value.CriticalityDiagnostics)
-			if errorIndicationIE[i].Value.CriticalityDiagnostics.ProcedureCode != "" && errorIndicationIE[i].Value.CriticalityDiagnostics.TriggeringMessage.SuccessfulOutcome != nil {
-				procedureCode := errorIndicationIE[i].Value.CriticalityDiagnostics.ProcedureCode
-				errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-procedureCode present is: %+v", procedureCode)
-				errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle- before triggeringMessage present is: %+v", errorIndicationIE[i].Value.CriticalityDiagnostics.TriggeringMessage)
-				triggeringMessageValue := &errorIndicationIE[i].Value.CriticalityDiagnostics.TriggeringMessage.SuccessfulOutcome
-				errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-triggeringMessage present is: %+v", *triggeringMessageValue)
-				if procedureCode != "" && triggeringMessageValue != nil {
-					errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.handleErrorIndicationBasedOnProcedureCode for all scenarios")
-					switch procedureCode {
-					case E2SETUP_PROCEDURE:
-						if triggeringMessageValue != nil {
-							errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication happened at E2Setup procedure")
-							err = errorIndicationHandler.ranDisconnectionManager.DisconnectRan(ranName)
-							errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-Cleanup Completed !!")
-							return
-						} else {
-							errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication recieved for unsuccessful-outcome, no action taken")
-						}
-					case RICSERVICEUPDATE_PROCEDURE:
-						if triggeringMessageValue != nil {
-							errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication happened at Ric Service Update procedure")
-							err = errorIndicationHandler.RicServiceUpdateManager.RevertRanFunctions(ranName)
-							if err != nil {
-								errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.Handle-reverting RanFunctions and updating the nodebInfo failed due to error %+v", err)
-							}
-							return
-						} else {
-							errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication recieved for unsuccessful-outcome, no action taken")
-						}
-					default:
-						errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-problem in handling of error indication")
-						return
-					}
-				}
-			}
-		}
+
+	record := models.NewErrorIndicationRecord(errorIndicationMessage, time.Now().UnixNano())
+	procedure := errorIndicationHandler.getProcedure(ranName, errorIndicationMessage)
+	if procedure != nil {
+		record.ProcedureType = procedure.Type
+	}
+	record.Action = errorIndicationHandler.actionPolicy.GetAction(errorIndicationMessage.GetCause())
+
+	log.Infof("#ErrorIndicationHandler.Handle - RAN name: %s - Error Indication parsed, transaction id: %s, cause: %s, procedure: %s, action: %s", ranName, record.TransactionId, record.Cause, record.ProcedureType, record.Action)
+
+	switch record.Action {
+	case models.ErrorIndicationActionIgnore:
+		log.Debugf("#ErrorIndicationHandler.Handle - RAN name: %s - ignoring Error Indication", ranName)
+	case models.ErrorIndicationActionLog:
+		log.Warnf("#ErrorIndicationHandler.Handle - RAN name: %s - Error Indication received, cause: %s, procedure: %s, transaction id: %s", ranName, record.Cause, record.ProcedureType, record.TransactionId)
+	case models.ErrorIndicationActionRevert:
+		errorIndicationHandler.revertProcedure(ranName, procedure)
+	case models.ErrorIndicationActionReset:
+		errorIndicationHandler.resetRan(ranName, errorIndicationMessage.GetCause())
+	case models.ErrorIndicationActionDisconnect:
+		errorIndicationHandler.disconnectRan(ranName)
+	}
+
+	errorIndicationHandler.storeErrorIndication(ranName, record)
+}
+
+// getProcedure returns the procedure the Error Indication refers to. The procedure code of the CriticalityDiagnostics IE is
+// preferred, otherwise the Error Indication is correlated to the procedure with the same transaction id, or else to the
+// last procedure of the RAN
+func (errorIndicationHandler *ErrorIndicationHandler) getProcedure(ranName string, errorIndicationMessage *models.ErrorIndicationMessage) *models.RanProcedure {
+	if procedureType, ok := errorIndicationMessage.GetProcedureType(); ok {
+		return errorIndicationHandler.getProcedureOfCriticalityDiagnostics(ranName, procedureType, errorIndicationMessage.GetCriticalityDiagnostics().TriggeringMessage)
+	}
+
+	if criticalityDiagnostics := errorIndicationMessage.GetCriticalityDiagnostics(); criticalityDiagnostics != nil && criticalityDiagnostics.ProcedureCode != "" {
+		errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.getProcedure - RAN name: %s - unknown procedure code %s", ranName, criticalityDiagnostics.ProcedureCode)
+		return nil
+	}
+
+	var procedure *models.RanProcedure
+
+	if transactionId := errorIndicationMessage.GetTransactionId(); transactionId != "" {
+		procedure = errorIndicationHandler.ranProcedureTracker.GetProcedureByTransactionId(ranName, transactionId)
 	}
-	errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-CriticalityDiagnostics IEs unsuccessful hence Retrieving based on procedureMap")
-	errorIndicationHandler.HandleBasedOnProcedureType(ranName)
-	errorIndicationHandler.logger.Debugf("#ErrorIndicationHandler.Handle-Cleanup Completed !!")
+
+	if procedure == nil {
+		procedure = errorIndicationHandler.ranProcedureTracker.GetLastProcedure(ranName)
+	}
+
+	return procedure
 }
 
+// getProcedureOfCriticalityDiagnostics : a successful outcome means the node rejected the response of the RIC to a completed
+// procedure, an initiating message means the node rejected a request of the RIC
+func (errorIndicationHandler *ErrorIndicationHandler) getProcedureOfCriticalityDiagnostics(ranName string, procedureType models.RanProcedureType, triggeringMessage models.TriggeringMessage) *models.RanProcedure {
+	procedure := errorIndicationHandler.ranProcedureTracker.GetProcedure(ranName, procedureType)
 
+	if procedure == nil {
+		procedure = &models.RanProcedure{Type: procedureType, State: models.RanProcedureFailed}
+	}
+
+	switch {
+	case triggeringMessage.SuccessfulOutcome != nil:
+		procedure.State = models.RanProcedureCompleted
+	case triggeringMessage.InitiatingMessage != nil:
+		procedure.State = models.RanProcedureOngoing
+	}
 
-func (errorIndicationHandler *ErrorIndicationHandler) HandleBasedOnProcedureType(ranName string) error {
-	errorIndicationHandler.procedureMapMutex.RLock()
-	procedureType, ok := models.ProcedureMap[ranName]
-	errorIndicationHandler.procedureMapMutex.RUnlock()
-	if !ok {
-		errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.Handle-Error ProcedureType not found for ranName %s", ranName)
-	} else {
-		switch procedureType {
-		case models.E2SetupProcedureCompleted:
-			errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication happened at E2Setup procedure")
-			err := errorIndicationHandler.ranDisconnectionManager.DisconnectRan(ranName)
-			if err != nil {
-				errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.Handle-Disconnect RAN and updating the nodebInfo failed due to error %+v", err)
-			}
-		case models.RicServiceUpdateCompleted:
-			errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication happened at Ric Service Update procedure")
-			err := errorIndicationHandler.RicServiceUpdateManager.RevertRanFunctions(ranName)
-			if err != nil {
-				errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.Handle-reverting RanFunctions and updating the nodebInfo failed due to error %+v", err)
-			}
-		case models.E2SetupProcedureFailure, models.RicServiceUpdateFailure:
-			errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-ErrorIndication occcured before successful outcome hence ignoring")
-		default:
-			errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.Handle-Error in handling the ErrorIndication based on enum")
+	return procedure
+}
+
+func (errorIndicationHandler *ErrorIndicationHandler) revertProcedure(ranName string, procedure *models.RanProcedure) {
+	if procedure == nil {
+		errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.revertProcedure - RAN name: %s - no procedure found", ranName)
+		return
+	}
+
+	errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.revertProcedure - RAN name: %s - ErrorIndication refers to %s procedure, transaction id: %s, state: %s", ranName, procedure.Type, procedure.TransactionId, procedure.State)
+
+	if procedure.IsOngoing() {
+		errorIndicationHandler.abortProcedure(ranName, procedure.Type)
+		return
+	}
+
+	if procedure.State != models.RanProcedureCompleted {
+		errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.revertProcedure - RAN name: %s - ErrorIndication occurred before successful outcome hence ignoring", ranName)
+		return
+	}
+
+	switch procedure.Type {
+	case models.E2SetupProcedure:
+		errorIndicationHandler.disconnectRan(ranName)
+	case models.RicServiceUpdateProcedure:
+		err := errorIndicationHandler.RicServiceUpdateManager.RevertRanFunctions(ranName)
+		if err != nil {
+			errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.revertProcedure - RAN name: %s - reverting RanFunctions and updating the nodebInfo failed due to error %+v", ranName, err)
 		}
+	default:
+		errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.revertProcedure - RAN name: %s - nothing to revert for %s procedure", ranName, procedure.Type)
+	}
+
+	// A reverted procedure is not reverted again by a later Error Indication
+	errorIndicationHandler.ranProcedureTracker.Fail(ranName, procedure.Type)
+}
+
+// abortProcedure handles the rejection of a request sent by the RIC
+func (errorIndicationHandler *ErrorIndicationHandler) abortProcedure(ranName string, procedureType models.RanProcedureType) {
+	switch procedureType {
+	case models.RicE2ResetProcedure:
+		errorIndicationHandler.e2ResetTransactionManager.Reject(ranName)
+	case models.RicServiceQueryProcedure:
+		errorIndicationHandler.ranProcedureTracker.Fail(ranName, procedureType)
+	default:
+		errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.abortProcedure - RAN name: %s - ErrorIndication occurred before successful outcome hence ignoring", ranName)
+	}
+}
+
+func (errorIndicationHandler *ErrorIndicationHandler) resetRan(ranName string, cause *models.Cause) {
+	resetCause, _ := models.GetE2ResetCause(models.E2ResetOmInterventionCause)
+	if cause != nil && cause.String() != "" {
+		resetCause = *cause
+	}
+
+	_, err := errorIndicationHandler.ricE2ResetManager.Reset(ranName, resetCause)
+	if err != nil {
+		errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.resetRan - RAN name: %s - E2 Reset failed due to error %+v", ranName, err)
+	}
+}
+
+func (errorIndicationHandler *ErrorIndicationHandler) disconnectRan(ranName string) {
+	err := errorIndicationHandler.ranDisconnectionManager.DisconnectRan(ranName)
+	if err != nil {
+		errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.disconnectRan - RAN name: %s - Disconnect RAN and updating the nodebInfo failed due to error %+v", ranName, err)
+	}
+}
+
+func (errorIndicationHandler *ErrorIndicationHandler) storeErrorIndication(ranName string, record *models.ErrorIndicationRecord) {
+	err := errorIndicationHandler.errorIndicationStore.Add(ranName, record, errorIndicationHandler.config.ErrorIndication.MaxStoredPerRan)
+	if err != nil {
+		errorIndicationHandler.logger.Errorf("#ErrorIndicationHandler.storeErrorIndication - RAN name: %s - failed storing Error Indication. Error: %s", ranName, err)
 	}
-	return nil
 }
 
 func (errorIndicationHandler *ErrorIndicationHandler) parseErrorIndication(payload []byte) (*models.ErrorIndicationMessage, error) {
@@ -145,12 +216,10 @@ func (errorIndicationHandler *ErrorIndicationHandler) parseErrorIndication(paylo
 	if pipInd < 0 {
 		return nil, common.NewInternalError(fmt.Errorf("#ErrorIndicationHandler.parseErrorIndication - Error parsing ERROR INDICATION failed extract Payload: no | separator found"))
 	}
-	errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.parseErrorIndication - payload: %s", payload)
-	errorIndicationHandler.logger.Infof("#ErrorIndicationHandler.parseErrorIndication - payload: %s", payload[pipInd+1:])
 	errorIndicationMessage := &models.ErrorIndicationMessage{}
 	err := xml.Unmarshal(utils.NormalizeXml(payload[pipInd+1:]), &errorIndicationMessage.E2APPDU)
 	if err != nil {
 		return nil, common.NewInternalError(fmt.Errorf("#ErrorIndicationHandler.parseErrorIndication - Error unmarshalling ERROR INDICATION payload: %x", payload))
 	}
 	return errorIndicationMessage, nil
-}
\ No newline at end of file
+}
@@ -57,6 +57,7 @@ func initErrorIndication(t *testing.T) (*ErrorIndicationHandler, *mocks.RnibRead
 		RnibWriter: configuration.RnibWriterConfig{
 			StateChangeMessageChannel: StateChangeMessageChannel,
 		},
+		E2Reset: configuration.E2ResetConfig{ResponseTimeoutSec: 10},
 		GlobalRicId: struct {
 			RicId string
 			Mcc   string
@@ -75,12 +76,20 @@ func initErrorIndication(t *testing.T) (*ErrorIndicationHandler, *mocks.RnibRead
 	ranListManagerMock := &mocks.RanListManagerMock{}
 
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, clients.NewAlarmManagerClient(logger, config, clients.NewHttpClient()))
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManagerMock, routingManagerClientMock, ranConnectStatusChangeManager)
 	ranDisconnectionManager := managers.NewRanDisconnectionManager(logger, configuration.ParseConfiguration(), rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager)
-	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService)
-	handler := ErrorIndicationNotificationHandler(logger, ranDisconnectionManager, RicServiceUpdateManager)
+	ranProcedureTracker := initRanProcedureTracker(logger, config)
+	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService, ranProcedureTracker, ranListManager)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(logger, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	ranResetManager := managers.NewRanResetManager(logger, rnibDataService, ranConnectStatusChangeManager)
+	changeStatusToConnectedRanManager := managers.NewChangeStatusToConnectedRanManager(logger, rnibDataService, ranConnectStatusChangeManager)
+	rmrSender := tests.InitRmrSender(rmrMessengerMock, logger)
+	ricE2ResetManager := managers.NewRicE2ResetManager(logger, rmrSender, rnibDataService, ranResetManager, changeStatusToConnectedRanManager, e2ResetTransactionManager)
+	errorIndicationStoreMock := &mocks.ErrorIndicationStoreMock{}
+	errorIndicationStoreMock.On("Add", RanNameForErrorIndication, mock.Anything, mock.Anything).Return(nil)
+	handler := ErrorIndicationNotificationHandler(logger, config, ranDisconnectionManager, RicServiceUpdateManager, ranProcedureTracker, e2ResetTransactionManager, ricE2ResetManager, errorIndicationStoreMock)
 
 	return handler, readerMock, writerMock, rmrMessengerMock, e2tInstancesManagerMock, routingManagerClientMock, ranListManager, RanDisconnectionManagerMock, ricServiceUpdateManagerMock,MockLogger,httpClientMock,ranListManagerMock
 }
@@ -131,7 +140,8 @@ func testErrorIndicationHandlerWhenConnectedRanSuccess(t *testing.T,xmlPath stri
 		AssociatedE2TInstanceAddress: E2tAddress,
 	}
 
-	models.UpdateProcedureType(RanNameForErrorIndication,models.E2SetupProcedureCompleted)
+	handler.ranProcedureTracker.Start(RanNameForErrorIndication, models.E2SetupProcedure, "1")
+	handler.ranProcedureTracker.Complete(RanNameForErrorIndication, models.E2SetupProcedure)
 	var rnibErr error
 	readerMock.On("GetNodeb", RanNameForErrorIndication).Return(origNodebInfo, rnibErr)
 	updatedNodebInfo1 := *origNodebInfo
@@ -222,8 +232,11 @@ func testErrorIndicationHandlerWhenConnectedRanSuccessServiceUpdate(t *testing.T
 			Mnc   string
 		}{Mcc: "327", Mnc: "94", RicId: "AACCE"}}
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
-	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService)
-	models.UpdateProcedureType(RanNameForErrorIndication,models.RicServiceUpdateCompleted)
+	ranProcedureTracker := initRanProcedureTracker(logger, config)
+	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService, ranProcedureTracker, managers.NewRanListManager(logger, rnibDataService))
+	ranProcedureTracker.Start(ranName, models.RicServiceUpdateProcedure, "1")
+	handler.ranProcedureTracker.Start(RanNameForErrorIndication, models.RicServiceUpdateProcedure, "1")
+	handler.ranProcedureTracker.Complete(RanNameForErrorIndication, models.RicServiceUpdateProcedure)
 
 	var rnibErr error
 	readerMock.On("GetNodeb", RanNameForErrorIndication).Return(origNodebInfo, rnibErr)
@@ -249,7 +262,6 @@ func testErrorIndicationHandlerWhenConnectedRanSuccessUnknownProcedureType(t *te
 	xml := utils.ReadXmlFile(t, xmlPath)
 	handler, readerMock, writerMock, _, _,_, _, _, _, _,httpClientMock,_ := initErrorIndication(t)
 
-	models.UpdateProcedureType(RanNameForErrorIndication,models.E2SetupProcedureNotInitiated)
 	notificationRequest := &models.NotificationRequest{RanName: RanNameForErrorIndication, Payload: append([]byte(e2SetupMsgPrefixErrorIndication), xml...)}
 	handler.Handle(notificationRequest)
 	readerMock.AssertExpectations(t)
@@ -261,7 +273,8 @@ func testErrorIndicationHandlerWhenConnectedRanSuccessUnhandlingProcedureType(t
 	xml := utils.ReadXmlFile(t, xmlPath)
 	handler, readerMock, writerMock, _, _,_, _, _, _, _,httpClientMock,_ := initErrorIndication(t)
 
-	models.UpdateProcedureType(RanNameForErrorIndication,models.E2SetupProcedureFailure)
+	handler.ranProcedureTracker.Start(RanNameForErrorIndication, models.E2SetupProcedure, "1")
+	handler.ranProcedureTracker.Fail(RanNameForErrorIndication, models.E2SetupProcedure)
 	notificationRequest := &models.NotificationRequest{RanName: RanNameForErrorIndication, Payload: append([]byte(e2SetupMsgPrefixErrorIndication), xml...)}
 
 	handler.Handle(notificationRequest)
@@ -292,3 +305,66 @@ func testErrorIndicationHandlerForDefaultProcedureCode(t *testing.T,xmlPath stri
 	writerMock.AssertExpectations(t)
 	httpClientMock.AssertExpectations(t)
 }
+
+func TestErrorIndicationHandlerLogActionDoesNotRevert(t *testing.T) {
+	xml := utils.ReadXmlFile(t, "../../tests/resources/errorIndication/errorIndicationForSetupRequest.xml")
+	handler, readerMock, writerMock, _, _, _, _, _, _, _, _, _ := initErrorIndication(t)
+	handler.actionPolicy = managers.NewErrorIndicationActionPolicy(&configuration.Configuration{
+		ErrorIndication: configuration.ErrorIndicationConfig{DefaultAction: "revert", CauseActions: map[string]string{"misc": "log"}},
+	})
+	handler.ranProcedureTracker.Start(RanNameForErrorIndication, models.E2SetupProcedure, "1")
+	handler.ranProcedureTracker.Complete(RanNameForErrorIndication, models.E2SetupProcedure)
+
+	notificationRequest := &models.NotificationRequest{RanName: RanNameForErrorIndication, Payload: append([]byte(e2SetupMsgPrefixErrorIndication), xml...)}
+	handler.Handle(notificationRequest)
+
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfo", mock.Anything)
+	errorIndicationStoreMock := handler.errorIndicationStore.(*mocks.ErrorIndicationStoreMock)
+	errorIndicationStoreMock.AssertCalled(t, "Add", RanNameForErrorIndication, mock.MatchedBy(func(record *models.ErrorIndicationRecord) bool {
+		return record.Action == models.ErrorIndicationActionLog && record.ProcedureType == models.E2SetupProcedure && record.Cause == "misc/om-intervention"
+	}), 0)
+}
+
+func TestErrorIndicationHandlerRejectsOngoingE2Reset(t *testing.T) {
+	xml := utils.ReadXmlFile(t, "../../tests/resources/errorIndication/errorIndicationForResetRequest.xml")
+	handler, readerMock, writerMock, _, _, _, _, _, _, _, _, _ := initErrorIndication(t)
+	nodebInfo := &entities.NodebInfo{RanName: RanNameForErrorIndication, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	readerMock.On("GetNodeb", RanNameForErrorIndication).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanNameForErrorIndication+"_CONNECTED").Return(nil)
+
+	transaction, err := handler.e2ResetTransactionManager.Start(RanNameForErrorIndication)
+	assert.Nil(t, err)
+
+	notificationRequest := &models.NotificationRequest{RanName: RanNameForErrorIndication, Payload: append([]byte(e2SetupMsgPrefixErrorIndication), xml...)}
+	handler.Handle(notificationRequest)
+
+	assert.False(t, transaction.Wait())
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodebInfo.ConnectionStatus)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(RanNameForErrorIndication, models.RicE2ResetProcedure).State)
+	writerMock.AssertExpectations(t)
+}
+
+func TestErrorIndicationHandlerKeepsOngoingE2ResetOnRanInitiatedE2ResetError(t *testing.T) {
+	xml := utils.ReadXmlFile(t, "../../tests/resources/errorIndication/errorIndicationForResetRequest.xml")
+	xml = bytes.Replace(xml, []byte("<initiating-message/>"), []byte("<successful-outcome/>"), 1)
+	handler, readerMock, writerMock, _, _, _, _, _, _, _, _, _ := initErrorIndication(t)
+	nodebInfo := &entities.NodebInfo{RanName: RanNameForErrorIndication, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	readerMock.On("GetNodeb", RanNameForErrorIndication).Return(nodebInfo, nil)
+
+	transaction, err := handler.e2ResetTransactionManager.Start(RanNameForErrorIndication)
+	assert.Nil(t, err)
+
+	notificationRequest := &models.NotificationRequest{RanName: RanNameForErrorIndication, Payload: append([]byte(e2SetupMsgPrefixErrorIndication), xml...)}
+	handler.Handle(notificationRequest)
+
+	select {
+	case <-transaction.Done():
+		t.Fatal("the E2 Reset requested by the RIC should still be in progress")
+	default:
+	}
+	assert.Equal(t, models.RanProcedureOngoing, handler.ranProcedureTracker.GetProcedure(RanNameForErrorIndication, models.RicE2ResetProcedure).State)
+	assert.Equal(t, entities.ConnectionStatus_UNDER_RESET, nodebInfo.ConnectionStatus)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, mock.Anything)
+	handler.e2ResetTransactionManager.Abort(RanNameForErrorIndication)
+}
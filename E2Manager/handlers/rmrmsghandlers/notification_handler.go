@@ -22,8 +22,22 @@ package rmrmsghandlers
 
 import (
 	"e2mgr/models"
+	"time"
 )
 
 type NotificationHandler interface {
 	Handle(*models.NotificationRequest)
 }
+
+// NotificationHandlerFunc adapts a function to a NotificationHandler
+type NotificationHandlerFunc func(*models.NotificationRequest)
+
+func (f NotificationHandlerFunc) Handle(request *models.NotificationRequest) {
+	f(request)
+}
+
+// NotificationScheduler hands a notification back to the dispatcher once the delay elapsed, so it is handled on the
+// shard of its RAN, in order with the other notifications of the RAN
+type NotificationScheduler interface {
+	DispatchAfter(delay time.Duration, handler NotificationHandler, request *models.NotificationRequest, messageType string)
+}
@@ -38,10 +38,11 @@ func NewRanLostConnectionHandler(logger *logger.Logger, ranDisconnectionManager
 	}
 }
 func (h *RanLostConnectionHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 
 	ranName := request.RanName
 
-	h.logger.Warnf("#RanLostConnectionHandler.Handle - RAN name: %s - Received lost connection notification", ranName)
+	log.Warnf("#RanLostConnectionHandler.Handle - RAN name: %s - Received lost connection notification", ranName)
 
 	_ = h.ranDisconnectionManager.DisconnectRan(ranName)
 }
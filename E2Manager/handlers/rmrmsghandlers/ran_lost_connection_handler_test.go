@@ -57,9 +57,10 @@ func setupLostConnectionHandlerTest(isSuccessfulHttpPost bool) (*RanLostConnecti
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, logger)
 	httpClientMock := &mocks.HttpClientMock{}
-	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(logger, config, httpClientMock)
+	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClientMock, alarmManagerClient)
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, alarmManagerClient)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager)
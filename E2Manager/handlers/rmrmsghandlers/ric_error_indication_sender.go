@@ -0,0 +1,31 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package rmrmsghandlers
+
+import (
+	"e2mgr/models"
+	"e2mgr/services/rmrsender"
+)
+
+// sendErrorIndication rejects an initiating message of the given procedure received from the RAN. A failure to send is
+// logged by the RmrSender and does not affect the handling of the message
+func sendErrorIndication(rmrSender *rmrsender.RmrSender, ranName string, procedureCode string, transactionId string, cause models.Cause) {
+	errorIndication := models.NewRicErrorIndicationMessage(transactionId, cause, models.NewCriticalityDiagnostics(procedureCode, transactionId))
+	_ = rmrSender.SendErrorIndication(ranName, errorIndication)
+}
@@ -20,6 +20,7 @@ package rmrmsghandlers
 
 import (
 	"bytes"
+	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/managers"
 	"e2mgr/models"
@@ -52,86 +53,152 @@ type functionDetails struct {
 
 type RicServiceUpdateHandler struct {
 	logger                  *logger.Logger
+	config                  *configuration.Configuration
 	rmrSender               *rmrsender.RmrSender
 	rNibDataService         services.RNibDataService
 	ranListManager          managers.RanListManager
 	RicServiceUpdateManager managers.IRicServiceUpdateManager
+	ranFunctionValidator    managers.IRanFunctionValidator
+	ranProcedureTracker     managers.IRanProcedureTracker
+	ricServiceQueryManager  managers.IRicServiceQueryManager
+	healthCheckJobManager   managers.IHealthCheckJobManager
 }
 
-func NewRicServiceUpdateHandler(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, ranListManager managers.RanListManager, RicServiceUpdateManager managers.IRicServiceUpdateManager) *RicServiceUpdateHandler {
+func NewRicServiceUpdateHandler(logger *logger.Logger, config *configuration.Configuration, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, ranListManager managers.RanListManager, RicServiceUpdateManager managers.IRicServiceUpdateManager, ranFunctionValidator managers.IRanFunctionValidator, ranProcedureTracker managers.IRanProcedureTracker, ricServiceQueryManager managers.IRicServiceQueryManager, healthCheckJobManager managers.IHealthCheckJobManager) *RicServiceUpdateHandler {
 	return &RicServiceUpdateHandler{
 		logger:                  logger,
+		config:                  config,
 		rmrSender:               rmrSender,
 		rNibDataService:         rNibDataService,
 		ranListManager:          ranListManager,
 		RicServiceUpdateManager: RicServiceUpdateManager,
+		ranFunctionValidator:    ranFunctionValidator,
+		ranProcedureTracker:     ranProcedureTracker,
+		ricServiceQueryManager:  ricServiceQueryManager,
+		healthCheckJobManager:   healthCheckJobManager,
 	}
 }
 
 func (h *RicServiceUpdateHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	ranName := request.RanName
-	h.logger.Infof("#RicServiceUpdateHandler.Handle - RAN name: %s - received RIC_SERVICE_UPDATE. Payload: %s", ranName, request.Payload)
+	log.Infof("#RicServiceUpdateHandler.Handle - RAN name: %s - received RIC_SERVICE_UPDATE. Payload: %s", ranName, request.Payload)
 
 	nodebInfo, err := h.rNibDataService.GetNodeb(ranName)
 	if err != nil {
 		_, ok := err.(*common.ResourceNotFoundError)
 		if !ok {
-			h.logger.Errorf("#RicServiceUpdateHandler.Handle - failed to get nodeB entity for ran name: %v due to RNIB Error: %s", ranName, err)
+			log.Errorf("#RicServiceUpdateHandler.Handle - failed to get nodeB entity for ran name: %v due to RNIB Error: %s", ranName, err)
 		} else {
-			h.logger.Errorf("#RicServiceUpdateHandler.Handle - nobeB entity of RanName:%s absent in RNIB. Error: %s", ranName, err)
+			log.Errorf("#RicServiceUpdateHandler.Handle - nobeB entity of RanName:%s absent in RNIB. Error: %s", ranName, err)
 		}
 		return
 	}
 
 	ricServiceUpdate, err := h.parseSetupRequest(request.Payload)
 	if err != nil {
-		h.logger.Errorf(err.Error())
+		log.Errorf(err.Error())
+		sendErrorIndication(h.rmrSender, ranName, models.ProcedureCode_id_RICserviceUpdate, "", models.NewTransferSyntaxErrorCause())
 		return
 	}
-	h.logger.Infof("#RicServiceUpdateHandler.Handle - RIC_SERVICE_UPDATE has been parsed successfully %+v", ricServiceUpdate)
-	h.RicServiceUpdateManager.StoreExistingRanFunctions(ranName)
-	h.logger.Infof("#RicServiceUpdate.Handle - Getting the ranFunctions before we do the RIC ServiceUpdate handling")
+	log.Infof("#RicServiceUpdateHandler.Handle - RIC_SERVICE_UPDATE has been parsed successfully %+v", ricServiceUpdate)
 
 	if len(ricServiceUpdate.E2APPDU.InitiatingMessage.Value.RICServiceUpdate.ProtocolIEs.RICServiceUpdateIEs) == 0 {
-		h.logger.Errorf("#RicServiceUpdateHandler.Handle - RAN name: %s - RICServiceUpdateIEs empty", ranName)
+		log.Errorf("#RicServiceUpdateHandler.Handle - RAN name: %s - RICServiceUpdateIEs empty", ranName)
+		sendErrorIndication(h.rmrSender, ranName, models.ProcedureCode_id_RICserviceUpdate, "", models.NewAbstractSyntaxErrorRejectCause())
+		return
+	}
+
+	transactionId := ricServiceUpdate.E2APPDU.InitiatingMessage.Value.RICServiceUpdate.ProtocolIEs.RICServiceUpdateIEs[0].Value.TransactionID
+
+	h.ranProcedureTracker.Start(ranName, models.RicServiceUpdateProcedure, transactionId)
+
+	if nodebInfo.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
+		log.Errorf("#RicServiceUpdateHandler.Handle - RAN name: %s - RIC_SERVICE_UPDATE received while RAN is %s", ranName, nodebInfo.ConnectionStatus)
+		h.rejectUpdate(nodebInfo, request, transactionId, models.NewMessageNotCompatibleWithReceiverStateCause())
 		return
 	}
 
-	ackFunctionIds := h.updateFunctions(ricServiceUpdate.E2APPDU.InitiatingMessage.Value.RICServiceUpdate.ProtocolIEs.RICServiceUpdateIEs, nodebInfo)
+	if nodebInfo.GetGnb() == nil {
+		log.Errorf("#RicServiceUpdateHandler.Handle - RAN name: %s - RIC_SERVICE_UPDATE received for a %s node without gNB configuration", ranName, nodebInfo.NodeType)
+		h.rejectUpdate(nodebInfo, request, transactionId, models.Cause{Misc: &models.CauseMisc{Unspecified: &struct{}{}}})
+		return
+	}
+
+	// The E2 node answers a RIC Service Query with a RIC Service Update carrying the same transaction id
+	if h.ricServiceQueryManager.HandleServiceUpdate(ranName, transactionId) {
+		log.Infof("#RicServiceUpdateHandler.Handle - RAN name: %s - RIC_SERVICE_UPDATE answers the RIC Service Query, transaction id: %s", ranName, transactionId)
+	}
+	h.healthCheckJobManager.HandleServiceUpdate(ranName, transactionId)
+	h.RicServiceUpdateManager.StoreExistingRanFunctions(ranName)
+	log.Infof("#RicServiceUpdate.Handle - Getting the ranFunctions before we do the RIC ServiceUpdate handling")
+
+	ackFunctionIds, rejectedFunctionIds := h.updateFunctions(ricServiceUpdate.E2APPDU.InitiatingMessage.Value.RICServiceUpdate.ProtocolIEs.RICServiceUpdateIEs, nodebInfo)
 	if len(ricServiceUpdate.E2APPDU.InitiatingMessage.Value.RICServiceUpdate.ProtocolIEs.RICServiceUpdateIEs) > 1 {
 		err = h.rNibDataService.UpdateNodebInfoAndPublish(nodebInfo)
 		if err != nil {
-			h.logger.Errorf("#RicServiceUpdateHandler.Handle - RAN name: %s - Failed at UpdateNodebInfoAndPublish. error: %s", nodebInfo.RanName, err)
+			log.Errorf("#RicServiceUpdateHandler.Handle - RAN name: %s - Failed at UpdateNodebInfoAndPublish. error: %s", nodebInfo.RanName, err)
+			h.ranProcedureTracker.Fail(ranName, models.RicServiceUpdateProcedure)
 			return
 		}
+		h.ranListManager.UpdateNbIdentityRanFunctions(ranName, nodebInfo.GetGnb().RanFunctions)
 	}
 
 	oldNbIdentity, newNbIdentity := h.ranListManager.UpdateHealthcheckTimeStampReceived(nodebInfo.RanName)
 	err = h.ranListManager.UpdateNbIdentities(nodebInfo.NodeType, []*entities.NbIdentity{oldNbIdentity}, []*entities.NbIdentity{newNbIdentity})
 	if err != nil {
-		h.logger.Errorf("#RicServiceUpdate.Handle - failed to Update NbIdentities: %s", err)
+		log.Errorf("#RicServiceUpdate.Handle - failed to Update NbIdentities: %s", err)
+		h.ranProcedureTracker.Fail(ranName, models.RicServiceUpdateProcedure)
 		return
 	}
 
-	updateAck := models.NewServiceUpdateAck(ackFunctionIds, ricServiceUpdate.E2APPDU.InitiatingMessage.Value.RICServiceUpdate.ProtocolIEs.RICServiceUpdateIEs[0].Value.TransactionID)
-	err = h.sendUpdateAck(updateAck, nodebInfo, request)
+	updateAck := models.NewServiceUpdateAck(ackFunctionIds, rejectedFunctionIds, transactionId)
+	err = h.sendUpdateAck(updateAck, rejectedFunctionIds, nodebInfo, request)
 	if err != nil {
-		h.logger.Errorf("#RicServiceUpdate.Handle - failed to send RIC_SERVICE_UPDATE_ACK message to RMR: %s", err)
+		log.Errorf("#RicServiceUpdate.Handle - failed to send RIC_SERVICE_UPDATE_ACK message to RMR: %s", err)
+		h.ranProcedureTracker.Fail(ranName, models.RicServiceUpdateProcedure)
 		return
 	}
 
-	h.logger.Infof("#RicServiceUpdate.Handle - Completed successfully")
-	models.UpdateProcedureType(ranName, models.RicServiceUpdateCompleted)
-	h.logger.Debugf("#RicServiceUpdateHandler.Handle  - updating the enum value to RicServiceUpdateCompleted completed")
+	log.Infof("#RicServiceUpdate.Handle - Completed successfully")
+	h.ranProcedureTracker.Complete(ranName, models.RicServiceUpdateProcedure)
+}
+
+// rejectUpdate answers with a RIC_SERVICE_UPDATE_FAILURE, the E2 node may retry after the configured time to wait
+func (h *RicServiceUpdateHandler) rejectUpdate(nodebInfo *entities.NodebInfo, request *models.NotificationRequest, transactionId string, cause models.Cause) {
+	updateFailure := models.NewServiceUpdateFailure(transactionId, cause, models.TimeToWait(h.config.RicServiceUpdate.TimeToWaitSec))
+	err := h.sendUpdateFailure(updateFailure, cause, nodebInfo, request)
+	if err != nil {
+		h.logger.Errorf("#RicServiceUpdate.rejectUpdate - RAN name: %s - failed to send RIC_SERVICE_UPDATE_FAILURE message to RMR: %s", nodebInfo.RanName, err)
+	}
+	h.ranProcedureTracker.Fail(nodebInfo.RanName, models.RicServiceUpdateProcedure)
 }
 
-func (h *RicServiceUpdateHandler) sendUpdateAck(updateAck models.RicServiceUpdateAckE2APPDU, nodebInfo *entities.NodebInfo, request *models.NotificationRequest) error {
+func (h *RicServiceUpdateHandler) sendUpdateFailure(updateFailure models.RicServiceUpdateFailureE2APPDU, cause models.Cause, nodebInfo *entities.NodebInfo, request *models.NotificationRequest) error {
+	payLoad, err := xml.Marshal(updateFailure)
+	if err != nil {
+		h.logger.Errorf("#RicServiceUpdate.sendUpdateFailure - RAN name: %s - Error marshalling RIC_SERVICE_UPDATE_FAILURE. Payload: %s", nodebInfo.RanName, payLoad)
+		return err
+	}
+
+	toReplaceTags := []string{"reject", "ignore", "v60s", "v20s", "v10s", "v5s", "v2s", "v1s", cause.ValueName()}
+	payLoad = utils.ReplaceEmptyTagsWithSelfClosing(payLoad, toReplaceTags)
+
+	h.logger.Infof("#RicServiceUpdate.sendUpdateFailure - Sending RIC_SERVICE_UPDATE_FAILURE to RAN name: %s with payload %s", nodebInfo.RanName, payLoad)
+	msg := models.NewRmrMessage(rmrCgo.RIC_SERVICE_UPDATE_FAILURE, nodebInfo.RanName, payLoad, request.TransactionId, request.GetMsgSrc())
+	return h.rmrSender.Send(msg)
+}
+
+func (h *RicServiceUpdateHandler) sendUpdateAck(updateAck models.RicServiceUpdateAckE2APPDU, rejectedFunctionIds []models.RicServiceRejectedRANFunctionIDItem, nodebInfo *entities.NodebInfo, request *models.NotificationRequest) error {
 	payLoad, err := xml.Marshal(updateAck)
 	if err != nil {
 		h.logger.Errorf("#RicServiceUpdate.sendUpdateAck - RAN name: %s - Error marshalling RIC_SERVICE_UPDATE_ACK. Payload: %s", nodebInfo.RanName, payLoad)
 	}
 
 	toReplaceTags := []string{"reject", "ignore", "procedureCode", "id", "RANfunctionID-Item", "RANfunctionsID-List"}
+	for _, rejectedFunctionId := range rejectedFunctionIds {
+		toReplaceTags = append(toReplaceTags, rejectedFunctionId.Cause.ValueName())
+	}
 	payLoad = utils.ReplaceEmptyTagsWithSelfClosing(payLoad, toReplaceTags)
 
 	h.logger.Infof("#RicServiceUpdate.sendUpdateAck - Sending RIC_SERVICE_UPDATE_ACK to RAN name: %s with payload %s", nodebInfo.RanName, payLoad)
@@ -140,50 +207,89 @@ func (h *RicServiceUpdateHandler) sendUpdateAck(updateAck models.RicServiceUpdat
 	return err
 }
 
-func (h *RicServiceUpdateHandler) updateFunctions(RICServiceUpdateIEs []models.RICServiceUpdateIEs, nodebInfo *entities.NodebInfo) []models.RicServiceAckRANFunctionIDItem {
+// updateFunctions applies the valid RAN function changes to the nodeb and returns the accepted and the rejected function ids
+func (h *RicServiceUpdateHandler) updateFunctions(RICServiceUpdateIEs []models.RICServiceUpdateIEs, nodebInfo *entities.NodebInfo) ([]models.RicServiceAckRANFunctionIDItem, []models.RicServiceRejectedRANFunctionIDItem) {
 	ranFunctions := nodebInfo.GetGnb().RanFunctions
 	RanFIdtoIdxMap := make(map[uint32]int)
 	var acceptedFunctionIds []models.RicServiceAckRANFunctionIDItem
+	var rejectedFunctionIds []models.RicServiceRejectedRANFunctionIDItem
 	functionsToBeDeleted := make(map[int]bool)
 
 	for index, ranFunction := range ranFunctions {
 		RanFIdtoIdxMap[ranFunction.RanFunctionId] = index
 	}
 
+	var allFunctionDetails []functionDetails
 	for _, ricServiceUpdateIE := range RICServiceUpdateIEs {
 		functionDetails, err := h.getFunctionDetails(ricServiceUpdateIE)
 		if err != nil {
 			h.logger.Errorf("#RicServiceUpdate.updateFunctions- GetFunctionDetails returned err: %s", err)
 		}
+		allFunctionDetails = append(allFunctionDetails, functionDetails...)
+	}
+
+	functionIdOccurrences := make(map[uint32]int)
+	for _, functionDetail := range allFunctionDetails {
+		functionIdOccurrences[functionDetail.functionId]++
+	}
+	rejectedDuplicates := make(map[uint32]bool)
+
+	for _, functionDetail := range allFunctionDetails {
+		functionChange, functionId, functionDefinition, functionRevision, functionOID := functionDetail.functionChange,
+			functionDetail.functionId, functionDetail.functionDefinition, functionDetail.functionRevision, functionDetail.functionOID
+		ranFIndex, ok := RanFIdtoIdxMap[functionId]
+
+		if functionIdOccurrences[functionId] > 1 {
+			if !rejectedDuplicates[functionId] {
+				rejectedDuplicates[functionId] = true
+				rejectedFunctionIds = h.appendRejected(nodebInfo.RanName, rejectedFunctionIds, h.ranFunctionValidator.RejectDuplicate(functionId))
+			}
+			continue
+		}
 
-		for _, functionDetail := range functionDetails {
-			functionChange, functionId, functionDefinition, functionRevision, functionOID := functionDetail.functionChange,
-				functionDetail.functionId, functionDetail.functionDefinition, functionDetail.functionRevision, functionDetail.functionOID
-			ranFIndex, ok := RanFIdtoIdxMap[functionId]
-			if !ok {
-				switch functionChange {
-				case RAN_FUNCTIONS_ADDED, RAN_FUNCTIONS_MODIFIED:
-					ranFunctions = append(ranFunctions, &entities.RanFunction{RanFunctionId: functionId,
-						RanFunctionDefinition: functionDefinition, RanFunctionRevision: functionRevision, RanFunctionOid: functionOID})
-				case RAN_FUNCTIONS_DELETED:
-					//Do nothing
-				}
-			} else {
-				switch functionChange {
-				case RAN_FUNCTIONS_ADDED, RAN_FUNCTIONS_MODIFIED:
-					ranFunctions[ranFIndex].RanFunctionDefinition = functionDefinition
-					ranFunctions[ranFIndex].RanFunctionRevision = functionRevision
-				case RAN_FUNCTIONS_DELETED:
-					functionsToBeDeleted[ranFIndex] = true
-				}
+		if functionChange != RAN_FUNCTIONS_DELETED {
+			var storedRanFunction *entities.RanFunction
+			if ok {
+				storedRanFunction = ranFunctions[ranFIndex]
+			}
+			candidate := &entities.RanFunction{RanFunctionId: functionId, RanFunctionDefinition: functionDefinition, RanFunctionRevision: functionRevision, RanFunctionOid: functionOID}
+			if storedRanFunction != nil && len(functionOID) == 0 {
+				candidate.RanFunctionOid = storedRanFunction.RanFunctionOid
+			}
+			if rejection := h.ranFunctionValidator.Validate(candidate, storedRanFunction); rejection != nil {
+				rejectedFunctionIds = h.appendRejected(nodebInfo.RanName, rejectedFunctionIds, rejection)
+				continue
+			}
+		}
+
+		if !ok {
+			switch functionChange {
+			case RAN_FUNCTIONS_ADDED, RAN_FUNCTIONS_MODIFIED:
+				ranFunctions = append(ranFunctions, &entities.RanFunction{RanFunctionId: functionId,
+					RanFunctionDefinition: functionDefinition, RanFunctionRevision: functionRevision, RanFunctionOid: functionOID})
+			case RAN_FUNCTIONS_DELETED:
+				//Do nothing
+			}
+		} else {
+			switch functionChange {
+			case RAN_FUNCTIONS_ADDED, RAN_FUNCTIONS_MODIFIED:
+				ranFunctions[ranFIndex].RanFunctionDefinition = functionDefinition
+				ranFunctions[ranFIndex].RanFunctionRevision = functionRevision
+			case RAN_FUNCTIONS_DELETED:
+				functionsToBeDeleted[ranFIndex] = true
 			}
-			serviceupdateAckFunctionId := models.RicServiceAckRANFunctionIDItem{RanFunctionID: functionId, RanFunctionRevision: functionRevision}
-			acceptedFunctionIds = append(acceptedFunctionIds, serviceupdateAckFunctionId)
 		}
+		serviceupdateAckFunctionId := models.RicServiceAckRANFunctionIDItem{RanFunctionID: functionId, RanFunctionRevision: functionRevision}
+		acceptedFunctionIds = append(acceptedFunctionIds, serviceupdateAckFunctionId)
 	}
 	finalranFunctions := h.remove(ranFunctions, functionsToBeDeleted)
 	nodebInfo.GetGnb().RanFunctions = finalranFunctions
-	return acceptedFunctionIds
+	return acceptedFunctionIds, rejectedFunctionIds
+}
+
+func (h *RicServiceUpdateHandler) appendRejected(ranName string, rejectedFunctionIds []models.RicServiceRejectedRANFunctionIDItem, rejection *managers.RanFunctionRejection) []models.RicServiceRejectedRANFunctionIDItem {
+	h.logger.Warnf("#RicServiceUpdate.updateFunctions - RAN name: %s - RAN function %d rejected: %s", ranName, rejection.RanFunctionId, rejection.Reason)
+	return append(rejectedFunctionIds, models.RicServiceRejectedRANFunctionIDItem{RanFunctionID: rejection.RanFunctionId, Cause: rejection.Cause})
 }
 
 func (h *RicServiceUpdateHandler) remove(ranFunctions []*entities.RanFunction, functionsToBeDeleted map[int]bool) []*entities.RanFunction {
@@ -29,6 +29,7 @@ import (
 	"e2mgr/utils"
 	"encoding/xml"
 	"fmt"
+	"strings"
 	"testing"
 
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
@@ -48,6 +49,7 @@ const (
 	RicServiceUpdateDeletePath      = "../../tests/resources/serviceUpdate/RicServiceUpdate_DeleteFunction.xml"
 	RicServiceUpdateAddedPath       = "../../tests/resources/serviceUpdate/RicServiceUpdate_AddedFunction.xml"
 	RicServiceUpdateEmptyPath       = "../../tests/resources/serviceUpdate/RicServiceUpdate_Empty.xml"
+	RicServiceUpdateRejectedPath    = "../../tests/resources/serviceUpdate/RicServiceUpdate_RejectedFunctions.xml"
 	RicServiceUpdateAckModifiedPath = "../../tests/resources/serviceUpdateAck/RicServiceUpdateAck_ModifiedFunction.xml"
 	RicServiceUpdateAckAddedPath    = "../../tests/resources/serviceUpdateAck/RicServiceUpdateAck_AddedFunction.xml"
 	RicServiceUpdateAckDeletePath   = "../../tests/resources/serviceUpdateAck/RicServiceUpdateAck_DeleteFunction.xml"
@@ -71,15 +73,18 @@ func initRicServiceUpdateHandler(t *testing.T) (*RicServiceUpdateHandler, *mocks
 			Mcc:   "337",
 			Mnc:   "94",
 			RicId: "AACCE",
-		}}
+		},
+		RicServiceUpdate: configuration.RicServiceUpdateConfig{TimeToWaitSec: 10}}
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrSender := tests.InitRmrSender(rmrMessengerMock, logger)
 	readerMock := &mocks.RnibReaderMock{}
 	writerMock := &mocks.RnibWriterMock{}
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	ranListManagerMock := &mocks.RanListManagerMock{}
-	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService)
-	handler := NewRicServiceUpdateHandler(logger, rmrSender, rnibDataService, ranListManagerMock, RicServiceUpdateManager)
+	ranProcedureTracker := initRanProcedureTracker(logger, config)
+	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService, ranProcedureTracker, ranListManagerMock)
+	ricServiceQueryManager := managers.NewRicServiceQueryManager(logger, config, rmrSender, rnibDataService, ranProcedureTracker)
+	handler := NewRicServiceUpdateHandler(logger, config, rmrSender, rnibDataService, ranListManagerMock, RicServiceUpdateManager, managers.NewRanFunctionValidator(config), ranProcedureTracker, ricServiceQueryManager, managers.NewHealthCheckJobManager(logger))
 	return handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock
 }
 
@@ -96,6 +101,33 @@ func TestRICServiceUpdateDeleteFuncSuccess(t *testing.T) {
 	testServiceUpdateSuccess(t, RicServiceUpdateDeletePath, RicServiceUpdateAckDeletePath)
 }
 
+func TestRICServiceUpdateCorrelatedWithRicServiceQuery(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock := initRicServiceUpdateHandler(t)
+	ricServiceQueryManagerMock := &mocks.RicServiceQueryManagerMock{}
+	handler.ricServiceQueryManager = ricServiceQueryManagerMock
+	healthCheckJobManagerMock := &mocks.HealthCheckJobManagerMock{}
+	handler.healthCheckJobManager = healthCheckJobManagerMock
+	xmlserviceUpdate := utils.ReadXmlFile(t, RicServiceUpdateModifiedPath)
+	xmlserviceUpdate = utils.CleanXML(xmlserviceUpdate)
+	nb1 := createNbInfo(t, serviceUpdateRANName, entities.ConnectionStatus_CONNECTED)
+	oldnbIdentity := &entities.NbIdentity{InventoryName: nb1.RanName, ConnectionStatus: nb1.ConnectionStatus}
+	newnbIdentity := &entities.NbIdentity{InventoryName: nb1.RanName, ConnectionStatus: nb1.ConnectionStatus}
+	readerMock.On("GetNodeb", nb1.RanName).Return(nb1, nil)
+	notificationRequest := &models.NotificationRequest{RanName: serviceUpdateRANName, Payload: append([]byte(serviceUpdateE2SetupMsgPrefix), xmlserviceUpdate...)}
+	ricServiceQueryManagerMock.On("HandleServiceUpdate", serviceUpdateRANName, "1234").Return(true)
+	healthCheckJobManagerMock.On("HandleServiceUpdate", serviceUpdateRANName, "1234").Return()
+	ranListManagerMock.On("UpdateHealthcheckTimeStampReceived", nb1.RanName).Return(oldnbIdentity, newnbIdentity)
+	ranListManagerMock.On("UpdateNbIdentities", nb1.NodeType, []*entities.NbIdentity{oldnbIdentity}, []*entities.NbIdentity{newnbIdentity}).Return(nil)
+	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityRanFunctions", serviceUpdateRANName, mock.Anything).Return()
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+
+	handler.Handle(notificationRequest)
+	ricServiceQueryManagerMock.AssertExpectations(t)
+	healthCheckJobManagerMock.AssertExpectations(t)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
 func TestRICServiceUpdateRnibFailure(t *testing.T) {
 	handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock := initRicServiceUpdateHandler(t)
 	xmlserviceUpdate := utils.ReadXmlFile(t, RicServiceUpdateDeletePath)
@@ -152,6 +184,7 @@ func TestSendRICServiceUpdateAckFailure(t *testing.T) {
 	ricServiceAckMsg := createRicServiceQueryAckRMRMbuf(t, RicServiceUpdateAckModifiedPath, notificationRequest)
 	ranListManagerMock.On("UpdateHealthcheckTimeStampReceived", nb1.RanName).Return(oldnbIdentity, newnbIdentity)
 	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityRanFunctions", serviceUpdateRANName, mock.Anything).Return()
 	rmrMessengerMock.On("SendMsg", ricServiceAckMsg, true).Return(&rmrCgo.MBuf{}, fmt.Errorf("rmr send failure"))
 	ranListManagerMock.On("UpdateNbIdentities", nb1.NodeType, []*entities.NbIdentity{oldnbIdentity}, []*entities.NbIdentity{newnbIdentity}).Return(nil)
 
@@ -160,6 +193,7 @@ func TestSendRICServiceUpdateAckFailure(t *testing.T) {
 	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
 	readerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(nb1.RanName, models.RicServiceUpdateProcedure).State)
 }
 
 func TestRICServiceUpdateUpdateNbIdentitiesFailure(t *testing.T) {
@@ -174,6 +208,7 @@ func TestRICServiceUpdateUpdateNbIdentitiesFailure(t *testing.T) {
 	ranListManagerMock.On("UpdateHealthcheckTimeStampReceived", nb1.RanName).Return(oldnbIdentity, newnbIdentity)
 	ranListManagerMock.On("UpdateNbIdentities", nb1.NodeType, []*entities.NbIdentity{oldnbIdentity}, []*entities.NbIdentity{newnbIdentity}).Return(common.NewInternalError(fmt.Errorf("internal error")))
 	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityRanFunctions", serviceUpdateRANName, mock.Anything).Return()
 
 	handler.Handle(notificationRequest)
 	writerMock.AssertExpectations(t)
@@ -201,6 +236,104 @@ func TestRICServiceUppdateParseRequest_UnmarshalFailure(t *testing.T) {
 	assert.EqualError(t, err, "#RicServiceUpdateHandler.parseSetupRequest - Error unmarshalling RIC SERVICE UPDATE payload: 31302e302e302e32373a393939397c010203")
 }
 
+func TestRICServiceUpdateUnmarshalFailureSendsErrorIndication(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, _ := initRicServiceUpdateHandler(t)
+	nb1 := createNbInfo(t, serviceUpdateRANName, entities.ConnectionStatus_CONNECTED)
+	readerMock.On("GetNodeb", nb1.RanName).Return(nb1, nil)
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isErrorIndicationMbuf("transfer-syntax-error")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: serviceUpdateRANName, Payload: append([]byte(serviceUpdateE2SetupMsgPrefix), 1, 2, 3)}
+
+	handler.Handle(notificationRequest)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoAndPublish", mock.Anything)
+}
+
+func TestRICServiceUpdateWrongStateSendsFailure(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock := initRicServiceUpdateHandler(t)
+	xmlserviceUpdate := utils.ReadXmlFile(t, RicServiceUpdateModifiedPath)
+	xmlserviceUpdate = utils.CleanXML(xmlserviceUpdate)
+	nb1 := createNbInfo(t, serviceUpdateRANName, entities.ConnectionStatus_DISCONNECTED)
+	readerMock.On("GetNodeb", nb1.RanName).Return(nb1, nil)
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isServiceUpdateFailureMbuf("message-not-compatible-with-receiver-state")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: serviceUpdateRANName, Payload: append([]byte(serviceUpdateE2SetupMsgPrefix), xmlserviceUpdate...)}
+
+	handler.Handle(notificationRequest)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoAndPublish", mock.Anything)
+	ranListManagerMock.AssertNotCalled(t, "UpdateHealthcheckTimeStampReceived", mock.Anything)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(serviceUpdateRANName, models.RicServiceUpdateProcedure).State)
+}
+
+func TestRICServiceUpdateEnbWithoutGnbConfigSendsFailure(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock := initRicServiceUpdateHandler(t)
+	xmlserviceUpdate := utils.ReadXmlFile(t, RicServiceUpdateModifiedPath)
+	xmlserviceUpdate = utils.CleanXML(xmlserviceUpdate)
+	nb1 := &entities.NodebInfo{RanName: serviceUpdateRANName, NodeType: entities.Node_ENB, ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+		Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{}}}
+	readerMock.On("GetNodeb", nb1.RanName).Return(nb1, nil)
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(isServiceUpdateFailureMbuf("unspecified")), true).Return(&rmrCgo.MBuf{}, nil)
+	notificationRequest := &models.NotificationRequest{RanName: serviceUpdateRANName, Payload: append([]byte(serviceUpdateE2SetupMsgPrefix), xmlserviceUpdate...)}
+
+	handler.Handle(notificationRequest)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertNotCalled(t, "UpdateNodebInfoAndPublish", mock.Anything)
+	ranListManagerMock.AssertNotCalled(t, "UpdateHealthcheckTimeStampReceived", mock.Anything)
+	assert.Equal(t, models.RanProcedureFailed, handler.ranProcedureTracker.GetProcedure(serviceUpdateRANName, models.RicServiceUpdateProcedure).State)
+}
+
+func TestRICServiceUpdateRejectsInvalidFunctions(t *testing.T) {
+	handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock := initRicServiceUpdateHandler(t)
+	xmlserviceUpdate := utils.ReadXmlFile(t, RicServiceUpdateRejectedPath)
+	xmlserviceUpdate = utils.CleanXML(xmlserviceUpdate)
+	nb1 := createNbInfo(t, serviceUpdateRANName, entities.ConnectionStatus_CONNECTED)
+	oldnbIdentity := &entities.NbIdentity{InventoryName: nb1.RanName, ConnectionStatus: nb1.ConnectionStatus}
+	newnbIdentity := &entities.NbIdentity{InventoryName: nb1.RanName, ConnectionStatus: nb1.ConnectionStatus}
+	readerMock.On("GetNodeb", nb1.RanName).Return(nb1, nil)
+	notificationRequest := &models.NotificationRequest{RanName: serviceUpdateRANName, Payload: append([]byte(serviceUpdateE2SetupMsgPrefix), xmlserviceUpdate...)}
+	ranListManagerMock.On("UpdateHealthcheckTimeStampReceived", nb1.RanName).Return(oldnbIdentity, newnbIdentity)
+	ranListManagerMock.On("UpdateNbIdentities", nb1.NodeType, []*entities.NbIdentity{oldnbIdentity}, []*entities.NbIdentity{newnbIdentity}).Return(nil)
+	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityRanFunctions", serviceUpdateRANName, mock.Anything).Return()
+	var ackPayload []byte
+	rmrMessengerMock.On("SendMsg", mock.MatchedBy(func(mbuf *rmrCgo.MBuf) bool { return mbuf.MType == rmrCgo.RIC_SERVICE_UPDATE_ACK }), true).Run(func(args mock.Arguments) {
+		ackPayload = *args.Get(0).(*rmrCgo.MBuf).Payload
+	}).Return(&rmrCgo.MBuf{}, nil)
+
+	handler.Handle(notificationRequest)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+	writerMock.AssertExpectations(t)
+
+	ranFunctions := map[uint32]*entities.RanFunction{}
+	for _, ranFunction := range nb1.GetGnb().RanFunctions {
+		ranFunctions[ranFunction.RanFunctionId] = ranFunction
+	}
+	assert.Equal(t, uint32(2), ranFunctions[17].RanFunctionRevision)
+	assert.NotContains(t, ranFunctions, uint32(21))
+	assert.NotContains(t, ranFunctions, uint32(22))
+	assert.Contains(t, ranFunctions, uint32(23))
+
+	ack := string(ackPayload)
+	assert.Contains(t, ack, "<RANfunctionID-Item><ranFunctionID>23</ranFunctionID><ranFunctionRevision>1</ranFunctionRevision></RANfunctionID-Item>")
+	assert.Contains(t, ack, "<RANfunctionIDcause-Item><ranFunctionID>17</ranFunctionID><cause><protocol><semantic-error/></protocol></cause></RANfunctionIDcause-Item>")
+	assert.Contains(t, ack, "<RANfunctionIDcause-Item><ranFunctionID>21</ranFunctionID><cause><protocol><semantic-error/></protocol></cause></RANfunctionIDcause-Item>")
+	assert.Contains(t, ack, "<RANfunctionIDcause-Item><ranFunctionID>22</ranFunctionID><cause><protocol><abstract-syntax-error-falsely-constructed-message/></protocol></cause></RANfunctionIDcause-Item>")
+	assert.Equal(t, 1, strings.Count(ack, "<RANfunctionIDcause-Item><ranFunctionID>21</ranFunctionID>"))
+	assert.Equal(t, models.RanProcedureCompleted, handler.ranProcedureTracker.GetProcedure(serviceUpdateRANName, models.RicServiceUpdateProcedure).State)
+}
+
+func isServiceUpdateFailureMbuf(cause string) func(*rmrCgo.MBuf) bool {
+	return func(mbuf *rmrCgo.MBuf) bool {
+		return mbuf.MType == rmrCgo.RIC_SERVICE_UPDATE_FAILURE && bytes.Contains(*mbuf.Payload, []byte("<"+cause+"/>")) &&
+			bytes.Contains(*mbuf.Payload, []byte("<TimeToWait><v10s/></TimeToWait>")) && bytes.Contains(*mbuf.Payload, []byte("<TransactionID>1234</TransactionID>"))
+	}
+}
+
+func isErrorIndicationMbuf(cause string) func(*rmrCgo.MBuf) bool {
+	return func(mbuf *rmrCgo.MBuf) bool {
+		return mbuf.MType == rmrCgo.RIC_E2_RIC_ERROR_INDICATION && bytes.Contains(*mbuf.Payload, []byte("<"+cause+"/>"))
+	}
+}
+
 func testServiceUpdateSuccess(t *testing.T, servicepdatePath string, serviceUpdateAckPath string) {
 	handler, readerMock, writerMock, rmrMessengerMock, ranListManagerMock := initRicServiceUpdateHandler(t)
 	xmlserviceUpdate := utils.ReadXmlFile(t, servicepdatePath)
@@ -216,6 +349,7 @@ func testServiceUpdateSuccess(t *testing.T, servicepdatePath string, serviceUpda
 	ranListManagerMock.On("UpdateNbIdentities", nb1.NodeType, []*entities.NbIdentity{oldnbIdentity},
 		[]*entities.NbIdentity{newnbIdentity}).Return(nil)
 	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityRanFunctions", serviceUpdateRANName, mock.Anything).Return()
 	rmrMessengerMock.On("SendMsg", ricServiceAckMsg, true).Return(&rmrCgo.MBuf{}, nil)
 
 	handler.Handle(notificationRequest)
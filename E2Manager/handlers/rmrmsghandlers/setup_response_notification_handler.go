@@ -56,20 +56,21 @@ func NewSetupResponseNotificationHandler(logger *logger.Logger, rnibDataService
 }
 
 func (h SetupResponseNotificationHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	msgName := msgTypeToMsgName[h.msgType]
-	h.logger.Infof("#SetupResponseNotificationHandler - RAN name: %s - Received %s notification", request.RanName, msgName)
+	log.Infof("#SetupResponseNotificationHandler - RAN name: %s - Received %s notification", request.RanName, msgName)
 
 	inventoryName := request.RanName
 
 	nodebInfo, rnibErr := h.rnibDataService.GetNodeb(inventoryName)
 
 	if rnibErr != nil {
-		h.logger.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Error fetching RAN from rNib: %v", request.RanName, rnibErr)
+		log.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Error fetching RAN from rNib: %v", request.RanName, rnibErr)
 		return
 	}
 
 	if !isConnectionStatusValid(nodebInfo.ConnectionStatus) {
-		h.logger.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Invalid RAN connection status: %s", request.RanName, nodebInfo.ConnectionStatus)
+		log.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Invalid RAN connection status: %s", request.RanName, nodebInfo.ConnectionStatus)
 		return
 	}
 
@@ -83,12 +84,12 @@ func (h SetupResponseNotificationHandler) Handle(request *models.NotificationReq
 	rnibErr = h.rnibDataService.SaveNodeb(nodebInfo)
 
 	if rnibErr != nil {
-		h.logger.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Error saving RAN to rNib: %v", request.RanName, rnibErr)
+		log.Errorf("#SetupResponseNotificationHandler - RAN name: %s - Error saving RAN to rNib: %v", request.RanName, rnibErr)
 		return
 	}
 
-	h.logger.Infof("#SetupResponseNotificationHandler - RAN name: %s - Successfully saved RAN to rNib", request.RanName)
-	h.logger.Infof("#SetupResponseNotificationHandler - Summary: elapsed time for receiving and handling setup response message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
+	log.Infof("#SetupResponseNotificationHandler - RAN name: %s - Successfully saved RAN to rNib", request.RanName)
+	log.Infof("#SetupResponseNotificationHandler - Summary: elapsed time for receiving and handling setup response message from E2 terminator: %f ms", utils.ElapsedTime(request.StartTime))
 
 	if !isSuccessSetupResponseMessage(h.msgType) {
 		return
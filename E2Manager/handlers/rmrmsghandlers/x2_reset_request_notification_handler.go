@@ -57,33 +57,34 @@ func NewX2ResetRequestNotificationHandler(logger *logger.Logger, rnibDataService
 }
 
 func (h X2ResetRequestNotificationHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 
-	h.logger.Infof("#X2ResetRequestNotificationHandler.Handle - Ran name: %s", request.RanName)
+	log.Infof("#X2ResetRequestNotificationHandler.Handle - Ran name: %s", request.RanName)
 
 	nb, rNibErr := h.rnibDataService.GetNodeb(request.RanName)
 	if rNibErr != nil {
-		h.logger.Errorf("#X2ResetRequestNotificationHandler.Handle - failed to retrieve nodeB entity. RanName: %s. Error: %s", request.RanName, rNibErr.Error())
-		h.logger.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+		log.Errorf("#X2ResetRequestNotificationHandler.Handle - failed to retrieve nodeB entity. RanName: %s. Error: %s", request.RanName, rNibErr.Error())
+		log.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 		return
 	}
 
-	h.logger.Debugf("#X2ResetRequestNotificationHandler.Handle - nodeB entity retrieved. RanName %s, ConnectionStatus %s", nb.RanName, nb.ConnectionStatus)
+	log.Debugf("#X2ResetRequestNotificationHandler.Handle - nodeB entity retrieved. RanName %s, ConnectionStatus %s", nb.RanName, nb.ConnectionStatus)
 
 	if nb.ConnectionStatus == entities.ConnectionStatus_SHUTTING_DOWN {
-		h.logger.Warnf("#X2ResetRequestNotificationHandler.Handle - nodeB entity in incorrect state. RanName %s, ConnectionStatus %s", nb.RanName, nb.ConnectionStatus)
-		h.logger.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+		log.Warnf("#X2ResetRequestNotificationHandler.Handle - nodeB entity in incorrect state. RanName %s, ConnectionStatus %s", nb.RanName, nb.ConnectionStatus)
+		log.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 		return
 	}
 
 	if nb.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
-		h.logger.Errorf("#X2ResetRequestNotificationHandler.Handle - nodeB entity in incorrect state. RanName %s, ConnectionStatus %s", nb.RanName, nb.ConnectionStatus)
-		h.logger.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+		log.Errorf("#X2ResetRequestNotificationHandler.Handle - nodeB entity in incorrect state. RanName %s, ConnectionStatus %s", nb.RanName, nb.ConnectionStatus)
+		log.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 		return
 	}
 
 	msg := models.NewRmrMessage(rmrCgo.RIC_X2_RESET_RESP, request.RanName, e2pdus.PackedX2ResetResponse, request.TransactionId, request.GetMsgSrc())
 
 	_ = h.rmrSender.Send(msg)
-	h.logger.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+	log.Infof(ResetRequesLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 	_ = h.ranStatusChangeManager.Execute(rmrCgo.RAN_RESTARTED, enums.RAN_TO_RIC, nb)
 }
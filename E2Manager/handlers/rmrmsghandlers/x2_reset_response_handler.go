@@ -39,48 +39,53 @@ import (
 const ResetResponseLogInfoElapsedTime = "#X2ResetResponseHandler.Handle - Summary: elapsed time for receiving and handling reset request message from E2 terminator: %f ms"
 
 type X2ResetResponseHandler struct {
-	logger                 *logger.Logger
-	rnibDataService        services.RNibDataService
-	ranStatusChangeManager managers.IRanStatusChangeManager
-	extractor              converters.IX2ResetResponseExtractor
+	logger                    *logger.Logger
+	rnibDataService           services.RNibDataService
+	ranStatusChangeManager    managers.IRanStatusChangeManager
+	extractor                 converters.IX2ResetResponseExtractor
+	x2ResetTransactionManager managers.IX2ResetTransactionManager
 }
 
-func NewX2ResetResponseHandler(logger *logger.Logger, rnibDataService services.RNibDataService, ranStatusChangeManager managers.IRanStatusChangeManager, x2ResetResponseExtractor converters.IX2ResetResponseExtractor) X2ResetResponseHandler {
+func NewX2ResetResponseHandler(logger *logger.Logger, rnibDataService services.RNibDataService, ranStatusChangeManager managers.IRanStatusChangeManager, x2ResetResponseExtractor converters.IX2ResetResponseExtractor, x2ResetTransactionManager managers.IX2ResetTransactionManager) X2ResetResponseHandler {
 	return X2ResetResponseHandler{
-		logger:                 logger,
-		rnibDataService:        rnibDataService,
-		ranStatusChangeManager: ranStatusChangeManager,
-		extractor:              x2ResetResponseExtractor,
+		logger:                    logger,
+		rnibDataService:           rnibDataService,
+		ranStatusChangeManager:    ranStatusChangeManager,
+		extractor:                 x2ResetResponseExtractor,
+		x2ResetTransactionManager: x2ResetTransactionManager,
 	}
 }
 
 func (h X2ResetResponseHandler) Handle(request *models.NotificationRequest) {
+	log := h.logger.With(logger.RequestId(request.RequestId), logger.RanName(request.RanName))
 	ranName := request.RanName
-	h.logger.Infof("#X2ResetResponseHandler.Handle - RAN name: %s - received reset response. Payload: %x", ranName, request.Payload)
+	log.Infof("#X2ResetResponseHandler.Handle - RAN name: %s - received reset response. Payload: %x", ranName, request.Payload)
+
+	// The response ends the outstanding RIC initiated X2 Reset whatever the state of the RAN
+	isSuccessfulResetResponse, _ := h.isSuccessfulResetResponse(ranName, request.Payload)
+	h.x2ResetTransactionManager.Complete(ranName, isSuccessfulResetResponse)
 
 	nodebInfo, err := h.rnibDataService.GetNodeb(ranName)
 	if err != nil {
-		h.logger.Errorf("#x2ResetResponseHandler.Handle - RAN name: %s - failed to retrieve nodebInfo entity. Error: %s", ranName, err)
+		log.Errorf("#x2ResetResponseHandler.Handle - RAN name: %s - failed to retrieve nodebInfo entity. Error: %s", ranName, err)
 		return
 	}
 
 	if nodebInfo.ConnectionStatus == entities.ConnectionStatus_SHUTTING_DOWN {
-		h.logger.Warnf("#X2ResetResponseHandler.Handle - RAN name: %s, connection status: %s - nodeB entity in incorrect state", nodebInfo.RanName, nodebInfo.ConnectionStatus)
-		h.logger.Infof(ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+		log.Warnf("#X2ResetResponseHandler.Handle - RAN name: %s, connection status: %s - nodeB entity in incorrect state", nodebInfo.RanName, nodebInfo.ConnectionStatus)
+		log.Infof(ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 		return
 	}
 
 	if nodebInfo.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
-		h.logger.Errorf("#X2ResetResponseHandler.Handle - RAN name: %s, connection status: %s - nodeB entity in incorrect state", nodebInfo.RanName, nodebInfo.ConnectionStatus)
-		h.logger.Infof(ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+		log.Errorf("#X2ResetResponseHandler.Handle - RAN name: %s, connection status: %s - nodeB entity in incorrect state", nodebInfo.RanName, nodebInfo.ConnectionStatus)
+		log.Infof(ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 		return
 	}
 
-	isSuccessfulResetResponse, err := h.isSuccessfulResetResponse(ranName, request.Payload)
-
-	h.logger.Infof(ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
+	log.Infof(ResetResponseLogInfoElapsedTime, utils.ElapsedTime(request.StartTime))
 
-	if err != nil || !isSuccessfulResetResponse {
+	if !isSuccessfulResetResponse {
 		return
 	}
 
@@ -44,9 +44,14 @@ const (
 )
 
 func initX2ResetResponseHandlerTest(t *testing.T) (X2ResetResponseHandler, *mocks.RnibReaderMock, *mocks.RmrMessengerMock) {
+	h, readerMock, rmrMessengerMock, _ := initX2ResetResponseHandlerWithTransactionTest(t)
+	return h, readerMock, rmrMessengerMock
+}
+
+func initX2ResetResponseHandlerWithTransactionTest(t *testing.T) (X2ResetResponseHandler, *mocks.RnibReaderMock, *mocks.RmrMessengerMock, *managers.X2ResetTransactionManager) {
 	InfoLevel := int8(3)
 	log, err := logger.InitLogger(InfoLevel)
-	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, X2Reset: configuration.X2ResetConfig{TimeoutSec: 10}}
 	if err != nil {
 		t.Errorf("#initX2ResetResponseHandlerTest - failed to initialize logger, error: %s", err)
 	}
@@ -58,8 +63,10 @@ func initX2ResetResponseHandlerTest(t *testing.T) (X2ResetResponseHandler, *mock
 	rmrSender := initRmrSender(rmrMessengerMock, log)
 	ranStatusChangeManager := managers.NewRanStatusChangeManager(log, rmrSender)
 
-	h := NewX2ResetResponseHandler(log, rnibDataService, ranStatusChangeManager, converters.NewX2ResetResponseExtractor(log))
-	return h, readerMock, rmrMessengerMock
+	x2ResetTransactionManager := managers.NewX2ResetTransactionManager(log, config, initRanProcedureTracker(log, config))
+
+	h := NewX2ResetResponseHandler(log, rnibDataService, ranStatusChangeManager, converters.NewX2ResetResponseExtractor(log), x2ResetTransactionManager)
+	return h, readerMock, rmrMessengerMock, x2ResetTransactionManager
 }
 
 func TestX2ResetResponseSuccess(t *testing.T) {
@@ -184,3 +191,50 @@ func TestX2ResetResponseUnpackFailure(t *testing.T) {
 	h.Handle(&notificationRequest)
 	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
 }
+
+func TestX2ResetResponseCompletesTransaction(t *testing.T) {
+	h, readerMock, rmrMessengerMock, x2ResetTransactionManager := initX2ResetResponseHandlerWithTransactionTest(t)
+	var payload []byte
+	_, err := fmt.Sscanf(SuccessfulX2ResetResponsePackedPdu, "%x", &payload)
+	if err != nil {
+		t.Fatalf("Failed converting packed pdu. Error: %v\n", err)
+	}
+
+	transaction, _ := x2ResetTransactionManager.Start(RanName, "misc:om-intervention")
+
+	var xAction []byte
+	notificationRequest := models.NotificationRequest{RanName: RanName, Len: len(payload), Payload: payload, StartTime: time.Now(), TransactionId: xAction}
+	nb := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED, NodeType: entities.Node_ENB}
+	var rnibErr error
+	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
+	ranRestartedMbuf := getRanRestartedMbuf(nb.NodeType, enums.RIC_TO_RAN)
+	rmrMessengerMock.On("SendMsg", ranRestartedMbuf, true).Return(&rmrCgo.MBuf{}, err)
+	h.Handle(&notificationRequest)
+
+	if status := transaction.Wait(); status != models.X2ResetSucceeded {
+		t.Errorf("#TestX2ResetResponseCompletesTransaction - expected status %s, got %s", models.X2ResetSucceeded, status)
+	}
+}
+
+func TestX2ResetResponseErrorFailsTransaction(t *testing.T) {
+	h, readerMock, rmrMessengerMock, x2ResetTransactionManager := initX2ResetResponseHandlerWithTransactionTest(t)
+	var payload []byte
+	_, err := fmt.Sscanf(UnsuccessfulX2ResetResponsePackedPdu, "%x", &payload)
+	if err != nil {
+		t.Fatalf("Failed converting packed pdu. Error: %v\n", err)
+	}
+
+	transaction, _ := x2ResetTransactionManager.Start(RanName, "misc:om-intervention")
+
+	var xAction []byte
+	notificationRequest := models.NotificationRequest{RanName: RanName, Len: len(payload), Payload: payload, StartTime: time.Now(), TransactionId: xAction}
+	nb := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_CONNECTED, NodeType: entities.Node_ENB}
+	var rnibErr error
+	readerMock.On("GetNodeb", RanName).Return(nb, rnibErr)
+	h.Handle(&notificationRequest)
+
+	if status := transaction.Wait(); status != models.X2ResetFailed {
+		t.Errorf("#TestX2ResetResponseErrorFailsTransaction - expected status %s, got %s", models.X2ResetFailed, status)
+	}
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg")
+}
@@ -21,31 +21,98 @@
 package httpserver
 
 import (
+	"context"
 	"e2mgr/controllers"
 	"e2mgr/logger"
+	"e2mgr/metrics"
 	"fmt"
 	"github.com/gorilla/mux"
 	"net/http"
+	"time"
 )
 
-func Run(log *logger.Logger, port int, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, symptomdataController controllers.ISymptomdataController) error {
+const RequestIdHeader = "X-Request-Id"
+
+// Run serves HTTP requests until the context is done, then waits up to shutdownTimeout for the requests in progress to complete
+func Run(ctx context.Context, log *logger.Logger, port int, shutdownTimeout time.Duration, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, symptomdataController controllers.ISymptomdataController, adminController controllers.IAdminController) error {
 
 	router := mux.NewRouter()
-	initializeRoutes(router, rootController, nodebController, e2tController, symptomdataController)
+	initializeRoutes(router, rootController, nodebController, e2tController, symptomdataController, adminController)
+	router.Use(requestIdMiddleware(log))
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: router,
+	}
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErrors:
+		log.Errorf("#http_server.Run - Fail initiating HTTP server. Error: %v", err)
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Infof("#http_server.Run - shutting down HTTP server, timeout: %s", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		log.Errorf("#http_server.Run - HTTP server did not shut down gracefully. Error: %v", err)
+		return err
+	}
+
+	log.Infof("#http_server.Run - HTTP server stopped")
+	return nil
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// requestIdMiddleware gives every request a correlation id, taken from the X-Request-Id header when the client sets it.
+// The id is returned in the response header and attached to the request context
+func requestIdMiddleware(log *logger.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requestId := request.Header.Get(RequestIdHeader)
+			if requestId == "" {
+				requestId = logger.NewRequestId()
+			}
 
-	addr := fmt.Sprintf(":%d", port)
+			writer.Header().Set(RequestIdHeader, requestId)
+			recorder := &statusRecorder{ResponseWriter: writer, status: http.StatusOK}
 
-	err := http.ListenAndServe(addr, router)
+			start := time.Now()
+			next.ServeHTTP(recorder, request.WithContext(logger.ContextWithRequestId(request.Context(), requestId)))
 
-	log.Errorf("#http_server.Run - Fail initiating HTTP server. Error: %v", err)
-	return err
+			log.With(logger.RequestId(requestId)).Infof("#http_server.requestIdMiddleware - %s %s - status: %d, duration: %s", request.Method, request.URL.Path, recorder.status, time.Since(start))
+		})
+	}
 }
 
-func initializeRoutes(router *mux.Router, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, symptomdataController controllers.ISymptomdataController) {
+func initializeRoutes(router *mux.Router, rootController controllers.IRootController, nodebController controllers.INodebController, e2tController controllers.IE2TController, symptomdataController controllers.ISymptomdataController, adminController controllers.IAdminController) {
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+
 	r := router.PathPrefix("/v1").Subrouter()
 	r.HandleFunc("/health", rootController.HandleHealthCheckRequest).Methods(http.MethodGet)
+	r.HandleFunc("/health/ready", rootController.HandleReadinessRequest).Methods(http.MethodGet)
+	r.HandleFunc("/health/alive", rootController.HandleLivenessRequest).Methods(http.MethodGet)
 
 	rr := r.PathPrefix("/nodeb").Subrouter()
+	rr.HandleFunc("", nodebController.GetNodebByGlobalNbId).Methods(http.MethodGet)
 	rr.HandleFunc("/states", nodebController.GetNodebIdList).Methods(http.MethodGet)
 	rr.HandleFunc("/states/{ranName}", nodebController.GetNodebId).Methods(http.MethodGet)
 	rr.HandleFunc("/{ranName}", nodebController.GetNodeb).Methods(http.MethodGet)
@@ -54,10 +121,24 @@ func initializeRoutes(router *mux.Router, rootController controllers.IRootContro
 	rr.HandleFunc("/gnb/{ranName}", nodebController.UpdateGnb).Methods(http.MethodPut)
 	rr.HandleFunc("/enb/{ranName}", nodebController.UpdateEnb).Methods(http.MethodPut)
 	rr.HandleFunc("/shutdown", nodebController.Shutdown).Methods(http.MethodPut)
+	rr.HandleFunc("/{ranName}/reset", nodebController.E2Reset).Methods(http.MethodPut)
+	rr.HandleFunc("/{ranName}/x2reset", nodebController.X2Reset).Methods(http.MethodPut)
+	rr.HandleFunc("/x2reset/{jobId}", nodebController.GetX2ResetJob).Methods(http.MethodGet)
+	rr.HandleFunc("/{ranName}/errorindications", nodebController.GetErrorIndications).Methods(http.MethodGet)
+	rr.HandleFunc("/{ranName}/servicequery", nodebController.RicServiceQuery).Methods(http.MethodPost)
+	rr.HandleFunc("/servicequery/report", nodebController.GetRicServiceQueryReport).Methods(http.MethodGet)
 	rr.HandleFunc("/parameters", nodebController.SetGeneralConfiguration).Methods(http.MethodPut)
 	rr.HandleFunc("/health", nodebController.HealthCheckRequest).Methods(http.MethodPut)
+	rr.HandleFunc("/health/{jobId}", nodebController.GetHealthCheckJob).Methods(http.MethodGet)
+	r.HandleFunc("/cells/{cellId}", nodebController.GetCell).Methods(http.MethodGet)
+	r.HandleFunc("/ranfunctions", nodebController.GetRanFunctions).Methods(http.MethodGet)
+	r.HandleFunc("/ranfunctions/{oid}/nodes", nodebController.GetRanFunctionNodes).Methods(http.MethodGet)
 	rrr := r.PathPrefix("/e2t").Subrouter()
 	rrr.HandleFunc("/list", e2tController.GetE2TInstances).Methods(http.MethodGet)
 
 	r.HandleFunc("/symptomdata", symptomdataController.GetSymptomData).Methods(http.MethodGet)
+
+	ra := r.PathPrefix("/admin").Subrouter()
+	ra.HandleFunc("/loglevel", adminController.GetLogLevel).Methods(http.MethodGet)
+	ra.HandleFunc("/loglevel", adminController.SetLogLevel).Methods(http.MethodPut)
 }
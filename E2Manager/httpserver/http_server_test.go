@@ -21,6 +21,7 @@
 package httpserver
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/mocks"
 	"github.com/gorilla/mux"
@@ -32,19 +33,37 @@ import (
 )
 
 func setupRouterAndMocks() (*mux.Router, *mocks.RootControllerMock, *mocks.NodebControllerMock, *mocks.E2TControllerMock, *mocks.SymptomdataControllerMock) {
+	router, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock, _ := setupRouterAndAllMocks()
+	return router, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock
+}
+
+func setupRouterAndAllMocks() (*mux.Router, *mocks.RootControllerMock, *mocks.NodebControllerMock, *mocks.E2TControllerMock, *mocks.SymptomdataControllerMock, *mocks.AdminControllerMock) {
 	rootControllerMock := &mocks.RootControllerMock{}
 	rootControllerMock.On("HandleHealthCheckRequest").Return(nil)
+	rootControllerMock.On("HandleReadinessRequest").Return(nil)
+	rootControllerMock.On("HandleLivenessRequest").Return(nil)
 
 	nodebControllerMock := &mocks.NodebControllerMock{}
 	nodebControllerMock.On("Shutdown").Return(nil)
+	nodebControllerMock.On("E2Reset").Return(nil)
+	nodebControllerMock.On("X2Reset").Return(nil)
 	nodebControllerMock.On("GetNodeb").Return(nil)
 	nodebControllerMock.On("GetNodebIdList").Return(nil)
 	nodebControllerMock.On("GetNodebId").Return(nil)
+	nodebControllerMock.On("GetErrorIndications").Return(nil)
+	nodebControllerMock.On("RicServiceQuery").Return(nil)
+	nodebControllerMock.On("GetRicServiceQueryReport").Return(nil)
 	nodebControllerMock.On("SetGeneralConfiguration").Return(nil)
 	nodebControllerMock.On("DeleteEnb").Return(nil)
 	nodebControllerMock.On("AddEnb").Return(nil)
 	nodebControllerMock.On("UpdateEnb").Return(nil)
 	nodebControllerMock.On("HealthCheckRequest").Return(nil)
+	nodebControllerMock.On("GetHealthCheckJob").Return(nil)
+	nodebControllerMock.On("GetX2ResetJob").Return(nil)
+	nodebControllerMock.On("GetNodebByGlobalNbId").Return(nil)
+	nodebControllerMock.On("GetCell").Return(nil)
+	nodebControllerMock.On("GetRanFunctions").Return(nil)
+	nodebControllerMock.On("GetRanFunctionNodes").Return(nil)
 
 	e2tControllerMock := &mocks.E2TControllerMock{}
 	e2tControllerMock.On("GetE2TInstances").Return(nil)
@@ -52,9 +71,13 @@ func setupRouterAndMocks() (*mux.Router, *mocks.RootControllerMock, *mocks.Nodeb
 	symptomdataControllerMock := &mocks.SymptomdataControllerMock{}
 	symptomdataControllerMock.On("GetSymptomData").Return(nil)
 
+	adminControllerMock := &mocks.AdminControllerMock{}
+	adminControllerMock.On("GetLogLevel").Return(nil)
+	adminControllerMock.On("SetLogLevel").Return(nil)
+
 	router := mux.NewRouter()
-	initializeRoutes(router, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock)
-	return router, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock
+	initializeRoutes(router, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock, adminControllerMock)
+	return router, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock, adminControllerMock
 }
 
 func TestRouteGetNodebIdList(t *testing.T) {
@@ -99,6 +122,49 @@ func TestRouteGetNodebRanName(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "GetNodeb", 1)
 }
 
+func TestRouteGetErrorIndications(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb/ran1/errorindications", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetErrorIndications", 1)
+}
+
+func TestRouteRicServiceQuery(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("POST", "/v1/nodeb/ran1/servicequery", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "RicServiceQuery", 1)
+}
+
+func TestRouteGetRicServiceQueryReport(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb/servicequery/report", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetRicServiceQueryReport", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
 func TestRouteGetHealth(t *testing.T) {
 	router, rootControllerMock, _, _, _ := setupRouterAndMocks()
 
@@ -112,6 +178,33 @@ func TestRouteGetHealth(t *testing.T) {
 	rootControllerMock.AssertNumberOfCalls(t, "HandleHealthCheckRequest", 1)
 }
 
+func TestRouteGetHealthReady(t *testing.T) {
+	router, rootControllerMock, _, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/health/ready", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	rootControllerMock.AssertNumberOfCalls(t, "HandleReadinessRequest", 1)
+	rootControllerMock.AssertNotCalled(t, "HandleHealthCheckRequest")
+}
+
+func TestRouteGetHealthAlive(t *testing.T) {
+	router, rootControllerMock, _, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/health/alive", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	rootControllerMock.AssertNumberOfCalls(t, "HandleLivenessRequest", 1)
+}
+
 func TestRoutePutNodebShutdown(t *testing.T) {
 	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
 
@@ -125,6 +218,20 @@ func TestRoutePutNodebShutdown(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "Shutdown", 1)
 }
 
+func TestRoutePutNodebE2Reset(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("PUT", "/v1/nodeb/ran1/reset", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+	nodebControllerMock.AssertNumberOfCalls(t, "E2Reset", 1)
+}
+
 func TestHealthCheckRequest(t *testing.T) {
 	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
 
@@ -139,6 +246,109 @@ func TestHealthCheckRequest(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "HealthCheckRequest", 1)
 }
 
+func TestRouteGetHealthCheckJob(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb/health/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetHealthCheckJob", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
+func TestRoutePutNodebX2Reset(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("PUT", "/v1/nodeb/ran1/x2reset", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	assert.Equal(t, "ran1", rr.Body.String(), "handler returned wrong body")
+	nodebControllerMock.AssertNumberOfCalls(t, "X2Reset", 1)
+	nodebControllerMock.AssertNotCalled(t, "E2Reset")
+}
+
+func TestRouteGetX2ResetJob(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb/x2reset/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetX2ResetJob", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
+func TestRouteGetNodebByGlobalNbId(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/nodeb?plmnId=02f829&nbId=4a952a0a", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetNodebByGlobalNbId", 1)
+	nodebControllerMock.AssertNotCalled(t, "GetNodeb")
+}
+
+func TestRouteGetCell(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/cells/02f8294a952a00", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetCell", 1)
+}
+
+func TestRouteGetRanFunctions(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/ranfunctions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetRanFunctions", 1)
+}
+
+func TestRouteGetRanFunctionNodes(t *testing.T) {
+	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/v1/ranfunctions/1.3.6.1.4.1.53148.1.2.2.2/nodes?status=CONNECTED", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	nodebControllerMock.AssertNumberOfCalls(t, "GetRanFunctionNodes", 1)
+}
+
 func TestRoutePutNodebSetGeneralConfiguration(t *testing.T) {
 	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
 
@@ -165,6 +375,32 @@ func TestRoutePutUpdateEnb(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "UpdateEnb", 1)
 }
 
+func TestRouteGetLogLevel(t *testing.T) {
+	router, _, _, _, _, adminControllerMock := setupRouterAndAllMocks()
+
+	req, err := http.NewRequest("GET", "/v1/admin/loglevel", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	adminControllerMock.AssertNumberOfCalls(t, "GetLogLevel", 1)
+}
+
+func TestRoutePutLogLevel(t *testing.T) {
+	router, _, _, _, _, adminControllerMock := setupRouterAndAllMocks()
+
+	req, err := http.NewRequest("PUT", "/v1/admin/loglevel", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	adminControllerMock.AssertNumberOfCalls(t, "SetLogLevel", 1)
+}
+
 func TestRouteNotFound(t *testing.T) {
 	router, _, _, _, _ := setupRouterAndMocks()
 
@@ -180,14 +416,14 @@ func TestRouteNotFound(t *testing.T) {
 
 func TestRunError(t *testing.T) {
 	log := initLog(t)
-	err := Run(log, 1234567, &mocks.RootControllerMock{}, &mocks.NodebControllerMock{}, &mocks.E2TControllerMock{}, &mocks.SymptomdataControllerMock{})
+	err := Run(context.Background(), log, 1234567, time.Second, &mocks.RootControllerMock{}, &mocks.NodebControllerMock{}, &mocks.E2TControllerMock{}, &mocks.SymptomdataControllerMock{}, &mocks.AdminControllerMock{})
 	assert.NotNil(t, err)
 }
 
 func TestRun(t *testing.T) {
 	log := initLog(t)
 	_, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock := setupRouterAndMocks()
-	go Run(log, 11223, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock)
+	go Run(context.Background(), log, 11223, time.Second, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock, &mocks.AdminControllerMock{})
 
 	time.Sleep(time.Millisecond * 100)
 	resp, err := http.Get("http://localhost:11223/v1/health")
@@ -197,6 +433,29 @@ func TestRun(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestRunShutdown(t *testing.T) {
+	log := initLog(t)
+	_, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock := setupRouterAndMocks()
+	ctx, cancel := context.WithCancel(context.Background())
+	runErrors := make(chan error, 1)
+	go func() {
+		runErrors <- Run(ctx, log, 11224, time.Second, rootControllerMock, nodebControllerMock, e2tControllerMock, symptomdataControllerMock, &mocks.AdminControllerMock{})
+	}()
+
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+
+	select {
+	case err := <-runErrors:
+		assert.Nil(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("HTTP server did not shut down")
+	}
+
+	_, err := http.Get("http://localhost:11224/v1/health")
+	assert.NotNil(t, err)
+}
+
 func TestRouteAddEnb(t *testing.T) {
 	router, _, nodebControllerMock, _, _ := setupRouterAndMocks()
 
@@ -225,6 +484,20 @@ func TestRouteDeleteEnb(t *testing.T) {
 	nodebControllerMock.AssertNumberOfCalls(t, "DeleteEnb", 1)
 }
 
+func TestRouteMetrics(t *testing.T) {
+	router, _, _, _, _ := setupRouterAndMocks()
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code, "handler returned wrong status code")
+	assert.Contains(t, rr.Body.String(), "go_goroutines")
+}
+
 func initLog(t *testing.T) *logger.Logger {
 	InfoLevel := int8(3)
 	log, err := logger.InitLogger(InfoLevel)
@@ -233,3 +506,21 @@ func initLog(t *testing.T) *logger.Logger {
 	}
 	return log
 }
+
+func TestRequestIdMiddleware(t *testing.T) {
+	router, _, _, _, _ := setupRouterAndMocks()
+	router.Use(requestIdMiddleware(initLog(t)))
+
+	req, err := http.NewRequest("GET", "/v1/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Len(t, rr.Header().Get(RequestIdHeader), 16)
+
+	req.Header.Set(RequestIdHeader, "abc")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, "abc", rr.Header().Get(RequestIdHeader))
+}
@@ -17,59 +17,312 @@
 //  This source code is part of the near-RT RIC (RAN Intelligent Controller)
 //  platform project (RICP).
 
-
 package logger
 
 import (
-	mdclog "gerrit.o-ran-sc.org/r/com/golog"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	ErrorLevel = 1
+	WarnLevel  = 2
+	InfoLevel  = 3
+	DebugLevel = 4
+)
+
+// Keys of the structured fields, they appear in the mdc object of every log entry
+const (
+	RanNameKey       = "ranName"
+	E2TAddressKey    = "e2tAddress"
+	MsgTypeKey       = "msgType"
+	TransactionIdKey = "transactionId"
+	ProcedureKey     = "procedure"
+	RequestIdKey     = "requestId"
+)
+
+// formatEnvVars are added to the mdc by SetFormat when set, as the mdclog format did
+var formatEnvVars = []string{"SYSTEM_NAME", "HOST_NAME", "SERVICE_NAME", "CONTAINER_NAME", "POD_NAME"}
+
+var levelNames = map[int32]string{ErrorLevel: "ERROR", WarnLevel: "WARNING", InfoLevel: "INFO", DebugLevel: "DEBUG"}
+
+var levelsByName = map[string]int{"error": ErrorLevel, "warn": WarnLevel, "warning": WarnLevel, "info": InfoLevel, "debug": DebugLevel}
+
+// ParseLevel converts a level name of the configuration or of the log level API, such as info, to a level
+func ParseLevel(name string) (int, error) {
+	level, ok := levelsByName[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("invalid log level %s, should be one of error, warning, info, debug", name)
+	}
+	return level, nil
+}
+
+// LevelName returns the name ParseLevel accepts for the level
+func LevelName(level int) string {
+	return strings.ToLower(levelNames[int32(level)])
+}
+
+type Field struct {
+	Key   string
+	Value string
+}
+
+func RanName(ranName string) Field {
+	return Field{Key: RanNameKey, Value: ranName}
+}
+
+func E2TAddress(e2tAddress string) Field {
+	return Field{Key: E2TAddressKey, Value: e2tAddress}
+}
+
+func MsgType(msgType string) Field {
+	return Field{Key: MsgTypeKey, Value: msgType}
+}
+
+func TransactionId(transactionId string) Field {
+	return Field{Key: TransactionIdKey, Value: transactionId}
+}
+
+func Procedure(procedure string) Field {
+	return Field{Key: ProcedureKey, Value: procedure}
+}
+
+func RequestId(requestId string) Field {
+	return Field{Key: RequestIdKey, Value: requestId}
+}
+
+// NewRequestId returns a random correlation id for an RMR notification or an HTTP request
+func NewRequestId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+type requestIdContextKey struct{}
+
+// ContextWithRequestId attaches the correlation id of an HTTP request to its context
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdContextKey{}, requestId)
+}
+
+// RequestIdFromContext returns the correlation id attached to the context, or an empty string
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdContextKey{}).(string)
+	return requestId
+}
+
+type logEntry struct {
+	Ts   int64             `json:"ts"`
+	Crit string            `json:"crit"`
+	Id   string            `json:"id"`
+	Mdc  map[string]string `json:"mdc"`
+	Msg  string            `json:"msg"`
+}
+
+// output is shared by a Logger and the loggers derived from it with With
+type output struct {
+	id     string
+	level  int32
+	mux    sync.Mutex
+	writer io.Writer
+	mdc    map[string]string
+	// packageLevels overrides the level for the entries logged from a package, it holds a map[string]int32 which is
+	// replaced, never modified, so log can read it without locking
+	packageLevels atomic.Value
+}
+
+// Logger writes one JSON object per line, in the format of mdclog. Fields passed to With are added to the mdc of
+// the entries of the derived logger only, so concurrent callers never see each other's fields
 type Logger struct {
-	logger *mdclog.MdcLogger
+	out    *output
+	fields []Field
 }
 
-func InitLogger(loglevel int8 ) (*Logger, error) {
-       name := "e2mgr"
-       log ,err:= NewLogger(name)
-       return log,err
+func InitLogger(loglevel int8) (*Logger, error) {
+	name := "e2mgr"
+	log, err := NewLogger(name)
+	if err != nil {
+		return nil, err
+	}
+	log.SetLevel(int(loglevel))
+	return log, nil
 }
 
 func NewLogger(name string) (*Logger, error) {
-	l,err:= mdclog.InitLogger(name)
+	out := &output{
+		id:     name,
+		level:  InfoLevel,
+		writer: os.Stdout,
+		mdc:    make(map[string]string),
+	}
+	out.packageLevels.Store(map[string]int32{})
+
 	return &Logger{
-		logger: l,
-	},err
+		out: out,
+	}, nil
 }
 
+// SetFormat adds the identity of the container to the mdc. logMonitor is kept for compatibility with mdclog,
+// the log level is changed with SetLevel
 func (l *Logger) SetFormat(logMonitor int) {
-    l.logger.Mdclog_format_initialize(logMonitor)
+	for _, envVar := range formatEnvVars {
+		if value, ok := os.LookupEnv(envVar); ok {
+			l.SetMdc(envVar, value)
+		}
+	}
 }
 
 func (l *Logger) SetLevel(level int) {
-	l.logger.LevelSet(mdclog.Level(level))
+	atomic.StoreInt32(&l.out.level, int32(level))
 }
 
+func (l *Logger) GetLevel() int {
+	return int(atomic.LoadInt32(&l.out.level))
+}
+
+// SetPackageLevel overrides the level of the entries logged from the package, such as managers or httpmsghandlers
+func (l *Logger) SetPackageLevel(pkg string, level int) {
+	l.updatePackageLevels(func(levels map[string]int32) {
+		levels[pkg] = int32(level)
+	})
+}
+
+// RemovePackageLevel makes the package use the level of the logger again
+func (l *Logger) RemovePackageLevel(pkg string) {
+	l.updatePackageLevels(func(levels map[string]int32) {
+		delete(levels, pkg)
+	})
+}
+
+func (l *Logger) GetPackageLevels() map[string]int {
+	levels := l.out.packageLevels.Load().(map[string]int32)
+	result := make(map[string]int, len(levels))
+	for pkg, level := range levels {
+		result[pkg] = int(level)
+	}
+	return result
+}
+
+func (l *Logger) updatePackageLevels(update func(levels map[string]int32)) {
+	l.out.mux.Lock()
+	defer l.out.mux.Unlock()
+
+	current := l.out.packageLevels.Load().(map[string]int32)
+	levels := make(map[string]int32, len(current)+1)
+	for pkg, level := range current {
+		levels[pkg] = level
+	}
+	update(levels)
+	l.out.packageLevels.Store(levels)
+}
+
+// callerPackage returns the last element of the package path of the function which called the logger
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if dot := strings.Index(name, "."); dot >= 0 {
+		name = name[:dot]
+	}
+	return name
+}
+
+// SetMdc adds a key to the mdc of every entry, of this logger and of the loggers derived from it
 func (l *Logger) SetMdc(key string, value string) {
-	l.logger.MdcAdd(key, value)
+	l.out.mux.Lock()
+	defer l.out.mux.Unlock()
+	l.out.mdc[key] = value
+}
+
+// With returns a logger which adds the fields to the mdc of its entries, fields without a value are left out
+func (l *Logger) With(fields ...Field) *Logger {
+	merged := make([]Field, 0, len(l.fields)+len(fields))
+	merged = append(merged, l.fields...)
+	for _, field := range fields {
+		if field.Value != "" {
+			merged = append(merged, field)
+		}
+	}
+
+	return &Logger{
+		out:    l.out,
+		fields: merged,
+	}
+}
+
+// WithContext returns a logger which adds the correlation id attached to the context to the mdc of its entries
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	return l.With(RequestId(RequestIdFromContext(ctx)))
 }
 
 func (l *Logger) Errorf(pattern string, args ...interface{}) {
-	l.SetMdc("time", time.Now().Format(time.RFC3339))
-	l.logger.Error(pattern, args...)
+	l.log(ErrorLevel, pattern, args...)
 }
 
 func (l *Logger) Warnf(pattern string, args ...interface{}) {
-	l.SetMdc("time", time.Now().Format(time.RFC3339))
-	l.logger.Warning(pattern, args...)
+	l.log(WarnLevel, pattern, args...)
 }
 
 func (l *Logger) Infof(pattern string, args ...interface{}) {
-	l.SetMdc("time", time.Now().Format(time.RFC3339))
-	l.logger.Info(pattern, args...)
+	l.log(InfoLevel, pattern, args...)
 }
 
 func (l *Logger) Debugf(pattern string, args ...interface{}) {
-	l.SetMdc("time", time.Now().Format(time.RFC3339))
-	l.logger.Debug(pattern, args...)
+	l.log(DebugLevel, pattern, args...)
+}
+
+func (l *Logger) log(level int32, pattern string, args ...interface{}) {
+	maxLevel := atomic.LoadInt32(&l.out.level)
+
+	// the caller is looked up only when a package level is set
+	if packageLevels := l.out.packageLevels.Load().(map[string]int32); len(packageLevels) != 0 {
+		if packageLevel, ok := packageLevels[callerPackage(2)]; ok {
+			maxLevel = packageLevel
+		}
+	}
+
+	if level > maxLevel {
+		return
+	}
+
+	now := time.Now()
+	entry := logEntry{
+		Ts:   now.UnixMilli(),
+		Crit: levelNames[level],
+		Id:   l.out.id,
+		Msg:  fmt.Sprintf(pattern, args...),
+	}
+
+	l.out.mux.Lock()
+	defer l.out.mux.Unlock()
+
+	entry.Mdc = make(map[string]string, len(l.out.mdc)+len(l.fields)+1)
+	for key, value := range l.out.mdc {
+		entry.Mdc[key] = value
+	}
+	for _, field := range l.fields {
+		entry.Mdc[field.Key] = field.Value
+	}
+	entry.Mdc["time"] = now.Format(time.RFC3339)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	_, _ = l.out.writer.Write(append(data, '\n'))
 }
@@ -0,0 +1,168 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initLoggerTest(t *testing.T, level int8) (*Logger, *bytes.Buffer) {
+	log, err := InitLogger(level)
+	if err != nil {
+		t.Fatalf("#... - failed to initialize logger, error: %s", err)
+	}
+	buffer := &bytes.Buffer{}
+	log.out.writer = buffer
+	return log, buffer
+}
+
+func parseEntries(t *testing.T, buffer *bytes.Buffer) []logEntry {
+	var entries []logEntry
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry logEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("#... - log line is not JSON: %s", line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLogWritesJsonEntry(t *testing.T) {
+	log, buffer := initLoggerTest(t, DebugLevel)
+	log.SetMdc("e2mgr", "0.2.2")
+
+	log.Infof("#LoggerTest - %d RANs", 3)
+
+	entries := parseEntries(t, buffer)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "INFO", entries[0].Crit)
+	assert.Equal(t, "e2mgr", entries[0].Id)
+	assert.Equal(t, "#LoggerTest - 3 RANs", entries[0].Msg)
+	assert.Equal(t, "0.2.2", entries[0].Mdc["e2mgr"])
+	assert.NotEmpty(t, entries[0].Mdc["time"])
+}
+
+func TestWithAddsFieldsToDerivedLoggerOnly(t *testing.T) {
+	log, buffer := initLoggerTest(t, DebugLevel)
+
+	ranLog := log.With(RanName("ran1"), RequestId("abc"))
+	ranLog.With(TransactionId("11")).Warnf("#LoggerTest - derived")
+	log.Errorf("#LoggerTest - parent")
+
+	entries := parseEntries(t, buffer)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "WARNING", entries[0].Crit)
+	assert.Equal(t, "ran1", entries[0].Mdc[RanNameKey])
+	assert.Equal(t, "abc", entries[0].Mdc[RequestIdKey])
+	assert.Equal(t, "11", entries[0].Mdc[TransactionIdKey])
+	assert.NotContains(t, entries[1].Mdc, RanNameKey)
+}
+
+func TestLevelFiltersEntries(t *testing.T) {
+	log, buffer := initLoggerTest(t, WarnLevel)
+
+	log.Debugf("#LoggerTest - debug")
+	log.Infof("#LoggerTest - info")
+	log.With(RanName("ran1")).Warnf("#LoggerTest - warn")
+
+	entries := parseEntries(t, buffer)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, WarnLevel, log.GetLevel())
+
+	log.With(RanName("ran1")).SetLevel(DebugLevel)
+	log.Debugf("#LoggerTest - debug")
+	assert.Len(t, parseEntries(t, buffer), 2)
+}
+
+func TestConcurrentLoggersWriteWholeLines(t *testing.T) {
+	log, buffer := initLoggerTest(t, DebugLevel)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(ranName string) {
+			defer wg.Done()
+			log.With(RanName(ranName)).Infof("#LoggerTest - concurrent")
+		}(string(rune('a' + i)))
+	}
+	wg.Wait()
+
+	assert.Len(t, parseEntries(t, buffer), 10)
+}
+
+func TestNewRequestId(t *testing.T) {
+	first := NewRequestId()
+
+	assert.Len(t, first, 16)
+	assert.NotEqual(t, first, NewRequestId())
+}
+
+func TestRequestIdContext(t *testing.T) {
+	ctx := ContextWithRequestId(context.Background(), "abc")
+
+	assert.Equal(t, "abc", RequestIdFromContext(ctx))
+	assert.Empty(t, RequestIdFromContext(context.Background()))
+}
+
+func TestWithContextAddsRequestId(t *testing.T) {
+	log, buffer := initLoggerTest(t, DebugLevel)
+
+	log.WithContext(ContextWithRequestId(context.Background(), "abc")).Infof("#LoggerTest - with request id")
+	log.WithContext(context.Background()).Infof("#LoggerTest - without request id")
+
+	entries := parseEntries(t, buffer)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "abc", entries[0].Mdc[RequestIdKey])
+	assert.NotContains(t, entries[1].Mdc, RequestIdKey)
+}
+
+func TestPackageLevelOverridesLevel(t *testing.T) {
+	log, buffer := initLoggerTest(t, ErrorLevel)
+
+	log.SetPackageLevel("logger", DebugLevel)
+	log.Debugf("#LoggerTest - debug")
+	assert.Len(t, parseEntries(t, buffer), 1)
+	assert.Equal(t, map[string]int{"logger": DebugLevel}, log.GetPackageLevels())
+
+	log.RemovePackageLevel("logger")
+	log.Debugf("#LoggerTest - debug")
+	assert.Len(t, parseEntries(t, buffer), 1)
+	assert.Empty(t, log.GetPackageLevels())
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("Warn")
+	assert.Nil(t, err)
+	assert.Equal(t, WarnLevel, level)
+	assert.Equal(t, "warning", LevelName(level))
+
+	_, err = ParseLevel("verbose")
+	assert.NotNil(t, err)
+}
@@ -20,6 +20,7 @@ package managers
 
 import (
 	// "e2mgr/configuration"
+	"context"
 	"e2mgr/logger"
 	"e2mgr/services"
 
@@ -54,7 +55,7 @@ func (m *ChangeStatusToConnectedRanManager) ChangeStatusToConnectedRan(inventory
 	connectionStatus := nodebInfo.GetConnectionStatus()
 	m.logger.Infof("#ChangeStatusToConnectedRanManager.ChangeStatusToConnectedRan - RAN name: %s - RAN's connection status: %s", nodebInfo.RanName, connectionStatus)
 
-	ranConnectStatusChange, err := m.ranConnectStatusChangeManager.ChangeStatus(nodebInfo, entities.ConnectionStatus_CONNECTED)
+	ranConnectStatusChange, err := m.ranConnectStatusChangeManager.ChangeStatus(context.Background(), nodebInfo, entities.ConnectionStatus_CONNECTED)
 
 	if err != nil {
 		return ranConnectStatusChange, err
@@ -18,6 +18,7 @@
 package managers
 
 import (
+	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
@@ -41,7 +42,7 @@ func initChangeStatusToConnectedRanTest(t *testing.T) (*logger.Logger, *mocks.Rm
 	writerMock := &mocks.RnibWriterMock{}
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	ranListManager := NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, clients.NewAlarmManagerClient(logger, config, clients.NewHttpClient()))
 	ranConnectStatusChangeManager := NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	changeStatusToConnectedRanManager := NewChangeStatusToConnectedRanManager(logger, rnibDataService, ranConnectStatusChangeManager)
 	return logger, rmrMessengerMock, readerMock, writerMock, changeStatusToConnectedRanManager
@@ -19,6 +19,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/logger"
 	"e2mgr/services"
 
@@ -54,7 +55,7 @@ func (m *RanResetManager) ResetRan(inventoryName string) (bool, error) {
 	connectionStatus := nodebInfo.GetConnectionStatus()
 	m.logger.Infof("#RanResetManager.ResetRan - RAN name: %s - RAN's connection status: %s", nodebInfo.RanName, connectionStatus)
 
-	ranConnectStatusChange, err := m.ranConnectStatusChangeManager.ChangeStatus(nodebInfo, entities.ConnectionStatus_UNDER_RESET)
+	ranConnectStatusChange, err := m.ranConnectStatusChangeManager.ChangeStatus(context.Background(), nodebInfo, entities.ConnectionStatus_UNDER_RESET)
 
 	if err != nil {
 		return ranConnectStatusChange, err
@@ -19,6 +19,7 @@
 package managers
 
 import (
+	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
@@ -43,7 +44,7 @@ func initE2ResetStatusChangeTest(t *testing.T) (*logger.Logger, *mocks.RmrMessen
 	writerMock := &mocks.RnibWriterMock{}
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	ranListManager := NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, clients.NewAlarmManagerClient(logger, config, clients.NewHttpClient()))
 	ranConnectStatusChangeManager := NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2ResetStatusChangeManager := NewRanResetManager(logger, rnibDataService, ranConnectStatusChangeManager)
 	return logger, rmrMessengerMock, readerMock, writerMock, e2ResetStatusChangeManager
@@ -0,0 +1,205 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+	"strconv"
+	"sync"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+// E2AP TransactionID is an INTEGER (0..255)
+const maxE2TransactionId = 256
+
+type E2ResetTransaction struct {
+	RanName       string
+	TransactionId string
+	StartTime     time.Time
+	timer         *time.Timer
+	done          chan bool
+}
+
+// Wait blocks until the transaction is completed (true) or timed out/aborted (false)
+func (t *E2ResetTransaction) Wait() bool {
+	return <-t.done
+}
+
+// Done delivers true once the transaction is completed, false once it timed out or was aborted
+func (t *E2ResetTransaction) Done() <-chan bool {
+	return t.done
+}
+
+type IE2ResetTransactionManager interface {
+	Start(ranName string) (*E2ResetTransaction, error)
+	Complete(ranName string, transactionId string) bool
+	Abort(ranName string)
+	Reject(ranName string)
+}
+
+type E2ResetTransactionManager struct {
+	logger                        *logger.Logger
+	config                        *configuration.Configuration
+	rnibDataService               services.RNibDataService
+	ranConnectStatusChangeManager IRanConnectStatusChangeManager
+	ranProcedureTracker           IRanProcedureTracker
+	transactions                  map[string]*E2ResetTransaction
+	nextTransactionId             int
+	mux                           sync.Mutex
+}
+
+func NewE2ResetTransactionManager(logger *logger.Logger, config *configuration.Configuration, rnibDataService services.RNibDataService, ranConnectStatusChangeManager IRanConnectStatusChangeManager, ranProcedureTracker IRanProcedureTracker) *E2ResetTransactionManager {
+	return &E2ResetTransactionManager{
+		logger:                        logger,
+		config:                        config,
+		rnibDataService:               rnibDataService,
+		ranConnectStatusChangeManager: ranConnectStatusChangeManager,
+		ranProcedureTracker:           ranProcedureTracker,
+		transactions:                  make(map[string]*E2ResetTransaction),
+	}
+}
+
+func (m *E2ResetTransactionManager) Start(ranName string) (*E2ResetTransaction, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if transaction, ok := m.transactions[ranName]; ok {
+		m.logger.Warnf("#E2ResetTransactionManager.Start - RAN name: %s - E2 Reset transaction %s is already in progress", ranName, transaction.TransactionId)
+		return nil, e2managererrors.NewCommandAlreadyInProgressError()
+	}
+
+	transaction := &E2ResetTransaction{
+		RanName:       ranName,
+		TransactionId: strconv.Itoa(m.nextTransactionId),
+		StartTime:     time.Now(),
+		done:          make(chan bool, 1),
+	}
+	m.nextTransactionId = (m.nextTransactionId + 1) % maxE2TransactionId
+
+	timeout := time.Duration(m.config.GetE2ResetResponseTimeoutSec()) * time.Second
+	transaction.timer = time.AfterFunc(timeout, func() {
+		m.expire(transaction)
+	})
+	m.transactions[ranName] = transaction
+	m.ranProcedureTracker.Start(ranName, models.RicE2ResetProcedure, transaction.TransactionId)
+
+	m.logger.Infof("#E2ResetTransactionManager.Start - RAN name: %s - E2 Reset transaction %s started, timeout: %s", ranName, transaction.TransactionId, timeout)
+	return transaction, nil
+}
+
+func (m *E2ResetTransactionManager) Complete(ranName string, transactionId string) bool {
+	transaction := m.remove(ranName, transactionId)
+
+	if transaction == nil {
+		m.logger.Warnf("#E2ResetTransactionManager.Complete - RAN name: %s - no outstanding E2 Reset transaction %s", ranName, transactionId)
+		return false
+	}
+
+	m.logger.Infof("#E2ResetTransactionManager.Complete - RAN name: %s - E2 Reset transaction %s completed after %s", ranName, transactionId, time.Since(transaction.StartTime))
+	m.ranProcedureTracker.Complete(ranName, models.RicE2ResetProcedure)
+	transaction.done <- m.changeStatus(ranName, entities.ConnectionStatus_CONNECTED)
+	return true
+}
+
+func (m *E2ResetTransactionManager) Abort(ranName string) {
+	transaction := m.removeOutstanding(ranName)
+
+	if transaction != nil {
+		m.logger.Infof("#E2ResetTransactionManager.Abort - RAN name: %s - E2 Reset transaction %s aborted", ranName, transaction.TransactionId)
+		m.ranProcedureTracker.Fail(ranName, models.RicE2ResetProcedure)
+		transaction.done <- false
+	}
+}
+
+// Reject fails the outstanding transaction of a RAN which answered the E2 Reset Request with an Error Indication. The RAN
+// did not reset, hence it is moved back to CONNECTED
+func (m *E2ResetTransactionManager) Reject(ranName string) {
+	transaction := m.removeOutstanding(ranName)
+
+	if transaction == nil {
+		m.logger.Warnf("#E2ResetTransactionManager.Reject - RAN name: %s - no outstanding E2 Reset transaction", ranName)
+		return
+	}
+
+	m.logger.Warnf("#E2ResetTransactionManager.Reject - RAN name: %s - E2 Reset transaction %s rejected by the RAN", ranName, transaction.TransactionId)
+	m.ranProcedureTracker.Fail(ranName, models.RicE2ResetProcedure)
+	m.changeStatus(ranName, entities.ConnectionStatus_CONNECTED)
+	transaction.done <- false
+}
+
+func (m *E2ResetTransactionManager) expire(transaction *E2ResetTransaction) {
+	if m.remove(transaction.RanName, transaction.TransactionId) == nil {
+		return
+	}
+
+	m.logger.Errorf("#E2ResetTransactionManager.expire - RAN name: %s - no RIC_E2_RESET_RESP received for transaction %s within %d seconds", transaction.RanName, transaction.TransactionId, m.config.GetE2ResetResponseTimeoutSec())
+	m.ranProcedureTracker.TimeOut(transaction.RanName, models.RicE2ResetProcedure)
+	m.changeStatus(transaction.RanName, entities.ConnectionStatus_DISCONNECTED)
+	transaction.done <- false
+}
+
+func (m *E2ResetTransactionManager) remove(ranName string, transactionId string) *E2ResetTransaction {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	transaction, ok := m.transactions[ranName]
+	if !ok || transaction.TransactionId != transactionId {
+		return nil
+	}
+
+	transaction.timer.Stop()
+	delete(m.transactions, ranName)
+	return transaction
+}
+
+func (m *E2ResetTransactionManager) removeOutstanding(ranName string) *E2ResetTransaction {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	transaction, ok := m.transactions[ranName]
+	if !ok {
+		return nil
+	}
+
+	transaction.timer.Stop()
+	delete(m.transactions, ranName)
+	return transaction
+}
+
+func (m *E2ResetTransactionManager) changeStatus(ranName string, nextStatus entities.ConnectionStatus) bool {
+	nodebInfo, err := m.rnibDataService.GetNodeb(ranName)
+	if err != nil {
+		m.logger.Errorf("#E2ResetTransactionManager.changeStatus - RAN name: %s - Failed fetching RAN from rNib. Error: %v", ranName, err)
+		return false
+	}
+
+	_, err = m.ranConnectStatusChangeManager.ChangeStatus(context.Background(), nodebInfo, nextStatus)
+	if err != nil {
+		m.logger.Errorf("#E2ResetTransactionManager.changeStatus - RAN name: %s - Failed changing connection status to %s. Error: %v", ranName, nextStatus, err)
+		return false
+	}
+	return true
+}
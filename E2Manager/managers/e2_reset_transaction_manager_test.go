@@ -0,0 +1,127 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/services"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initE2ResetTransactionManagerTest(t *testing.T, responseTimeoutSec int) (*mocks.RnibReaderMock, *mocks.RnibWriterMock, *E2ResetTransactionManager) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, E2Reset: configuration.E2ResetConfig{ResponseTimeoutSec: responseTimeoutSec},
+		RnibWriter: configuration.RnibWriterConfig{
+			StateChangeMessageChannel: EventChannelForTest,
+		},
+	}
+
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	ranAlarmServiceMock := &mocks.RanAlarmServiceMock{}
+	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
+	ranConnectStatusChangeManager := NewRanConnectStatusChangeManager(log, rnibDataService, ranListManagerMock, ranAlarmServiceMock)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	return readerMock, writerMock, NewE2ResetTransactionManager(log, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+}
+
+func TestE2ResetTransactionStartAlreadyInProgress(t *testing.T) {
+	_, _, manager := initE2ResetTransactionManagerTest(t, 10)
+
+	transaction, err := manager.Start(RanName)
+	assert.Nil(t, err)
+	assert.Equal(t, "0", transaction.TransactionId)
+
+	_, err = manager.Start(RanName)
+	assert.IsType(t, &e2managererrors.CommandAlreadyInProgressError{}, err)
+}
+
+func TestE2ResetTransactionCompleteSuccess(t *testing.T) {
+	readerMock, writerMock, manager := initE2ResetTransactionManagerTest(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(nil)
+
+	transaction, _ := manager.Start(RanName)
+
+	assert.True(t, manager.Complete(RanName, transaction.TransactionId))
+	assert.True(t, transaction.Wait())
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodebInfo.ConnectionStatus)
+	writerMock.AssertExpectations(t)
+}
+
+func TestE2ResetTransactionCompleteWrongTransactionId(t *testing.T) {
+	readerMock, _, manager := initE2ResetTransactionManagerTest(t, 10)
+
+	transaction, _ := manager.Start(RanName)
+
+	assert.False(t, manager.Complete(RanName, "17"))
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+
+	manager.Abort(RanName)
+	assert.False(t, transaction.Wait())
+}
+
+func TestE2ResetTransactionTimeout(t *testing.T) {
+	readerMock, writerMock, manager := initE2ResetTransactionManagerTest(t, 1)
+
+	nodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+
+	transaction, _ := manager.Start(RanName)
+
+	assert.False(t, transaction.Wait())
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, nodebInfo.ConnectionStatus)
+	assert.False(t, manager.Complete(RanName, transaction.TransactionId))
+}
+
+func TestE2ResetTransactionReject(t *testing.T) {
+	readerMock, writerMock, manager := initE2ResetTransactionManagerTest(t, 10)
+
+	nodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(nil)
+
+	transaction, _ := manager.Start(RanName)
+
+	manager.Reject(RanName)
+	assert.False(t, transaction.Wait())
+	assert.Equal(t, entities.ConnectionStatus_CONNECTED, nodebInfo.ConnectionStatus)
+	assert.False(t, manager.Complete(RanName, transaction.TransactionId))
+}
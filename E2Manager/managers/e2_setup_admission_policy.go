@@ -0,0 +1,344 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/metrics"
+	"e2mgr/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+type E2SetupAdmissionCandidate struct {
+	RanName      string
+	NodeType     entities.Node_Type
+	NodeTypeName string
+	PlmnId       string
+	NbId         string
+	RanFunctions []*entities.RanFunction
+	E2TInstance  *entities.E2TInstance
+}
+
+func NewE2SetupAdmissionCandidate(ranName string, setupRequest *models.E2SetupRequestMessage, e2tInstance *entities.E2TInstance) *E2SetupAdmissionCandidate {
+	candidate := &E2SetupAdmissionCandidate{
+		RanName:      ranName,
+		NodeType:     setupRequest.GetNodeType(),
+		PlmnId:       setupRequest.GetPlmnId(),
+		NbId:         setupRequest.GetNbId(),
+		RanFunctions: setupRequest.ExtractRanFunctionsList(),
+		E2TInstance:  e2tInstance,
+	}
+
+	switch candidate.NodeType {
+	case entities.Node_GNB:
+		candidate.NodeTypeName = setupRequest.GetGnbType().String()
+	case entities.Node_ENB:
+		candidate.NodeTypeName = setupRequest.GetEnbType().String()
+	}
+
+	return candidate
+}
+
+type E2SetupAdmissionRejection struct {
+	Reason string
+	Cause  models.Cause
+}
+
+type IE2SetupAdmissionRule interface {
+	Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection
+}
+
+type IE2SetupAdmissionPolicy interface {
+	Admit(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection
+	GetTimeToWait() models.TimeToWait
+}
+
+type E2SetupAdmissionPolicy struct {
+	logger      *logger.Logger
+	config      *configuration.Configuration
+	mux         sync.RWMutex
+	generation  uint64
+	timeToWait  models.TimeToWait
+	configRules []IE2SetupAdmissionRule
+	rules       []IE2SetupAdmissionRule
+}
+
+func NewE2SetupAdmissionPolicy(logger *logger.Logger, config *configuration.Configuration) *E2SetupAdmissionPolicy {
+	policy := &E2SetupAdmissionPolicy{
+		logger: logger,
+		config: config,
+	}
+
+	policy.generation = config.ReloadGeneration()
+	policy.timeToWait, policy.configRules = buildE2SetupAdmissionRules(config.GetE2SetupAdmission())
+
+	return policy
+}
+
+func buildE2SetupAdmissionRules(admissionConfig configuration.E2SetupAdmissionConfig) (models.TimeToWait, []IE2SetupAdmissionRule) {
+	timeToWait := models.TimeToWait(admissionConfig.TimeToWaitSec)
+	if timeToWait == 0 {
+		timeToWait = models.TimeToWaitEnum.V60s
+	}
+
+	rules := []IE2SetupAdmissionRule{&nodeIdentityRule{}}
+
+	if len(admissionConfig.AllowedPlmnIds) != 0 || len(admissionConfig.DeniedPlmnIds) != 0 {
+		rules = append(rules, &plmnIdRule{allowed: upperCaseStringSet(admissionConfig.AllowedPlmnIds), denied: upperCaseStringSet(admissionConfig.DeniedPlmnIds)})
+	}
+
+	if len(admissionConfig.AllowedNodeTypes) != 0 || len(admissionConfig.DeniedNodeTypes) != 0 {
+		rules = append(rules, &nodeTypeRule{allowed: upperCaseStringSet(admissionConfig.AllowedNodeTypes), denied: upperCaseStringSet(admissionConfig.DeniedNodeTypes)})
+	}
+
+	if len(admissionConfig.AllowedNbIdRanges) != 0 {
+		rules = append(rules, &nbIdRangeRule{ranges: admissionConfig.AllowedNbIdRanges})
+	}
+
+	if len(admissionConfig.AllowedRanFunctionOids) != 0 || len(admissionConfig.DeniedRanFunctionOids) != 0 {
+		rules = append(rules, &ranFunctionOidRule{allowed: stringSet(admissionConfig.AllowedRanFunctionOids), denied: stringSet(admissionConfig.DeniedRanFunctionOids)})
+	}
+
+	if admissionConfig.MaxNodesPerE2T > 0 {
+		rules = append(rules, &maxNodesPerE2TRule{maxNodes: admissionConfig.MaxNodesPerE2T})
+	}
+
+	return timeToWait, rules
+}
+
+// AddRule appends a rule evaluated after the configured ones; it survives configuration reloads
+func (p *E2SetupAdmissionPolicy) AddRule(rule IE2SetupAdmissionRule) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.rules = append(p.rules, rule)
+}
+
+// refresh rebuilds the configured rules when the configuration was reloaded since they were built
+func (p *E2SetupAdmissionPolicy) refresh() {
+	generation := p.config.ReloadGeneration()
+
+	p.mux.RLock()
+	upToDate := p.generation == generation
+	p.mux.RUnlock()
+
+	if upToDate {
+		return
+	}
+
+	timeToWait, configRules := buildE2SetupAdmissionRules(p.config.GetE2SetupAdmission())
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.generation = generation
+	p.timeToWait = timeToWait
+	p.configRules = configRules
+	p.logger.Infof("#E2SetupAdmissionPolicy.refresh - admission rules rebuilt for configuration generation %d", generation)
+}
+
+func (p *E2SetupAdmissionPolicy) Admit(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	p.refresh()
+
+	p.mux.RLock()
+	rules := make([]IE2SetupAdmissionRule, 0, len(p.configRules)+len(p.rules))
+	rules = append(append(rules, p.configRules...), p.rules...)
+	p.mux.RUnlock()
+
+	for _, rule := range rules {
+		if rejection := rule.Evaluate(candidate); rejection != nil {
+			return rejection
+		}
+	}
+
+	p.logger.Debugf("#E2SetupAdmissionPolicy.Admit - RAN name: %s - E2 setup admitted. node type: %s, plmnId: %s, nbId: %s", candidate.RanName, candidate.NodeTypeName, candidate.PlmnId, candidate.NbId)
+	return nil
+}
+
+func (p *E2SetupAdmissionPolicy) GetTimeToWait() models.TimeToWait {
+	p.refresh()
+
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	return p.timeToWait
+}
+
+type nodeIdentityRule struct{}
+
+func (r *nodeIdentityRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	if candidate.NodeType == entities.Node_UNKNOWN || len(candidate.PlmnId) == 0 || len(candidate.NbId) == 0 {
+		return newE2SetupAdmissionRejection("global E2 node ID is missing or not supported", models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}})
+	}
+	return nil
+}
+
+type plmnIdRule struct {
+	allowed map[string]bool
+	denied  map[string]bool
+}
+
+func (r *plmnIdRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	plmnId := strings.ToUpper(candidate.PlmnId)
+	if r.denied[plmnId] || (len(r.allowed) != 0 && !r.allowed[plmnId]) {
+		return newOmInterventionRejection(fmt.Sprintf("plmnId %s is not admitted", candidate.PlmnId))
+	}
+	return nil
+}
+
+type nodeTypeRule struct {
+	allowed map[string]bool
+	denied  map[string]bool
+}
+
+func (r *nodeTypeRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	if r.denied[candidate.NodeTypeName] || (len(r.allowed) != 0 && !r.allowed[candidate.NodeTypeName]) {
+		return newOmInterventionRejection(fmt.Sprintf("node type %s is not admitted", candidate.NodeTypeName))
+	}
+	return nil
+}
+
+type nbIdRangeRule struct {
+	ranges []configuration.NbIdRange
+}
+
+func (r *nbIdRangeRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	nbId, err := strconv.ParseUint(candidate.NbId, 2, 64)
+	if err != nil {
+		return newOmInterventionRejection(fmt.Sprintf("nbId %s is not a bit string", candidate.NbId))
+	}
+
+	for _, nbIdRange := range r.ranges {
+		if nbId >= nbIdRange.Min && nbId <= nbIdRange.Max {
+			return nil
+		}
+	}
+
+	return newOmInterventionRejection(fmt.Sprintf("nbId %s is out of the admitted ranges", candidate.NbId))
+}
+
+type ranFunctionOidRule struct {
+	allowed map[string]bool
+	denied  map[string]bool
+}
+
+// The RIC requires at least one of the allowed RAN functions, and none of the denied ones.
+func (r *ranFunctionOidRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	hasAllowed := len(r.allowed) == 0
+
+	for _, ranFunction := range candidate.RanFunctions {
+		if r.denied[ranFunction.RanFunctionOid] {
+			return newFunctionNotRequiredRejection(fmt.Sprintf("RAN function OID %s is denied", ranFunction.RanFunctionOid))
+		}
+		if r.allowed[ranFunction.RanFunctionOid] {
+			hasAllowed = true
+		}
+	}
+
+	if !hasAllowed {
+		return newFunctionNotRequiredRejection("none of the RAN function OIDs is required by the RIC")
+	}
+	return nil
+}
+
+type maxNodesPerE2TRule struct {
+	maxNodes int
+}
+
+func (r *maxNodesPerE2TRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	if candidate.E2TInstance == nil {
+		return nil
+	}
+
+	associatedNodes := 0
+	for _, ranName := range candidate.E2TInstance.AssociatedRanList {
+		if ranName != candidate.RanName {
+			associatedNodes++
+		}
+	}
+
+	if associatedNodes >= r.maxNodes {
+		return newE2SetupAdmissionRejection(fmt.Sprintf("E2T instance %s already serves %d nodes", candidate.E2TInstance.Address, associatedNodes),
+			models.Cause{RicService: &models.CauseRicService{RicResourceLimit: &struct{}{}}})
+	}
+	return nil
+}
+
+// duplicateGlobalNbIdRule detects a RAN claiming the global E2 node ID of another RAN name
+type duplicateGlobalNbIdRule struct {
+	logger         *logger.Logger
+	config         *configuration.Configuration
+	ranListManager RanListManager
+}
+
+func NewDuplicateGlobalNbIdRule(logger *logger.Logger, config *configuration.Configuration, ranListManager RanListManager) IE2SetupAdmissionRule {
+	return &duplicateGlobalNbIdRule{
+		logger:         logger,
+		config:         config,
+		ranListManager: ranListManager,
+	}
+}
+
+func (r *duplicateGlobalNbIdRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	owner, err := r.ranListManager.GetRanNameByGlobalNbId(candidate.PlmnId, candidate.NbId)
+	if err != nil || owner == candidate.RanName {
+		return nil
+	}
+
+	if r.config.GetE2SetupAdmission().DuplicateGlobalNbIdAction == "flag" {
+		metrics.DuplicateGlobalNbIds.WithLabelValues(metrics.DuplicateGlobalNbIdFlagged).Inc()
+		r.logger.Warnf("#duplicateGlobalNbIdRule.Evaluate - RAN name: %s - plmnId: %s, nbId: %s is already owned by RAN %s", candidate.RanName, candidate.PlmnId, candidate.NbId, owner)
+		return nil
+	}
+
+	metrics.DuplicateGlobalNbIds.WithLabelValues(metrics.DuplicateGlobalNbIdRejected).Inc()
+	return newE2SetupAdmissionRejection(fmt.Sprintf("plmnId %s, nbId %s is already owned by RAN %s", candidate.PlmnId, candidate.NbId, owner),
+		models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}})
+}
+
+func newE2SetupAdmissionRejection(reason string, cause models.Cause) *E2SetupAdmissionRejection {
+	return &E2SetupAdmissionRejection{Reason: reason, Cause: cause}
+}
+
+func newOmInterventionRejection(reason string) *E2SetupAdmissionRejection {
+	return newE2SetupAdmissionRejection(reason, models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}})
+}
+
+func newFunctionNotRequiredRejection(reason string) *E2SetupAdmissionRejection {
+	return newE2SetupAdmissionRejection(reason, models.Cause{RicService: &models.CauseRicService{FunctionNotRequired: &struct{}{}}})
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func upperCaseStringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToUpper(v)] = true
+	}
+	return set
+}
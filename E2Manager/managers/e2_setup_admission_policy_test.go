@@ -0,0 +1,200 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func initE2SetupAdmissionPolicyTest(t *testing.T, admissionConfig configuration.E2SetupAdmissionConfig) *E2SetupAdmissionPolicy {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{E2SetupAdmission: admissionConfig}
+	return NewE2SetupAdmissionPolicy(log, config)
+}
+
+func getGnbAdmissionCandidate() *E2SetupAdmissionCandidate {
+	return &E2SetupAdmissionCandidate{
+		RanName:      RanName,
+		NodeType:     entities.Node_GNB,
+		NodeTypeName: entities.GnbType_GNB.String(),
+		PlmnId:       "02F829",
+		NbId:         "001100000011000000110000",
+		RanFunctions: []*entities.RanFunction{{RanFunctionId: 1, RanFunctionOid: "1.3.6.1.4.1.53148.1.2.2.2"}},
+		E2TInstance:  &entities.E2TInstance{Address: E2TAddress},
+	}
+}
+
+func TestE2SetupAdmissionPolicyDefaultAdmits(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{})
+
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+	assert.Equal(t, models.TimeToWaitEnum.V60s, policy.GetTimeToWait())
+}
+
+func TestE2SetupAdmissionPolicyConfiguredTimeToWait(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{TimeToWaitSec: 5})
+
+	assert.Equal(t, models.TimeToWaitEnum.V5s, policy.GetTimeToWait())
+}
+
+func TestE2SetupAdmissionPolicyUnknownNodeRejected(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{})
+	candidate := getGnbAdmissionCandidate()
+	candidate.NodeType = entities.Node_UNKNOWN
+
+	rejection := policy.Admit(candidate)
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.Protocol.SemanticError)
+}
+
+func TestE2SetupAdmissionPolicyPlmnId(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedPlmnIds: []string{"131014"}})
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.Misc.OmIntervention)
+
+	policy = initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedPlmnIds: []string{"02f829"}})
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+
+	policy = initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{DeniedPlmnIds: []string{"02F829"}})
+	assert.NotNil(t, policy.Admit(getGnbAdmissionCandidate()))
+}
+
+func TestE2SetupAdmissionPolicyNodeType(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedNodeTypes: []string{"en_gnb"}})
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.Misc.OmIntervention)
+
+	policy = initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{DeniedNodeTypes: []string{"MACRO_ENB"}})
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+}
+
+func TestE2SetupAdmissionPolicyNbIdRange(t *testing.T) {
+	// 001100000011000000110000 == 3158064
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedNbIdRanges: []configuration.NbIdRange{{Min: 0, Max: 100}, {Min: 3158000, Max: 3159000}}})
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+
+	policy = initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedNbIdRanges: []configuration.NbIdRange{{Min: 0, Max: 100}}})
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.Misc.OmIntervention)
+}
+
+func TestE2SetupAdmissionPolicyRanFunctionOids(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedRanFunctionOids: []string{"1.3.6.1.4.1.53148.1.2.2.2"}})
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+
+	policy = initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{AllowedRanFunctionOids: []string{"1.3.6.1.4.1.53148.1.1.2.3"}})
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.RicService.FunctionNotRequired)
+
+	policy = initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{DeniedRanFunctionOids: []string{"1.3.6.1.4.1.53148.1.2.2.2"}})
+	rejection = policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.RicService.FunctionNotRequired)
+}
+
+func TestE2SetupAdmissionPolicyMaxNodesPerE2T(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{MaxNodesPerE2T: 2})
+	candidate := getGnbAdmissionCandidate()
+
+	candidate.E2TInstance.AssociatedRanList = []string{"ran1", RanName}
+	assert.Nil(t, policy.Admit(candidate))
+
+	candidate.E2TInstance.AssociatedRanList = []string{"ran1", "ran2"}
+	rejection := policy.Admit(candidate)
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.RicService.RicResourceLimit)
+}
+
+type rejectAllRule struct{}
+
+func (r *rejectAllRule) Evaluate(candidate *E2SetupAdmissionCandidate) *E2SetupAdmissionRejection {
+	return newOmInterventionRejection("rejected by test rule")
+}
+
+func TestE2SetupAdmissionPolicyAddRule(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{})
+	policy.AddRule(&rejectAllRule{})
+
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.Equal(t, "rejected by test rule", rejection.Reason)
+}
+
+func TestE2SetupAdmissionPolicyRebuiltOnReload(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{})
+	policy.AddRule(&rejectAllRule{})
+
+	reloaded := *policy.config
+	reloaded.E2SetupAdmission = configuration.E2SetupAdmissionConfig{TimeToWaitSec: 5, DeniedPlmnIds: []string{"02f829"}}
+	policy.config.ApplyReloadable(&reloaded)
+
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotEqual(t, "rejected by test rule", rejection.Reason)
+	assert.Equal(t, models.TimeToWaitEnum.V5s, policy.GetTimeToWait())
+}
+
+func TestE2SetupAdmissionPolicyDuplicateGlobalNbId(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{DuplicateGlobalNbIdAction: "reject"})
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ranListManagerMock.On("GetRanNameByGlobalNbId", "02F829", "001100000011000000110000").Return("ran1", nil)
+	policy.AddRule(NewDuplicateGlobalNbIdRule(policy.logger, policy.config, ranListManagerMock))
+
+	rejection := policy.Admit(getGnbAdmissionCandidate())
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.Protocol.SemanticError)
+
+	candidate := getGnbAdmissionCandidate()
+	candidate.RanName = "ran1"
+	assert.Nil(t, policy.Admit(candidate))
+}
+
+func TestE2SetupAdmissionPolicyDuplicateGlobalNbIdFlagged(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{DuplicateGlobalNbIdAction: "flag"})
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ranListManagerMock.On("GetRanNameByGlobalNbId", "02F829", "001100000011000000110000").Return("ran1", nil)
+	policy.AddRule(NewDuplicateGlobalNbIdRule(policy.logger, policy.config, ranListManagerMock))
+
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+}
+
+func TestE2SetupAdmissionPolicyUniqueGlobalNbId(t *testing.T) {
+	policy := initE2SetupAdmissionPolicyTest(t, configuration.E2SetupAdmissionConfig{DuplicateGlobalNbIdAction: "reject"})
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ranListManagerMock.On("GetRanNameByGlobalNbId", "02F829", "001100000011000000110000").Return("", e2managererrors.NewResourceNotFoundError())
+	policy.AddRule(NewDuplicateGlobalNbIdRule(policy.logger, policy.config, ranListManagerMock))
+
+	assert.Nil(t, policy.Admit(getGnbAdmissionCandidate()))
+}
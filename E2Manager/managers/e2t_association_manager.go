@@ -20,6 +20,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/clients"
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
@@ -45,40 +46,41 @@ func NewE2TAssociationManager(logger *logger.Logger, rnibDataService services.RN
 	}
 }
 
-func (m *E2TAssociationManager) AssociateRan(e2tAddress string, nodebInfo *entities.NodebInfo) (bool, error) {
+func (m *E2TAssociationManager) AssociateRan(ctx context.Context, e2tAddress string, nodebInfo *entities.NodebInfo) (bool, error) {
 	ranName := nodebInfo.RanName
-	m.logger.Infof("#E2TAssociationManager.AssociateRan - Associating RAN %s to E2T Instance address: %s", ranName, e2tAddress)
+	log := m.logger.WithContext(ctx).With(logger.RanName(ranName), logger.E2TAddress(e2tAddress))
+	log.Infof("#E2TAssociationManager.AssociateRan - Associating RAN %s to E2T Instance address: %s", ranName, e2tAddress)
 
-	ranStatusChangePublished, err := m.associateRanAndUpdateNodeb(e2tAddress, nodebInfo)
+	ranStatusChangePublished, err := m.associateRanAndUpdateNodeb(ctx, e2tAddress, nodebInfo)
 	if err != nil {
-		m.logger.Errorf("#E2TAssociationManager.AssociateRan - RoutingManager failure: Failed to associate RAN %s to E2T %s. Error: %s", nodebInfo, e2tAddress, err)
+		log.Errorf("#E2TAssociationManager.AssociateRan - RoutingManager failure: Failed to associate RAN %s to E2T %s. Error: %s", nodebInfo, e2tAddress, err)
 		return ranStatusChangePublished, err
 	}
 	err = m.e2tInstanceManager.AddRansToInstance(e2tAddress, []string{ranName})
 	if err != nil {
-		m.logger.Errorf("#E2TAssociationManager.AssociateRan - RAN name: %s - Failed to add RAN to E2T instance %s. Error: %s", ranName, e2tAddress, err)
+		log.Errorf("#E2TAssociationManager.AssociateRan - RAN name: %s - Failed to add RAN to E2T instance %s. Error: %s", ranName, e2tAddress, err)
 		return ranStatusChangePublished, e2managererrors.NewRnibDbError()
 	}
-	m.logger.Infof("#E2TAssociationManager.AssociateRan - successfully associated RAN %s with E2T %s", ranName, e2tAddress)
+	log.Infof("#E2TAssociationManager.AssociateRan - successfully associated RAN %s with E2T %s", ranName, e2tAddress)
 	return ranStatusChangePublished, nil
 }
 
-func (m *E2TAssociationManager) associateRanAndUpdateNodeb(e2tAddress string, nodebInfo *entities.NodebInfo) (bool, error) {
+func (m *E2TAssociationManager) associateRanAndUpdateNodeb(ctx context.Context, e2tAddress string, nodebInfo *entities.NodebInfo) (bool, error) {
 
 	rmErr := m.rmClient.AssociateRanToE2TInstance(e2tAddress, nodebInfo.RanName)
 
 	if rmErr != nil {
-		ranStatusChangePublished, _ := m.ranConnectStatusChangeManager.ChangeStatus(nodebInfo, entities.ConnectionStatus_DISCONNECTED)
+		ranStatusChangePublished, _ := m.ranConnectStatusChangeManager.ChangeStatus(ctx, nodebInfo, entities.ConnectionStatus_DISCONNECTED)
 		return ranStatusChangePublished, e2managererrors.NewRoutingManagerError()
 	}
 
-	ranStatusChangePublished, rnibErr := m.ranConnectStatusChangeManager.ChangeStatus(nodebInfo, entities.ConnectionStatus_CONNECTED)
+	nodebInfo.AssociatedE2TInstanceAddress = e2tAddress
+	ranStatusChangePublished, rnibErr := m.ranConnectStatusChangeManager.ChangeStatus(ctx, nodebInfo, entities.ConnectionStatus_CONNECTED)
 
 	if rnibErr != nil {
 		return ranStatusChangePublished, e2managererrors.NewRnibDbError()
 	}
 
-	nodebInfo.AssociatedE2TInstanceAddress = e2tAddress
 	rnibErr = m.rnibDataService.UpdateNodebInfo(nodebInfo)
 
 	if rnibErr != nil {
@@ -21,6 +21,7 @@ package managers
 
 import (
 	"bytes"
+	"context"
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
@@ -55,9 +56,10 @@ func initE2TAssociationManagerTest(t *testing.T) (*E2TAssociationManager, *mocks
 
 	e2tInstancesManager := NewE2TInstancesManager(rnibDataService, log)
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(log, config, httpClientMock)
+	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock, alarmManagerClient)
 	ranListManager := NewRanListManager(log, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(log, config)
+	ranAlarmService := services.NewRanAlarmService(log, config, alarmManagerClient)
 	ranConnectStatusChangeManager := NewRanConnectStatusChangeManager(log, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := NewE2TAssociationManager(log, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager)
 	return e2tAssociationManager, readerMock, writerMock, httpClientMock
@@ -94,7 +96,7 @@ func TestAssociateRanSuccess(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = append(updatedE2tInstance.AssociatedRanList, RanName)
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(nil)
 
-	_, err := manager.AssociateRan(E2TAddress, nb)
+	_, err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.Nil(t, err)
 	readerMock.AssertExpectations(t)
@@ -110,7 +112,7 @@ func TestAssociateRan_RnibError(t *testing.T) {
 	updatedNb.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_CONNECTED").Return(common.NewInternalError(fmt.Errorf("for tests")))
 
-	_, err := manager.AssociateRan(E2TAddress, nb)
+	_, err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -125,7 +127,7 @@ func TestAssociateRanRoutingManagerError(t *testing.T) {
 	nb := &entities.NodebInfo{RanName: RanName, AssociatedE2TInstanceAddress: ""}
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 
-	_, err := manager.AssociateRan(E2TAddress, nb)
+	_, err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RoutingManagerError{}, err)
@@ -146,7 +148,7 @@ func TestAssociateRanUpdateNodebError(t *testing.T) {
 	updatedNb2.AssociatedE2TInstanceAddress = E2TAddress
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(e2managererrors.NewRnibDbError())
 
-	_, err := manager.AssociateRan(E2TAddress, nb)
+	_, err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -171,7 +173,7 @@ func TestAssociateRanGetE2tInstanceError(t *testing.T) {
 	var e2tInstance *entities.E2TInstance
 	readerMock.On("GetE2TInstance", E2TAddress).Return(e2tInstance, errors.New("test"))
 
-	_, err := manager.AssociateRan(E2TAddress, nb)
+	_, err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -199,7 +201,7 @@ func TestAssociateRanSaveE2tInstanceError(t *testing.T) {
 	updatedE2tInstance.AssociatedRanList = append(updatedE2tInstance.AssociatedRanList, RanName)
 	writerMock.On("SaveE2TInstance", &updatedE2tInstance).Return(errors.New("test"))
 
-	_, err := manager.AssociateRan(E2TAddress, nb)
+	_, err := manager.AssociateRan(context.Background(), E2TAddress, nb)
 
 	assert.NotNil(t, err)
 	assert.IsType(t, &e2managererrors.RnibDbError{}, err)
@@ -48,6 +48,7 @@ type IE2TInstancesManager interface {
 	AddRansToInstance(e2tAddress string, ranNames []string) error
 	RemoveRanFromInstance(ranName string, e2tAddress string) error
 	ResetKeepAliveTimestamp(e2tAddress string) error
+	ResetKeepAliveTimestampsForAllE2TInstances()
 	ClearRansOfAllE2TInstances() error
 	SetE2tInstanceState(e2tAddress string, currentState entities.E2TInstanceState, newState entities.E2TInstanceState) error
 }
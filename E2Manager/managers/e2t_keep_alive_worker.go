@@ -21,8 +21,10 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/metrics"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
 	"e2mgr/services/rmrsender"
@@ -35,28 +37,58 @@ type E2TKeepAliveWorker struct {
 	e2TInstancesManager IE2TInstancesManager
 	rmrSender           *rmrsender.RmrSender
 	config              *configuration.Configuration
+	leaderElector       ILeaderElector
 }
 
-func NewE2TKeepAliveWorker(logger *logger.Logger, rmrSender *rmrsender.RmrSender, e2TInstancesManager IE2TInstancesManager, e2tShutdownManager IE2TShutdownManager, config *configuration.Configuration) E2TKeepAliveWorker {
+func NewE2TKeepAliveWorker(logger *logger.Logger, rmrSender *rmrsender.RmrSender, e2TInstancesManager IE2TInstancesManager, e2tShutdownManager IE2TShutdownManager, config *configuration.Configuration, leaderElector ILeaderElector) E2TKeepAliveWorker {
 	return E2TKeepAliveWorker{
 		logger:              logger,
 		e2tShutdownManager:  e2tShutdownManager,
 		e2TInstancesManager: e2TInstancesManager,
 		rmrSender:           rmrSender,
 		config:              config,
+		leaderElector:       leaderElector,
 	}
 }
 
-func (h E2TKeepAliveWorker) Execute() {
+func (h E2TKeepAliveWorker) Execute(ctx context.Context) {
 
 	h.logger.Infof("#E2TKeepAliveWorker.Execute - keep alive started")
 
-	ticker := time.NewTicker(time.Duration(h.config.KeepAliveDelayMs) * time.Millisecond)
-
-	for _ = range ticker.C {
-
-		h.SendKeepAliveRequest()
-		h.E2TKeepAliveExpired()
+	keepAliveDelayMs := h.config.GetKeepAliveDelayMs()
+	ticker := time.NewTicker(time.Duration(keepAliveDelayMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	// without leader election the keep alive timestamps are reset once on startup
+	leader := !h.config.LeaderElection.Enabled
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Infof("#E2TKeepAliveWorker.Execute - keep alive stopped")
+			return
+		case <-ticker.C:
+			if delayMs := h.config.GetKeepAliveDelayMs(); delayMs != keepAliveDelayMs {
+				h.logger.Infof("#E2TKeepAliveWorker.Execute - keep alive delay changed from %d to %d ms", keepAliveDelayMs, delayMs)
+				keepAliveDelayMs = delayMs
+				ticker.Reset(time.Duration(keepAliveDelayMs) * time.Millisecond)
+			}
+
+			if !h.leaderElector.IsLeader() {
+				leader = false
+				continue
+			}
+
+			if !leader {
+				// the timestamps were not refreshed while another replica was the leader
+				h.logger.Infof("#E2TKeepAliveWorker.Execute - became leader, resetting keep alive timestamps")
+				h.e2TInstancesManager.ResetKeepAliveTimestampsForAllE2TInstances()
+				leader = true
+			}
+
+			h.SendKeepAliveRequest()
+			h.E2TKeepAliveExpired()
+		}
 	}
 }
 
@@ -68,10 +100,14 @@ func (h E2TKeepAliveWorker) E2TKeepAliveExpired() {
 		return
 	}
 
+	// instances that are gone should not keep reporting their last age
+	metrics.E2TKeepAliveAge.Reset()
+
 	for _, e2tInstance := range e2tInstances {
 
 		delta := int64(time.Now().UnixNano()) - e2tInstance.KeepAliveTimestamp
-		timestampNanosec := int64(time.Duration(h.config.KeepAliveResponseTimeoutMs) * time.Millisecond)
+		metrics.E2TKeepAliveAge.WithLabelValues(e2tInstance.Address).Set(time.Duration(delta).Seconds())
+		timestampNanosec := int64(time.Duration(h.config.GetKeepAliveResponseTimeoutMs()) * time.Millisecond)
 
 		if delta > timestampNanosec {
 
@@ -21,6 +21,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
@@ -36,12 +37,19 @@ import (
 )
 
 func initE2TKeepAliveTest(t *testing.T) (*mocks.RmrMessengerMock, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.E2TShutdownManagerMock, *E2TKeepAliveWorker) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(true)
+	return initE2TKeepAliveTestWithLeaderElector(t, false, leaderElectorMock)
+}
+
+func initE2TKeepAliveTestWithLeaderElector(t *testing.T, leaderElectionEnabled bool, leaderElector ILeaderElector) (*mocks.RmrMessengerMock, *mocks.RnibReaderMock, *mocks.RnibWriterMock, *mocks.E2TShutdownManagerMock, *E2TKeepAliveWorker) {
 	DebugLevel := int8(4)
 	logger, err := logger.InitLogger(DebugLevel)
 	if err != nil {
 		t.Errorf("#... - failed to initialize logger, error: %s", err)
 	}
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, KeepAliveResponseTimeoutMs: 400, KeepAliveDelayMs: 100}
+	config.LeaderElection.Enabled = leaderElectionEnabled
 
 	readerMock := &mocks.RnibReaderMock{}
 	writerMock := &mocks.RnibWriterMock{}
@@ -53,7 +61,7 @@ func initE2TKeepAliveTest(t *testing.T) (*mocks.RmrMessengerMock, *mocks.RnibRea
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrSender := initRmrSender(rmrMessengerMock, logger)
 
-	e2tKeepAliveWorker := NewE2TKeepAliveWorker(logger, rmrSender, e2tInstancesManager, e2tShutdownManagerMock, config)
+	e2tKeepAliveWorker := NewE2TKeepAliveWorker(logger, rmrSender, e2tInstancesManager, e2tShutdownManagerMock, config, leaderElector)
 
 	return rmrMessengerMock, readerMock, writerMock, e2tShutdownManagerMock, &e2tKeepAliveWorker
 }
@@ -193,7 +201,7 @@ func TestExecute_Two_E2TExpired(t *testing.T) {
 	e2tShutdownManagerMock.On("Shutdown", e2tInstance1).Return(nil)
 	rmrMessengerMock.On("SendMsg", mock.Anything, false).Return(&rmrCgo.MBuf{}, nil)
 
-	go e2tKeepAliveWorker.Execute()
+	go e2tKeepAliveWorker.Execute(context.Background())
 
 	time.Sleep(time.Duration(500) * time.Millisecond)
 
@@ -204,3 +212,61 @@ func TestExecute_Two_E2TExpired(t *testing.T) {
 	rmrMessengerMock.AssertCalled(t, "SendMsg", req, false)
 	e2tShutdownManagerMock.AssertCalled(t, "Shutdown", e2tInstance1)
 }
+
+func TestExecute_StopsWhenContextIsCancelled(t *testing.T) {
+	_, _, _, _, e2tKeepAliveWorker := initE2TKeepAliveTest(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e2tKeepAliveWorker.Execute(ctx)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("#E2TKeepAliveWorkerTest.TestExecute_StopsWhenContextIsCancelled - keep alive worker did not stop")
+	}
+}
+
+func TestExecute_FollowerSendsNoKeepAlive(t *testing.T) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(false)
+	rmrMessengerMock, readerMock, _, e2tShutdownManagerMock, e2tKeepAliveWorker := initE2TKeepAliveTestWithLeaderElector(t, true, leaderElectorMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go e2tKeepAliveWorker.Execute(ctx)
+
+	time.Sleep(time.Duration(300) * time.Millisecond)
+	cancel()
+
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
+	readerMock.AssertNotCalled(t, "GetE2TAddresses")
+	e2tShutdownManagerMock.AssertNotCalled(t, "Shutdown", mock.Anything)
+}
+
+func TestExecute_NewLeaderResetsKeepAliveTimestamps(t *testing.T) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(true)
+	rmrMessengerMock, readerMock, writerMock, e2tShutdownManagerMock, e2tKeepAliveWorker := initE2TKeepAliveTestWithLeaderElector(t, true, leaderElectorMock)
+
+	addresses := []string{E2TAddress}
+	e2tInstance := entities.NewE2TInstance(E2TAddress, PodName)
+
+	readerMock.On("GetE2TAddresses").Return(addresses, nil)
+	readerMock.On("GetE2TInstances", addresses).Return([]*entities.E2TInstance{e2tInstance}, nil)
+	writerMock.On("SaveE2TInstance", e2tInstance).Return(nil)
+	rmrMessengerMock.On("SendMsg", mock.Anything, false).Return(&rmrCgo.MBuf{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go e2tKeepAliveWorker.Execute(ctx)
+
+	time.Sleep(time.Duration(300) * time.Millisecond)
+	cancel()
+
+	writerMock.AssertCalled(t, "SaveE2TInstance", e2tInstance)
+	e2tShutdownManagerMock.AssertNotCalled(t, "Shutdown", mock.Anything)
+}
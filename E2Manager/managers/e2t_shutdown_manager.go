@@ -21,6 +21,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/services"
@@ -40,9 +41,10 @@ type E2TShutdownManager struct {
 	e2TInstancesManager           IE2TInstancesManager
 	e2tAssociationManager         *E2TAssociationManager
 	ranConnectStatusChangeManager IRanConnectStatusChangeManager
+	ranAlarmService               services.RanAlarmService
 }
 
-func NewE2TShutdownManager(logger *logger.Logger, config *configuration.Configuration, rnibDataService services.RNibDataService, e2TInstancesManager IE2TInstancesManager, e2tAssociationManager *E2TAssociationManager, ranConnectStatusChangeManager IRanConnectStatusChangeManager) *E2TShutdownManager {
+func NewE2TShutdownManager(logger *logger.Logger, config *configuration.Configuration, rnibDataService services.RNibDataService, e2TInstancesManager IE2TInstancesManager, e2tAssociationManager *E2TAssociationManager, ranConnectStatusChangeManager IRanConnectStatusChangeManager, ranAlarmService services.RanAlarmService) *E2TShutdownManager {
 	return &E2TShutdownManager{
 		logger:                        logger,
 		config:                        config,
@@ -50,6 +52,7 @@ func NewE2TShutdownManager(logger *logger.Logger, config *configuration.Configur
 		e2TInstancesManager:           e2TInstancesManager,
 		e2tAssociationManager:         e2tAssociationManager,
 		ranConnectStatusChangeManager: ranConnectStatusChangeManager,
+		ranAlarmService:               ranAlarmService,
 	}
 }
 
@@ -62,7 +65,13 @@ func (m E2TShutdownManager) Shutdown(e2tInstance *entities.E2TInstance) error {
 		return nil
 	}
 
-	err := m.markE2tInstanceToBeDeleted(e2tInstance)
+	err := m.ranAlarmService.SetE2TKeepAliveLostAlarm(e2tInstance.Address)
+	if err != nil {
+		m.logger.Errorf("#E2TShutdownManager.Shutdown - Failed setting an alarm for E2T %s. Error: %v", e2tInstance.Address, err)
+		// log and proceed...
+	}
+
+	err = m.markE2tInstanceToBeDeleted(e2tInstance)
 	if err != nil {
 		m.logger.Errorf("#E2TShutdownManager.Shutdown - Failed to mark E2T %s as 'ToBeDeleted'.", e2tInstance.Address)
 		return err
@@ -96,7 +105,7 @@ func (m E2TShutdownManager) clearNodebsAssociation(ranNamesToBeDissociated []str
 			return err
 		}
 
-		_, err = m.ranConnectStatusChangeManager.ChangeStatus(nodeb, entities.ConnectionStatus_DISCONNECTED)
+		_, err = m.ranConnectStatusChangeManager.ChangeStatus(context.Background(), nodeb, entities.ConnectionStatus_DISCONNECTED)
 		if err != nil {
 			return err
 		}
@@ -56,13 +56,14 @@ func initE2TShutdownManagerTest(t *testing.T) (*E2TShutdownManager, *mocks.RnibR
 
 	e2tInstancesManager := NewE2TInstancesManager(rnibDataService, log)
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(log, config, httpClientMock)
+	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock, alarmManagerClient)
 
 	ranListManager := NewRanListManager(log, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(log, config)
+	ranAlarmService := services.NewRanAlarmService(log, config, alarmManagerClient)
 	ranConnectStatusChangeManager := NewRanConnectStatusChangeManager(log, rnibDataService, ranListManager, ranAlarmService)
 	associationManager := NewE2TAssociationManager(log, rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager)
-	shutdownManager := NewE2TShutdownManager(log, config, rnibDataService, e2tInstancesManager, associationManager, ranConnectStatusChangeManager)
+	shutdownManager := NewE2TShutdownManager(log, config, rnibDataService, e2tInstancesManager, associationManager, ranConnectStatusChangeManager, ranAlarmService)
 
 	return shutdownManager, readerMock, writerMock, httpClientMock
 }
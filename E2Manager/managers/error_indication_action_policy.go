@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/models"
+	"strings"
+)
+
+type ErrorIndicationActionPolicy struct {
+	defaultAction models.ErrorIndicationAction
+	causeActions  map[string]models.ErrorIndicationAction
+}
+
+func NewErrorIndicationActionPolicy(config *configuration.Configuration) *ErrorIndicationActionPolicy {
+	policy := &ErrorIndicationActionPolicy{
+		defaultAction: models.ErrorIndicationAction(config.ErrorIndication.DefaultAction),
+		causeActions:  make(map[string]models.ErrorIndicationAction, len(config.ErrorIndication.CauseActions)),
+	}
+
+	if policy.defaultAction == "" {
+		policy.defaultAction = models.ErrorIndicationActionRevert
+	}
+
+	for cause, action := range config.ErrorIndication.CauseActions {
+		policy.causeActions[strings.ToLower(cause)] = models.ErrorIndicationAction(strings.ToLower(action))
+	}
+
+	return policy
+}
+
+// GetAction looks up the action of the exact cause first, then the action of its cause group, then the default action
+func (p *ErrorIndicationActionPolicy) GetAction(cause *models.Cause) models.ErrorIndicationAction {
+	if cause == nil {
+		return p.defaultAction
+	}
+
+	causeName := strings.ToLower(cause.String())
+
+	if action, ok := p.causeActions[causeName]; ok {
+		return action
+	}
+
+	if action, ok := p.causeActions[strings.Split(causeName, "/")[0]]; ok {
+		return action
+	}
+
+	return p.defaultAction
+}
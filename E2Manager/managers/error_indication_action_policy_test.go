@@ -0,0 +1,48 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorIndicationActionPolicyDefaultAction(t *testing.T) {
+	policy := NewErrorIndicationActionPolicy(&configuration.Configuration{})
+
+	assert.Equal(t, models.ErrorIndicationActionRevert, policy.GetAction(nil))
+	assert.Equal(t, models.ErrorIndicationActionRevert, policy.GetAction(&models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}}))
+}
+
+func TestErrorIndicationActionPolicyCauseActions(t *testing.T) {
+	config := &configuration.Configuration{ErrorIndication: configuration.ErrorIndicationConfig{
+		DefaultAction: "log",
+		CauseActions:  map[string]string{"misc/hardware-failure": "reset", "misc": "ignore", "ricservice/ric-resource-limit": "disconnect"},
+	}}
+	policy := NewErrorIndicationActionPolicy(config)
+
+	assert.Equal(t, models.ErrorIndicationActionReset, policy.GetAction(&models.Cause{Misc: &models.CauseMisc{HardwareFailure: &struct{}{}}}))
+	assert.Equal(t, models.ErrorIndicationActionIgnore, policy.GetAction(&models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}}))
+	assert.Equal(t, models.ErrorIndicationActionDisconnect, policy.GetAction(&models.Cause{RicService: &models.CauseRicService{RicResourceLimit: &struct{}{}}}))
+	assert.Equal(t, models.ErrorIndicationActionLog, policy.GetAction(&models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}}))
+	assert.Equal(t, models.ErrorIndicationActionLog, policy.GetAction(nil))
+}
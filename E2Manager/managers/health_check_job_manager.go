@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"sync"
+	"time"
+)
+
+const (
+	MaxHealthCheckJobs = 100
+)
+
+type IHealthCheckJobManager interface {
+	CreateJob() string
+	QuerySent(jobId string, record *models.RicServiceQueryRecord)
+	QueryFailed(jobId string, ranName string)
+	HandleServiceUpdate(ranName string, transactionId string)
+	GetJob(jobId string) (*models.HealthCheckJob, error)
+}
+
+// HealthCheckJobManager keeps the outcome of the latest health checks, per RAN, so that callers can poll it by job id
+type HealthCheckJobManager struct {
+	logger *logger.Logger
+	jobs   *jobStore[*models.HealthCheckJob]
+	now    func() time.Time
+	mux    sync.Mutex
+}
+
+func NewHealthCheckJobManager(logger *logger.Logger) *HealthCheckJobManager {
+	return &HealthCheckJobManager{
+		logger: logger,
+		jobs:   newJobStore[*models.HealthCheckJob](MaxHealthCheckJobs),
+		now:    time.Now,
+	}
+}
+
+// CreateJob registers a new health check job, the oldest job is dropped once MaxHealthCheckJobs are kept
+func (m *HealthCheckJobManager) CreateJob() string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	jobId := newJobId()
+	m.jobs.add(jobId, &models.HealthCheckJob{
+		JobId:     jobId,
+		CreatedAt: m.now().UnixNano(),
+		Rans:      make([]*models.HealthCheckRanResult, 0),
+	})
+
+	m.logger.Infof("#HealthCheckJobManager.CreateJob - created health check job %s", jobId)
+	return jobId
+}
+
+func (m *HealthCheckJobManager) QuerySent(jobId string, record *models.RicServiceQueryRecord) {
+	m.addResult(jobId, &models.HealthCheckRanResult{
+		RanName:       record.RanName,
+		Status:        models.HealthCheckQuerySent,
+		TransactionId: record.TransactionId,
+		SentAt:        record.SentAt,
+		Deadline:      record.Deadline,
+	})
+}
+
+func (m *HealthCheckJobManager) QueryFailed(jobId string, ranName string) {
+	m.addResult(jobId, &models.HealthCheckRanResult{
+		RanName: ranName,
+		Status:  models.HealthCheckSendFailed,
+	})
+}
+
+// HandleServiceUpdate completes the RAN results of every job which is waiting for a RIC Service Update with the given transaction id,
+// an update which arrives after the deadline leaves the result timed out but records its latency
+func (m *HealthCheckJobManager) HandleServiceUpdate(ranName string, transactionId string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	receivedAt := m.now().UnixNano()
+
+	m.jobs.forEach(func(jobId string, job *models.HealthCheckJob) {
+		for _, result := range job.Rans {
+			if result.RanName != ranName || result.TransactionId != transactionId || result.ReceivedAt != 0 {
+				continue
+			}
+
+			if result.Status != models.HealthCheckQuerySent && result.Status != models.HealthCheckTimedOut {
+				continue
+			}
+
+			result.ReceivedAt = receivedAt
+			result.LatencyMs = time.Duration(receivedAt - result.SentAt).Milliseconds()
+
+			if receivedAt > result.Deadline {
+				result.Status = models.HealthCheckTimedOut
+				m.logger.Warnf("#HealthCheckJobManager.HandleServiceUpdate - RAN name: %s - RIC Service Update of health check job %s received after the deadline, latency: %dms", ranName, jobId, result.LatencyMs)
+				continue
+			}
+
+			result.Status = models.HealthCheckUpdateReceived
+			m.logger.Infof("#HealthCheckJobManager.HandleServiceUpdate - RAN name: %s - RIC Service Update of health check job %s received, latency: %dms", ranName, jobId, result.LatencyMs)
+		}
+	})
+}
+
+// GetJob returns a copy of the job, queries whose deadline passed without a RIC Service Update are reported as timed out
+func (m *HealthCheckJobManager) GetJob(jobId string) (*models.HealthCheckJob, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	job, ok := m.jobs.get(jobId)
+	if !ok {
+		m.logger.Infof("#HealthCheckJobManager.GetJob - health check job %s not found", jobId)
+		return nil, e2managererrors.NewResourceNotFoundError()
+	}
+
+	m.resolveTimeouts(job)
+
+	jobCopy := *job
+	jobCopy.Rans = make([]*models.HealthCheckRanResult, len(job.Rans))
+	for i, result := range job.Rans {
+		resultCopy := *result
+		jobCopy.Rans[i] = &resultCopy
+	}
+
+	return &jobCopy, nil
+}
+
+func (m *HealthCheckJobManager) addResult(jobId string, result *models.HealthCheckRanResult) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	job, ok := m.jobs.get(jobId)
+	if !ok {
+		m.logger.Warnf("#HealthCheckJobManager.addResult - RAN name: %s - health check job %s not found", result.RanName, jobId)
+		return
+	}
+
+	job.Rans = append(job.Rans, result)
+}
+
+func (m *HealthCheckJobManager) resolveTimeouts(job *models.HealthCheckJob) {
+	now := m.now().UnixNano()
+
+	for _, result := range job.Rans {
+		if result.Status == models.HealthCheckQuerySent && now > result.Deadline {
+			result.Status = models.HealthCheckTimedOut
+		}
+	}
+}
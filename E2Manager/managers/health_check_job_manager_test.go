@@ -0,0 +1,151 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initHealthCheckJobManagerTest(t *testing.T) (*HealthCheckJobManager, *time.Time) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+
+	now := time.Unix(1000, 0)
+	manager := NewHealthCheckJobManager(log)
+	manager.now = func() time.Time { return now }
+	return manager, &now
+}
+
+func healthCheckQueryRecord(ranName string, transactionId string, sentAt time.Time) *models.RicServiceQueryRecord {
+	return &models.RicServiceQueryRecord{
+		RanName:       ranName,
+		TransactionId: transactionId,
+		SentAt:        sentAt.UnixNano(),
+		Deadline:      sentAt.Add(10 * time.Second).UnixNano(),
+	}
+}
+
+func TestHealthCheckJobManagerUpdateReceived(t *testing.T) {
+	manager, now := initHealthCheckJobManagerTest(t)
+	jobId := manager.CreateJob()
+	manager.QuerySent(jobId, healthCheckQueryRecord("ran1", "11", *now))
+	manager.QueryFailed(jobId, "ran2")
+
+	*now = now.Add(250 * time.Millisecond)
+	manager.HandleServiceUpdate("ran1", "11")
+
+	job, err := manager.GetJob(jobId)
+	assert.Nil(t, err)
+	assert.Equal(t, jobId, job.JobId)
+	assert.Len(t, job.Rans, 2)
+	assert.Equal(t, models.HealthCheckUpdateReceived, job.Rans[0].Status)
+	assert.Equal(t, int64(250), job.Rans[0].LatencyMs)
+	assert.Equal(t, now.UnixNano(), job.Rans[0].ReceivedAt)
+	assert.Equal(t, models.HealthCheckSendFailed, job.Rans[1].Status)
+}
+
+func TestHealthCheckJobManagerUpdateWithOtherTransactionIdIgnored(t *testing.T) {
+	manager, now := initHealthCheckJobManagerTest(t)
+	jobId := manager.CreateJob()
+	manager.QuerySent(jobId, healthCheckQueryRecord("ran1", "11", *now))
+
+	manager.HandleServiceUpdate("ran1", "12")
+	manager.HandleServiceUpdate("ran2", "11")
+
+	job, _ := manager.GetJob(jobId)
+	assert.Equal(t, models.HealthCheckQuerySent, job.Rans[0].Status)
+	assert.Zero(t, job.Rans[0].ReceivedAt)
+}
+
+func TestHealthCheckJobManagerTimedOut(t *testing.T) {
+	manager, now := initHealthCheckJobManagerTest(t)
+	jobId := manager.CreateJob()
+	manager.QuerySent(jobId, healthCheckQueryRecord("ran1", "11", *now))
+
+	*now = now.Add(11 * time.Second)
+	job, _ := manager.GetJob(jobId)
+	assert.Equal(t, models.HealthCheckTimedOut, job.Rans[0].Status)
+	assert.Zero(t, job.Rans[0].ReceivedAt)
+}
+
+func TestHealthCheckJobManagerLateUpdateStaysTimedOut(t *testing.T) {
+	manager, now := initHealthCheckJobManagerTest(t)
+	jobId := manager.CreateJob()
+	manager.QuerySent(jobId, healthCheckQueryRecord("ran1", "11", *now))
+
+	*now = now.Add(12 * time.Second)
+	manager.HandleServiceUpdate("ran1", "11")
+
+	job, _ := manager.GetJob(jobId)
+	assert.Equal(t, models.HealthCheckTimedOut, job.Rans[0].Status)
+	assert.Equal(t, int64(12000), job.Rans[0].LatencyMs)
+}
+
+func TestHealthCheckJobManagerGetJobReturnsCopy(t *testing.T) {
+	manager, now := initHealthCheckJobManagerTest(t)
+	jobId := manager.CreateJob()
+	manager.QuerySent(jobId, healthCheckQueryRecord("ran1", "11", *now))
+
+	job, _ := manager.GetJob(jobId)
+	job.Rans[0].Status = models.HealthCheckSendFailed
+
+	job, _ = manager.GetJob(jobId)
+	assert.Equal(t, models.HealthCheckQuerySent, job.Rans[0].Status)
+}
+
+func TestHealthCheckJobManagerJobNotFound(t *testing.T) {
+	manager, _ := initHealthCheckJobManagerTest(t)
+
+	job, err := manager.GetJob("1")
+	assert.Nil(t, job)
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
+
+func TestHealthCheckJobManagerOldestJobEvicted(t *testing.T) {
+	manager, _ := initHealthCheckJobManagerTest(t)
+
+	var jobIds []string
+	for i := 0; i <= MaxHealthCheckJobs; i++ {
+		jobIds = append(jobIds, manager.CreateJob())
+	}
+
+	_, err := manager.GetJob(jobIds[0])
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+
+	_, err = manager.GetJob(jobIds[MaxHealthCheckJobs])
+	assert.Nil(t, err)
+	assert.Len(t, manager.jobs.jobs, MaxHealthCheckJobs)
+}
+
+func TestHealthCheckJobManagerRandomJobIds(t *testing.T) {
+	manager, _ := initHealthCheckJobManagerTest(t)
+
+	jobId := manager.CreateJob()
+	assert.Len(t, jobId, 32)
+	assert.NotEqual(t, jobId, manager.CreateJob())
+}
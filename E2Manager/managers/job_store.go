@@ -0,0 +1,68 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// jobStore keeps the latest jobs of a manager by job id, the oldest job is dropped once maxJobs are kept. It is not
+// safe for concurrent use, the manager owning it guards it with its own mutex
+type jobStore[J any] struct {
+	maxJobs int
+	jobs    map[string]J
+	jobIds  []string
+}
+
+func newJobStore[J any](maxJobs int) *jobStore[J] {
+	return &jobStore[J]{
+		maxJobs: maxJobs,
+		jobs:    make(map[string]J),
+	}
+}
+
+// newJobId returns a random job id, so that job ids are neither guessable nor reused after a restart
+func newJobId() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func (s *jobStore[J]) add(jobId string, job J) {
+	s.jobs[jobId] = job
+	s.jobIds = append(s.jobIds, jobId)
+
+	if len(s.jobIds) > s.maxJobs {
+		delete(s.jobs, s.jobIds[0])
+		s.jobIds = s.jobIds[1:]
+	}
+}
+
+func (s *jobStore[J]) get(jobId string) (J, bool) {
+	job, ok := s.jobs[jobId]
+	return job, ok
+}
+
+// forEach visits the jobs from the oldest to the latest
+func (s *jobStore[J]) forEach(visit func(jobId string, job J)) {
+	for _, jobId := range s.jobIds {
+		visit(jobId, s.jobs[jobId])
+	}
+}
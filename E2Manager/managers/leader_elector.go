@@ -0,0 +1,233 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/metrics"
+	"e2mgr/models"
+	"e2mgr/services"
+	"sync"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+)
+
+type ILeaderElector interface {
+	IsLeader() bool
+}
+
+// LeaderElector decides which E2 Manager replica runs the E2T keep alive, the E2T shutdown, the scheduled jobs and
+// the RMR notification handlers. The replicas compete for a lease in SDL, the leader renews it every RenewIntervalSec
+// and a follower takes it over once it has not been renewed for LeaseDurationSec. With leader election disabled the
+// replica is always the leader.
+//
+// The ExpiresAt written in the lease comes from the wall clock of its holder, so comparing it with the clock of
+// another replica would let a clock skew between them elect two leaders at once. Both sides therefore measure the
+// lease duration on their own monotonic clock instead: the leader from the moment it started its last successful
+// renewal, a follower from the moment it last saw the lease change. The follower's interval starts after the
+// leader's and is at least as long, so the leader always gives up before a follower may take over
+type LeaderElector struct {
+	logger        *logger.Logger
+	config        configuration.LeaderElectionConfig
+	store         services.LeaderLeaseStore
+	holderId      string
+	lease         *models.LeaderLease
+	renewedAt     time.Time
+	observedLease *models.LeaderLease
+	observedAt    time.Time
+	leader        bool
+	now           func() time.Time
+	mux           sync.Mutex
+}
+
+func NewLeaderElector(logger *logger.Logger, config *configuration.Configuration, store services.LeaderLeaseStore, holderId string) *LeaderElector {
+	e := &LeaderElector{
+		logger:   logger,
+		config:   config.LeaderElection,
+		store:    store,
+		holderId: holderId,
+		now:      time.Now,
+	}
+
+	if !e.config.Enabled {
+		metrics.LeaderElectionIsLeader.Set(1)
+	}
+
+	return e
+}
+
+func (e *LeaderElector) IsLeader() bool {
+	if !e.config.Enabled {
+		return true
+	}
+
+	now := e.now()
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.leader && !e.ownLeaseExpired(now)
+}
+
+// Execute competes for the lease until ctx is done, then releases it so that a follower can take over right away
+func (e *LeaderElector) Execute(ctx context.Context) {
+	if !e.config.Enabled {
+		return
+	}
+
+	e.logger.Infof("#LeaderElector.Execute - leader election started, holder id: %s", e.holderId)
+
+	ticker := time.NewTicker(time.Duration(e.config.RenewIntervalSec) * time.Second)
+	defer ticker.Stop()
+
+	e.Elect()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.Release()
+			e.logger.Infof("#LeaderElector.Execute - leader election stopped")
+			return
+		case <-ticker.C:
+			e.Elect()
+		}
+	}
+}
+
+// Elect renews the lease held by this replica, acquires a free lease or takes over an expired one
+func (e *LeaderElector) Elect() {
+	now := e.now()
+	newLease := models.NewLeaderLease(e.holderId, now.Add(time.Duration(e.config.LeaseDurationSec)*time.Second).UnixMilli())
+
+	currentLease, err := e.store.Get()
+
+	if err != nil {
+		if _, ok := err.(*common.ResourceNotFoundError); !ok {
+			e.logger.Errorf("#LeaderElector.Elect - failed reading leader lease. error: %s", err)
+			e.stepDownIfExpired(now)
+			return
+		}
+
+		ok, err := e.store.Acquire(newLease)
+		e.handleLeaseUpdate(now, newLease, ok, err)
+		return
+	}
+
+	if currentLease.HolderId != e.holderId {
+		if !e.observedLeaseExpired(now, currentLease) {
+			e.setFollower()
+			return
+		}
+
+		e.logger.Infof("#LeaderElector.Elect - leader lease of %s was not renewed for %d seconds, taking over", currentLease.HolderId, e.config.LeaseDurationSec)
+	}
+
+	ok, err := e.store.Replace(currentLease, newLease)
+	e.handleLeaseUpdate(now, newLease, ok, err)
+}
+
+// Release gives up the lease if this replica still holds it
+func (e *LeaderElector) Release() {
+	e.mux.Lock()
+	lease := e.lease
+	e.mux.Unlock()
+
+	if lease == nil {
+		return
+	}
+
+	_, err := e.store.Release(lease)
+
+	if err != nil {
+		e.logger.Errorf("#LeaderElector.Release - failed releasing leader lease. error: %s", err)
+	}
+
+	e.setFollower()
+}
+
+func (e *LeaderElector) handleLeaseUpdate(now time.Time, newLease *models.LeaderLease, ok bool, err error) {
+	if err != nil {
+		e.logger.Errorf("#LeaderElector.handleLeaseUpdate - failed updating leader lease. error: %s", err)
+		e.stepDownIfExpired(now)
+		return
+	}
+
+	if !ok {
+		e.setFollower()
+		return
+	}
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	if !e.leader {
+		e.logger.Infof("#LeaderElector.handleLeaseUpdate - became leader, holder id: %s", e.holderId)
+	}
+
+	e.lease = newLease
+	e.renewedAt = now
+	e.leader = true
+	metrics.LeaderElectionIsLeader.Set(1)
+}
+
+// stepDownIfExpired keeps the leadership while SDL is unreachable, but not beyond the lease a follower could take over
+func (e *LeaderElector) stepDownIfExpired(now time.Time) {
+	e.mux.Lock()
+	expired := e.lease != nil && e.ownLeaseExpired(now.Add(time.Duration(e.config.RenewIntervalSec)*time.Second))
+	e.mux.Unlock()
+
+	if expired {
+		e.setFollower()
+	}
+}
+
+// ownLeaseExpired tells whether the lease of this replica is over at the given time. Must be called with e.mux held
+func (e *LeaderElector) ownLeaseExpired(at time.Time) bool {
+	return at.Sub(e.renewedAt) >= time.Duration(e.config.LeaseDurationSec)*time.Second
+}
+
+// observedLeaseExpired tells whether the lease of another replica has stayed unchanged for LeaseDurationSec since this
+// replica first saw it. Any renewal or change of holder restarts the count
+func (e *LeaderElector) observedLeaseExpired(now time.Time, lease *models.LeaderLease) bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	if e.observedLease == nil || *e.observedLease != *lease {
+		e.observedLease = lease
+		e.observedAt = now
+		return false
+	}
+
+	return now.Sub(e.observedAt) >= time.Duration(e.config.LeaseDurationSec)*time.Second
+}
+
+func (e *LeaderElector) setFollower() {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	if e.leader {
+		e.logger.Warnf("#LeaderElector.setFollower - lost leadership, holder id: %s", e.holderId)
+	}
+
+	e.lease = nil
+	e.leader = false
+	metrics.LeaderElectionIsLeader.Set(0)
+}
@@ -0,0 +1,217 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const leaderHolderId = "e2mgr-0"
+
+func initLeaderElectorTest(t *testing.T, enabled bool) (*LeaderElector, *mocks.LeaderLeaseStoreMock, *time.Time) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+
+	config := &configuration.Configuration{LeaderElection: configuration.LeaderElectionConfig{Enabled: enabled, LeaseDurationSec: 15, RenewIntervalSec: 5}}
+	storeMock := &mocks.LeaderLeaseStoreMock{}
+	now := time.Unix(1000, 0)
+	elector := NewLeaderElector(log, config, storeMock, leaderHolderId)
+	elector.now = func() time.Time { return now }
+	return elector, storeMock, &now
+}
+
+func leaseUntil(holderId string, expiresAt time.Time) *models.LeaderLease {
+	return models.NewLeaderLease(holderId, expiresAt.UnixMilli())
+}
+
+func TestLeaderElectorDisabledIsAlwaysLeader(t *testing.T) {
+	elector, storeMock, _ := initLeaderElectorTest(t, false)
+	elector.Execute(context.Background())
+	assert.True(t, elector.IsLeader())
+	storeMock.AssertNotCalled(t, "Get")
+}
+
+func TestLeaderElectorAcquiresFreeLease(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	storeMock.On("Get").Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Acquire", leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(true, nil)
+
+	elector.Elect()
+
+	assert.True(t, elector.IsLeader())
+	storeMock.AssertExpectations(t)
+}
+
+func TestLeaderElectorLosesRaceForFreeLease(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	storeMock.On("Get").Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Acquire", leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(false, nil)
+
+	elector.Elect()
+
+	assert.False(t, elector.IsLeader())
+}
+
+func TestLeaderElectorFollowsWhileOtherLeaseValid(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	storeMock.On("Get").Return(leaseUntil("e2mgr-1", now.Add(time.Second)), nil)
+
+	elector.Elect()
+
+	assert.False(t, elector.IsLeader())
+	storeMock.AssertNotCalled(t, "Replace")
+}
+
+func TestLeaderElectorTakesOverLeaseNotRenewedForLeaseDuration(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	staleLease := leaseUntil("e2mgr-1", now.Add(-time.Second))
+	storeMock.On("Get").Return(staleLease, nil)
+
+	elector.Elect()
+	assert.False(t, elector.IsLeader())
+	storeMock.AssertNotCalled(t, "Replace")
+
+	*now = now.Add(15 * time.Second)
+	storeMock.On("Replace", staleLease, leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(true, nil)
+
+	elector.Elect()
+
+	assert.True(t, elector.IsLeader())
+	storeMock.AssertExpectations(t)
+}
+
+func TestLeaderElectorIgnoresClockOfOtherHolder(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+
+	// the other holder's clock is a minute behind, its lease looks expired but it keeps renewing it
+	for i := 0; i < 5; i++ {
+		renewedLease := leaseUntil("e2mgr-1", now.Add(-time.Minute))
+		storeMock.On("Get").Return(renewedLease, nil).Once()
+
+		elector.Elect()
+
+		assert.False(t, elector.IsLeader())
+		*now = now.Add(5 * time.Second)
+	}
+
+	storeMock.AssertNotCalled(t, "Replace")
+}
+
+func TestLeaderElectorFollowsLeaseWithFutureExpiryOnceNotRenewed(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+
+	// the other holder's clock is an hour ahead, the lease must not be trusted until then
+	staleLease := leaseUntil("e2mgr-1", now.Add(time.Hour))
+	storeMock.On("Get").Return(staleLease, nil)
+	elector.Elect()
+
+	*now = now.Add(15 * time.Second)
+	storeMock.On("Replace", staleLease, leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(true, nil)
+	elector.Elect()
+
+	assert.True(t, elector.IsLeader())
+}
+
+func TestLeaderElectorRenewsOwnLease(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	storeMock.On("Get").Return(nil, common.NewResourceNotFoundError("not found")).Once()
+	storeMock.On("Acquire", leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(true, nil)
+	elector.Elect()
+
+	ownLease := leaseUntil(leaderHolderId, now.Add(15*time.Second))
+	*now = now.Add(5 * time.Second)
+	storeMock.On("Get").Return(ownLease, nil)
+	storeMock.On("Replace", ownLease, leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(true, nil)
+
+	elector.Elect()
+
+	assert.True(t, elector.IsLeader())
+	storeMock.AssertExpectations(t)
+}
+
+func TestLeaderElectorStepsDownWhenRenewalRejected(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	elector.leader = true
+	ownLease := leaseUntil(leaderHolderId, now.Add(10*time.Second))
+	elector.lease = ownLease
+	elector.renewedAt = *now
+	storeMock.On("Get").Return(ownLease, nil)
+	storeMock.On("Replace", ownLease, leaseUntil(leaderHolderId, now.Add(15*time.Second))).Return(false, nil)
+
+	elector.Elect()
+
+	assert.False(t, elector.IsLeader())
+}
+
+func TestLeaderElectorKeepsLeadershipOnSdlErrorUntilLeaseExpires(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	elector.leader = true
+	elector.lease = leaseUntil(leaderHolderId, now.Add(15*time.Second))
+	elector.renewedAt = *now
+	storeMock.On("Get").Return(nil, common.NewInternalError(assert.AnError))
+
+	*now = now.Add(5 * time.Second)
+	elector.Elect()
+	assert.True(t, elector.IsLeader())
+
+	// the lease would expire before the next renewal attempt
+	*now = now.Add(5 * time.Second)
+	elector.Elect()
+	assert.False(t, elector.IsLeader())
+}
+
+func TestLeaderElectorIsNotLeaderOnceOwnLeaseExpired(t *testing.T) {
+	elector, _, now := initLeaderElectorTest(t, true)
+	elector.leader = true
+	elector.lease = leaseUntil(leaderHolderId, now.Add(15*time.Second))
+	elector.renewedAt = *now
+
+	*now = now.Add(14 * time.Second)
+	assert.True(t, elector.IsLeader())
+
+	*now = now.Add(time.Second)
+	assert.False(t, elector.IsLeader())
+}
+
+func TestLeaderElectorReleasesLeaseOnStop(t *testing.T) {
+	elector, storeMock, now := initLeaderElectorTest(t, true)
+	ownLease := leaseUntil(leaderHolderId, now.Add(15*time.Second))
+	storeMock.On("Get").Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Acquire", ownLease).Return(true, nil)
+	storeMock.On("Release", ownLease).Return(true, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	elector.Execute(ctx)
+
+	assert.False(t, elector.IsLeader())
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,211 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package notificationmanager
+
+import (
+	"e2mgr/handlers/rmrmsghandlers"
+	"e2mgr/logger"
+	"e2mgr/metrics"
+	"e2mgr/models"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const e2tWorker = "e2t"
+
+type notificationTask struct {
+	handler     rmrmsghandlers.NotificationHandler
+	request     *models.NotificationRequest
+	messageType string
+}
+
+// NotificationDispatcher hands RMR notifications to a fixed set of workers. Notifications are sharded by RAN name,
+// so those of the same RAN are handled one at a time, in arrival order. When the queue of a worker is full,
+// Dispatch blocks, pushing back on the RMR receiver. E2T level notifications do not belong to a RAN and are handled
+// apart, so a busy RAN shard cannot delay them.
+type NotificationDispatcher struct {
+	logger  *logger.Logger
+	queues  []chan notificationTask
+	workers sync.WaitGroup
+	delayed map[*time.Timer]notificationTask
+	firing  sync.WaitGroup
+	stopped bool
+	mux     sync.Mutex
+}
+
+func NewNotificationDispatcher(logger *logger.Logger, workers int, queueSize int) *NotificationDispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	queues := make([]chan notificationTask, workers)
+	for i := range queues {
+		queues[i] = make(chan notificationTask, queueSize)
+	}
+
+	return &NotificationDispatcher{
+		logger:  logger,
+		queues:  queues,
+		delayed: make(map[*time.Timer]notificationTask),
+	}
+}
+
+func (d *NotificationDispatcher) Start() {
+	d.logger.Infof("#NotificationDispatcher.Start - starting %d notification workers, queue size: %d", len(d.queues), cap(d.queues[0]))
+
+	for i, queue := range d.queues {
+		d.workers.Add(1)
+		go d.work(strconv.Itoa(i), queue)
+	}
+}
+
+// Stop dispatches the delayed notifications right away and waits for the queued notifications to be handled. Dispatch
+// must not be called afterwards.
+func (d *NotificationDispatcher) Stop() {
+	d.mux.Lock()
+	d.stopped = true
+	var delayed []notificationTask
+	for timer, task := range d.delayed {
+		timer.Stop()
+		delayed = append(delayed, task)
+	}
+	d.delayed = make(map[*time.Timer]notificationTask)
+	d.mux.Unlock()
+
+	if len(delayed) != 0 {
+		d.logger.Infof("#NotificationDispatcher.Stop - dispatching %d delayed notifications", len(delayed))
+	}
+
+	for _, task := range delayed {
+		d.Dispatch(task.handler, task.request, task.messageType)
+	}
+
+	d.firing.Wait()
+
+	for _, queue := range d.queues {
+		close(queue)
+	}
+
+	d.workers.Wait()
+	d.logger.Infof("#NotificationDispatcher.Stop - all notification workers stopped")
+}
+
+func (d *NotificationDispatcher) Dispatch(handler rmrmsghandlers.NotificationHandler, request *models.NotificationRequest, messageType string) {
+	worker := d.shard(request.RanName)
+	queue := d.queues[worker]
+	task := notificationTask{handler: handler, request: request, messageType: messageType}
+
+	select {
+	case queue <- task:
+	default:
+		d.logger.Warnf("#NotificationDispatcher.Dispatch - RAN name: %s - queue of worker %d is full, waiting", request.RanName, worker)
+		metrics.NotificationQueueFull.Inc()
+		queue <- task
+	}
+
+	metrics.NotificationQueueLength.WithLabelValues(strconv.Itoa(worker)).Set(float64(len(queue)))
+}
+
+// DispatchAfter dispatches the notification once the delay elapsed. Once the dispatcher is stopping, the notification
+// is handled right away by the caller, which is the worker of the RAN when called by a notification handler
+func (d *NotificationDispatcher) DispatchAfter(delay time.Duration, handler rmrmsghandlers.NotificationHandler, request *models.NotificationRequest, messageType string) {
+	d.mux.Lock()
+
+	if d.stopped {
+		d.mux.Unlock()
+		d.logger.Infof("#NotificationDispatcher.DispatchAfter - RAN name: %s - dispatcher is stopping, handling the delayed notification right away", request.RanName)
+		handler.Handle(request)
+		return
+	}
+
+	task := notificationTask{handler: handler, request: request, messageType: messageType}
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		d.fire(timer)
+	})
+	d.delayed[timer] = task
+	d.mux.Unlock()
+}
+
+func (d *NotificationDispatcher) fire(timer *time.Timer) {
+	d.mux.Lock()
+	task, ok := d.delayed[timer]
+	if ok {
+		delete(d.delayed, timer)
+		d.firing.Add(1)
+	}
+	d.mux.Unlock()
+
+	if !ok {
+		return
+	}
+
+	defer d.firing.Done()
+
+	// the queue wait is measured from the end of the delay
+	request := *task.request
+	request.StartTime = time.Now()
+	d.Dispatch(task.handler, &request, task.messageType)
+}
+
+// DispatchE2TMessage handles a notification of an E2T instance, such as a keep alive response or E2_TERM_INIT, in its
+// own goroutine
+func (d *NotificationDispatcher) DispatchE2TMessage(handler rmrmsghandlers.NotificationHandler, request *models.NotificationRequest, messageType string) {
+	d.workers.Add(1)
+
+	go func() {
+		defer d.workers.Done()
+		d.handle(e2tWorker, notificationTask{handler: handler, request: request, messageType: messageType})
+	}()
+}
+
+func (d *NotificationDispatcher) shard(ranName string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(ranName))
+	return int(hash.Sum32() % uint32(len(d.queues)))
+}
+
+func (d *NotificationDispatcher) work(worker string, queue chan notificationTask) {
+	defer d.workers.Done()
+
+	for task := range queue {
+		metrics.NotificationQueueLength.WithLabelValues(worker).Set(float64(len(queue)))
+		d.handle(worker, task)
+	}
+}
+
+func (d *NotificationDispatcher) handle(worker string, task notificationTask) {
+	metrics.NotificationQueueWait.Observe(time.Since(task.request.StartTime).Seconds())
+
+	log := d.logger.With(logger.RequestId(task.request.RequestId), logger.RanName(task.request.RanName), logger.MsgType(task.messageType), logger.TransactionId(string(task.request.TransactionId)))
+	log.Debugf("#NotificationDispatcher.handle - worker %s - handling notification", worker)
+
+	start := time.Now()
+	task.handler.Handle(task.request)
+	duration := time.Since(start)
+	metrics.RmrMessageHandlingDuration.WithLabelValues(task.messageType).Observe(duration.Seconds())
+
+	log.Debugf("#NotificationDispatcher.handle - worker %s - notification handled in %s", worker, duration)
+}
@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package notificationmanager
+
+import (
+	"e2mgr/metrics"
+	"e2mgr/models"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHandler struct {
+	mutex   sync.Mutex
+	handled []string
+	release chan struct{}
+}
+
+func (h *recordingHandler) Handle(request *models.NotificationRequest) {
+	if h.release != nil {
+		<-h.release
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.handled = append(h.handled, string(request.Payload))
+}
+
+func (h *recordingHandler) getHandled() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]string(nil), h.handled...)
+}
+
+func newTestNotificationRequest(ranName string, payload string) *models.NotificationRequest {
+	return &models.NotificationRequest{RanName: ranName, Payload: []byte(payload), StartTime: time.Now()}
+}
+
+func TestNewNotificationDispatcherDefaults(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 0, -1)
+	assert.Len(t, dispatcher.queues, 1)
+	assert.Equal(t, 0, cap(dispatcher.queues[0]))
+}
+
+func TestDispatchSameRanInArrivalOrder(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 4, 10)
+	dispatcher.Start()
+	handler := &recordingHandler{}
+
+	var expected []string
+	for i := 0; i < 100; i++ {
+		payload := fmt.Sprintf("%d", i)
+		expected = append(expected, payload)
+		dispatcher.Dispatch(handler, newTestNotificationRequest("ran1", payload), "12001")
+	}
+
+	dispatcher.Stop()
+	assert.Equal(t, expected, handler.getHandled())
+}
+
+func TestDispatchDifferentRansInParallel(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 2, 10)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	blockedRan := "ran1"
+	otherRan := "ran2"
+	for i := 3; dispatcher.shard(otherRan) == dispatcher.shard(blockedRan); i++ {
+		otherRan = fmt.Sprintf("ran%d", i)
+	}
+
+	blockedHandler := &recordingHandler{release: make(chan struct{})}
+	otherHandler := &recordingHandler{}
+
+	dispatcher.Dispatch(blockedHandler, newTestNotificationRequest(blockedRan, "blocked"), "12001")
+	dispatcher.Dispatch(otherHandler, newTestNotificationRequest(otherRan, "other"), "12001")
+
+	assert.Eventually(t, func() bool {
+		return len(otherHandler.getHandled()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, blockedHandler.getHandled())
+
+	close(blockedHandler.release)
+}
+
+func TestDispatchBlocksWhenQueueIsFull(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 1, 1)
+	dispatcher.Start()
+	handler := &recordingHandler{release: make(chan struct{})}
+	queueFull := testutil.ToFloat64(metrics.NotificationQueueFull)
+
+	dispatcher.Dispatch(handler, newTestNotificationRequest("ran1", "1"), "12001")
+	assert.Eventually(t, func() bool {
+		return len(dispatcher.queues[0]) == 0
+	}, time.Second, 10*time.Millisecond)
+	dispatcher.Dispatch(handler, newTestNotificationRequest("ran1", "2"), "12001")
+
+	dispatched := make(chan struct{})
+	go func() {
+		dispatcher.Dispatch(handler, newTestNotificationRequest("ran1", "3"), "12001")
+		close(dispatched)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.NotificationQueueFull) == queueFull+1
+	}, time.Second, 10*time.Millisecond)
+
+	select {
+	case <-dispatched:
+		t.Fatal("dispatch should block while the worker queue is full")
+	default:
+	}
+
+	close(handler.release)
+	<-dispatched
+	dispatcher.Stop()
+	assert.Equal(t, []string{"1", "2", "3"}, handler.getHandled())
+}
+
+func TestDispatchE2TMessageNotBlockedByRanShard(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 1, 10)
+	dispatcher.Start()
+	blockedHandler := &recordingHandler{release: make(chan struct{})}
+	e2tHandler := &recordingHandler{}
+
+	dispatcher.Dispatch(blockedHandler, newTestNotificationRequest("ran1", "reset"), "12001")
+	dispatcher.DispatchE2TMessage(e2tHandler, newTestNotificationRequest("10.0.2.15:38000", "keepalive"), "1102")
+
+	assert.Eventually(t, func() bool {
+		return len(e2tHandler.getHandled()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, blockedHandler.getHandled())
+
+	close(blockedHandler.release)
+	dispatcher.Stop()
+	assert.Equal(t, []string{"reset"}, blockedHandler.getHandled())
+}
+
+func TestDispatchAfterHandledOnRanShard(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 1, 10)
+	dispatcher.Start()
+	handler := &recordingHandler{}
+
+	dispatcher.DispatchAfter(50*time.Millisecond, handler, newTestNotificationRequest("ran1", "delayed"), "12001")
+	dispatcher.Dispatch(handler, newTestNotificationRequest("ran1", "1"), "12001")
+
+	assert.Eventually(t, func() bool {
+		return len(handler.getHandled()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	dispatcher.Stop()
+	assert.Equal(t, []string{"1", "delayed"}, handler.getHandled())
+	assert.Empty(t, dispatcher.delayed)
+}
+
+func TestStopDispatchesDelayedNotifications(t *testing.T) {
+	dispatcher := NewNotificationDispatcher(initLog(t), 2, 10)
+	dispatcher.Start()
+	handler := &recordingHandler{}
+
+	dispatcher.DispatchAfter(time.Hour, handler, newTestNotificationRequest("ran1", "delayed"), "12001")
+	dispatcher.Stop()
+
+	assert.Equal(t, []string{"delayed"}, handler.getHandled())
+	assert.Empty(t, dispatcher.delayed)
+
+	dispatcher.DispatchAfter(time.Hour, handler, newTestNotificationRequest("ran1", "stopping"), "12001")
+	assert.Equal(t, []string{"delayed", "stopping"}, handler.getHandled())
+}
@@ -21,35 +21,67 @@
 package notificationmanager
 
 import (
+	"e2mgr/e2managererrors"
 	"e2mgr/logger"
+	"e2mgr/managers"
+	"e2mgr/metrics"
 	"e2mgr/models"
 	"e2mgr/providers/rmrmsghandlerprovider"
 	"e2mgr/rmrCgo"
+	"strconv"
 	"time"
 )
 
+// e2tMessageTypes are the notifications sent by an E2T instance about itself rather than about a RAN
+var e2tMessageTypes = map[int]bool{
+	rmrCgo.RIC_E2_TERM_INIT:        true,
+	rmrCgo.E2_TERM_KEEP_ALIVE_RESP: true,
+}
+
 type NotificationManager struct {
 	logger                      *logger.Logger
 	notificationHandlerProvider *rmrmsghandlerprovider.NotificationHandlerProvider
+	notificationDispatcher      *NotificationDispatcher
+	leaderElector               managers.ILeaderElector
 }
 
-func NewNotificationManager(logger *logger.Logger, notificationHandlerProvider *rmrmsghandlerprovider.NotificationHandlerProvider) *NotificationManager {
+func NewNotificationManager(logger *logger.Logger, notificationHandlerProvider *rmrmsghandlerprovider.NotificationHandlerProvider, notificationDispatcher *NotificationDispatcher, leaderElector managers.ILeaderElector) *NotificationManager {
 	return &NotificationManager{
 		logger:                      logger,
 		notificationHandlerProvider: notificationHandlerProvider,
+		notificationDispatcher:      notificationDispatcher,
+		leaderElector:               leaderElector,
 	}
 }
 
 func (m NotificationManager) HandleMessage(mbuf *rmrCgo.MBuf) error {
 
+	messageType := strconv.Itoa(mbuf.MType)
+	metrics.RmrMessagesReceived.WithLabelValues(messageType).Inc()
+
+	// the notification handlers update rNib and the RAN list, only the leader may run them
+	if !m.leaderElector.IsLeader() {
+		m.logger.Warnf("#NotificationManager.HandleMessage - not the leader, dropping message type: %d, RAN name: %s", mbuf.MType, mbuf.Meid)
+		metrics.RmrMessagesDroppedNotLeader.WithLabelValues(messageType).Inc()
+		return e2managererrors.NewNotLeaderError()
+	}
+
 	notificationHandler, err := m.notificationHandlerProvider.GetNotificationHandler(mbuf.MType)
 
 	if err != nil {
 		m.logger.Errorf("#NotificationManager.HandleMessage - Error: %s", err)
+		metrics.RmrMessagesWithoutHandler.WithLabelValues(messageType).Inc()
 		return err
 	}
 
 	notificationRequest := models.NewNotificationRequest(mbuf.Meid, *mbuf.Payload, time.Now(), *mbuf.XAction, mbuf.GetMsgSrc())
-	go notificationHandler.Handle(notificationRequest)
+	notificationRequest.RequestId = logger.NewRequestId()
+
+	if e2tMessageTypes[mbuf.MType] {
+		m.notificationDispatcher.DispatchE2TMessage(notificationHandler, notificationRequest, messageType)
+		return nil
+	}
+
+	m.notificationDispatcher.Dispatch(notificationHandler, notificationRequest, messageType)
 	return nil
 }
@@ -22,8 +22,10 @@ package notificationmanager
 import (
 	"e2mgr/clients"
 	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
 	"e2mgr/logger"
 	"e2mgr/managers"
+	"e2mgr/metrics"
 	"e2mgr/mocks"
 	"e2mgr/providers/rmrmsghandlerprovider"
 	"e2mgr/rmrCgo"
@@ -31,12 +33,22 @@ import (
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
 	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strconv"
 	"testing"
 )
 
 func initNotificationManagerTest(t *testing.T) (*logger.Logger, *mocks.RnibReaderMock, *NotificationManager) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(true)
+	return initNotificationManagerTestWithLeaderElector(t, leaderElectorMock)
+}
+
+func initNotificationManagerTestWithLeaderElector(t *testing.T, leaderElector managers.ILeaderElector) (*logger.Logger, *mocks.RnibReaderMock, *NotificationManager) {
 	logger := initLog(t)
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 
@@ -47,15 +59,23 @@ func initNotificationManagerTest(t *testing.T) (*logger.Logger, *mocks.RnibReade
 	rmrSender := initRmrSender(&mocks.RmrMessengerMock{}, logger)
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, logger)
-	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClient)
+	alarmManagerClient := clients.NewAlarmManagerClient(logger, config, httpClient)
+	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClient, alarmManagerClient)
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
-	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService)
+	ranAlarmService := services.NewRanAlarmService(logger, config, alarmManagerClient)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(logger, config, ranProcedureStoreMock)
+	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService, ranProcedureTracker, ranListManager)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService,ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(logger, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
 	rmrNotificationHandlerProvider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
-	rmrNotificationHandlerProvider.Init(logger, config, rnibDataService, rmrSender, e2tInstancesManager,routingManagerClient, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, RicServiceUpdateManager)
-	notificationManager := NewNotificationManager(logger, rmrNotificationHandlerProvider )
+	rmrNotificationHandlerProvider.Init(logger, config, rnibDataService, rmrSender, e2tInstancesManager,routingManagerClient, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, RicServiceUpdateManager, e2ResetTransactionManager, ranAlarmService, ranProcedureTracker, &mocks.ErrorIndicationStoreMock{}, &mocks.RicServiceQueryManagerMock{}, &mocks.HealthCheckJobManagerMock{}, managers.NewX2ResetTransactionManager(logger, config, ranProcedureTracker), NewNotificationDispatcher(logger, 1, 0))
+	notificationDispatcher := NewNotificationDispatcher(logger, 1, 10)
+	notificationDispatcher.Start()
+	notificationManager := NewNotificationManager(logger, rmrNotificationHandlerProvider, notificationDispatcher, leaderElector)
 	return logger, readerMock, notificationManager
 }
 
@@ -63,9 +83,13 @@ func TestHandleMessageUnexistingMessageType(t *testing.T) {
 	_, _, nm := initNotificationManagerTest(t)
 
 	mbuf := &rmrCgo.MBuf{MType: 1234}
+	received := testutil.ToFloat64(metrics.RmrMessagesReceived.WithLabelValues("1234"))
+	withoutHandler := testutil.ToFloat64(metrics.RmrMessagesWithoutHandler.WithLabelValues("1234"))
 
 	err := nm.HandleMessage(mbuf)
 	assert.NotNil(t, err)
+	assert.Equal(t, received+1, testutil.ToFloat64(metrics.RmrMessagesReceived.WithLabelValues("1234")))
+	assert.Equal(t, withoutHandler+1, testutil.ToFloat64(metrics.RmrMessagesWithoutHandler.WithLabelValues("1234")))
 }
 
 func TestHandleMessageExistingMessageType(t *testing.T) {
@@ -78,6 +102,22 @@ func TestHandleMessageExistingMessageType(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestHandleMessageDroppedWhenNotLeader(t *testing.T) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(false)
+	_, readerMock, nm := initNotificationManagerTestWithLeaderElector(t, leaderElectorMock)
+	payload := []byte("123")
+	xaction := []byte("test")
+	mbuf := &rmrCgo.MBuf{MType: rmrCgo.RIC_X2_SETUP_RESP, Meid: "test", Payload: &payload, XAction: &xaction}
+	dropped := testutil.ToFloat64(metrics.RmrMessagesDroppedNotLeader.WithLabelValues(strconv.Itoa(rmrCgo.RIC_X2_SETUP_RESP)))
+
+	err := nm.HandleMessage(mbuf)
+
+	assert.IsType(t, &e2managererrors.NotLeaderError{}, err)
+	assert.Equal(t, dropped+1, testutil.ToFloat64(metrics.RmrMessagesDroppedNotLeader.WithLabelValues(strconv.Itoa(rmrCgo.RIC_X2_SETUP_RESP))))
+	readerMock.AssertNotCalled(t, "GetNodeb", mock.Anything)
+}
+
 // TODO: extract to test_utils
 func initRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger) *rmrsender.RmrSender {
 	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
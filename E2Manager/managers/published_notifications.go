@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"sync"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+)
+
+// publishedNotificationExpiry bounds the time a notification published by this process is expected back. A
+// notification which never comes back, e.g. while the subscription is down, is forgotten afterwards
+const publishedNotificationExpiry = 10 * time.Second
+
+// PublishedNotifications records the rNib notifications this process publishes, so that the subscriber of the same
+// process can tell them from the notifications published by the other replicas and tools
+type PublishedNotifications struct {
+	mux       sync.Mutex
+	published map[string][]time.Time
+}
+
+func NewPublishedNotifications() *PublishedNotifications {
+	return &PublishedNotifications{
+		published: make(map[string][]time.Time),
+	}
+}
+
+// Recording returns the storage publishing through sdl, which records the notifications it publishes
+func (p *PublishedNotifications) Recording(sdl common.ISdlSyncStorage) common.ISdlSyncStorage {
+	return &recordingSdlStorage{ISdlSyncStorage: sdl, published: p}
+}
+
+// IsOwn tells whether a received notification is one this process published. Each recorded notification matches a
+// single received one
+func (p *PublishedNotifications) IsOwn(channel string, event string) bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	key := channel + "/" + event
+	times := p.unexpired(key)
+
+	if len(times) == 0 {
+		p.store(key, times)
+		return false
+	}
+
+	p.store(key, times[1:])
+	return true
+}
+
+func (p *PublishedNotifications) record(channelsAndEvents []string) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	now := time.Now()
+	for i := 0; i+1 < len(channelsAndEvents); i += 2 {
+		key := channelsAndEvents[i] + "/" + channelsAndEvents[i+1]
+		p.store(key, append(p.unexpired(key), now))
+	}
+}
+
+// unexpired returns the publication times of a notification which are recent enough to be expected back. It must be
+// called with the mutex held
+func (p *PublishedNotifications) unexpired(key string) []time.Time {
+	times := p.published[key]
+	oldest := time.Now().Add(-publishedNotificationExpiry)
+
+	for len(times) > 0 && times[0].Before(oldest) {
+		times = times[1:]
+	}
+
+	return times
+}
+
+func (p *PublishedNotifications) store(key string, times []time.Time) {
+	if len(times) == 0 {
+		delete(p.published, key)
+		return
+	}
+
+	p.published[key] = times
+}
+
+type recordingSdlStorage struct {
+	common.ISdlSyncStorage
+	published *PublishedNotifications
+}
+
+func (s *recordingSdlStorage) SetAndPublish(ns string, channelsAndEvents []string, pairs ...interface{}) error {
+	s.published.record(channelsAndEvents)
+	return s.ISdlSyncStorage.SetAndPublish(ns, channelsAndEvents, pairs...)
+}
+
+func (s *recordingSdlStorage) RemoveAndPublish(ns string, channelsAndEvents []string, keys []string) error {
+	s.published.record(channelsAndEvents)
+	return s.ISdlSyncStorage.RemoveAndPublish(ns, channelsAndEvents, keys)
+}
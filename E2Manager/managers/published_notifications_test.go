@@ -0,0 +1,57 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPublishedNotificationsRecordsEveryEvent(t *testing.T) {
+	publishedNotifications := NewPublishedNotifications()
+	sdlMock := &mocks.MockSdlSyncStorage{}
+	channelsAndEvents := []string{"RAN_MANIPULATION", "ran1_DELETED", "RAN_CONNECTION_STATUS_CHANGE", "ran1_DISCONNECTED"}
+	sdlMock.On("RemoveAndPublish", "e2Manager", channelsAndEvents, []string{"key"}).Return(nil)
+
+	err := publishedNotifications.Recording(sdlMock).RemoveAndPublish("e2Manager", channelsAndEvents, []string{"key"})
+
+	assert.Nil(t, err)
+	sdlMock.AssertExpectations(t)
+	assert.True(t, publishedNotifications.IsOwn("RAN_MANIPULATION", "ran1_DELETED"))
+	assert.True(t, publishedNotifications.IsOwn("RAN_CONNECTION_STATUS_CHANGE", "ran1_DISCONNECTED"))
+	assert.False(t, publishedNotifications.IsOwn("RAN_MANIPULATION", "ran1_DELETED"))
+	assert.False(t, publishedNotifications.IsOwn("RAN_MANIPULATION", "ran2_DELETED"))
+}
+
+func TestPublishedNotificationsExpire(t *testing.T) {
+	publishedNotifications := NewPublishedNotifications()
+	sdlMock := &mocks.MockSdlSyncStorage{}
+	sdlMock.On("SetAndPublish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sdl := publishedNotifications.Recording(sdlMock)
+
+	_ = sdl.SetAndPublish("e2Manager", []string{"RAN_MANIPULATION", "ran1_UPDATED"}, "key", "value")
+	publishedNotifications.published["RAN_MANIPULATION/ran1_UPDATED"][0] = time.Now().Add(-publishedNotificationExpiry - time.Second)
+
+	assert.False(t, publishedNotifications.IsOwn("RAN_MANIPULATION", "ran1_UPDATED"))
+	assert.Empty(t, publishedNotifications.published)
+}
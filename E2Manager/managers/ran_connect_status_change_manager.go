@@ -20,7 +20,9 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/logger"
+	"e2mgr/metrics"
 	"e2mgr/services"
 	"time"
 
@@ -35,7 +37,7 @@ const (
 )
 
 type IRanConnectStatusChangeManager interface {
-	ChangeStatus(nodebInfo *entities.NodebInfo, nextStatus entities.ConnectionStatus) (bool, error)
+	ChangeStatus(ctx context.Context, nodebInfo *entities.NodebInfo, nextStatus entities.ConnectionStatus) (bool, error)
 }
 
 type RanConnectStatusChangeManager struct {
@@ -54,14 +56,17 @@ func NewRanConnectStatusChangeManager(logger *logger.Logger, rnibDataService ser
 	}
 }
 
-func (m *RanConnectStatusChangeManager) ChangeStatus(nodebInfo *entities.NodebInfo, nextStatus entities.ConnectionStatus) (bool, error) {
-	m.logger.Infof("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s, currentStatus: %s, nextStatus: %s", nodebInfo.RanName, nodebInfo.GetConnectionStatus(), nextStatus)
+func (m *RanConnectStatusChangeManager) ChangeStatus(ctx context.Context, nodebInfo *entities.NodebInfo, nextStatus entities.ConnectionStatus) (bool, error) {
+	log := m.logger.WithContext(ctx).With(logger.RanName(nodebInfo.RanName))
+	log.Infof("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s, currentStatus: %s, nextStatus: %s", nodebInfo.RanName, nodebInfo.GetConnectionStatus(), nextStatus)
 
 	var ranStatusChangePublished bool
+	previousStatus := nodebInfo.GetConnectionStatus()
 
 	// set the proper event
 	event := m.setEvent(nodebInfo, nextStatus)
 	isConnectivityEvent := event != NONE_RAW_EVENT
+	isResetEnded := previousStatus == entities.ConnectionStatus_UNDER_RESET && nextStatus != entities.ConnectionStatus_UNDER_RESET
 
 	// only after determining event we set next status
 	nodebInfo.ConnectionStatus = nextStatus
@@ -80,20 +85,27 @@ func (m *RanConnectStatusChangeManager) ChangeStatus(nodebInfo *entities.NodebIn
 		ranStatusChangePublished = true
 	}
 
+	metrics.ConnectionStatusTransitions.WithLabelValues(previousStatus.String(), nextStatus.String()).Inc()
+
 	// in any case, update RanListManager
 	connectionStatus := nodebInfo.GetConnectionStatus()
-	m.logger.Infof("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s, updating RanListManager... status: %s", nodebInfo.RanName, connectionStatus)
+	log.Infof("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s, updating RanListManager... status: %s", nodebInfo.RanName, connectionStatus)
 	err := m.ranListManager.UpdateNbIdentityConnectionStatus(nodebInfo.GetNodeType(), nodebInfo.RanName, connectionStatus)
 	if err != nil {
-		m.logger.Errorf("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s - Failed updating RAN's connection status by RanListManager. Error: %v", nodebInfo.RanName, err)
+		log.Errorf("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s - Failed updating RAN's connection status by RanListManager. Error: %v", nodebInfo.RanName, err)
 		// log and proceed...
 	}
 
-	if isConnectivityEvent {
-		m.logger.Infof("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s, setting alarm at RanAlarmService... event: %s", nodebInfo.RanName, event)
+	if connectionStatus == entities.ConnectionStatus_CONNECTED && nodebInfo.AssociatedE2TInstanceAddress != "" {
+		m.ranListManager.UpdateNbIdentityE2TAddress(nodebInfo.RanName, nodebInfo.AssociatedE2TInstanceAddress)
+	}
+
+	// UNDER_RESET -> DISCONNECTED is not a connectivity event, yet the alarms should follow it
+	if isConnectivityEvent || isResetEnded {
+		log.Infof("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s, setting alarm at RanAlarmService... event: %s", nodebInfo.RanName, event)
 		err := m.ranAlarmService.SetConnectivityChangeAlarm(nodebInfo)
 		if err != nil {
-			m.logger.Errorf("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s - Failed setting an alarm by RanAlarmService. Error: %v", nodebInfo.RanName, err)
+			log.Errorf("#RanConnectStatusChangeManager.ChangeStatus - RAN name: %s - Failed setting an alarm by RanAlarmService. Error: %v", nodebInfo.RanName, err)
 			// log and proceed...
 		}
 	}
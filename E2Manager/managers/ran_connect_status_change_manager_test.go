@@ -20,6 +20,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
@@ -65,7 +66,22 @@ func TestChangeStatusSuccessNewRan(t *testing.T) {
 	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
 	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_CONNECTED)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_CONNECTED)
+	assert.Nil(t, err)
+	writerMock.AssertExpectations(t)
+	ranListManagerMock.AssertExpectations(t)
+	ranAlarmServiceMock.AssertExpectations(t)
+}
+
+func TestChangeStatusConnectedUpdatesE2TAddress(t *testing.T) {
+	writerMock, ranListManagerMock, ranAlarmServiceMock, ranConnectStatusChangeManager := initRanConnectStatusChangeManagerTest(t)
+
+	origNodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, AssociatedE2TInstanceAddress: "10.0.2.15:38000"}
+	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", origNodebInfo.GetNodeType(), RanName, entities.ConnectionStatus_CONNECTED).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityE2TAddress", RanName, "10.0.2.15:38000").Return()
+	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_CONNECTED)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -80,7 +96,7 @@ func TestChangeStatusSuccessEventNone1(t *testing.T) {
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -95,7 +111,7 @@ func TestChangeStatusSuccessEventNone2(t *testing.T) {
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -111,7 +127,7 @@ func TestChangeStatusSuccessEventConnected(t *testing.T) {
 	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
 	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_CONNECTED)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_CONNECTED)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -127,7 +143,23 @@ func TestChangeStatusSuccessEventDisconnected(t *testing.T) {
 	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+DISCONNECTED_RAW_EVENT).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
 	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_DISCONNECTED)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_DISCONNECTED)
+	assert.Nil(t, err)
+	writerMock.AssertExpectations(t)
+	ranListManagerMock.AssertExpectations(t)
+	ranAlarmServiceMock.AssertExpectations(t)
+}
+
+func TestChangeStatusSuccessUnderResetToDisconnected(t *testing.T) {
+	writerMock, ranListManagerMock, ranAlarmServiceMock, ranConnectStatusChangeManager := initRanConnectStatusChangeManagerTest(t)
+
+	origNodebInfo := &entities.NodebInfo{RanName: RanName, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+	updatedNodebInfo := *origNodebInfo
+	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
+	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
+	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
+	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(nil)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_DISCONNECTED)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -141,7 +173,7 @@ func TestChangeStatusRnibErrorEventNone(t *testing.T) {
 	updatedNodebInfo := *origNodebInfo
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(common.NewInternalError(errors.New("Error")))
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
 	assert.NotNil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -155,7 +187,7 @@ func TestChangeStatusRnibErrorEventConnected(t *testing.T) {
 	updatedNodebInfo := *origNodebInfo
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_CONNECTED
 	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(common.NewInternalError(errors.New("Error")))
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_CONNECTED)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_CONNECTED)
 	assert.NotNil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -170,7 +202,7 @@ func TestChangeStatusRanListManagerError(t *testing.T) {
 	updatedNodebInfo.ConnectionStatus = entities.ConnectionStatus_SHUT_DOWN
 	writerMock.On("UpdateNodebInfo", mock.Anything).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), ranName, updatedNodebInfo.GetConnectionStatus()).Return(common.NewInternalError(errors.New("Error")))
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_SHUT_DOWN)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
@@ -186,7 +218,7 @@ func TestChangeStatusRanAlarmServiceErrorEventConnected(t *testing.T) {
 	writerMock.On("UpdateNodebInfoOnConnectionStatusInversion", mock.Anything, RanName+"_"+CONNECTED_RAW_EVENT).Return(nil)
 	ranListManagerMock.On("UpdateNbIdentityConnectionStatus", updatedNodebInfo.GetNodeType(), RanName, updatedNodebInfo.GetConnectionStatus()).Return(nil)
 	ranAlarmServiceMock.On("SetConnectivityChangeAlarm", mock.Anything).Return(common.NewInternalError(errors.New("Error")))
-	_, err := ranConnectStatusChangeManager.ChangeStatus(origNodebInfo, entities.ConnectionStatus_CONNECTED)
+	_, err := ranConnectStatusChangeManager.ChangeStatus(context.Background(), origNodebInfo, entities.ConnectionStatus_CONNECTED)
 	assert.Nil(t, err)
 	writerMock.AssertExpectations(t)
 	ranListManagerMock.AssertExpectations(t)
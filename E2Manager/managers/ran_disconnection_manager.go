@@ -20,6 +20,7 @@
 package managers
 
 import (
+	"context"
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/services"
@@ -65,11 +66,11 @@ func (m *RanDisconnectionManager) DisconnectRan(inventoryName string) error {
 	}
 
 	if connectionStatus == entities.ConnectionStatus_SHUTTING_DOWN {
-		_, err = m.ranConnectStatusChangeManager.ChangeStatus(nodebInfo, entities.ConnectionStatus_SHUT_DOWN)
+		_, err = m.ranConnectStatusChangeManager.ChangeStatus(context.Background(), nodebInfo, entities.ConnectionStatus_SHUT_DOWN)
 		return err
 	}
 
-	_, err = m.ranConnectStatusChangeManager.ChangeStatus(nodebInfo, entities.ConnectionStatus_DISCONNECTED)
+	_, err = m.ranConnectStatusChangeManager.ChangeStatus(context.Background(), nodebInfo, entities.ConnectionStatus_DISCONNECTED)
 
 	if err != nil {
 		return err
@@ -54,9 +54,10 @@ func initRanLostConnectionTest(t *testing.T) (*logger.Logger, *mocks.RmrMessenge
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
 	e2tInstancesManager := NewE2TInstancesManager(rnibDataService, logger)
 	httpClient := &mocks.HttpClientMock{}
-	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClient)
+	alarmManagerClient := clients.NewAlarmManagerClient(logger, config, httpClient)
+	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClient, alarmManagerClient)
 	ranListManager := NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
+	ranAlarmService := services.NewRanAlarmService(logger, config, alarmManagerClient)
 	ranConnectStatusChangeManager := NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager)
 	ranDisconnectionManager := NewRanDisconnectionManager(logger, configuration.ParseConfiguration(), rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager)
@@ -0,0 +1,79 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/models"
+	"fmt"
+	"strings"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+type RanFunctionRejection struct {
+	RanFunctionId uint32
+	Reason        string
+	Cause         models.Cause
+}
+
+type IRanFunctionValidator interface {
+	Validate(ranFunction *entities.RanFunction, storedRanFunction *entities.RanFunction) *RanFunctionRejection
+	RejectDuplicate(ranFunctionId uint32) *RanFunctionRejection
+}
+
+type RanFunctionValidator struct {
+	knownOids map[string]bool
+}
+
+func NewRanFunctionValidator(config *configuration.Configuration) *RanFunctionValidator {
+	return &RanFunctionValidator{
+		knownOids: stringSet(config.RicServiceUpdate.KnownRanFunctionOids),
+	}
+}
+
+// Validate checks an added or modified RAN function. storedRanFunction is the function the RIC already holds under the same id, if any
+func (v *RanFunctionValidator) Validate(ranFunction *entities.RanFunction, storedRanFunction *entities.RanFunction) *RanFunctionRejection {
+	if len(strings.TrimSpace(ranFunction.RanFunctionDefinition)) == 0 {
+		return newRanFunctionRejection(ranFunction.RanFunctionId, "RAN function definition is empty",
+			models.Cause{Protocol: &models.CauseProtocol{AbstractSyntaxErrorFalselyConstructedMessage: &struct{}{}}})
+	}
+
+	if len(v.knownOids) != 0 && !v.knownOids[ranFunction.RanFunctionOid] {
+		return newRanFunctionRejection(ranFunction.RanFunctionId, fmt.Sprintf("RAN function OID %s is unknown", ranFunction.RanFunctionOid),
+			models.Cause{RicService: &models.CauseRicService{FunctionNotRequired: &struct{}{}}})
+	}
+
+	if storedRanFunction != nil && ranFunction.RanFunctionRevision < storedRanFunction.RanFunctionRevision {
+		return newRanFunctionRejection(ranFunction.RanFunctionId, fmt.Sprintf("RAN function revision %d is lower than the stored revision %d", ranFunction.RanFunctionRevision, storedRanFunction.RanFunctionRevision),
+			models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}})
+	}
+
+	return nil
+}
+
+// RejectDuplicate rejects a RAN function id that appears more than once in the same message
+func (v *RanFunctionValidator) RejectDuplicate(ranFunctionId uint32) *RanFunctionRejection {
+	return newRanFunctionRejection(ranFunctionId, "RAN function id appears more than once in the message",
+		models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}})
+}
+
+func newRanFunctionRejection(ranFunctionId uint32, reason string, cause models.Cause) *RanFunctionRejection {
+	return &RanFunctionRejection{RanFunctionId: ranFunctionId, Reason: reason, Cause: cause}
+}
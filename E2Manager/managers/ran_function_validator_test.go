@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+const kpmOid = "1.3.6.1.4.1.53148.1.2.2.2"
+
+func initRanFunctionValidatorTest(knownOids []string) *RanFunctionValidator {
+	config := &configuration.Configuration{RicServiceUpdate: configuration.RicServiceUpdateConfig{KnownRanFunctionOids: knownOids}}
+	return NewRanFunctionValidator(config)
+}
+
+func getRanFunction(revision uint32) *entities.RanFunction {
+	return &entities.RanFunction{RanFunctionId: 1, RanFunctionDefinition: "20 6D 6F 6E", RanFunctionRevision: revision, RanFunctionOid: kpmOid}
+}
+
+func TestRanFunctionValidatorAcceptsValidFunction(t *testing.T) {
+	validator := initRanFunctionValidatorTest(nil)
+
+	assert.Nil(t, validator.Validate(getRanFunction(2), nil))
+	assert.Nil(t, validator.Validate(getRanFunction(2), getRanFunction(2)))
+	assert.Nil(t, validator.Validate(getRanFunction(3), getRanFunction(2)))
+}
+
+func TestRanFunctionValidatorEmptyDefinition(t *testing.T) {
+	validator := initRanFunctionValidatorTest(nil)
+	ranFunction := getRanFunction(1)
+	ranFunction.RanFunctionDefinition = " "
+
+	rejection := validator.Validate(ranFunction, nil)
+	assert.NotNil(t, rejection)
+	assert.Equal(t, uint32(1), rejection.RanFunctionId)
+	assert.NotNil(t, rejection.Cause.Protocol.AbstractSyntaxErrorFalselyConstructedMessage)
+}
+
+func TestRanFunctionValidatorUnknownOid(t *testing.T) {
+	validator := initRanFunctionValidatorTest([]string{kpmOid})
+	assert.Nil(t, validator.Validate(getRanFunction(1), nil))
+
+	ranFunction := getRanFunction(1)
+	ranFunction.RanFunctionOid = "1.3.6.1.4.1.53148.1.1.2.3"
+	rejection := validator.Validate(ranFunction, nil)
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.RicService.FunctionNotRequired)
+}
+
+func TestRanFunctionValidatorRevisionRegression(t *testing.T) {
+	validator := initRanFunctionValidatorTest(nil)
+
+	rejection := validator.Validate(getRanFunction(1), getRanFunction(2))
+	assert.NotNil(t, rejection)
+	assert.NotNil(t, rejection.Cause.Protocol.SemanticError)
+}
+
+func TestRanFunctionValidatorRejectDuplicate(t *testing.T) {
+	validator := initRanFunctionValidatorTest(nil)
+
+	rejection := validator.RejectDuplicate(7)
+	assert.Equal(t, uint32(7), rejection.RanFunctionId)
+	assert.NotNil(t, rejection.Cause.Protocol.SemanticError)
+}
@@ -23,8 +23,12 @@ package managers
 import (
 	"e2mgr/e2managererrors"
 	"e2mgr/logger"
+	"e2mgr/metrics"
+	"e2mgr/models"
 	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"sort"
 	"sync"
 	"time"
 )
@@ -34,6 +38,16 @@ type ranListManagerInstance struct {
 	rnibDataService services.RNibDataService
 	mux             sync.Mutex
 	nbIdentityMap   map[string]*entities.NbIdentity
+	nodeTypeMap     map[string]entities.Node_Type
+	e2tAddressMap   map[string]string
+	globalNbIdIndex map[string]string
+	cellIndex       map[string]string
+	ranCells        map[string][]string
+	ranFunctionMap  map[string][]*entities.RanFunction
+	ranFunctionOids map[string]map[string]bool
+	initialized     bool
+	// changedRans collects the RANs this process changes while ReconcileNbIdentityMap reads rNib, nil otherwise
+	changedRans map[string]bool
 }
 
 type RanListManager interface {
@@ -45,7 +59,20 @@ type RanListManager interface {
 	GetNbIdentity(ranName string) (*entities.NbIdentity, error)
 	UpdateHealthcheckTimeStampReceived(oldRRanName string) (*entities.NbIdentity, *entities.NbIdentity)
 	UpdateHealthcheckTimeStampSent(oldRRanName string) (*entities.NbIdentity, *entities.NbIdentity)
+	UpdateHealthcheckTimeStampsSent(ranNames []string) error
 	UpdateNbIdentities(nodeType entities.Node_Type, oldNbIdentities []*entities.NbIdentity, newNbIdentities []*entities.NbIdentity) error
+	IsInitialized() bool
+	ReconcileNbIdentityMap() (int, error)
+	LoadNbIdentityAttributes() error
+	UpdateNbIdentityE2TAddress(ranName string, e2tAddress string)
+	GetNbIdentityPage(request *models.GetNodebIdListRequest) *models.NbIdentityPage
+	GetRanNameByGlobalNbId(plmnId string, nbId string) (string, error)
+	GetRanNameByCellId(cellId string) (string, error)
+	UpdateNbIdentityCells(nodebInfo *entities.NodebInfo)
+	UpdateNbIdentityRanFunctions(ranName string, ranFunctions []*entities.RanFunction)
+	GetRanFunctionCapabilities() []*models.RanFunctionCapability
+	GetRanFunctionNodes(request *models.GetRanFunctionNodesRequest) (*models.RanFunctionCapability, error)
+	InitNodebIndexes() error
 }
 
 func NewRanListManager(logger *logger.Logger, rnibDataService services.RNibDataService) RanListManager {
@@ -53,6 +80,13 @@ func NewRanListManager(logger *logger.Logger, rnibDataService services.RNibDataS
 		logger:          logger,
 		rnibDataService: rnibDataService,
 		nbIdentityMap:   make(map[string]*entities.NbIdentity),
+		nodeTypeMap:     make(map[string]entities.Node_Type),
+		e2tAddressMap:   make(map[string]string),
+		globalNbIdIndex: make(map[string]string),
+		cellIndex:       make(map[string]string),
+		ranCells:        make(map[string][]string),
+		ranFunctionMap:  make(map[string][]*entities.RanFunction),
+		ranFunctionOids: make(map[string]map[string]bool),
 	}
 }
 
@@ -64,9 +98,13 @@ func (m *ranListManagerInstance) InitNbIdentityMap() error {
 		return err
 	}
 
+	m.mux.Lock()
 	for _, v := range nbIds {
 		m.nbIdentityMap[v.InventoryName] = v
 	}
+	m.rebuildGlobalNbIdIndex()
+	m.initialized = true
+	m.mux.Unlock()
 
 	m.logger.Infof("#ranListManagerInstance.InitNbIdentityMap - Successfully initiated nodeb identity map")
 	m.logger.Debugf("#ranListManagerInstance.InitNbIdentityMap - nodeb Identity map: %s", m.nbIdentityMap)
@@ -78,6 +116,9 @@ func (m *ranListManagerInstance) AddNbIdentity(nodeType entities.Node_Type, nbId
 	defer m.mux.Unlock()
 
 	m.nbIdentityMap[nbIdentity.InventoryName] = nbIdentity
+	m.nodeTypeMap[nbIdentity.InventoryName] = nodeType
+	m.indexGlobalNbId(nbIdentity)
+	m.markChanged(nbIdentity.InventoryName)
 
 	err := m.rnibDataService.AddNbIdentity(nodeType, nbIdentity)
 
@@ -111,6 +152,13 @@ func (m *ranListManagerInstance) UpdateNbIdentityConnectionStatus(nodeType entit
 		HealthCheckTimestampReceived: oldNbIdentity.HealthCheckTimestampReceived,
 	}
 	m.nbIdentityMap[ranName] = newNbIdentity
+	m.nodeTypeMap[ranName] = nodeType
+	m.markChanged(ranName)
+
+	// a disconnected RAN is dissociated from its E2T instance
+	if connectionStatus == entities.ConnectionStatus_DISCONNECTED || connectionStatus == entities.ConnectionStatus_SHUT_DOWN {
+		delete(m.e2tAddressMap, ranName)
+	}
 
 	err := m.rnibDataService.UpdateNbIdentity(nodeType, oldNbIdentity, newNbIdentity)
 	if err != nil {
@@ -134,6 +182,12 @@ func (m *ranListManagerInstance) RemoveNbIdentity(nodeType entities.Node_Type, r
 	}
 
 	delete(m.nbIdentityMap, ranName)
+	delete(m.nodeTypeMap, ranName)
+	delete(m.e2tAddressMap, ranName)
+	m.unindexGlobalNbId(nbIdentity)
+	m.unindexCells(ranName)
+	m.unindexRanFunctions(ranName)
+	m.markChanged(ranName)
 
 	err := m.rnibDataService.RemoveNbIdentity(nodeType, nbIdentity)
 	if err != nil {
@@ -146,6 +200,9 @@ func (m *ranListManagerInstance) RemoveNbIdentity(nodeType entities.Node_Type, r
 }
 
 func (m *ranListManagerInstance) GetNbIdentityList() []*entities.NbIdentity {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
 	nbIds := make([]*entities.NbIdentity, 0, len(m.nbIdentityMap))
 	for _, v := range m.nbIdentityMap {
 		nbIds = append(nbIds, v)
@@ -157,6 +214,9 @@ func (m *ranListManagerInstance) GetNbIdentityList() []*entities.NbIdentity {
 }
 
 func (m *ranListManagerInstance) GetNbIdentity(ranName string) (*entities.NbIdentity, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
 	nbIdentity, ok := m.nbIdentityMap[ranName]
 	if !ok {
 		m.logger.Infof("#ranListManagerInstance.GetNbIdentity - RAN name: %s - nodeb identity not found", ranName)
@@ -169,6 +229,9 @@ func (m *ranListManagerInstance) GetNbIdentity(ranName string) (*entities.NbIden
 }
 
 func (m *ranListManagerInstance) UpdateHealthcheckTimeStampSent(oldRRanName string) (*entities.NbIdentity, *entities.NbIdentity){
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
 	currentTimeStamp := time.Now().UnixNano()
 	oldNbIdentity := m.nbIdentityMap[oldRRanName]
 
@@ -184,7 +247,56 @@ func (m *ranListManagerInstance) UpdateHealthcheckTimeStampSent(oldRRanName stri
 	return oldNbIdentity, newNbIdentity
 }
 
+// UpdateHealthcheckTimeStampsSent sets the health check sent timestamp of the given RANs and saves it to RNIB, one update per node type.
+// The lock is released before writing to RNIB
+func (m *ranListManagerInstance) UpdateHealthcheckTimeStampsSent(ranNames []string) error {
+	oldNbIdentities := make(map[entities.Node_Type][]*entities.NbIdentity)
+	newNbIdentities := make(map[entities.Node_Type][]*entities.NbIdentity)
+	currentTimeStamp := time.Now().UnixNano()
+
+	m.mux.Lock()
+	for _, ranName := range ranNames {
+		oldNbIdentity, ok := m.nbIdentityMap[ranName]
+		if !ok {
+			continue
+		}
+
+		newNbIdentity := &entities.NbIdentity{
+			GlobalNbId:                   oldNbIdentity.GlobalNbId,
+			InventoryName:                oldNbIdentity.InventoryName,
+			ConnectionStatus:             oldNbIdentity.ConnectionStatus,
+			HealthCheckTimestampSent:     currentTimeStamp,
+			HealthCheckTimestampReceived: oldNbIdentity.HealthCheckTimestampReceived,
+		}
+		m.nbIdentityMap[ranName] = newNbIdentity
+
+		nodeType, ok := m.nodeTypeMap[ranName]
+		if !ok {
+			m.logger.Warnf("#ranListManagerInstance.UpdateHealthcheckTimeStampsSent - RAN name: %s - unknown node type, the timestamp is not saved to RNIB", ranName)
+			continue
+		}
+
+		oldNbIdentities[nodeType] = append(oldNbIdentities[nodeType], oldNbIdentity)
+		newNbIdentities[nodeType] = append(newNbIdentities[nodeType], newNbIdentity)
+	}
+	m.mux.Unlock()
+
+	for nodeType := range oldNbIdentities {
+		err := m.rnibDataService.UpdateNbIdentities(nodeType, oldNbIdentities[nodeType], newNbIdentities[nodeType])
+
+		if err != nil {
+			m.logger.Errorf("#ranListManagerInstance.UpdateHealthcheckTimeStampsSent - Failed updating %d nbIdentities of nodetype - %s. error: %s", len(newNbIdentities[nodeType]), nodeType.String(), err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ranListManagerInstance) UpdateHealthcheckTimeStampReceived(oldRRanName string) (*entities.NbIdentity, *entities.NbIdentity){
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
 	currentTimeStamp := time.Now().UnixNano()
 	oldNbIdentity := m.nbIdentityMap[oldRRanName]
 
@@ -214,3 +326,581 @@ func (m *ranListManagerInstance) UpdateNbIdentities(nodeType entities.Node_Type,
 
 	return err
 }
+
+// IsInitialized tells whether the RAN list has been loaded from RNIB
+func (m *ranListManagerInstance) IsInitialized() bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.initialized
+}
+
+// ReconcileNbIdentityMap corrects the nodeb identity map from rNib, so it also reflects the changes made by other replicas
+// or tools. rNib is read without holding the lock, the RANs this process changes meanwhile are left to the next
+// reconciliation, so that a change this process is writing cannot be reverted.
+// Only the connection status and the global nb id are compared. A corrected entry takes the rNib identity with the most
+// recent of the in-memory and rNib health check timestamps, and its node type, E2T address, cells and RAN functions are
+// indexed again from the nodeb. It returns the number of corrected entries
+func (m *ranListManagerInstance) ReconcileNbIdentityMap() (int, error) {
+	m.mux.Lock()
+	m.changedRans = make(map[string]bool)
+	m.mux.Unlock()
+
+	defer func() {
+		m.mux.Lock()
+		m.changedRans = nil
+		m.mux.Unlock()
+	}()
+
+	nbIds, err := m.rnibDataService.GetListNodebIds()
+
+	if err != nil {
+		m.logger.Errorf("#ranListManagerInstance.ReconcileNbIdentityMap - Failed fetching RAN list from DB. error: %s", err)
+		return 0, err
+	}
+
+	m.mux.Lock()
+	var drifted []string
+	for _, nbIdentity := range nbIds {
+		if m.changedRans[nbIdentity.InventoryName] {
+			continue
+		}
+		if current, ok := m.nbIdentityMap[nbIdentity.InventoryName]; !ok || nbIdentityDiffers(current, nbIdentity) {
+			drifted = append(drifted, nbIdentity.InventoryName)
+		}
+	}
+	m.mux.Unlock()
+
+	nodebs := make(map[string]*entities.NodebInfo, len(drifted))
+	for _, ranName := range drifted {
+		nodebInfo, err := m.rnibDataService.GetNodeb(ranName)
+
+		if err != nil {
+			m.logger.Warnf("#ranListManagerInstance.ReconcileNbIdentityMap - RAN name: %s - Failed fetching nodeb from DB, the RAN is not indexed. error: %s", ranName, err)
+			continue
+		}
+		nodebs[ranName] = nodebInfo
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	drift := 0
+	rnibNbIdentities := make(map[string]bool, len(nbIds))
+
+	for _, nbIdentity := range nbIds {
+		rnibNbIdentities[nbIdentity.InventoryName] = true
+
+		if m.changedRans[nbIdentity.InventoryName] {
+			continue
+		}
+
+		current, ok := m.nbIdentityMap[nbIdentity.InventoryName]
+
+		if !ok {
+			m.logger.Warnf("#ranListManagerInstance.ReconcileNbIdentityMap - RAN name: %s - nodeb identity missing in memory, adding it", nbIdentity.InventoryName)
+			metrics.RanListDrift.WithLabelValues(metrics.RanListDriftAdded).Inc()
+			m.nbIdentityMap[nbIdentity.InventoryName] = nbIdentity
+			m.indexNodeb(nbIdentity.InventoryName, nodebs[nbIdentity.InventoryName])
+			drift++
+			continue
+		}
+
+		if nbIdentityDiffers(current, nbIdentity) {
+			m.logger.Warnf("#ranListManagerInstance.ReconcileNbIdentityMap - RAN name: %s - nodeb identity differs from DB, connection status in memory: %s, in DB: %s", nbIdentity.InventoryName, current.ConnectionStatus, nbIdentity.ConnectionStatus)
+			metrics.RanListDrift.WithLabelValues(metrics.RanListDriftUpdated).Inc()
+			if current.HealthCheckTimestampSent > nbIdentity.HealthCheckTimestampSent {
+				nbIdentity.HealthCheckTimestampSent = current.HealthCheckTimestampSent
+			}
+			if current.HealthCheckTimestampReceived > nbIdentity.HealthCheckTimestampReceived {
+				nbIdentity.HealthCheckTimestampReceived = current.HealthCheckTimestampReceived
+			}
+			m.nbIdentityMap[nbIdentity.InventoryName] = nbIdentity
+			m.indexNodeb(nbIdentity.InventoryName, nodebs[nbIdentity.InventoryName])
+			drift++
+		}
+	}
+
+	for ranName := range m.nbIdentityMap {
+		if !rnibNbIdentities[ranName] && !m.changedRans[ranName] {
+			m.logger.Warnf("#ranListManagerInstance.ReconcileNbIdentityMap - RAN name: %s - nodeb identity missing in DB, removing it from memory", ranName)
+			metrics.RanListDrift.WithLabelValues(metrics.RanListDriftRemoved).Inc()
+			delete(m.nbIdentityMap, ranName)
+			delete(m.nodeTypeMap, ranName)
+			delete(m.e2tAddressMap, ranName)
+			m.unindexCells(ranName)
+			m.unindexRanFunctions(ranName)
+			drift++
+		}
+	}
+
+	if drift > 0 {
+		m.rebuildGlobalNbIdIndex()
+	}
+
+	return drift, nil
+}
+
+// markChanged records a RAN this process changed, while a reconciliation is reading rNib. It must be called with the
+// mutex held
+func (m *ranListManagerInstance) markChanged(ranName string) {
+	if m.changedRans != nil {
+		m.changedRans[ranName] = true
+	}
+}
+
+// nbIdentityDiffers compares the connection status and the global nb id of two nodeb identities
+func nbIdentityDiffers(current *entities.NbIdentity, nbIdentity *entities.NbIdentity) bool {
+	return current.ConnectionStatus != nbIdentity.ConnectionStatus ||
+		current.GetGlobalNbId().GetPlmnId() != nbIdentity.GetGlobalNbId().GetPlmnId() ||
+		current.GetGlobalNbId().GetNbId() != nbIdentity.GetGlobalNbId().GetNbId()
+}
+
+// indexNodeb indexes the node type, E2T address, cells and RAN functions of a RAN from its nodeb, like the RAN list
+// changes of this process do. A RAN whose nodeb could not be read is not indexed. It must be called with the mutex held
+func (m *ranListManagerInstance) indexNodeb(ranName string, nodebInfo *entities.NodebInfo) {
+	if nodebInfo == nil {
+		return
+	}
+
+	m.nodeTypeMap[ranName] = nodebInfo.NodeType
+
+	if nodebInfo.AssociatedE2TInstanceAddress != "" {
+		m.e2tAddressMap[ranName] = nodebInfo.AssociatedE2TInstanceAddress
+	} else {
+		delete(m.e2tAddressMap, ranName)
+	}
+
+	m.unindexCells(ranName)
+	m.indexCells(ranName, nodebCellIds(nodebInfo))
+	m.unindexRanFunctions(ranName)
+	m.indexRanFunctions(ranName, nodebInfo.GetGnb().GetRanFunctions())
+}
+
+// LoadNbIdentityAttributes loads from rNib the node type and the associated E2T address of the RANs, which the nodeb
+// identities do not hold. The RAN list changes keep them up to date afterwards
+func (m *ranListManagerInstance) LoadNbIdentityAttributes() error {
+	enbIds, err := m.rnibDataService.GetListEnbIds()
+
+	if err != nil {
+		m.logger.Errorf("#ranListManagerInstance.LoadNbIdentityAttributes - Failed fetching ENB list from DB. error: %s", err)
+		return err
+	}
+
+	gnbIds, err := m.rnibDataService.GetListGnbIds()
+
+	if err != nil {
+		m.logger.Errorf("#ranListManagerInstance.LoadNbIdentityAttributes - Failed fetching GNB list from DB. error: %s", err)
+		return err
+	}
+
+	e2tInstances, err := m.getE2TInstances()
+
+	if err != nil {
+		m.logger.Errorf("#ranListManagerInstance.LoadNbIdentityAttributes - Failed fetching E2T instances from DB. error: %s", err)
+		return err
+	}
+
+	nodeTypeMap := make(map[string]entities.Node_Type, len(enbIds)+len(gnbIds))
+	for _, v := range enbIds {
+		nodeTypeMap[v.InventoryName] = entities.Node_ENB
+	}
+	for _, v := range gnbIds {
+		nodeTypeMap[v.InventoryName] = entities.Node_GNB
+	}
+
+	e2tAddressMap := make(map[string]string)
+	for _, e2tInstance := range e2tInstances {
+		for _, ranName := range e2tInstance.AssociatedRanList {
+			e2tAddressMap[ranName] = e2tInstance.Address
+		}
+	}
+
+	m.mux.Lock()
+	m.nodeTypeMap = nodeTypeMap
+	m.e2tAddressMap = e2tAddressMap
+	m.mux.Unlock()
+
+	m.logger.Infof("#ranListManagerInstance.LoadNbIdentityAttributes - Successfully loaded the attributes of %d RANs", len(nodeTypeMap))
+	return nil
+}
+
+func (m *ranListManagerInstance) getE2TInstances() ([]*entities.E2TInstance, error) {
+	e2tAddresses, err := m.rnibDataService.GetE2TAddresses()
+
+	if err != nil {
+		if _, ok := err.(*common.ResourceNotFoundError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(e2tAddresses) == 0 {
+		return nil, nil
+	}
+
+	return m.rnibDataService.GetE2TInstances(e2tAddresses)
+}
+
+// UpdateNbIdentityE2TAddress keeps the E2T instance address a RAN is associated with, in memory only
+func (m *ranListManagerInstance) UpdateNbIdentityE2TAddress(ranName string, e2tAddress string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.markChanged(ranName)
+
+	if e2tAddress == "" {
+		delete(m.e2tAddressMap, ranName)
+		return
+	}
+
+	m.e2tAddressMap[ranName] = e2tAddress
+}
+
+// GetNbIdentityPage returns the RANs matching the request filters, sorted and starting after the request cursor
+func (m *ranListManagerInstance) GetNbIdentityPage(request *models.GetNodebIdListRequest) *models.NbIdentityPage {
+	type sortableNbIdentity struct {
+		nbIdentity *entities.NbIdentity
+		sortKey    string
+	}
+
+	m.mux.Lock()
+	matching := make([]sortableNbIdentity, 0, len(m.nbIdentityMap))
+	for ranName, v := range m.nbIdentityMap {
+		if request.Matches(v, m.nodeTypeMap[ranName], m.e2tAddressMap[ranName]) {
+			matching = append(matching, sortableNbIdentity{nbIdentity: v, sortKey: request.SortKey(v)})
+		}
+	}
+	m.mux.Unlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		return request.Less(matching[i].sortKey, matching[i].nbIdentity.InventoryName, matching[j].sortKey, matching[j].nbIdentity.InventoryName)
+	})
+
+	start := 0
+	if request.Cursor != nil {
+		start = sort.Search(len(matching), func(i int) bool {
+			return request.Less(request.Cursor.SortKey, request.Cursor.InventoryName, matching[i].sortKey, matching[i].nbIdentity.InventoryName)
+		})
+	}
+
+	end := len(matching)
+	if request.Limit > 0 && start+request.Limit < end {
+		end = start + request.Limit
+	}
+
+	page := &models.NbIdentityPage{
+		NbIdentities: make([]*entities.NbIdentity, 0, end-start),
+		TotalCount:   len(matching),
+	}
+
+	for _, v := range matching[start:end] {
+		page.NbIdentities = append(page.NbIdentities, v.nbIdentity)
+	}
+
+	if end < len(matching) {
+		last := matching[end-1]
+		page.NextCursor = (&models.NodebIdListCursor{SortBy: request.SortBy, Descending: request.Descending, SortKey: last.sortKey, InventoryName: last.nbIdentity.InventoryName}).Encode()
+	}
+
+	m.logger.Infof("#ranListManagerInstance.GetNbIdentityPage - %d of %d matching identities returned", len(page.NbIdentities), page.TotalCount)
+
+	return page
+}
+
+func globalNbIdKey(plmnId string, nbId string) string {
+	return plmnId + "/" + nbId
+}
+
+// indexGlobalNbId keeps the first RAN claiming a global nb id as its owner, the RANs claiming it afterwards are not indexed
+func (m *ranListManagerInstance) indexGlobalNbId(nbIdentity *entities.NbIdentity) {
+	globalNbId := nbIdentity.GetGlobalNbId()
+	if globalNbId.GetPlmnId() == "" || globalNbId.GetNbId() == "" {
+		return
+	}
+
+	key := globalNbIdKey(globalNbId.GetPlmnId(), globalNbId.GetNbId())
+	if owner, ok := m.globalNbIdIndex[key]; ok && owner != nbIdentity.InventoryName {
+		if _, exists := m.nbIdentityMap[owner]; exists {
+			m.logger.Warnf("#ranListManagerInstance.indexGlobalNbId - RAN name: %s - global nb id %s is already owned by RAN %s", nbIdentity.InventoryName, key, owner)
+			return
+		}
+	}
+
+	m.globalNbIdIndex[key] = nbIdentity.InventoryName
+}
+
+// unindexGlobalNbId hands the global nb id of a removed RAN over to another RAN claiming it, if any
+func (m *ranListManagerInstance) unindexGlobalNbId(nbIdentity *entities.NbIdentity) {
+	globalNbId := nbIdentity.GetGlobalNbId()
+	key := globalNbIdKey(globalNbId.GetPlmnId(), globalNbId.GetNbId())
+
+	if m.globalNbIdIndex[key] != nbIdentity.InventoryName {
+		return
+	}
+
+	delete(m.globalNbIdIndex, key)
+
+	for _, v := range m.nbIdentityMap {
+		if v.GetGlobalNbId().GetPlmnId() == globalNbId.GetPlmnId() && v.GetGlobalNbId().GetNbId() == globalNbId.GetNbId() {
+			m.indexGlobalNbId(v)
+			return
+		}
+	}
+}
+
+func (m *ranListManagerInstance) rebuildGlobalNbIdIndex() {
+	m.globalNbIdIndex = make(map[string]string, len(m.nbIdentityMap))
+
+	ranNames := make([]string, 0, len(m.nbIdentityMap))
+	for ranName := range m.nbIdentityMap {
+		ranNames = append(ranNames, ranName)
+	}
+
+	// the owner of a duplicated global nb id does not depend on the map iteration order
+	sort.Strings(ranNames)
+
+	for _, ranName := range ranNames {
+		m.indexGlobalNbId(m.nbIdentityMap[ranName])
+	}
+}
+
+// GetRanNameByGlobalNbId returns the name of the RAN owning a global nb id
+func (m *ranListManagerInstance) GetRanNameByGlobalNbId(plmnId string, nbId string) (string, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	ranName, ok := m.globalNbIdIndex[globalNbIdKey(plmnId, nbId)]
+	if !ok {
+		m.logger.Infof("#ranListManagerInstance.GetRanNameByGlobalNbId - plmnId: %s, nbId: %s - RAN not found", plmnId, nbId)
+		return "", e2managererrors.NewResourceNotFoundError()
+	}
+
+	return ranName, nil
+}
+
+func nodebCellIds(nodebInfo *entities.NodebInfo) []string {
+	var cellIds []string
+
+	for _, cell := range nodebInfo.GetEnb().GetServedCells() {
+		if cell.GetCellId() != "" {
+			cellIds = append(cellIds, cell.GetCellId())
+		}
+	}
+
+	for _, cell := range nodebInfo.GetGnb().GetServedNrCells() {
+		if cellId := cell.GetServedNrCellInformation().GetCellId(); cellId != "" {
+			cellIds = append(cellIds, cellId)
+		}
+	}
+
+	return cellIds
+}
+
+func (m *ranListManagerInstance) unindexCells(ranName string) {
+	for _, cellId := range m.ranCells[ranName] {
+		if m.cellIndex[cellId] == ranName {
+			delete(m.cellIndex, cellId)
+		}
+	}
+	delete(m.ranCells, ranName)
+}
+
+func (m *ranListManagerInstance) indexCells(ranName string, cellIds []string) {
+	for _, cellId := range cellIds {
+		if owner, ok := m.cellIndex[cellId]; ok && owner != ranName {
+			m.logger.Warnf("#ranListManagerInstance.indexCells - RAN name: %s - cell %s moved from RAN %s", ranName, cellId, owner)
+		}
+		m.cellIndex[cellId] = ranName
+	}
+	m.ranCells[ranName] = cellIds
+}
+
+// UpdateNbIdentityCells replaces the cells indexed for a RAN with the served cells of its nodeb, in memory only
+func (m *ranListManagerInstance) UpdateNbIdentityCells(nodebInfo *entities.NodebInfo) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.unindexCells(nodebInfo.RanName)
+	m.indexCells(nodebInfo.RanName, nodebCellIds(nodebInfo))
+	m.markChanged(nodebInfo.RanName)
+}
+
+// InitNodebIndexes reads the nodebs from rNib to index their served cells and RAN functions. The indexes of a RAN
+// updated meanwhile are kept
+func (m *ranListManagerInstance) InitNodebIndexes() error {
+	m.mux.Lock()
+	ranNames := make([]string, 0, len(m.nbIdentityMap))
+	for ranName := range m.nbIdentityMap {
+		ranNames = append(ranNames, ranName)
+	}
+	m.mux.Unlock()
+
+	cellCount, ranFunctionCount := 0, 0
+
+	for _, ranName := range ranNames {
+		nodebInfo, err := m.rnibDataService.GetNodeb(ranName)
+
+		if err != nil {
+			if _, ok := err.(*common.ResourceNotFoundError); ok {
+				continue
+			}
+			m.logger.Errorf("#ranListManagerInstance.InitNodebIndexes - RAN name: %s - Failed fetching nodeb from DB. error: %s", ranName, err)
+			return err
+		}
+
+		cellIds := nodebCellIds(nodebInfo)
+		ranFunctions := nodebInfo.GetGnb().GetRanFunctions()
+
+		m.mux.Lock()
+		if _, ok := m.ranCells[ranName]; !ok {
+			m.indexCells(ranName, cellIds)
+			cellCount += len(cellIds)
+		}
+		if _, ok := m.ranFunctionMap[ranName]; !ok {
+			m.indexRanFunctions(ranName, ranFunctions)
+			ranFunctionCount += len(ranFunctions)
+		}
+		m.mux.Unlock()
+	}
+
+	m.logger.Infof("#ranListManagerInstance.InitNodebIndexes - Successfully indexed %d cells and %d RAN functions of %d RANs", cellCount, ranFunctionCount, len(ranNames))
+	return nil
+}
+
+// GetRanNameByCellId returns the name of the RAN serving a cell
+func (m *ranListManagerInstance) GetRanNameByCellId(cellId string) (string, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	ranName, ok := m.cellIndex[cellId]
+	if !ok {
+		m.logger.Infof("#ranListManagerInstance.GetRanNameByCellId - cell id: %s - RAN not found", cellId)
+		return "", e2managererrors.NewResourceNotFoundError()
+	}
+
+	return ranName, nil
+}
+
+func (m *ranListManagerInstance) unindexRanFunctions(ranName string) {
+	for _, ranFunction := range m.ranFunctionMap[ranName] {
+		if ranNames, ok := m.ranFunctionOids[ranFunction.RanFunctionOid]; ok {
+			delete(ranNames, ranName)
+			if len(ranNames) == 0 {
+				delete(m.ranFunctionOids, ranFunction.RanFunctionOid)
+			}
+		}
+	}
+	delete(m.ranFunctionMap, ranName)
+}
+
+func (m *ranListManagerInstance) indexRanFunctions(ranName string, ranFunctions []*entities.RanFunction) {
+	indexed := make([]*entities.RanFunction, 0, len(ranFunctions))
+
+	for _, ranFunction := range ranFunctions {
+		if ranFunction == nil || ranFunction.RanFunctionOid == "" {
+			continue
+		}
+
+		// the definition is not indexed, it is served with the nodeb
+		indexed = append(indexed, &entities.RanFunction{
+			RanFunctionId:       ranFunction.RanFunctionId,
+			RanFunctionRevision: ranFunction.RanFunctionRevision,
+			RanFunctionOid:      ranFunction.RanFunctionOid,
+		})
+
+		if _, ok := m.ranFunctionOids[ranFunction.RanFunctionOid]; !ok {
+			m.ranFunctionOids[ranFunction.RanFunctionOid] = make(map[string]bool)
+		}
+		m.ranFunctionOids[ranFunction.RanFunctionOid][ranName] = true
+	}
+
+	m.ranFunctionMap[ranName] = indexed
+}
+
+// UpdateNbIdentityRanFunctions replaces the RAN functions indexed for a RAN, in memory only
+func (m *ranListManagerInstance) UpdateNbIdentityRanFunctions(ranName string, ranFunctions []*entities.RanFunction) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.unindexRanFunctions(ranName)
+	m.indexRanFunctions(ranName, ranFunctions)
+	m.markChanged(ranName)
+	m.logger.Debugf("#ranListManagerInstance.UpdateNbIdentityRanFunctions - RAN name: %s - %d RAN functions indexed", ranName, len(m.ranFunctionMap[ranName]))
+}
+
+// ranFunctionCapability lists the nodes supporting a RAN function OID, in RAN name and RAN function id order
+func (m *ranListManagerInstance) ranFunctionCapability(oid string, connectionStatuses []entities.ConnectionStatus) *models.RanFunctionCapability {
+	capability := &models.RanFunctionCapability{RanFunctionOid: oid, Nodes: []*models.RanFunctionNode{}}
+
+	ranNames := make([]string, 0, len(m.ranFunctionOids[oid]))
+	for ranName := range m.ranFunctionOids[oid] {
+		ranNames = append(ranNames, ranName)
+	}
+	sort.Strings(ranNames)
+
+	for _, ranName := range ranNames {
+		connectionStatus := m.nbIdentityMap[ranName].GetConnectionStatus()
+
+		if len(connectionStatuses) != 0 && !containsConnectionStatus(connectionStatuses, connectionStatus) {
+			continue
+		}
+
+		for _, ranFunction := range m.ranFunctionMap[ranName] {
+			if ranFunction.RanFunctionOid != oid {
+				continue
+			}
+
+			capability.Nodes = append(capability.Nodes, &models.RanFunctionNode{
+				RanName:             ranName,
+				RanFunctionId:       ranFunction.RanFunctionId,
+				RanFunctionRevision: ranFunction.RanFunctionRevision,
+				ConnectionStatus:    connectionStatus.String(),
+			})
+		}
+	}
+
+	return capability
+}
+
+func containsConnectionStatus(connectionStatuses []entities.ConnectionStatus, connectionStatus entities.ConnectionStatus) bool {
+	for _, v := range connectionStatuses {
+		if v == connectionStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRanFunctionCapabilities returns the indexed RAN functions grouped by OID, in OID order
+func (m *ranListManagerInstance) GetRanFunctionCapabilities() []*models.RanFunctionCapability {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	oids := make([]string, 0, len(m.ranFunctionOids))
+	for oid := range m.ranFunctionOids {
+		oids = append(oids, oid)
+	}
+	sort.Strings(oids)
+
+	capabilities := make([]*models.RanFunctionCapability, 0, len(oids))
+	for _, oid := range oids {
+		capabilities = append(capabilities, m.ranFunctionCapability(oid, nil))
+	}
+
+	m.logger.Infof("#ranListManagerInstance.GetRanFunctionCapabilities - %d RAN function OIDs returned", len(capabilities))
+	return capabilities
+}
+
+// GetRanFunctionNodes returns the nodes supporting a RAN function OID, filtered by connection status
+func (m *ranListManagerInstance) GetRanFunctionNodes(request *models.GetRanFunctionNodesRequest) (*models.RanFunctionCapability, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if _, ok := m.ranFunctionOids[request.RanFunctionOid]; !ok {
+		m.logger.Infof("#ranListManagerInstance.GetRanFunctionNodes - RAN function OID: %s - no node supports it", request.RanFunctionOid)
+		return nil, e2managererrors.NewResourceNotFoundError()
+	}
+
+	return m.ranFunctionCapability(request.RanFunctionOid, request.ConnectionStatuses), nil
+}
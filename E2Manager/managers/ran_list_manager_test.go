@@ -23,11 +23,13 @@ import (
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
+	"e2mgr/models"
 	"e2mgr/services"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"testing"
 )
 
@@ -51,8 +53,10 @@ func initRanListManagerTest(t *testing.T) (*mocks.RnibReaderMock, *mocks.RnibWri
 func TestRanListManagerInstance_InitNbIdentityMapSuccess(t *testing.T) {
 	readerMock, _, ranListManager := initRanListManagerTest(t)
 	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{{InventoryName: RanName, GlobalNbId: &entities.GlobalNbId{NbId: "asd", PlmnId: "efg"}, ConnectionStatus: entities.ConnectionStatus_CONNECTED}}, nil)
+	assert.False(t, ranListManager.IsInitialized())
 	err := ranListManager.InitNbIdentityMap()
 	assert.Nil(t, err)
+	assert.True(t, ranListManager.IsInitialized())
 }
 
 func TestRanListManagerInstance_InitNbIdentityMapFailure(t *testing.T) {
@@ -60,6 +64,7 @@ func TestRanListManagerInstance_InitNbIdentityMapFailure(t *testing.T) {
 	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{}, common.NewInternalError(errors.New("#reader.GetListNodebIds - Internal Error")))
 	err := ranListManager.InitNbIdentityMap()
 	assert.NotNil(t, err)
+	assert.False(t, ranListManager.IsInitialized())
 }
 
 func TestRanListManagerInstance_AddNbIdentitySuccess(t *testing.T) {
@@ -109,3 +114,309 @@ func TestRanListManagerInstance_UpdateNbIdentities(t *testing.T) {
         res := ranListManager.UpdateNbIdentities(nodeType, oldNbIdentities, newNbIdentities)
         assert.Nil(t, res)
 }
+
+func TestRanListManagerInstance_ReconcileNbIdentityMap(t *testing.T) {
+	readerMock, _, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "ran2", ConnectionStatus: entities.ConnectionStatus_CONNECTED, HealthCheckTimestampSent: 20, HealthCheckTimestampReceived: 10},
+		{InventoryName: "ran3", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+	}, nil).Once()
+	err := ranListManager.InitNbIdentityMap()
+	assert.Nil(t, err)
+
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "ran2", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, HealthCheckTimestampSent: 15, HealthCheckTimestampReceived: 15},
+		{InventoryName: "ran4", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+	}, nil)
+	readerMock.On("GetNodeb", "ran2").Return(&entities.NodebInfo{RanName: "ran2", NodeType: entities.Node_ENB}, nil)
+	readerMock.On("GetNodeb", "ran4").Return(&entities.NodebInfo{
+		RanName:                      "ran4",
+		NodeType:                     entities.Node_GNB,
+		AssociatedE2TInstanceAddress: "10.0.2.15:38000",
+		Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{
+			ServedNrCells: []*entities.ServedNRCell{{ServedNrCellInformation: &entities.ServedNRCellInformation{CellId: "cell4"}}},
+			RanFunctions:  []*entities.RanFunction{{RanFunctionId: 2, RanFunctionOid: "1.3.6.1.4.1.53148.1.2.2.2"}},
+		}},
+	}, nil)
+	drift, err := ranListManager.ReconcileNbIdentityMap()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, drift)
+
+	nbIdentity, err := ranListManager.GetNbIdentity("ran2")
+	assert.Nil(t, err)
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, nbIdentity.ConnectionStatus)
+	assert.Equal(t, int64(20), nbIdentity.HealthCheckTimestampSent)
+	assert.Equal(t, int64(15), nbIdentity.HealthCheckTimestampReceived)
+	_, err = ranListManager.GetNbIdentity("ran3")
+	assert.NotNil(t, err)
+	_, err = ranListManager.GetNbIdentity("ran4")
+	assert.Nil(t, err)
+
+	instance := ranListManager.(*ranListManagerInstance)
+	assert.Equal(t, entities.Node_ENB, instance.nodeTypeMap["ran2"])
+	assert.Equal(t, entities.Node_GNB, instance.nodeTypeMap["ran4"])
+	assert.Equal(t, "10.0.2.15:38000", instance.e2tAddressMap["ran4"])
+	ranName, err := ranListManager.GetRanNameByCellId("cell4")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran4", ranName)
+	assert.Len(t, instance.ranFunctionMap["ran4"], 1)
+
+	drift, err = ranListManager.ReconcileNbIdentityMap()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, drift)
+}
+
+func TestRanListManagerInstance_ReconcileNbIdentityMapKeepsChangesMadeMeanwhile(t *testing.T) {
+	readerMock, writerMock, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+	}, nil).Once()
+	err := ranListManager.InitNbIdentityMap()
+	assert.Nil(t, err)
+
+	writerMock.On("UpdateNbIdentities", entities.Node_GNB, mock.Anything, mock.Anything).Return(nil)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "ran2", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+	}, nil).Run(func(args mock.Arguments) {
+		err := ranListManager.UpdateNbIdentityConnectionStatus(entities.Node_GNB, "ran1", entities.ConnectionStatus_DISCONNECTED)
+		assert.Nil(t, err)
+	}).Once()
+	readerMock.On("GetNodeb", "ran2").Return(&entities.NodebInfo{RanName: "ran2", NodeType: entities.Node_GNB}, nil)
+	drift, err := ranListManager.ReconcileNbIdentityMap()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, drift)
+
+	nbIdentity, err := ranListManager.GetNbIdentity("ran1")
+	assert.Nil(t, err)
+	assert.Equal(t, entities.ConnectionStatus_DISCONNECTED, nbIdentity.ConnectionStatus)
+	readerMock.AssertNotCalled(t, "GetNodeb", "ran1")
+}
+
+func TestRanListManagerInstance_ReconcileNbIdentityMapFailure(t *testing.T) {
+	readerMock, _, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{}, common.NewInternalError(errors.New("#reader.GetListNodebIds - Internal Error")))
+	drift, err := ranListManager.ReconcileNbIdentityMap()
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, drift)
+}
+
+func initRanListManagerPageTest(t *testing.T) (*mocks.RnibReaderMock, *mocks.RnibWriterMock, RanListManager) {
+	readerMock, writerMock, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "gnb_3", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "3"}},
+		{InventoryName: "gnb_1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "1"}},
+		{InventoryName: "gnb_2", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "02f830", NbId: "2"}},
+		{InventoryName: "enb_1", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "02f830", NbId: "4"}},
+	}, nil)
+	readerMock.On("GetListEnbIds").Return([]*entities.NbIdentity{{InventoryName: "enb_1"}}, nil)
+	readerMock.On("GetListGnbIds").Return([]*entities.NbIdentity{{InventoryName: "gnb_1"}, {InventoryName: "gnb_2"}, {InventoryName: "gnb_3"}}, nil)
+	readerMock.On("GetE2TAddresses").Return([]string{"10.0.2.15:38000"}, nil)
+	readerMock.On("GetE2TInstances", []string{"10.0.2.15:38000"}).Return([]*entities.E2TInstance{{Address: "10.0.2.15:38000", AssociatedRanList: []string{"gnb_2", "gnb_3"}}}, nil)
+
+	assert.Nil(t, ranListManager.InitNbIdentityMap())
+	assert.Nil(t, ranListManager.LoadNbIdentityAttributes())
+	return readerMock, writerMock, ranListManager
+}
+
+func pageRanNames(page *models.NbIdentityPage) []string {
+	ranNames := make([]string, len(page.NbIdentities))
+	for i, nbIdentity := range page.NbIdentities {
+		ranNames[i] = nbIdentity.InventoryName
+	}
+	return ranNames
+}
+
+func TestRanListManagerInstance_GetNbIdentityPageFilters(t *testing.T) {
+	_, _, ranListManager := initRanListManagerPageTest(t)
+
+	page := ranListManager.GetNbIdentityPage(&models.GetNodebIdListRequest{})
+	assert.Equal(t, []string{"enb_1", "gnb_1", "gnb_2", "gnb_3"}, pageRanNames(page))
+	assert.Equal(t, 4, page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+
+	page = ranListManager.GetNbIdentityPage(&models.GetNodebIdListRequest{NodeType: entities.Node_GNB, ConnectionStatuses: []entities.ConnectionStatus{entities.ConnectionStatus_CONNECTED}})
+	assert.Equal(t, []string{"gnb_2", "gnb_3"}, pageRanNames(page))
+
+	page = ranListManager.GetNbIdentityPage(&models.GetNodebIdListRequest{E2TAddress: "10.0.2.15:38000", PlmnId: "02f829"})
+	assert.Equal(t, []string{"gnb_3"}, pageRanNames(page))
+
+	page = ranListManager.GetNbIdentityPage(&models.GetNodebIdListRequest{NamePrefix: "enb"})
+	assert.Equal(t, []string{"enb_1"}, pageRanNames(page))
+}
+
+func TestRanListManagerInstance_GetNbIdentityPagePagination(t *testing.T) {
+	_, _, ranListManager := initRanListManagerPageTest(t)
+	request := &models.GetNodebIdListRequest{SortBy: models.NodebIdListSortByNbId, Descending: true, Limit: 3}
+
+	page := ranListManager.GetNbIdentityPage(request)
+	assert.Equal(t, []string{"enb_1", "gnb_3", "gnb_2"}, pageRanNames(page))
+	assert.Equal(t, 4, page.TotalCount)
+	assert.NotEmpty(t, page.NextCursor)
+
+	cursor, err := models.DecodeNodebIdListCursor(page.NextCursor)
+	assert.Nil(t, err)
+	request.Cursor = cursor
+
+	page = ranListManager.GetNbIdentityPage(request)
+	assert.Equal(t, []string{"gnb_1"}, pageRanNames(page))
+	assert.Equal(t, 4, page.TotalCount)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestRanListManagerInstance_UpdateNbIdentityE2TAddress(t *testing.T) {
+	_, writerMock, ranListManager := initRanListManagerPageTest(t)
+	request := &models.GetNodebIdListRequest{E2TAddress: "10.0.2.16:38000"}
+
+	ranListManager.UpdateNbIdentityE2TAddress("gnb_1", "10.0.2.16:38000")
+	assert.Equal(t, []string{"gnb_1"}, pageRanNames(ranListManager.GetNbIdentityPage(request)))
+
+	writerMock.On("UpdateNbIdentities", entities.Node_GNB, mock.Anything, mock.Anything).Return(nil)
+	err := ranListManager.UpdateNbIdentityConnectionStatus(entities.Node_GNB, "gnb_1", entities.ConnectionStatus_DISCONNECTED)
+	assert.Nil(t, err)
+	assert.Empty(t, ranListManager.GetNbIdentityPage(request).NbIdentities)
+}
+
+func TestRanListManagerInstance_LoadNbIdentityAttributesFailure(t *testing.T) {
+	readerMock, _, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListEnbIds").Return([]*entities.NbIdentity{}, common.NewInternalError(errors.New("#reader.GetListEnbIds - Internal Error")))
+	err := ranListManager.LoadNbIdentityAttributes()
+	assert.NotNil(t, err)
+}
+
+func TestRanListManagerInstance_GetRanNameByGlobalNbId(t *testing.T) {
+	readerMock, writerMock, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "ran2", GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"}},
+		{InventoryName: "ran1", GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"}},
+		{InventoryName: "ran3", GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0b"}},
+	}, nil)
+	err := ranListManager.InitNbIdentityMap()
+	assert.Nil(t, err)
+
+	ranName, err := ranListManager.GetRanNameByGlobalNbId("02f829", "4a952a0a")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran1", ranName)
+
+	_, err = ranListManager.GetRanNameByGlobalNbId("02f829", "4a952a0c")
+	assert.NotNil(t, err)
+
+	writerMock.On("RemoveNbIdentity", entities.Node_ENB, mock.Anything).Return(nil)
+	err = ranListManager.RemoveNbIdentity(entities.Node_ENB, "ran1")
+	assert.Nil(t, err)
+
+	ranName, err = ranListManager.GetRanNameByGlobalNbId("02f829", "4a952a0a")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran2", ranName)
+}
+
+func TestRanListManagerInstance_AddNbIdentityKeepsGlobalNbIdOwner(t *testing.T) {
+	_, writerMock, ranListManager := initRanListManagerTest(t)
+	writerMock.On("AddNbIdentity", entities.Node_GNB, mock.Anything).Return(nil)
+
+	_ = ranListManager.AddNbIdentity(entities.Node_GNB, &entities.NbIdentity{InventoryName: "ran1", GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"}})
+	_ = ranListManager.AddNbIdentity(entities.Node_GNB, &entities.NbIdentity{InventoryName: "ran2", GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"}})
+
+	ranName, err := ranListManager.GetRanNameByGlobalNbId("02f829", "4a952a0a")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran1", ranName)
+}
+
+func TestRanListManagerInstance_GetRanNameByCellId(t *testing.T) {
+	_, writerMock, ranListManager := initRanListManagerTest(t)
+	writerMock.On("AddNbIdentity", entities.Node_ENB, mock.Anything).Return(nil)
+	_ = ranListManager.AddNbIdentity(entities.Node_ENB, &entities.NbIdentity{InventoryName: "ran1"})
+
+	ranListManager.UpdateNbIdentityCells(&entities.NodebInfo{
+		RanName:       "ran1",
+		Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: []*entities.ServedCellInfo{{CellId: "cell1"}, {CellId: "cell2"}}}},
+	})
+
+	ranName, err := ranListManager.GetRanNameByCellId("cell2")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran1", ranName)
+
+	ranListManager.UpdateNbIdentityCells(&entities.NodebInfo{
+		RanName:       "ran1",
+		Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: []*entities.ServedCellInfo{{CellId: "cell1"}}}},
+	})
+
+	_, err = ranListManager.GetRanNameByCellId("cell2")
+	assert.NotNil(t, err)
+
+	writerMock.On("RemoveNbIdentity", entities.Node_ENB, mock.Anything).Return(nil)
+	_ = ranListManager.RemoveNbIdentity(entities.Node_ENB, "ran1")
+
+	_, err = ranListManager.GetRanNameByCellId("cell1")
+	assert.NotNil(t, err)
+}
+
+func TestRanListManagerInstance_InitNodebIndexes(t *testing.T) {
+	readerMock, _, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{{InventoryName: "ran1"}, {InventoryName: "ran2"}, {InventoryName: "ran3"}}, nil)
+	err := ranListManager.InitNbIdentityMap()
+	assert.Nil(t, err)
+
+	readerMock.On("GetNodeb", "ran1").Return(&entities.NodebInfo{
+		RanName:       "ran1",
+		Configuration: &entities.NodebInfo_Enb{Enb: &entities.Enb{ServedCells: []*entities.ServedCellInfo{{CellId: "cell1"}}}},
+	}, nil)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", "ran2").Return(nodebInfo, common.NewResourceNotFoundError("#reader.GetNodeb - Not found Error"))
+	readerMock.On("GetNodeb", "ran3").Return(&entities.NodebInfo{
+		RanName:       "ran3",
+		Configuration: &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{RanFunctions: []*entities.RanFunction{{RanFunctionId: 2, RanFunctionOid: "1.3.6.1.4.1.53148.1.2.2.2"}}}},
+	}, nil)
+
+	err = ranListManager.InitNodebIndexes()
+	assert.Nil(t, err)
+
+	ranName, err := ranListManager.GetRanNameByCellId("cell1")
+	assert.Nil(t, err)
+	assert.Equal(t, "ran1", ranName)
+
+	capability, err := ranListManager.GetRanFunctionNodes(&models.GetRanFunctionNodesRequest{RanFunctionOid: "1.3.6.1.4.1.53148.1.2.2.2"})
+	assert.Nil(t, err)
+	assert.Len(t, capability.Nodes, 1)
+	assert.Equal(t, "ran3", capability.Nodes[0].RanName)
+}
+
+func TestRanListManagerInstance_RanFunctionIndex(t *testing.T) {
+	readerMock, writerMock, ranListManager := initRanListManagerTest(t)
+	readerMock.On("GetListNodebIds").Return([]*entities.NbIdentity{
+		{InventoryName: "gnb_2", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED},
+		{InventoryName: "gnb_1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+	}, nil)
+	err := ranListManager.InitNbIdentityMap()
+	assert.Nil(t, err)
+
+	kpm := "1.3.6.1.4.1.53148.1.2.2.2"
+	rc := "1.3.6.1.4.1.53148.1.1.2.3"
+	ranListManager.UpdateNbIdentityRanFunctions("gnb_1", []*entities.RanFunction{{RanFunctionId: 1, RanFunctionRevision: 1, RanFunctionOid: kpm}, {RanFunctionId: 2, RanFunctionOid: rc}})
+	ranListManager.UpdateNbIdentityRanFunctions("gnb_2", []*entities.RanFunction{{RanFunctionId: 4, RanFunctionOid: kpm}})
+
+	capabilities := ranListManager.GetRanFunctionCapabilities()
+	assert.Len(t, capabilities, 2)
+	assert.Equal(t, rc, capabilities[0].RanFunctionOid)
+	assert.Equal(t, kpm, capabilities[1].RanFunctionOid)
+	assert.Len(t, capabilities[1].Nodes, 2)
+	assert.Equal(t, "gnb_1", capabilities[1].Nodes[0].RanName)
+
+	capability, err := ranListManager.GetRanFunctionNodes(&models.GetRanFunctionNodesRequest{RanFunctionOid: kpm, ConnectionStatuses: []entities.ConnectionStatus{entities.ConnectionStatus_CONNECTED}})
+	assert.Nil(t, err)
+	assert.Len(t, capability.Nodes, 1)
+	assert.Equal(t, "gnb_1", capability.Nodes[0].RanName)
+	assert.Equal(t, "CONNECTED", capability.Nodes[0].ConnectionStatus)
+
+	ranListManager.UpdateNbIdentityRanFunctions("gnb_1", []*entities.RanFunction{{RanFunctionId: 1, RanFunctionOid: kpm}})
+	_, err = ranListManager.GetRanFunctionNodes(&models.GetRanFunctionNodesRequest{RanFunctionOid: rc})
+	assert.NotNil(t, err)
+
+	writerMock.On("RemoveNbIdentity", entities.Node_GNB, mock.Anything).Return(nil)
+	_ = ranListManager.RemoveNbIdentity(entities.Node_GNB, "gnb_2")
+	capability, err = ranListManager.GetRanFunctionNodes(&models.GetRanFunctionNodesRequest{RanFunctionOid: kpm})
+	assert.Nil(t, err)
+	assert.Len(t, capability.Nodes, 1)
+}
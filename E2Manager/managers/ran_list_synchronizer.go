@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+)
+
+// RanListSynchronizer keeps the in-memory RAN list coherent with rNib when other replicas or tools modify it.
+// The RAN changes published by the rNib writer trigger a reconciliation, and a periodic one covers lost notifications.
+// The notifications this process published itself are its own changes, which its RAN list already holds, they are
+// ignored
+type RanListSynchronizer struct {
+	logger                 *logger.Logger
+	config                 *configuration.Configuration
+	sdl                    common.ISdlSyncStorage
+	ns                     string
+	ranListManager         RanListManager
+	publishedNotifications *PublishedNotifications
+	trigger                chan struct{}
+}
+
+func NewRanListSynchronizer(logger *logger.Logger, config *configuration.Configuration, sdl common.ISdlSyncStorage, ranListManager RanListManager, publishedNotifications *PublishedNotifications) *RanListSynchronizer {
+	return &RanListSynchronizer{
+		logger:                 logger,
+		config:                 config,
+		sdl:                    sdl,
+		ns:                     common.GetRNibNamespace(),
+		ranListManager:         ranListManager,
+		publishedNotifications: publishedNotifications,
+		trigger:                make(chan struct{}, 1),
+	}
+}
+
+func (s *RanListSynchronizer) Execute(ctx context.Context) {
+	channels := []string{s.config.RnibWriter.StateChangeMessageChannel, s.config.RnibWriter.RanManipulationMessageChannel}
+
+	err := s.sdl.SubscribeChannel(s.ns, s.handleNotification, channels...)
+
+	if err != nil {
+		s.logger.Errorf("#RanListSynchronizer.Execute - failed subscribing to rNib channels %s, relying on the periodic reconciliation. error: %s", channels, err)
+	} else {
+		defer s.unsubscribe(channels)
+	}
+
+	var reconcileTicks <-chan time.Time
+
+	if s.config.RanListSync.ReconcileIntervalSec > 0 {
+		ticker := time.NewTicker(time.Duration(s.config.RanListSync.ReconcileIntervalSec) * time.Second)
+		defer ticker.Stop()
+		reconcileTicks = ticker.C
+	}
+
+	s.logger.Infof("#RanListSynchronizer.Execute - RAN list synchronization started, reconcile interval: %ds", s.config.RanListSync.ReconcileIntervalSec)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Infof("#RanListSynchronizer.Execute - RAN list synchronization stopped")
+			return
+		case <-reconcileTicks:
+			s.Reconcile()
+		case <-s.trigger:
+			s.Reconcile()
+		}
+	}
+}
+
+// Reconcile corrects the in-memory RAN list from rNib and returns the number of corrected entries
+func (s *RanListSynchronizer) Reconcile() int {
+	drift, err := s.ranListManager.ReconcileNbIdentityMap()
+
+	if err != nil {
+		s.logger.Errorf("#RanListSynchronizer.Reconcile - failed reconciling RAN list. error: %s", err)
+		return 0
+	}
+
+	if drift > 0 {
+		s.logger.Warnf("#RanListSynchronizer.Reconcile - %d RAN list entries were corrected from rNib", drift)
+	}
+
+	return drift
+}
+
+// handleNotification coalesces the notifications, a burst of RAN changes results in a single reconciliation
+func (s *RanListSynchronizer) handleNotification(channel string, events ...string) {
+	if s.areOwn(channel, events) {
+		return
+	}
+
+	s.logger.Debugf("#RanListSynchronizer.handleNotification - channel: %s, events: %s", channel, events)
+
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (s *RanListSynchronizer) areOwn(channel string, events []string) bool {
+	own := len(events) > 0
+
+	for _, event := range events {
+		if !s.publishedNotifications.IsOwn(channel, event) {
+			own = false
+		}
+	}
+
+	return own
+}
+
+func (s *RanListSynchronizer) unsubscribe(channels []string) {
+	err := s.sdl.UnsubscribeChannel(s.ns, channels...)
+
+	if err != nil {
+		s.logger.Errorf("#RanListSynchronizer.unsubscribe - failed unsubscribing from rNib channels %s. error: %s", channels, err)
+	}
+}
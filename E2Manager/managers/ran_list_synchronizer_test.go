@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initRanListSynchronizerTest(t *testing.T) (*mocks.MockSdlSyncStorage, *mocks.RanListManagerMock, *RanListSynchronizer) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{RnibWriter: configuration.RnibWriterConfig{StateChangeMessageChannel: "RAN_CONNECTION_STATUS_CHANGE", RanManipulationMessageChannel: "RAN_MANIPULATION"}}
+
+	sdlMock := &mocks.MockSdlSyncStorage{}
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	return sdlMock, ranListManagerMock, NewRanListSynchronizer(log, config, sdlMock, ranListManagerMock, NewPublishedNotifications())
+}
+
+func TestRanListSynchronizerReconcilesOnNotification(t *testing.T) {
+	sdlMock, ranListManagerMock, synchronizer := initRanListSynchronizerTest(t)
+	channels := []string{"RAN_CONNECTION_STATUS_CHANGE", "RAN_MANIPULATION"}
+	notified := make(chan func(string, ...string), 1)
+	sdlMock.On("SubscribeChannel", mock.Anything, mock.Anything, channels).Return(nil).Run(func(args mock.Arguments) {
+		notified <- args.Get(1).(func(string, ...string))
+	})
+	sdlMock.On("UnsubscribeChannel", mock.Anything, channels).Return(nil)
+	reconciled := make(chan struct{}, 1)
+	ranListManagerMock.On("ReconcileNbIdentityMap").Return(1, nil).Run(func(args mock.Arguments) {
+		reconciled <- struct{}{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		synchronizer.Execute(ctx)
+		close(done)
+	}()
+
+	cb := <-notified
+	cb("RAN_MANIPULATION", "ran1_UPDATED")
+
+	select {
+	case <-reconciled:
+	case <-time.After(time.Second):
+		t.Errorf("#RanListSynchronizerTest.TestRanListSynchronizerReconcilesOnNotification - RAN list was not reconciled")
+	}
+
+	cancel()
+	<-done
+	sdlMock.AssertCalled(t, "UnsubscribeChannel", mock.Anything, channels)
+}
+
+func TestRanListSynchronizerNotificationsAreCoalesced(t *testing.T) {
+	_, _, synchronizer := initRanListSynchronizerTest(t)
+
+	synchronizer.handleNotification("RAN_CONNECTION_STATUS_CHANGE", "ran1_CONNECTED")
+	synchronizer.handleNotification("RAN_CONNECTION_STATUS_CHANGE", "ran2_CONNECTED")
+
+	assert.Len(t, synchronizer.trigger, 1)
+}
+
+func TestRanListSynchronizerIgnoresOwnNotifications(t *testing.T) {
+	sdlMock, _, synchronizer := initRanListSynchronizerTest(t)
+	sdlMock.On("SetAndPublish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sdl := synchronizer.publishedNotifications.Recording(sdlMock)
+
+	err := sdl.SetAndPublish("e2Manager", []string{"RAN_CONNECTION_STATUS_CHANGE", "ran1_CONNECTED"}, "key", "value")
+	assert.Nil(t, err)
+
+	synchronizer.handleNotification("RAN_CONNECTION_STATUS_CHANGE", "ran1_CONNECTED")
+	assert.Len(t, synchronizer.trigger, 0)
+
+	synchronizer.handleNotification("RAN_CONNECTION_STATUS_CHANGE", "ran1_CONNECTED")
+	assert.Len(t, synchronizer.trigger, 1)
+}
+
+func TestRanListSynchronizerReconcileFailure(t *testing.T) {
+	_, ranListManagerMock, synchronizer := initRanListSynchronizerTest(t)
+	ranListManagerMock.On("ReconcileNbIdentityMap").Return(0, errors.New("error"))
+
+	assert.Equal(t, 0, synchronizer.Reconcile())
+}
+
+func TestRanListSynchronizerReconcileDrift(t *testing.T) {
+	_, ranListManagerMock, synchronizer := initRanListSynchronizerTest(t)
+	ranListManagerMock.On("ReconcileNbIdentityMap").Return(2, nil)
+
+	assert.Equal(t, 2, synchronizer.Reconcile())
+	ranListManagerMock.AssertNotCalled(t, "LoadNbIdentityAttributes")
+}
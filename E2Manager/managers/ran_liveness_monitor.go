@@ -0,0 +1,240 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+	"sync"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	RanLivenessActionNone       = "none"
+	RanLivenessActionReset      = "reset"
+	RanLivenessActionDisconnect = "disconnect"
+)
+
+type IRanLivenessMonitor interface {
+	Check()
+	GetHealthStates() map[string]models.RanHealthState
+}
+
+type ranLiveness struct {
+	state          models.RanHealthState
+	missed         int
+	lastMissedSent int64
+}
+
+// RanLivenessMonitor compares the health check timestamps of the connected RANs. A RAN which misses a health check is STALE,
+// after the configured number of consecutive misses it is UNRESPONSIVE, an alarm is raised and the configured action is taken.
+// The alarm is cleared once the RAN answers a health check, a RAN which is disconnected meanwhile keeps it until it
+// answers again after reconnecting, or until it is removed
+type RanLivenessMonitor struct {
+	logger                  *logger.Logger
+	config                  *configuration.Configuration
+	ranListManager          RanListManager
+	ranAlarmService         services.RanAlarmService
+	ricE2ResetManager       IRicE2ResetManager
+	ranDisconnectionManager IRanDisconnectionManager
+	leaderElector           ILeaderElector
+	liveness                map[string]*ranLiveness
+	unresponsiveAlarms      map[string]bool
+	mux                     sync.Mutex
+}
+
+func NewRanLivenessMonitor(logger *logger.Logger, config *configuration.Configuration, ranListManager RanListManager, ranAlarmService services.RanAlarmService, ricE2ResetManager IRicE2ResetManager, ranDisconnectionManager IRanDisconnectionManager, leaderElector ILeaderElector) *RanLivenessMonitor {
+	return &RanLivenessMonitor{
+		logger:                  logger,
+		config:                  config,
+		ranListManager:          ranListManager,
+		ranAlarmService:         ranAlarmService,
+		ricE2ResetManager:       ricE2ResetManager,
+		ranDisconnectionManager: ranDisconnectionManager,
+		leaderElector:           leaderElector,
+		liveness:                make(map[string]*ranLiveness),
+		unresponsiveAlarms:      make(map[string]bool),
+	}
+}
+
+func (m *RanLivenessMonitor) Execute(ctx context.Context) {
+
+	if m.config.RanLiveness.CheckIntervalSec <= 0 {
+		m.logger.Infof("#RanLivenessMonitor.Execute - RAN liveness monitoring is disabled")
+		return
+	}
+
+	m.logger.Infof("#RanLivenessMonitor.Execute - RAN liveness monitoring started, interval: %ds", m.config.RanLiveness.CheckIntervalSec)
+
+	ticker := time.NewTicker(time.Duration(m.config.RanLiveness.CheckIntervalSec) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.Infof("#RanLivenessMonitor.Execute - RAN liveness monitoring stopped")
+			return
+		case <-ticker.C:
+			if !m.leaderElector.IsLeader() {
+				continue
+			}
+			m.Check()
+		}
+	}
+}
+
+// Check updates the health state of every connected RAN, RANs becoming unresponsive are handled once the states are updated
+func (m *RanLivenessMonitor) Check() {
+	now := time.Now().UnixNano()
+	responseThreshold := int64(time.Duration(m.config.RanLiveness.ResponseThresholdSec) * time.Second)
+
+	var unresponsiveRans []string
+	var recoveredRans []string
+
+	m.mux.Lock()
+
+	listedRans := make(map[string]bool)
+	connectedRans := make(map[string]bool)
+
+	for _, nbIdentity := range m.ranListManager.GetNbIdentityList() {
+		listedRans[nbIdentity.InventoryName] = true
+
+		if nbIdentity.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
+			continue
+		}
+
+		ranName := nbIdentity.InventoryName
+		connectedRans[ranName] = true
+
+		liveness, ok := m.liveness[ranName]
+		if !ok {
+			liveness = &ranLiveness{state: models.RanHealthStateHealthy}
+			m.liveness[ranName] = liveness
+		}
+
+		sent := nbIdentity.HealthCheckTimestampSent
+		received := nbIdentity.HealthCheckTimestampReceived
+
+		if sent == 0 || received >= sent {
+			if sent != 0 && m.unresponsiveAlarms[ranName] {
+				recoveredRans = append(recoveredRans, ranName)
+				delete(m.unresponsiveAlarms, ranName)
+			}
+			if liveness.state != models.RanHealthStateHealthy {
+				m.logger.Infof("#RanLivenessMonitor.Check - RAN name: %s - RAN answers health checks again", ranName)
+			}
+			*liveness = ranLiveness{state: models.RanHealthStateHealthy}
+			continue
+		}
+
+		if now-sent <= responseThreshold || sent == liveness.lastMissedSent {
+			continue
+		}
+
+		liveness.missed++
+		liveness.lastMissedSent = sent
+
+		if liveness.missed < m.config.RanLiveness.MaxMissedHealthChecks {
+			liveness.state = models.RanHealthStateStale
+			m.logger.Warnf("#RanLivenessMonitor.Check - RAN name: %s - RAN missed %d consecutive health checks", ranName, liveness.missed)
+			continue
+		}
+
+		if liveness.state != models.RanHealthStateUnresponsive {
+			liveness.state = models.RanHealthStateUnresponsive
+			m.unresponsiveAlarms[ranName] = true
+			unresponsiveRans = append(unresponsiveRans, ranName)
+		}
+	}
+
+	for ranName := range m.liveness {
+		if !connectedRans[ranName] {
+			delete(m.liveness, ranName)
+		}
+	}
+
+	var removedRans []string
+
+	for ranName := range m.unresponsiveAlarms {
+		if !listedRans[ranName] {
+			removedRans = append(removedRans, ranName)
+			delete(m.unresponsiveAlarms, ranName)
+		}
+	}
+
+	m.mux.Unlock()
+
+	for _, ranName := range recoveredRans {
+		m.clearUnresponsiveAlarm(ranName)
+	}
+
+	for _, ranName := range removedRans {
+		m.logger.Infof("#RanLivenessMonitor.Check - RAN name: %s - RAN was removed, clearing its unresponsive alarm", ranName)
+		m.clearUnresponsiveAlarm(ranName)
+	}
+
+	for _, ranName := range unresponsiveRans {
+		m.handleUnresponsiveRan(ranName)
+	}
+}
+
+func (m *RanLivenessMonitor) clearUnresponsiveAlarm(ranName string) {
+	if err := m.ranAlarmService.ClearRanUnresponsiveAlarm(ranName); err != nil {
+		m.logger.Errorf("#RanLivenessMonitor.clearUnresponsiveAlarm - RAN name: %s - Failed clearing alarm. Error: %v", ranName, err)
+	}
+}
+
+func (m *RanLivenessMonitor) GetHealthStates() map[string]models.RanHealthState {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	healthStates := make(map[string]models.RanHealthState, len(m.liveness))
+
+	for ranName, liveness := range m.liveness {
+		healthStates[ranName] = liveness.state
+	}
+
+	return healthStates
+}
+
+func (m *RanLivenessMonitor) handleUnresponsiveRan(ranName string) {
+	action := m.config.RanLiveness.Action
+	m.logger.Warnf("#RanLivenessMonitor.handleUnresponsiveRan - RAN name: %s - RAN missed %d consecutive health checks, action: %s", ranName, m.config.RanLiveness.MaxMissedHealthChecks, action)
+
+	if err := m.ranAlarmService.SetRanUnresponsiveAlarm(ranName); err != nil {
+		m.logger.Errorf("#RanLivenessMonitor.handleUnresponsiveRan - RAN name: %s - Failed raising alarm. Error: %v", ranName, err)
+	}
+
+	switch action {
+	case RanLivenessActionReset:
+		resetCause, _ := models.GetE2ResetCause(models.E2ResetOmInterventionCause)
+		if _, err := m.ricE2ResetManager.Reset(ranName, resetCause); err != nil {
+			m.logger.Errorf("#RanLivenessMonitor.handleUnresponsiveRan - RAN name: %s - E2 Reset failed. Error: %v", ranName, err)
+		}
+	case RanLivenessActionDisconnect:
+		if err := m.ranDisconnectionManager.DisconnectRan(ranName); err != nil {
+			m.logger.Errorf("#RanLivenessMonitor.handleUnresponsiveRan - RAN name: %s - Disconnect RAN failed. Error: %v", ranName, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/services"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ricE2ResetManagerStub struct {
+	mock.Mock
+}
+
+func (m *ricE2ResetManagerStub) Reset(ranName string, cause models.Cause) (*E2ResetTransaction, error) {
+	args := m.Called(ranName, cause)
+	return nil, args.Error(1)
+}
+
+type ranDisconnectionManagerStub struct {
+	mock.Mock
+}
+
+func (m *ranDisconnectionManagerStub) DisconnectRan(inventoryName string) error {
+	args := m.Called(inventoryName)
+	return args.Error(0)
+}
+
+func initRanLivenessMonitorTest(t *testing.T, action string) (*mocks.RanListManagerMock, *mocks.RanAlarmServiceMock, *ricE2ResetManagerStub, *ranDisconnectionManagerStub, *RanLivenessMonitor) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{}
+	config.RanLiveness.CheckIntervalSec = 60
+	config.RanLiveness.ResponseThresholdSec = 30
+	config.RanLiveness.MaxMissedHealthChecks = 2
+	config.RanLiveness.Action = action
+
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ranAlarmServiceMock := &mocks.RanAlarmServiceMock{}
+	ricE2ResetManager := &ricE2ResetManagerStub{}
+	ranDisconnectionManager := &ranDisconnectionManagerStub{}
+	monitor := NewRanLivenessMonitor(log, config, ranListManagerMock, ranAlarmServiceMock, ricE2ResetManager, ranDisconnectionManager, &mocks.LeaderElectorMock{})
+
+	return ranListManagerMock, ranAlarmServiceMock, ricE2ResetManager, ranDisconnectionManager, monitor
+}
+
+func nbIdentityWithHealthCheck(ranName string, sentSecAgo int, receivedSecAgo int) *entities.NbIdentity {
+	now := time.Now()
+	nbIdentity := &entities.NbIdentity{InventoryName: ranName, ConnectionStatus: entities.ConnectionStatus_CONNECTED}
+	if sentSecAgo >= 0 {
+		nbIdentity.HealthCheckTimestampSent = now.Add(-time.Duration(sentSecAgo) * time.Second).UnixNano()
+	}
+	if receivedSecAgo >= 0 {
+		nbIdentity.HealthCheckTimestampReceived = now.Add(-time.Duration(receivedSecAgo) * time.Second).UnixNano()
+	}
+	return nbIdentity
+}
+
+func TestRanLivenessMonitorHealthyRans(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, _, _, monitor := initRanLivenessMonitorTest(t, RanLivenessActionNone)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{
+		nbIdentityWithHealthCheck("ran1", 60, 59),
+		nbIdentityWithHealthCheck("ran2", -1, -1),
+		nbIdentityWithHealthCheck("ran3", 10, -1),
+		{InventoryName: "ran4", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED},
+	})
+
+	monitor.Check()
+
+	assert.Equal(t, map[string]models.RanHealthState{"ran1": models.RanHealthStateHealthy, "ran2": models.RanHealthStateHealthy, "ran3": models.RanHealthStateHealthy}, monitor.GetHealthStates())
+	ranAlarmServiceMock.AssertNotCalled(t, "SetRanUnresponsiveAlarm", mock.Anything)
+}
+
+func TestRanLivenessMonitorMissedHealthCheckIsCountedOnce(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, _, _, monitor := initRanLivenessMonitorTest(t, RanLivenessActionNone)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 120)})
+
+	monitor.Check()
+	monitor.Check()
+
+	assert.Equal(t, models.RanHealthStateStale, monitor.GetHealthStates()["ran1"])
+	ranAlarmServiceMock.AssertNotCalled(t, "SetRanUnresponsiveAlarm", mock.Anything)
+}
+
+func TestRanLivenessMonitorUnresponsiveRanIsDisconnected(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, ricE2ResetManager, ranDisconnectionManager, monitor := initRanLivenessMonitorTest(t, RanLivenessActionDisconnect)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 120, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 45, 300)}).Once()
+	ranAlarmServiceMock.On("SetRanUnresponsiveAlarm", "ran1").Return(nil)
+	ranDisconnectionManager.On("DisconnectRan", "ran1").Return(nil)
+
+	monitor.Check()
+	assert.Equal(t, models.RanHealthStateStale, monitor.GetHealthStates()["ran1"])
+
+	monitor.Check()
+	assert.Equal(t, models.RanHealthStateUnresponsive, monitor.GetHealthStates()["ran1"])
+
+	monitor.Check()
+	assert.Equal(t, models.RanHealthStateUnresponsive, monitor.GetHealthStates()["ran1"])
+
+	ranAlarmServiceMock.AssertNumberOfCalls(t, "SetRanUnresponsiveAlarm", 1)
+	ranDisconnectionManager.AssertNumberOfCalls(t, "DisconnectRan", 1)
+	ricE2ResetManager.AssertNotCalled(t, "Reset", mock.Anything, mock.Anything)
+}
+
+func TestRanLivenessMonitorUnresponsiveRanIsReset(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, ricE2ResetManager, ranDisconnectionManager, monitor := initRanLivenessMonitorTest(t, RanLivenessActionReset)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 120, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 300)}).Once()
+	ranAlarmServiceMock.On("SetRanUnresponsiveAlarm", "ran1").Return(nil)
+	ricE2ResetManager.On("Reset", "ran1", mock.Anything).Return(nil, nil)
+
+	monitor.Check()
+	monitor.Check()
+
+	ricE2ResetManager.AssertNumberOfCalls(t, "Reset", 1)
+	ranDisconnectionManager.AssertNotCalled(t, "DisconnectRan", mock.Anything)
+}
+
+func TestRanLivenessMonitorUnresponsiveRanRecovers(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, _, _, monitor := initRanLivenessMonitorTest(t, RanLivenessActionNone)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 120, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 59)}).Once()
+	ranAlarmServiceMock.On("SetRanUnresponsiveAlarm", "ran1").Return(nil)
+	ranAlarmServiceMock.On("ClearRanUnresponsiveAlarm", "ran1").Return(nil)
+
+	monitor.Check()
+	monitor.Check()
+	monitor.Check()
+
+	assert.Equal(t, models.RanHealthStateHealthy, monitor.GetHealthStates()["ran1"])
+	ranAlarmServiceMock.AssertNumberOfCalls(t, "ClearRanUnresponsiveAlarm", 1)
+}
+
+func TestRanLivenessMonitorDisconnectedRanKeepsAlarmUntilItAnswers(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, _, _, monitor := initRanLivenessMonitorTest(t, RanLivenessActionNone)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 120, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 10, 9)}).Once()
+	ranAlarmServiceMock.On("SetRanUnresponsiveAlarm", "ran1").Return(nil)
+	ranAlarmServiceMock.On("ClearRanUnresponsiveAlarm", "ran1").Return(nil)
+
+	monitor.Check()
+	monitor.Check()
+	monitor.Check()
+
+	assert.Empty(t, monitor.GetHealthStates())
+	ranAlarmServiceMock.AssertNotCalled(t, "ClearRanUnresponsiveAlarm", "ran1")
+
+	monitor.Check()
+	ranAlarmServiceMock.AssertNotCalled(t, "ClearRanUnresponsiveAlarm", "ran1")
+
+	monitor.Check()
+	assert.Equal(t, models.RanHealthStateHealthy, monitor.GetHealthStates()["ran1"])
+	ranAlarmServiceMock.AssertNumberOfCalls(t, "ClearRanUnresponsiveAlarm", 1)
+}
+
+func TestRanLivenessMonitorRemovedRanAlarmIsCleared(t *testing.T) {
+	ranListManagerMock, ranAlarmServiceMock, _, _, monitor := initRanLivenessMonitorTest(t, RanLivenessActionNone)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 120, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{nbIdentityWithHealthCheck("ran1", 60, 300)}).Once()
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{}).Once()
+	ranAlarmServiceMock.On("SetRanUnresponsiveAlarm", "ran1").Return(nil)
+	ranAlarmServiceMock.On("ClearRanUnresponsiveAlarm", "ran1").Return(nil)
+
+	monitor.Check()
+	monitor.Check()
+	monitor.Check()
+
+	assert.Empty(t, monitor.GetHealthStates())
+	ranAlarmServiceMock.AssertNumberOfCalls(t, "ClearRanUnresponsiveAlarm", 1)
+}
+
+func TestRanLivenessMonitorScheduledRicServiceQueryIsHealthCheck(t *testing.T) {
+	log, err := logger.InitLogger(logger.DebugLevel)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
+	config.RicServiceQuery.IntervalSec = 300
+	config.RanLiveness.CheckIntervalSec = 60
+	config.RanLiveness.MaxMissedHealthChecks = 1
+	config.RanLiveness.Action = RanLivenessActionNone
+
+	readerMock := &mocks.RnibReaderMock{}
+	writerMock := &mocks.RnibWriterMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, writerMock)
+	ranListManager := NewRanListManager(log, rnibDataService)
+	ricServiceQueryManagerMock := &mocks.RicServiceQueryManagerMock{}
+	ranAlarmServiceMock := &mocks.RanAlarmServiceMock{}
+	worker := NewRicServiceQueryWorker(log, config, ranListManager, ricServiceQueryManagerMock, &mocks.LeaderElectorMock{})
+	monitor := NewRanLivenessMonitor(log, config, ranListManager, ranAlarmServiceMock, &ricE2ResetManagerStub{}, &ranDisconnectionManagerStub{}, &mocks.LeaderElectorMock{})
+
+	writerMock.On("AddNbIdentity", entities.Node_GNB, mock.Anything).Return(nil)
+	writerMock.On("UpdateNbIdentities", entities.Node_GNB, mock.Anything, mock.Anything).Return(nil)
+	ricServiceQueryManagerMock.On("Query", "ran1").Return(&models.RicServiceQueryRecord{RanName: "ran1"}, nil)
+	ranAlarmServiceMock.On("SetRanUnresponsiveAlarm", "ran1").Return(nil)
+	_ = ranListManager.AddNbIdentity(entities.Node_GNB, &entities.NbIdentity{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED})
+
+	monitor.Check()
+	assert.Equal(t, map[string]models.RanHealthState{"ran1": models.RanHealthStateHealthy}, monitor.GetHealthStates())
+
+	worker.QueryConnectedRans()
+	time.Sleep(time.Millisecond)
+	monitor.Check()
+
+	nbIdentity, _ := ranListManager.GetNbIdentity("ran1")
+	assert.NotZero(t, nbIdentity.HealthCheckTimestampSent)
+	writerMock.AssertNumberOfCalls(t, "UpdateNbIdentities", 1)
+	assert.Equal(t, map[string]models.RanHealthState{"ran1": models.RanHealthStateUnresponsive}, monitor.GetHealthStates())
+	ranAlarmServiceMock.AssertNumberOfCalls(t, "SetRanUnresponsiveAlarm", 1)
+}
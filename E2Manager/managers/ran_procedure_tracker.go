@@ -0,0 +1,312 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/services"
+	"sync"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+type IRanProcedureTracker interface {
+	Start(ranName string, procedureType models.RanProcedureType, transactionId string)
+	Complete(ranName string, procedureType models.RanProcedureType)
+	Fail(ranName string, procedureType models.RanProcedureType)
+	TimeOut(ranName string, procedureType models.RanProcedureType)
+	SavePreviousRanFunctions(ranName string, procedureType models.RanProcedureType, ranFunctions []*entities.RanFunction)
+	GetProcedure(ranName string, procedureType models.RanProcedureType) *models.RanProcedure
+	GetProcedureByTransactionId(ranName string, transactionId string) *models.RanProcedure
+	GetLastProcedure(ranName string) *models.RanProcedure
+	Remove(ranName string) error
+}
+
+// ranProcedureEntry holds the procedures of a single RAN. Its mutex serializes the changes of the RAN and their writes to rNib
+type ranProcedureEntry struct {
+	procedures *models.RanProcedures
+	timers     map[models.RanProcedureType]*time.Timer
+	removed    bool
+	mux        sync.Mutex
+}
+
+// RanProcedureTracker keeps the state of the latest procedure of each type per RAN.
+// Every change is written through to rNib, and RANs that are not cached yet are loaded from it on first access.
+// The tracker mutex only guards the RAN entries map, so the rNib I/O of one RAN does not block the other RANs
+type RanProcedureTracker struct {
+	logger  *logger.Logger
+	store   services.RanProcedureStore
+	timeout time.Duration
+	entries map[string]*ranProcedureEntry
+	mux     sync.Mutex
+}
+
+func NewRanProcedureTracker(logger *logger.Logger, config *configuration.Configuration, store services.RanProcedureStore) *RanProcedureTracker {
+	return &RanProcedureTracker{
+		logger:  logger,
+		store:   store,
+		timeout: time.Duration(config.ProcedureTimeoutSec) * time.Second,
+		entries: make(map[string]*ranProcedureEntry),
+	}
+}
+
+func (t *RanProcedureTracker) Start(ranName string, procedureType models.RanProcedureType, transactionId string) {
+	entry := t.lock(ranName)
+	defer entry.mux.Unlock()
+
+	ranProcedures := entry.procedures
+
+	// A new E2 Setup establishes a new E2 association, which makes the previous procedures of the RAN irrelevant
+	if procedureType == models.E2SetupProcedure {
+		for existingType := range ranProcedures.Procedures {
+			entry.stopTimer(existingType)
+		}
+		ranProcedures.Procedures = make(map[models.RanProcedureType]*models.RanProcedure)
+	}
+
+	entry.stopTimer(procedureType)
+
+	procedure := &models.RanProcedure{
+		Type:          procedureType,
+		TransactionId: transactionId,
+		State:         models.RanProcedureOngoing,
+		StartTime:     time.Now().UnixNano(),
+	}
+	ranProcedures.Procedures[procedureType] = procedure
+	ranProcedures.LastType = procedureType
+	t.startTimer(entry, procedure, t.timeout)
+
+	t.logger.Debugf("#RanProcedureTracker.Start - RAN name: %s - %s procedure started, transaction id: %s", ranName, procedureType, transactionId)
+	t.save(ranProcedures)
+}
+
+func (t *RanProcedureTracker) Complete(ranName string, procedureType models.RanProcedureType) {
+	t.end(ranName, procedureType, models.RanProcedureCompleted)
+}
+
+func (t *RanProcedureTracker) Fail(ranName string, procedureType models.RanProcedureType) {
+	t.end(ranName, procedureType, models.RanProcedureFailed)
+}
+
+// TimeOut is used by procedures which supervise their own response timer
+func (t *RanProcedureTracker) TimeOut(ranName string, procedureType models.RanProcedureType) {
+	t.end(ranName, procedureType, models.RanProcedureTimedOut)
+}
+
+func (t *RanProcedureTracker) SavePreviousRanFunctions(ranName string, procedureType models.RanProcedureType, ranFunctions []*entities.RanFunction) {
+	entry := t.lock(ranName)
+	defer entry.mux.Unlock()
+
+	procedure, ok := entry.procedures.Procedures[procedureType]
+
+	if !ok {
+		t.logger.Warnf("#RanProcedureTracker.SavePreviousRanFunctions - RAN name: %s - no %s procedure found", ranName, procedureType)
+		return
+	}
+
+	procedure.PreviousRanFunctions = ranFunctions
+	t.save(entry.procedures)
+}
+
+func (t *RanProcedureTracker) GetProcedure(ranName string, procedureType models.RanProcedureType) *models.RanProcedure {
+	entry := t.lock(ranName)
+	defer entry.mux.Unlock()
+
+	return copyRanProcedure(entry.procedures.Procedures[procedureType])
+}
+
+func (t *RanProcedureTracker) GetProcedureByTransactionId(ranName string, transactionId string) *models.RanProcedure {
+	entry := t.lock(ranName)
+	defer entry.mux.Unlock()
+
+	return copyRanProcedure(entry.procedures.GetByTransactionId(transactionId))
+}
+
+func (t *RanProcedureTracker) GetLastProcedure(ranName string) *models.RanProcedure {
+	entry := t.lock(ranName)
+	defer entry.mux.Unlock()
+
+	return copyRanProcedure(entry.procedures.GetLast())
+}
+
+// Remove forgets the procedures of a deleted RAN, both in memory and in rNib
+func (t *RanProcedureTracker) Remove(ranName string) error {
+	t.mux.Lock()
+	entry, ok := t.entries[ranName]
+	delete(t.entries, ranName)
+	t.mux.Unlock()
+
+	if ok {
+		entry.mux.Lock()
+		for procedureType := range entry.timers {
+			entry.stopTimer(procedureType)
+		}
+		entry.removed = true
+		entry.mux.Unlock()
+	}
+
+	err := t.store.Delete(ranName)
+
+	if err != nil {
+		t.logger.Errorf("#RanProcedureTracker.Remove - RAN name: %s - failed deleting procedures from rNib. Error: %s", ranName, err)
+		return err
+	}
+
+	t.logger.Debugf("#RanProcedureTracker.Remove - RAN name: %s - procedures removed", ranName)
+	return nil
+}
+
+func (t *RanProcedureTracker) end(ranName string, procedureType models.RanProcedureType, state models.RanProcedureState) {
+	entry := t.lock(ranName)
+	defer entry.mux.Unlock()
+
+	procedure, ok := entry.procedures.Procedures[procedureType]
+
+	if !ok {
+		t.logger.Warnf("#RanProcedureTracker.end - RAN name: %s - no %s procedure found, cannot set it to %s", ranName, procedureType, state)
+		return
+	}
+
+	entry.stopTimer(procedureType)
+	procedure.State = state
+	procedure.EndTime = time.Now().UnixNano()
+
+	t.logger.Debugf("#RanProcedureTracker.end - RAN name: %s - %s procedure %s, transaction id: %s", ranName, procedureType, state, procedure.TransactionId)
+	t.save(entry.procedures)
+}
+
+func (t *RanProcedureTracker) expire(entry *ranProcedureEntry, procedure *models.RanProcedure) {
+	entry.mux.Lock()
+	defer entry.mux.Unlock()
+
+	if entry.removed || entry.procedures.Procedures[procedure.Type] != procedure || !procedure.IsOngoing() {
+		return
+	}
+
+	delete(entry.timers, procedure.Type)
+	procedure.State = models.RanProcedureTimedOut
+	procedure.EndTime = time.Now().UnixNano()
+
+	t.logger.Warnf("#RanProcedureTracker.expire - RAN name: %s - %s procedure timed out, transaction id: %s", entry.procedures.RanName, procedure.Type, procedure.TransactionId)
+	t.save(entry.procedures)
+}
+
+// lock returns the locked entry of the RAN, loading its procedures from rNib on first access
+func (t *RanProcedureTracker) lock(ranName string) *ranProcedureEntry {
+	for {
+		t.mux.Lock()
+		entry, ok := t.entries[ranName]
+		if !ok {
+			entry = &ranProcedureEntry{timers: make(map[models.RanProcedureType]*time.Timer)}
+			t.entries[ranName] = entry
+		}
+		t.mux.Unlock()
+
+		entry.mux.Lock()
+
+		// The RAN was removed while waiting for its entry, a new entry is used
+		if entry.removed {
+			entry.mux.Unlock()
+			continue
+		}
+
+		if entry.procedures == nil {
+			t.load(ranName, entry)
+		}
+
+		return entry
+	}
+}
+
+// load must be called with the entry mutex held
+func (t *RanProcedureTracker) load(ranName string, entry *ranProcedureEntry) {
+	ranProcedures, err := t.store.Get(ranName)
+
+	if err != nil {
+		if _, ok := err.(*common.ResourceNotFoundError); !ok {
+			t.logger.Errorf("#RanProcedureTracker.load - RAN name: %s - failed loading procedures from rNib. Error: %s", ranName, err)
+		}
+		ranProcedures = models.NewRanProcedures(ranName)
+	}
+
+	entry.procedures = ranProcedures
+	t.resumeOngoing(entry)
+}
+
+// resumeOngoing re-arms the timeouts of procedures which were ongoing when the procedures were persisted
+func (t *RanProcedureTracker) resumeOngoing(entry *ranProcedureEntry) {
+	expired := false
+
+	for _, procedure := range entry.procedures.Procedures {
+		if !procedure.IsOngoing() {
+			continue
+		}
+
+		remaining := t.timeout - time.Since(time.Unix(0, procedure.StartTime))
+
+		if remaining > 0 {
+			t.startTimer(entry, procedure, remaining)
+			continue
+		}
+
+		procedure.State = models.RanProcedureTimedOut
+		procedure.EndTime = time.Now().UnixNano()
+		expired = true
+	}
+
+	if expired {
+		t.save(entry.procedures)
+	}
+}
+
+func (t *RanProcedureTracker) startTimer(entry *ranProcedureEntry, procedure *models.RanProcedure, timeout time.Duration) {
+	if t.timeout <= 0 {
+		return
+	}
+
+	entry.timers[procedure.Type] = time.AfterFunc(timeout, func() {
+		t.expire(entry, procedure)
+	})
+}
+
+func (e *ranProcedureEntry) stopTimer(procedureType models.RanProcedureType) {
+	if timer, ok := e.timers[procedureType]; ok {
+		timer.Stop()
+		delete(e.timers, procedureType)
+	}
+}
+
+func (t *RanProcedureTracker) save(ranProcedures *models.RanProcedures) {
+	if err := t.store.Save(ranProcedures); err != nil {
+		t.logger.Errorf("#RanProcedureTracker.save - RAN name: %s - failed saving procedures to rNib. Error: %s", ranProcedures.RanName, err)
+	}
+}
+
+func copyRanProcedure(procedure *models.RanProcedure) *models.RanProcedure {
+	if procedure == nil {
+		return nil
+	}
+
+	procedureCopy := *procedure
+	return &procedureCopy
+}
@@ -0,0 +1,162 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"errors"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initRanProcedureTrackerTest(t *testing.T, procedureTimeoutSec int) (*mocks.RanProcedureStoreMock, *RanProcedureTracker) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{ProcedureTimeoutSec: procedureTimeoutSec}
+	storeMock := &mocks.RanProcedureStoreMock{}
+	return storeMock, NewRanProcedureTracker(log, config, storeMock)
+}
+
+func TestRanProcedureTrackerStartAndComplete(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 10)
+	storeMock.On("Get", RanName).Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Save", mock.Anything).Return(nil)
+
+	tracker.Start(RanName, models.E2SetupProcedure, "1")
+	procedure := tracker.GetLastProcedure(RanName)
+	assert.Equal(t, models.E2SetupProcedure, procedure.Type)
+	assert.Equal(t, models.RanProcedureOngoing, procedure.State)
+	assert.NotZero(t, procedure.StartTime)
+
+	tracker.Complete(RanName, models.E2SetupProcedure)
+	procedure = tracker.GetProcedureByTransactionId(RanName, "1")
+	assert.Equal(t, models.RanProcedureCompleted, procedure.State)
+	assert.NotZero(t, procedure.EndTime)
+
+	storeMock.AssertNumberOfCalls(t, "Get", 1)
+	storeMock.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestRanProcedureTrackerE2SetupResetsProcedures(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 10)
+	storeMock.On("Get", RanName).Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Save", mock.Anything).Return(nil)
+
+	tracker.Start(RanName, models.RicServiceUpdateProcedure, "3")
+	tracker.SavePreviousRanFunctions(RanName, models.RicServiceUpdateProcedure, []*entities.RanFunction{{RanFunctionId: 1}})
+	assert.Len(t, tracker.GetProcedure(RanName, models.RicServiceUpdateProcedure).PreviousRanFunctions, 1)
+
+	tracker.Start(RanName, models.E2SetupProcedure, "4")
+	assert.Nil(t, tracker.GetProcedure(RanName, models.RicServiceUpdateProcedure))
+	assert.Equal(t, models.E2SetupProcedure, tracker.GetLastProcedure(RanName).Type)
+}
+
+func TestRanProcedureTrackerTimeout(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 1)
+	storeMock.On("Get", RanName).Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Save", mock.Anything).Return(nil)
+
+	tracker.Start(RanName, models.RicServiceQueryProcedure, "5")
+
+	assert.Eventually(t, func() bool {
+		return tracker.GetLastProcedure(RanName).State == models.RanProcedureTimedOut
+	}, 3*time.Second, 50*time.Millisecond)
+
+	tracker.Complete(RanName, models.RicServiceQueryProcedure)
+	assert.Equal(t, models.RanProcedureCompleted, tracker.GetLastProcedure(RanName).State)
+}
+
+func TestRanProcedureTrackerLoadsFromStore(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 10)
+	procedures := models.NewRanProcedures(RanName)
+	procedures.Procedures[models.E2SetupProcedure] = &models.RanProcedure{Type: models.E2SetupProcedure, TransactionId: "1", State: models.RanProcedureCompleted}
+	procedures.Procedures[models.E2NodeConfigUpdateProcedure] = &models.RanProcedure{Type: models.E2NodeConfigUpdateProcedure, TransactionId: "2",
+		State: models.RanProcedureOngoing, StartTime: time.Now().Add(-time.Minute).UnixNano()}
+	procedures.LastType = models.E2NodeConfigUpdateProcedure
+	storeMock.On("Get", RanName).Return(procedures, nil)
+	storeMock.On("Save", mock.Anything).Return(nil)
+
+	procedure := tracker.GetLastProcedure(RanName)
+	assert.Equal(t, models.E2NodeConfigUpdateProcedure, procedure.Type)
+	assert.Equal(t, models.RanProcedureTimedOut, procedure.State)
+	assert.Equal(t, models.RanProcedureCompleted, tracker.GetProcedure(RanName, models.E2SetupProcedure).State)
+	storeMock.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestRanProcedureTrackerUnknownProcedure(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 10)
+	storeMock.On("Get", RanName).Return(nil, common.NewInternalError(errors.New("connection refused")))
+
+	tracker.Complete(RanName, models.E2ResetProcedure)
+	assert.Nil(t, tracker.GetLastProcedure(RanName))
+	storeMock.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestRanProcedureTrackerRemove(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 1)
+	storeMock.On("Get", RanName).Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Save", mock.Anything).Return(nil)
+	storeMock.On("Delete", RanName).Return(nil)
+
+	tracker.Start(RanName, models.RicServiceQueryProcedure, "6")
+	err := tracker.Remove(RanName)
+
+	assert.Nil(t, err)
+	assert.Empty(t, tracker.entries)
+	storeMock.AssertCalled(t, "Delete", RanName)
+
+	time.Sleep(1500 * time.Millisecond)
+	storeMock.AssertNumberOfCalls(t, "Save", 1)
+
+	assert.Nil(t, tracker.GetLastProcedure(RanName))
+	storeMock.AssertNumberOfCalls(t, "Get", 2)
+}
+
+func TestRanProcedureTrackerRemoveFailure(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 10)
+	storeMock.On("Delete", RanName).Return(common.NewInternalError(errors.New("connection refused")))
+
+	err := tracker.Remove(RanName)
+
+	assert.NotNil(t, err)
+}
+
+func TestRanProcedureTrackerSlowStoreDoesNotBlockOtherRans(t *testing.T) {
+	storeMock, tracker := initRanProcedureTrackerTest(t, 10)
+	storeMock.On("Get", "slowRan").After(time.Second).Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Get", RanName).Return(nil, common.NewResourceNotFoundError("not found"))
+
+	go tracker.GetLastProcedure("slowRan")
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	tracker.GetLastProcedure(RanName)
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
@@ -0,0 +1,130 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/rmrCgo"
+	"e2mgr/services"
+	"e2mgr/services/rmrsender"
+	"e2mgr/utils"
+	"encoding/xml"
+	"unsafe"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	E2_RESET_ACTIVITY_NAME = "E2_RESET"
+)
+
+var e2ResetRequestEmptyTagsToReplaceToSelfClosingTags = []string{"reject", "ignore", "protocolIEs",
+	"control-processing-overload", "hardware-failure", "om-intervention", "unspecified", "transfer-syntax-error", "abstract-syntax-error-reject",
+	"abstract-syntax-error-ignore-and-notify", "message-not-compatible-with-receiver-state", "semantic-error",
+	"abstract-syntax-error-falsely-constructed-message", "transport-resource-unavailable", "function-not-required", "excessive-functions",
+	"ric-resource-limit"}
+
+type IRicE2ResetManager interface {
+	Reset(ranName string, cause models.Cause) (*E2ResetTransaction, error)
+}
+
+type RicE2ResetManager struct {
+	logger                            *logger.Logger
+	rmrSender                         *rmrsender.RmrSender
+	rNibDataService                   services.RNibDataService
+	ranResetManager                   *RanResetManager
+	changeStatusToConnectedRanManager *ChangeStatusToConnectedRanManager
+	e2ResetTransactionManager         IE2ResetTransactionManager
+}
+
+func NewRicE2ResetManager(logger *logger.Logger, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, ranResetManager *RanResetManager, changeStatusToConnectedRanManager *ChangeStatusToConnectedRanManager, e2ResetTransactionManager IE2ResetTransactionManager) *RicE2ResetManager {
+	return &RicE2ResetManager{
+		logger:                            logger,
+		rmrSender:                         rmrSender,
+		rNibDataService:                   rNibDataService,
+		ranResetManager:                   ranResetManager,
+		changeStatusToConnectedRanManager: changeStatusToConnectedRanManager,
+		e2ResetTransactionManager:         e2ResetTransactionManager,
+	}
+}
+
+// Reset sends a RIC initiated E2 Reset to a connected RAN, the caller may wait on the returned transaction for the RAN response
+func (m *RicE2ResetManager) Reset(ranName string, cause models.Cause) (*E2ResetTransaction, error) {
+	nodebInfo, err := m.rNibDataService.GetNodeb(ranName)
+	if err != nil {
+		m.logger.Errorf("#RicE2ResetManager.Reset - RAN name: %s - failed to get status of RAN from RNIB. Error: %s", ranName, err.Error())
+		_, ok := err.(*common.ResourceNotFoundError)
+		if ok {
+			return nil, e2managererrors.NewResourceNotFoundError()
+		}
+		return nil, e2managererrors.NewRnibDbError()
+	}
+
+	if nodebInfo.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
+		m.logger.Errorf("#RicE2ResetManager.Reset - RAN name: %s - RAN in wrong state (%s)", ranName, entities.ConnectionStatus_name[int32(nodebInfo.ConnectionStatus)])
+		return nil, e2managererrors.NewWrongStateError(E2_RESET_ACTIVITY_NAME, entities.ConnectionStatus_name[int32(nodebInfo.ConnectionStatus)])
+	}
+
+	transaction, err := m.e2ResetTransactionManager.Start(ranName)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := BuildRicE2ResetRequest(transaction.TransactionId, cause)
+	if err != nil {
+		m.logger.Errorf("#RicE2ResetManager.Reset - RAN name: %s - Error marshalling RIC_E2_RESET_REQ. Error: %s", ranName, err)
+		m.e2ResetTransactionManager.Abort(ranName)
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	_, err = m.ranResetManager.ResetRan(ranName)
+	if err != nil {
+		m.logger.Errorf("#RicE2ResetManager.Reset - RAN name: %s - failed to update and notify connection status of nodeB entity. Error: %s", ranName, err)
+		m.e2ResetTransactionManager.Abort(ranName)
+		return nil, e2managererrors.NewRnibDbError()
+	}
+
+	var xAction []byte
+	var msgSrc unsafe.Pointer
+	msg := models.NewRmrMessage(rmrCgo.RIC_E2_RESET_REQ, ranName, payload, xAction, msgSrc)
+
+	err = m.rmrSender.Send(msg)
+	if err != nil {
+		m.logger.Errorf("#RicE2ResetManager.Reset - RAN name: %s - failed to send RIC_E2_RESET_REQ message to RMR. Error: %s", ranName, err)
+		m.e2ResetTransactionManager.Abort(ranName)
+		_, _ = m.changeStatusToConnectedRanManager.ChangeStatusToConnectedRan(ranName)
+		return nil, e2managererrors.NewRmrError()
+	}
+
+	m.logger.Infof("#RicE2ResetManager.Reset - RAN name: %s - sent RIC_E2_RESET_REQ, transaction id: %s, cause: %s", ranName, transaction.TransactionId, cause)
+	return transaction, nil
+}
+
+func BuildRicE2ResetRequest(transactionId string, cause models.Cause) ([]byte, error) {
+	resetRequest := models.NewRicE2ResetRequestMessage(transactionId, cause)
+	payload, err := xml.Marshal(resetRequest.E2APPDU)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.ReplaceEmptyTagsWithSelfClosing(payload, e2ResetRequestEmptyTagsToReplaceToSelfClosingTags), nil
+}
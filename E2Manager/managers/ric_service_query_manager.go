@@ -0,0 +1,299 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"e2mgr/rmrCgo"
+	"e2mgr/services"
+	"e2mgr/services/rmrsender"
+	"e2mgr/utils"
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	RIC_SERVICE_QUERY_ACTIVITY_NAME = "RIC_SERVICE_QUERY"
+)
+
+var ricServiceQueryEmptyTagsToReplaceToSelfClosingTags = []string{"reject", "ignore", "protocolIEs", "procedureCode"}
+
+type IRicServiceQueryManager interface {
+	Query(ranName string) (*models.RicServiceQueryRecord, error)
+	SendQuery(nodebInfo *entities.NodebInfo) (*models.RicServiceQueryRecord, error)
+	HandleServiceUpdate(ranName string, transactionId string) bool
+	GetUnresponsiveRans() []*models.RicServiceQueryRecord
+}
+
+type pendingRicServiceQuery struct {
+	record  *models.RicServiceQueryRecord
+	timer   *time.Timer
+	expired bool
+}
+
+// RicServiceQueryManager sends RIC Service Queries and matches them with the RIC Service Update the E2 node sends in return
+type RicServiceQueryManager struct {
+	logger              *logger.Logger
+	rmrSender           *rmrsender.RmrSender
+	rNibDataService     services.RNibDataService
+	ranProcedureTracker IRanProcedureTracker
+	responseDeadline    time.Duration
+	pending             map[string]*pendingRicServiceQuery
+	nextTransactionIds  map[string]int
+	mux                 sync.Mutex
+}
+
+func NewRicServiceQueryManager(logger *logger.Logger, config *configuration.Configuration, rmrSender *rmrsender.RmrSender, rNibDataService services.RNibDataService, ranProcedureTracker IRanProcedureTracker) *RicServiceQueryManager {
+	return &RicServiceQueryManager{
+		logger:              logger,
+		rmrSender:           rmrSender,
+		rNibDataService:     rNibDataService,
+		ranProcedureTracker: ranProcedureTracker,
+		responseDeadline:    time.Duration(config.RicServiceQuery.ResponseDeadlineSec) * time.Second,
+		pending:             make(map[string]*pendingRicServiceQuery),
+		nextTransactionIds:  make(map[string]int),
+	}
+}
+
+// Query sends a RIC Service Query to a connected RAN
+func (m *RicServiceQueryManager) Query(ranName string) (*models.RicServiceQueryRecord, error) {
+	nodebInfo, err := m.rNibDataService.GetNodeb(ranName)
+	if err != nil {
+		m.logger.Errorf("#RicServiceQueryManager.Query - RAN name: %s - failed to get RAN from RNIB. Error: %s", ranName, err)
+		if _, ok := err.(*common.ResourceNotFoundError); ok {
+			return nil, e2managererrors.NewResourceNotFoundError()
+		}
+		return nil, e2managererrors.NewRnibDbError()
+	}
+
+	if nodebInfo.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
+		m.logger.Errorf("#RicServiceQueryManager.Query - RAN name: %s - RAN in wrong state (%s)", ranName, entities.ConnectionStatus_name[int32(nodebInfo.ConnectionStatus)])
+		return nil, e2managererrors.NewWrongStateError(RIC_SERVICE_QUERY_ACTIVITY_NAME, entities.ConnectionStatus_name[int32(nodebInfo.ConnectionStatus)])
+	}
+
+	return m.SendQuery(nodebInfo)
+}
+
+// SendQuery sends a RIC Service Query listing the RAN functions the RIC holds for the RAN, a previous query which is still pending is replaced
+func (m *RicServiceQueryManager) SendQuery(nodebInfo *entities.NodebInfo) (*models.RicServiceQueryRecord, error) {
+	ranName := nodebInfo.RanName
+	transactionId := m.allocateTransactionId(ranName)
+	serviceQuery := models.NewRicServiceQueryMessage(nodebInfo.GetGnb().GetRanFunctions(), transactionId)
+
+	payload, err := xml.Marshal(serviceQuery.E2APPDU)
+	if err != nil {
+		m.logger.Errorf("#RicServiceQueryManager.SendQuery - RAN name: %s - Error marshalling RIC_SERVICE_QUERY. Error: %s", ranName, err)
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	payload = utils.ReplaceEmptyTagsWithSelfClosing(payload, ricServiceQueryEmptyTagsToReplaceToSelfClosingTags)
+
+	var xAction []byte
+	var msgSrc unsafe.Pointer
+	msg := models.NewRmrMessage(rmrCgo.RIC_SERVICE_QUERY, ranName, payload, xAction, msgSrc)
+
+	m.ranProcedureTracker.Start(ranName, models.RicServiceQueryProcedure, transactionId)
+
+	// the query is pending before it is sent, so a RIC Service Update handled right after the send finds it
+	sentAt := time.Now()
+	record := &models.RicServiceQueryRecord{
+		RanName:       ranName,
+		TransactionId: transactionId,
+		SentAt:        sentAt.UnixNano(),
+		Deadline:      sentAt.Add(m.responseDeadline).UnixNano(),
+	}
+	pendingQuery := m.addPending(record)
+
+	err = m.rmrSender.Send(msg)
+	if err != nil {
+		m.logger.Errorf("#RicServiceQueryManager.SendQuery - RAN name: %s - failed to send RIC_SERVICE_QUERY message to RMR. Error: %s", ranName, err)
+		m.removePending(pendingQuery)
+		m.ranProcedureTracker.Fail(ranName, models.RicServiceQueryProcedure)
+		return nil, e2managererrors.NewRmrError()
+	}
+
+	m.logger.Infof("#RicServiceQueryManager.SendQuery - RAN name: %s - Successfully built and sent RIC_SERVICE_QUERY, transaction id: %s", ranName, transactionId)
+	return record, nil
+}
+
+// HandleServiceUpdate completes the pending query of the RAN when the RIC Service Update carries its transaction id
+func (m *RicServiceQueryManager) HandleServiceUpdate(ranName string, transactionId string) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	pendingQuery, ok := m.pending[ranName]
+
+	if !ok {
+		// the query may have been sent before a restart, in which case only the tracked procedure is left
+		serviceQuery := m.ranProcedureTracker.GetProcedure(ranName, models.RicServiceQueryProcedure)
+		if serviceQuery == nil || !serviceQuery.IsOngoing() || serviceQuery.TransactionId != transactionId {
+			return false
+		}
+		m.ranProcedureTracker.Complete(ranName, models.RicServiceQueryProcedure)
+		return true
+	}
+
+	if pendingQuery.record.TransactionId != transactionId {
+		m.logger.Infof("#RicServiceQueryManager.HandleServiceUpdate - RAN name: %s - RIC Service Update transaction id %s does not match the RIC Service Query transaction id %s", ranName, transactionId, pendingQuery.record.TransactionId)
+		return false
+	}
+
+	pendingQuery.timer.Stop()
+	delete(m.pending, ranName)
+
+	if pendingQuery.expired {
+		m.logger.Warnf("#RicServiceQueryManager.HandleServiceUpdate - RAN name: %s - RIC Service Update received after the deadline, transaction id: %s", ranName, transactionId)
+	}
+
+	m.ranProcedureTracker.Complete(ranName, models.RicServiceQueryProcedure)
+	return true
+}
+
+// GetUnresponsiveRans returns the queries whose deadline passed without a RIC Service Update, sorted by RAN name.
+// The queries of RANs which are no longer connected are dropped, they are not queried again until they reconnect
+func (m *RicServiceQueryManager) GetUnresponsiveRans() []*models.RicServiceQueryRecord {
+	records := make([]*models.RicServiceQueryRecord, 0)
+
+	for _, pendingQuery := range m.getExpired() {
+		if !m.isConnected(pendingQuery.record.RanName) {
+			m.logger.Infof("#RicServiceQueryManager.GetUnresponsiveRans - RAN name: %s - RAN is no longer connected, dropping its RIC Service Query, transaction id: %s", pendingQuery.record.RanName, pendingQuery.record.TransactionId)
+			m.removeRan(pendingQuery)
+			continue
+		}
+
+		recordCopy := *pendingQuery.record
+		records = append(records, &recordCopy)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].RanName < records[j].RanName
+	})
+
+	return records
+}
+
+func (m *RicServiceQueryManager) getExpired() []*pendingRicServiceQuery {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var expired []*pendingRicServiceQuery
+
+	for _, pendingQuery := range m.pending {
+		if pendingQuery.expired {
+			expired = append(expired, pendingQuery)
+		}
+	}
+
+	return expired
+}
+
+// isConnected reads the connection status from rNib, a RAN which can not be read is considered connected
+func (m *RicServiceQueryManager) isConnected(ranName string) bool {
+	nodebInfo, err := m.rNibDataService.GetNodeb(ranName)
+	if err != nil {
+		if _, ok := err.(*common.ResourceNotFoundError); ok {
+			return false
+		}
+		m.logger.Warnf("#RicServiceQueryManager.isConnected - RAN name: %s - failed to get RAN from RNIB. Error: %s", ranName, err)
+		return true
+	}
+
+	return nodebInfo.GetConnectionStatus() == entities.ConnectionStatus_CONNECTED
+}
+
+// allocateTransactionId returns the next E2AP TransactionID (0..255) of the RAN
+func (m *RicServiceQueryManager) allocateTransactionId(ranName string) string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	transactionId := m.nextTransactionIds[ranName]
+	m.nextTransactionIds[ranName] = (transactionId + 1) % maxE2TransactionId
+	return strconv.Itoa(transactionId)
+}
+
+func (m *RicServiceQueryManager) addPending(record *models.RicServiceQueryRecord) *pendingRicServiceQuery {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if previous, ok := m.pending[record.RanName]; ok {
+		previous.timer.Stop()
+	}
+
+	pendingQuery := &pendingRicServiceQuery{record: record}
+	pendingQuery.timer = time.AfterFunc(m.responseDeadline, func() {
+		m.expire(pendingQuery)
+	})
+	m.pending[record.RanName] = pendingQuery
+	return pendingQuery
+}
+
+// removePending drops the query unless a newer query of the RAN replaced it
+func (m *RicServiceQueryManager) removePending(pendingQuery *pendingRicServiceQuery) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.removePendingLocked(pendingQuery)
+}
+
+// removeRan drops the query and the transaction ids of a RAN which is no longer connected, unless a newer query of the RAN replaced it
+func (m *RicServiceQueryManager) removeRan(pendingQuery *pendingRicServiceQuery) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.removePendingLocked(pendingQuery) {
+		delete(m.nextTransactionIds, pendingQuery.record.RanName)
+	}
+}
+
+func (m *RicServiceQueryManager) removePendingLocked(pendingQuery *pendingRicServiceQuery) bool {
+	ranName := pendingQuery.record.RanName
+
+	if m.pending[ranName] != pendingQuery {
+		return false
+	}
+
+	pendingQuery.timer.Stop()
+	delete(m.pending, ranName)
+	return true
+}
+
+func (m *RicServiceQueryManager) expire(pendingQuery *pendingRicServiceQuery) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	ranName := pendingQuery.record.RanName
+
+	if m.pending[ranName] != pendingQuery {
+		return
+	}
+
+	pendingQuery.expired = true
+	m.logger.Warnf("#RicServiceQueryManager.expire - RAN name: %s - no RIC Service Update within %s, transaction id: %s", ranName, m.responseDeadline, pendingQuery.record.TransactionId)
+	m.ranProcedureTracker.TimeOut(ranName, models.RicServiceQueryProcedure)
+}
@@ -0,0 +1,206 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"e2mgr/rmrCgo"
+	"e2mgr/services"
+	"errors"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initRicServiceQueryManagerTest(t *testing.T) (*mocks.RnibReaderMock, *mocks.RmrMessengerMock, *RanProcedureTracker, *RicServiceQueryManager) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, ProcedureTimeoutSec: 10}
+	config.RicServiceQuery.ResponseDeadlineSec = 10
+
+	readerMock := &mocks.RnibReaderMock{}
+	rnibDataService := services.NewRnibDataService(log, config, readerMock, &mocks.RnibWriterMock{})
+	rmrMessengerMock := &mocks.RmrMessengerMock{}
+	rmrSender := initRmrSender(rmrMessengerMock, log)
+	storeMock := &mocks.RanProcedureStoreMock{}
+	storeMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("not found"))
+	storeMock.On("Save", mock.Anything).Return(nil)
+	tracker := NewRanProcedureTracker(log, config, storeMock)
+
+	return readerMock, rmrMessengerMock, tracker, NewRicServiceQueryManager(log, config, rmrSender, rnibDataService, tracker)
+}
+
+func connectedGnb(ranName string) *entities.NodebInfo {
+	return &entities.NodebInfo{
+		RanName:          ranName,
+		ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+		NodeType:         entities.Node_GNB,
+		Configuration:    &entities.NodebInfo_Gnb{Gnb: &entities.Gnb{RanFunctions: []*entities.RanFunction{{RanFunctionId: 1, RanFunctionRevision: 1}}}},
+	}
+}
+
+func TestRicServiceQueryManagerQuerySuccess(t *testing.T) {
+	readerMock, rmrMessengerMock, tracker, manager := initRicServiceQueryManagerTest(t)
+	readerMock.On("GetNodeb", RanName).Return(connectedGnb(RanName), nil)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+
+	record, err := manager.Query(RanName)
+
+	assert.Nil(t, err)
+	assert.Equal(t, RanName, record.RanName)
+	assert.NotEmpty(t, record.TransactionId)
+	assert.Equal(t, int64(10*time.Second), record.Deadline-record.SentAt)
+	procedure := tracker.GetProcedure(RanName, models.RicServiceQueryProcedure)
+	assert.Equal(t, models.RanProcedureOngoing, procedure.State)
+	assert.Equal(t, record.TransactionId, procedure.TransactionId)
+	rmrMessengerMock.AssertNumberOfCalls(t, "SendMsg", 1)
+}
+
+func TestRicServiceQueryManagerQueryRanNotConnected(t *testing.T) {
+	readerMock, rmrMessengerMock, _, manager := initRicServiceQueryManagerTest(t)
+	nodebInfo := connectedGnb(RanName)
+	nodebInfo.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, nil)
+
+	record, err := manager.Query(RanName)
+
+	assert.Nil(t, record)
+	assert.IsType(t, &e2managererrors.WrongStateError{}, err)
+	rmrMessengerMock.AssertNotCalled(t, "SendMsg", mock.Anything, mock.Anything)
+}
+
+func TestRicServiceQueryManagerQueryRanNotFound(t *testing.T) {
+	readerMock, _, _, manager := initRicServiceQueryManagerTest(t)
+	var nodebInfo *entities.NodebInfo
+	readerMock.On("GetNodeb", RanName).Return(nodebInfo, common.NewResourceNotFoundError("not found"))
+
+	_, err := manager.Query(RanName)
+
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
+
+func TestRicServiceQueryManagerSendFailure(t *testing.T) {
+	_, rmrMessengerMock, tracker, manager := initRicServiceQueryManagerTest(t)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, errors.New("rmr failure"))
+
+	record, err := manager.SendQuery(connectedGnb(RanName))
+
+	assert.Nil(t, record)
+	assert.IsType(t, &e2managererrors.RmrError{}, err)
+	assert.Equal(t, models.RanProcedureFailed, tracker.GetProcedure(RanName, models.RicServiceQueryProcedure).State)
+	assert.Empty(t, manager.pending)
+}
+
+func TestRicServiceQueryManagerHandleServiceUpdate(t *testing.T) {
+	_, rmrMessengerMock, tracker, manager := initRicServiceQueryManagerTest(t)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+	record, _ := manager.SendQuery(connectedGnb(RanName))
+
+	assert.False(t, manager.HandleServiceUpdate(RanName, record.TransactionId+"1"))
+	assert.Equal(t, models.RanProcedureOngoing, tracker.GetProcedure(RanName, models.RicServiceQueryProcedure).State)
+
+	assert.True(t, manager.HandleServiceUpdate(RanName, record.TransactionId))
+	assert.Equal(t, models.RanProcedureCompleted, tracker.GetProcedure(RanName, models.RicServiceQueryProcedure).State)
+
+	assert.False(t, manager.HandleServiceUpdate(RanName, record.TransactionId))
+}
+
+func TestRicServiceQueryManagerTransactionIds(t *testing.T) {
+	_, rmrMessengerMock, _, manager := initRicServiceQueryManagerTest(t)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+
+	first, _ := manager.SendQuery(connectedGnb(RanName))
+	second, _ := manager.SendQuery(connectedGnb(RanName))
+	other, _ := manager.SendQuery(connectedGnb("test2"))
+
+	assert.Equal(t, "0", first.TransactionId)
+	assert.Equal(t, "1", second.TransactionId)
+	assert.Equal(t, "0", other.TransactionId)
+
+	manager.nextTransactionIds[RanName] = 255
+	last, _ := manager.SendQuery(connectedGnb(RanName))
+	wrapped, _ := manager.SendQuery(connectedGnb(RanName))
+
+	assert.Equal(t, "255", last.TransactionId)
+	assert.Equal(t, "0", wrapped.TransactionId)
+}
+
+func TestRicServiceQueryManagerServiceUpdateBeforeSendReturns(t *testing.T) {
+	_, rmrMessengerMock, tracker, manager := initRicServiceQueryManagerTest(t)
+	handled := false
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil).Run(func(args mock.Arguments) {
+		handled = manager.HandleServiceUpdate(RanName, "0")
+	})
+
+	record, err := manager.SendQuery(connectedGnb(RanName))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "0", record.TransactionId)
+	assert.True(t, handled)
+	assert.Empty(t, manager.pending)
+	assert.Equal(t, models.RanProcedureCompleted, tracker.GetProcedure(RanName, models.RicServiceQueryProcedure).State)
+}
+
+func TestRicServiceQueryManagerUnresponsiveRanDisconnected(t *testing.T) {
+	readerMock, rmrMessengerMock, _, manager := initRicServiceQueryManagerTest(t)
+	manager.responseDeadline = 50 * time.Millisecond
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+	disconnectedGnb := connectedGnb(RanName)
+	disconnectedGnb.ConnectionStatus = entities.ConnectionStatus_DISCONNECTED
+	readerMock.On("GetNodeb", RanName).Return(disconnectedGnb, nil)
+	var deletedGnb *entities.NodebInfo
+	readerMock.On("GetNodeb", "test2").Return(deletedGnb, common.NewResourceNotFoundError("not found"))
+	_, _ = manager.SendQuery(connectedGnb(RanName))
+	_, _ = manager.SendQuery(connectedGnb("test2"))
+
+	time.Sleep(150 * time.Millisecond)
+
+	assert.Empty(t, manager.GetUnresponsiveRans())
+	assert.Empty(t, manager.pending)
+	assert.Empty(t, manager.nextTransactionIds)
+}
+
+func TestRicServiceQueryManagerUnresponsiveRan(t *testing.T) {
+	readerMock, rmrMessengerMock, tracker, manager := initRicServiceQueryManagerTest(t)
+	manager.responseDeadline = 50 * time.Millisecond
+	readerMock.On("GetNodeb", RanName).Return(connectedGnb(RanName), nil)
+	rmrMessengerMock.On("SendMsg", mock.Anything, true).Return(&rmrCgo.MBuf{}, nil)
+	record, _ := manager.SendQuery(connectedGnb(RanName))
+
+	time.Sleep(150 * time.Millisecond)
+
+	unresponsiveRans := manager.GetUnresponsiveRans()
+	assert.Len(t, unresponsiveRans, 1)
+	assert.Equal(t, *record, *unresponsiveRans[0])
+	assert.Equal(t, models.RanProcedureTimedOut, tracker.GetProcedure(RanName, models.RicServiceQueryProcedure).State)
+
+	assert.True(t, manager.HandleServiceUpdate(RanName, record.TransactionId))
+	assert.Empty(t, manager.GetUnresponsiveRans())
+}
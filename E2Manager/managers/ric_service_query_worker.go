@@ -0,0 +1,132 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"context"
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"math/rand"
+	"strings"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+// RicServiceQueryWorker periodically queries the RAN functions of every connected RAN
+type RicServiceQueryWorker struct {
+	logger                 *logger.Logger
+	config                 *configuration.Configuration
+	ranListManager         RanListManager
+	ricServiceQueryManager IRicServiceQueryManager
+	leaderElector          ILeaderElector
+}
+
+func NewRicServiceQueryWorker(logger *logger.Logger, config *configuration.Configuration, ranListManager RanListManager, ricServiceQueryManager IRicServiceQueryManager, leaderElector ILeaderElector) RicServiceQueryWorker {
+	return RicServiceQueryWorker{
+		logger:                 logger,
+		config:                 config,
+		ranListManager:         ranListManager,
+		ricServiceQueryManager: ricServiceQueryManager,
+		leaderElector:          leaderElector,
+	}
+}
+
+func (w RicServiceQueryWorker) Execute(ctx context.Context) {
+
+	if w.config.RicServiceQuery.IntervalSec <= 0 {
+		w.logger.Infof("#RicServiceQueryWorker.Execute - scheduled RIC service queries are disabled")
+		return
+	}
+
+	w.logger.Infof("#RicServiceQueryWorker.Execute - RIC service queries started, interval: %ds, jitter: %ds", w.config.RicServiceQuery.IntervalSec, w.config.RicServiceQuery.JitterSec)
+
+	interval := time.Duration(w.config.RicServiceQuery.IntervalSec) * time.Second
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Infof("#RicServiceQueryWorker.Execute - RIC service queries stopped")
+			return
+		case <-time.After(interval + w.jitter()):
+			if !w.leaderElector.IsLeader() {
+				continue
+			}
+			w.ReportUnresponsiveRans()
+			w.QueryConnectedRans()
+		}
+	}
+}
+
+// QueryConnectedRans queries every connected RAN and records the health check sent timestamp of the queried RANs,
+// so the RAN liveness monitor counts the scheduled queries as health checks
+func (w RicServiceQueryWorker) QueryConnectedRans() {
+
+	var queriedRans []string
+
+	for _, nbIdentity := range w.ranListManager.GetNbIdentityList() {
+
+		if nbIdentity.ConnectionStatus != entities.ConnectionStatus_CONNECTED {
+			continue
+		}
+
+		if _, err := w.ricServiceQueryManager.Query(nbIdentity.InventoryName); err != nil {
+			w.logger.Warnf("#RicServiceQueryWorker.QueryConnectedRans - RAN name: %s - RIC service query was not sent. Error: %s", nbIdentity.InventoryName, err)
+			continue
+		}
+
+		queriedRans = append(queriedRans, nbIdentity.InventoryName)
+	}
+
+	if len(queriedRans) == 0 {
+		return
+	}
+
+	if err := w.ranListManager.UpdateHealthcheckTimeStampsSent(queriedRans); err != nil {
+		w.logger.Errorf("#RicServiceQueryWorker.QueryConnectedRans - Failed saving the health check sent timestamp of %d RANs. Error: %s", len(queriedRans), err)
+	}
+}
+
+// ReportUnresponsiveRans logs the RANs which did not answer the queries of the previous round
+func (w RicServiceQueryWorker) ReportUnresponsiveRans() []*models.RicServiceQueryRecord {
+
+	records := w.ricServiceQueryManager.GetUnresponsiveRans()
+
+	if len(records) == 0 {
+		return records
+	}
+
+	ranNames := make([]string, len(records))
+	for i, record := range records {
+		ranNames[i] = record.RanName
+	}
+
+	w.logger.Warnf("#RicServiceQueryWorker.ReportUnresponsiveRans - %d RANs did not answer the RIC service query: %s", len(records), strings.Join(ranNames, ", "))
+	return records
+}
+
+func (w RicServiceQueryWorker) jitter() time.Duration {
+
+	if w.config.RicServiceQuery.JitterSec <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(time.Duration(w.config.RicServiceQuery.JitterSec) * time.Second)))
+}
@@ -0,0 +1,95 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initRicServiceQueryWorkerTest(t *testing.T) (*mocks.RanListManagerMock, *mocks.RicServiceQueryManagerMock, RicServiceQueryWorker) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#... - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{}
+	config.RicServiceQuery.IntervalSec = 300
+	config.RicServiceQuery.JitterSec = 30
+
+	ranListManagerMock := &mocks.RanListManagerMock{}
+	ricServiceQueryManagerMock := &mocks.RicServiceQueryManagerMock{}
+
+	return ranListManagerMock, ricServiceQueryManagerMock, NewRicServiceQueryWorker(log, config, ranListManagerMock, ricServiceQueryManagerMock, &mocks.LeaderElectorMock{})
+}
+
+func TestRicServiceQueryWorkerQueriesConnectedRans(t *testing.T) {
+	ranListManagerMock, ricServiceQueryManagerMock, worker := initRicServiceQueryWorkerTest(t)
+	nbIdentityList := []*entities.NbIdentity{
+		{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "ran2", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED},
+		{InventoryName: "ran3", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+	}
+	ranListManagerMock.On("GetNbIdentityList").Return(nbIdentityList)
+	ricServiceQueryManagerMock.On("Query", "ran1").Return(&models.RicServiceQueryRecord{RanName: "ran1"}, nil)
+	ricServiceQueryManagerMock.On("Query", "ran3").Return(nil, e2managererrors.NewRmrError())
+	ranListManagerMock.On("UpdateHealthcheckTimeStampsSent", []string{"ran1"}).Return(nil)
+
+	worker.QueryConnectedRans()
+
+	ricServiceQueryManagerMock.AssertNumberOfCalls(t, "Query", 2)
+	ricServiceQueryManagerMock.AssertNotCalled(t, "Query", "ran2")
+	ranListManagerMock.AssertCalled(t, "UpdateHealthcheckTimeStampsSent", []string{"ran1"})
+}
+
+func TestRicServiceQueryWorkerNoRanQueried(t *testing.T) {
+	ranListManagerMock, ricServiceQueryManagerMock, worker := initRicServiceQueryWorkerTest(t)
+	ranListManagerMock.On("GetNbIdentityList").Return([]*entities.NbIdentity{{InventoryName: "ran1", ConnectionStatus: entities.ConnectionStatus_CONNECTED}})
+	ricServiceQueryManagerMock.On("Query", "ran1").Return(nil, e2managererrors.NewRmrError())
+
+	worker.QueryConnectedRans()
+
+	ranListManagerMock.AssertNotCalled(t, "UpdateHealthcheckTimeStampsSent", mock.Anything)
+}
+
+func TestRicServiceQueryWorkerReportUnresponsiveRans(t *testing.T) {
+	_, ricServiceQueryManagerMock, worker := initRicServiceQueryWorkerTest(t)
+	records := []*models.RicServiceQueryRecord{{RanName: "ran1", TransactionId: "1"}, {RanName: "ran3", TransactionId: "2"}}
+	ricServiceQueryManagerMock.On("GetUnresponsiveRans").Return(records)
+
+	assert.Equal(t, records, worker.ReportUnresponsiveRans())
+}
+
+func TestRicServiceQueryWorkerJitter(t *testing.T) {
+	_, _, worker := initRicServiceQueryWorkerTest(t)
+
+	for i := 0; i < 10; i++ {
+		jitter := worker.jitter()
+		assert.True(t, jitter >= 0 && jitter < time.Duration(worker.config.RicServiceQuery.JitterSec)*time.Second)
+	}
+}
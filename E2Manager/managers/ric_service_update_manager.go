@@ -34,49 +34,62 @@ type IRicServiceUpdateManager interface {
 
 
 type RicServiceUpdateManager struct {
-	logger          *logger.Logger
-	rNibDataService services.RNibDataService
+	logger              *logger.Logger
+	rNibDataService     services.RNibDataService
+	ranProcedureTracker IRanProcedureTracker
+	ranListManager      RanListManager
 }
 
-func NewRicServiceUpdateManager(logger *logger.Logger, rNibDataService services.RNibDataService) *RicServiceUpdateManager {
+func NewRicServiceUpdateManager(logger *logger.Logger, rNibDataService services.RNibDataService, ranProcedureTracker IRanProcedureTracker, ranListManager RanListManager) *RicServiceUpdateManager {
 	return &RicServiceUpdateManager{
-		logger:          logger,
-		rNibDataService: rNibDataService,
+		logger:              logger,
+		rNibDataService:     rNibDataService,
+		ranProcedureTracker: ranProcedureTracker,
+		ranListManager:      ranListManager,
 	}
 }
 
-
+// StoreExistingRanFunctions keeps the RAN functions of the RAN in its ongoing RIC Service Update procedure, so it can be reverted later
 func (h *RicServiceUpdateManager) StoreExistingRanFunctions(ranName string) error {
 	nodebInfo, err := h.rNibDataService.GetNodeb(ranName)
 	if err != nil {
-		h.logger.Errorf("#RicServiceUpdateManager.revertRanFunctions - failed to get nodeB entity for ran name: %v due to RNIB Error: %s", ranName, err)
+		h.logger.Errorf("#RicServiceUpdateManager.StoreExistingRanFunctions - failed to get nodeB entity for ran name: %v due to RNIB Error: %s", ranName, err)
+		return err
 	}
 	if nodebInfo.GetGnb() == nil {
-        h.logger.Errorf("#RicServiceUpdateManager.revertRanFunctions - GNB is nil for RAN name: %s", ranName)
-        return errors.New("There is empty gnb nodebInfo")
-    }
-	models.ExistingRanFunctiuonsMap[ranName] =  nodebInfo.GetGnb().RanFunctions
-	h.logger.Errorf("#RicServiceUpdateManager.revertRanFunctions - Updated ranFunctions for reverting the changes are %v:", models.ExistingRanFunctiuonsMap[ranName])
+		h.logger.Errorf("#RicServiceUpdateManager.StoreExistingRanFunctions - GNB is nil for RAN name: %s", ranName)
+		return errors.New("There is empty gnb nodebInfo")
+	}
+	h.ranProcedureTracker.SavePreviousRanFunctions(ranName, models.RicServiceUpdateProcedure, nodebInfo.GetGnb().RanFunctions)
+	h.logger.Debugf("#RicServiceUpdateManager.StoreExistingRanFunctions - RAN name: %s - stored ranFunctions for reverting the changes: %v", ranName, nodebInfo.GetGnb().RanFunctions)
 	return nil
 }
 
 func (h *RicServiceUpdateManager) RevertRanFunctions(ranName string) error {
+	procedure := h.ranProcedureTracker.GetProcedure(ranName, models.RicServiceUpdateProcedure)
+	if procedure == nil {
+		h.logger.Errorf("#RicServiceUpdateManager.RevertRanFunctions - RAN name: %s - no RIC Service Update procedure to revert", ranName)
+		return errors.New("no RIC Service Update procedure to revert")
+	}
+
 	nodebInfo, err := h.rNibDataService.GetNodeb(ranName)
 	if err != nil {
-		h.logger.Errorf("#RicServiceUpdateManager.revertRanFunctions - failed to get nodeB entity for ran name: %v due to RNIB Error: %s", ranName, err)
+		h.logger.Errorf("#RicServiceUpdateManager.RevertRanFunctions - failed to get nodeB entity for ran name: %v due to RNIB Error: %s", ranName, err)
+		return err
 	}
 
 	if nodebInfo.GetGnb() != nil && nodebInfo.GetGnb().RanFunctions != nil {
-		nodebInfo.GetGnb().RanFunctions = models.ExistingRanFunctiuonsMap[ranName]
+		nodebInfo.GetGnb().RanFunctions = procedure.PreviousRanFunctions
 	} else {
-		h.logger.Errorf("#RicServiceUpdateManager.revertRanFunctions returned nil")
+		h.logger.Errorf("#RicServiceUpdateManager.RevertRanFunctions returned nil")
 	}
 	err = h.rNibDataService.UpdateNodebInfoAndPublish(nodebInfo)
 	if err != nil {
-		h.logger.Errorf("#RicServiceUpdateManager.revertRanFunctions - RAN name: %s - Failed at UpdateNodebInfoAndPublish. error: %s", nodebInfo.RanName, err)
+		h.logger.Errorf("#RicServiceUpdateManager.RevertRanFunctions - RAN name: %s - Failed at UpdateNodebInfoAndPublish. error: %s", nodebInfo.RanName, err)
 		return err
 	}
 
-	h.logger.Infof("#RicServiceUpdateManager.revertRanFunctions - Revert ranFunctions for RAN name: %s", ranName)
+	h.ranListManager.UpdateNbIdentityRanFunctions(ranName, nodebInfo.GetGnb().GetRanFunctions())
+	h.logger.Infof("#RicServiceUpdateManager.RevertRanFunctions - Revert ranFunctions for RAN name: %s", ranName)
 	return nil
-}
\ No newline at end of file
+}
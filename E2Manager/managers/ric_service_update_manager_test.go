@@ -23,8 +23,10 @@ import (
 	"e2mgr/configuration"
 	"e2mgr/logger"
 	"e2mgr/mocks"
+	"e2mgr/models"
 	
 	"e2mgr/services"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"testing"
 	"github.com/stretchr/testify/assert"
@@ -38,19 +40,23 @@ const (
 )
 
 
-func initRicServiceUpdateManagerTest(t *testing.T) (*logger.Logger,*mocks.RnibReaderMock, *mocks.RnibWriterMock,services.RNibDataService, *configuration.Configuration, *RicServiceUpdateManager) {
+func initRicServiceUpdateManagerTest(t *testing.T) (*logger.Logger,*mocks.RnibReaderMock, *mocks.RnibWriterMock,services.RNibDataService, *configuration.Configuration, *RicServiceUpdateManager, *RanProcedureTracker) {
 	logger := tests.InitLog(t)
 
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
 	readerMock := &mocks.RnibReaderMock{}
 	writerMock := &mocks.RnibWriterMock{}
 	rnibDataService := services.NewRnibDataService(logger, config, readerMock, writerMock)
-	RicServiceUpdateManager := NewRicServiceUpdateManager(logger, rnibDataService)
-	return logger, readerMock, writerMock, rnibDataService, config, RicServiceUpdateManager
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := NewRanProcedureTracker(logger, config, ranProcedureStoreMock)
+	RicServiceUpdateManager := NewRicServiceUpdateManager(logger, rnibDataService, ranProcedureTracker, NewRanListManager(logger, rnibDataService))
+	return logger, readerMock, writerMock, rnibDataService, config, RicServiceUpdateManager, ranProcedureTracker
 }
 func TestUpdateRevertRanFunctions(t *testing.T) {
 
-	_,readerMock, writerMock, _, _, RicServiceUpdateManager := initRicServiceUpdateManagerTest(t)
+	_,readerMock, writerMock, _, _, RicServiceUpdateManager, ranProcedureTracker := initRicServiceUpdateManagerTest(t)
 	InvName := "test"
 	nodebInfo := &entities.NodebInfo{
 		RanName: InvName,
@@ -61,10 +67,13 @@ func TestUpdateRevertRanFunctions(t *testing.T) {
 	gnb.RanFunctions = []*entities.RanFunction{{RanFunctionId: 2, RanFunctionRevision: 2}}
 	readerMock.On("GetNodeb", InvName).Return(nodebInfo, nil)
 	writerMock.On("UpdateNodebInfoAndPublish", mock.Anything).Return(nil)
+	ranProcedureTracker.Start(ranName, models.RicServiceUpdateProcedure, "1")
 	err := RicServiceUpdateManager.StoreExistingRanFunctions(ranName)
 	assert.Nil(t, err)
+	gnb.RanFunctions = []*entities.RanFunction{{RanFunctionId: 3, RanFunctionRevision: 1}}
 	err = RicServiceUpdateManager.RevertRanFunctions(ranName)
 	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), gnb.RanFunctions[0].RanFunctionRevision)
 	writerMock.AssertExpectations(t)
 	readerMock.AssertExpectations(t)
 	readerMock.AssertCalled(t, "GetNodeb", InvName)
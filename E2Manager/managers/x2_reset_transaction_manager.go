@@ -0,0 +1,204 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	MaxX2ResetJobs = 100
+)
+
+type X2ResetTransaction struct {
+	RanName   string
+	JobId     string
+	StartTime time.Time
+	timer     *time.Timer
+	done      chan models.X2ResetStatus
+}
+
+// Wait blocks until the transaction is completed, failed or timed out, and returns its final status
+func (t *X2ResetTransaction) Wait() models.X2ResetStatus {
+	return <-t.done
+}
+
+// Done delivers the final status once the transaction is completed, failed or timed out
+func (t *X2ResetTransaction) Done() <-chan models.X2ResetStatus {
+	return t.done
+}
+
+type IX2ResetTransactionManager interface {
+	Start(ranName string, cause string) (*X2ResetTransaction, error)
+	Complete(ranName string, successful bool) bool
+	Abort(ranName string)
+	GetJob(jobId string) (*models.X2ResetJob, error)
+}
+
+// X2ResetTransactionManager tracks the RIC initiated X2 Resets until the RAN answers them. X2AP Reset carries no
+// transaction id, hence a RAN has at most one outstanding reset, which RIC_X2_RESET_RESP is matched to by RAN name.
+// The outcome of the latest resets is kept as jobs, so that callers can poll it by job id
+type X2ResetTransactionManager struct {
+	logger              *logger.Logger
+	config              *configuration.Configuration
+	ranProcedureTracker IRanProcedureTracker
+	transactions        map[string]*X2ResetTransaction
+	jobs                *jobStore[*models.X2ResetJob]
+	mux                 sync.Mutex
+}
+
+func NewX2ResetTransactionManager(logger *logger.Logger, config *configuration.Configuration, ranProcedureTracker IRanProcedureTracker) *X2ResetTransactionManager {
+	return &X2ResetTransactionManager{
+		logger:              logger,
+		config:              config,
+		ranProcedureTracker: ranProcedureTracker,
+		transactions:        make(map[string]*X2ResetTransaction),
+		jobs:                newJobStore[*models.X2ResetJob](MaxX2ResetJobs),
+	}
+}
+
+func (m *X2ResetTransactionManager) Start(ranName string, cause string) (*X2ResetTransaction, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if transaction, ok := m.transactions[ranName]; ok {
+		m.logger.Warnf("#X2ResetTransactionManager.Start - RAN name: %s - X2 Reset job %s is already in progress", ranName, transaction.JobId)
+		return nil, e2managererrors.NewCommandAlreadyInProgressError()
+	}
+
+	transaction := &X2ResetTransaction{
+		RanName:   ranName,
+		JobId:     newJobId(),
+		StartTime: time.Now(),
+		done:      make(chan models.X2ResetStatus, 1),
+	}
+
+	m.jobs.add(transaction.JobId, &models.X2ResetJob{
+		JobId:     transaction.JobId,
+		RanName:   ranName,
+		Cause:     cause,
+		Status:    models.X2ResetInProgress,
+		StartedAt: transaction.StartTime.UnixNano(),
+	})
+
+	timeout := time.Duration(m.config.GetX2ResetTimeoutSec()) * time.Second
+	transaction.timer = time.AfterFunc(timeout, func() {
+		m.expire(transaction, timeout)
+	})
+	m.transactions[ranName] = transaction
+	m.ranProcedureTracker.Start(ranName, models.X2ResetProcedure, "")
+
+	m.logger.Infof("#X2ResetTransactionManager.Start - RAN name: %s - X2 Reset job %s started, timeout: %s", ranName, transaction.JobId, timeout)
+	return transaction, nil
+}
+
+// Complete ends the outstanding transaction of a RAN which answered with RIC_X2_RESET_RESP, an unsuccessful response fails it
+func (m *X2ResetTransactionManager) Complete(ranName string, successful bool) bool {
+	transaction := m.remove(ranName)
+
+	if transaction == nil {
+		m.logger.Warnf("#X2ResetTransactionManager.Complete - RAN name: %s - no outstanding X2 Reset", ranName)
+		return false
+	}
+
+	if !successful {
+		m.logger.Warnf("#X2ResetTransactionManager.Complete - RAN name: %s - X2 Reset job %s failed after %s", ranName, transaction.JobId, time.Since(transaction.StartTime))
+		m.ranProcedureTracker.Fail(ranName, models.X2ResetProcedure)
+		m.end(transaction, models.X2ResetFailed, "unsuccessful RIC_X2_RESET_RESP received")
+		return true
+	}
+
+	m.logger.Infof("#X2ResetTransactionManager.Complete - RAN name: %s - X2 Reset job %s completed after %s", ranName, transaction.JobId, time.Since(transaction.StartTime))
+	m.ranProcedureTracker.Complete(ranName, models.X2ResetProcedure)
+	m.end(transaction, models.X2ResetSucceeded, "")
+	return true
+}
+
+// Abort fails the outstanding transaction of a RAN the X2 Reset could not be sent to
+func (m *X2ResetTransactionManager) Abort(ranName string) {
+	transaction := m.remove(ranName)
+
+	if transaction != nil {
+		m.logger.Infof("#X2ResetTransactionManager.Abort - RAN name: %s - X2 Reset job %s aborted", ranName, transaction.JobId)
+		m.ranProcedureTracker.Fail(ranName, models.X2ResetProcedure)
+		m.end(transaction, models.X2ResetFailed, "failed sending RIC_X2_RESET")
+	}
+}
+
+// GetJob returns a copy of the job
+func (m *X2ResetTransactionManager) GetJob(jobId string) (*models.X2ResetJob, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	job, ok := m.jobs.get(jobId)
+	if !ok {
+		m.logger.Infof("#X2ResetTransactionManager.GetJob - X2 Reset job %s not found", jobId)
+		return nil, e2managererrors.NewResourceNotFoundError()
+	}
+
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+func (m *X2ResetTransactionManager) expire(transaction *X2ResetTransaction, timeout time.Duration) {
+	m.mux.Lock()
+	outstanding, ok := m.transactions[transaction.RanName]
+	if !ok || outstanding != transaction {
+		m.mux.Unlock()
+		return
+	}
+	delete(m.transactions, transaction.RanName)
+	m.mux.Unlock()
+
+	m.logger.Errorf("#X2ResetTransactionManager.expire - RAN name: %s - no RIC_X2_RESET_RESP received for X2 Reset job %s within %s", transaction.RanName, transaction.JobId, timeout)
+	m.ranProcedureTracker.TimeOut(transaction.RanName, models.X2ResetProcedure)
+	m.end(transaction, models.X2ResetTimedOut, fmt.Sprintf("no RIC_X2_RESET_RESP received within %s", timeout))
+}
+
+func (m *X2ResetTransactionManager) remove(ranName string) *X2ResetTransaction {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	transaction, ok := m.transactions[ranName]
+	if !ok {
+		return nil
+	}
+
+	transaction.timer.Stop()
+	delete(m.transactions, ranName)
+	return transaction
+}
+
+func (m *X2ResetTransactionManager) end(transaction *X2ResetTransaction, status models.X2ResetStatus, reason string) {
+	m.mux.Lock()
+	if job, ok := m.jobs.get(transaction.JobId); ok {
+		job.Status = status
+		job.CompletedAt = time.Now().UnixNano()
+		job.Reason = reason
+	}
+	m.mux.Unlock()
+
+	transaction.done <- status
+}
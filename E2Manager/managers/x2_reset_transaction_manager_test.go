@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package managers
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/e2managererrors"
+	"e2mgr/logger"
+	"e2mgr/mocks"
+	"e2mgr/models"
+	"strconv"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func initX2ResetTransactionManagerTest(t *testing.T, timeoutSec int) (*X2ResetTransactionManager, *RanProcedureTracker) {
+	Debug := int8(4)
+	log, err := logger.InitLogger(Debug)
+	if err != nil {
+		t.Errorf("#initX2ResetTransactionManagerTest - failed to initialize log, error: %s", err)
+	}
+	config := &configuration.Configuration{X2Reset: configuration.X2ResetConfig{TimeoutSec: timeoutSec}}
+
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	return NewX2ResetTransactionManager(log, config, ranProcedureTracker), ranProcedureTracker
+}
+
+func TestX2ResetTransactionStartAlreadyInProgress(t *testing.T) {
+	manager, ranProcedureTracker := initX2ResetTransactionManagerTest(t, 10)
+
+	transaction, err := manager.Start(RanName, "misc:om-intervention")
+	assert.Nil(t, err)
+	assert.Len(t, transaction.JobId, 32)
+	assert.Equal(t, models.RanProcedureOngoing, ranProcedureTracker.GetProcedure(RanName, models.X2ResetProcedure).State)
+
+	_, err = manager.Start(RanName, "misc:om-intervention")
+	assert.IsType(t, &e2managererrors.CommandAlreadyInProgressError{}, err)
+}
+
+func TestX2ResetTransactionCompleteSuccess(t *testing.T) {
+	manager, ranProcedureTracker := initX2ResetTransactionManagerTest(t, 10)
+
+	transaction, _ := manager.Start(RanName, "misc:om-intervention")
+
+	assert.True(t, manager.Complete(RanName, true))
+	assert.Equal(t, models.X2ResetSucceeded, transaction.Wait())
+	assert.Equal(t, models.RanProcedureCompleted, ranProcedureTracker.GetProcedure(RanName, models.X2ResetProcedure).State)
+
+	job, err := manager.GetJob(transaction.JobId)
+	assert.Nil(t, err)
+	assert.Equal(t, RanName, job.RanName)
+	assert.Equal(t, "misc:om-intervention", job.Cause)
+	assert.Equal(t, models.X2ResetSucceeded, job.Status)
+	assert.NotZero(t, job.CompletedAt)
+	assert.False(t, manager.Complete(RanName, true))
+}
+
+func TestX2ResetTransactionCompleteUnsuccessful(t *testing.T) {
+	manager, ranProcedureTracker := initX2ResetTransactionManagerTest(t, 10)
+
+	transaction, _ := manager.Start(RanName, "misc:om-intervention")
+
+	assert.True(t, manager.Complete(RanName, false))
+	assert.Equal(t, models.X2ResetFailed, transaction.Wait())
+	assert.Equal(t, models.RanProcedureFailed, ranProcedureTracker.GetProcedure(RanName, models.X2ResetProcedure).State)
+}
+
+func TestX2ResetTransactionAbort(t *testing.T) {
+	manager, _ := initX2ResetTransactionManagerTest(t, 10)
+
+	transaction, _ := manager.Start(RanName, "misc:om-intervention")
+
+	manager.Abort(RanName)
+	assert.Equal(t, models.X2ResetFailed, transaction.Wait())
+
+	_, err := manager.Start(RanName, "misc:om-intervention")
+	assert.Nil(t, err)
+}
+
+func TestX2ResetTransactionTimeout(t *testing.T) {
+	manager, ranProcedureTracker := initX2ResetTransactionManagerTest(t, 1)
+
+	transaction, _ := manager.Start(RanName, "misc:om-intervention")
+
+	assert.Equal(t, models.X2ResetTimedOut, transaction.Wait())
+	assert.Equal(t, models.RanProcedureTimedOut, ranProcedureTracker.GetProcedure(RanName, models.X2ResetProcedure).State)
+	assert.False(t, manager.Complete(RanName, true))
+
+	job, _ := manager.GetJob(transaction.JobId)
+	assert.Equal(t, models.X2ResetTimedOut, job.Status)
+	assert.NotEmpty(t, job.Reason)
+}
+
+func TestX2ResetTransactionGetJobNotFound(t *testing.T) {
+	manager, _ := initX2ResetTransactionManagerTest(t, 10)
+
+	_, err := manager.GetJob("1")
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+}
+
+func TestX2ResetTransactionOldestJobDropped(t *testing.T) {
+	manager, _ := initX2ResetTransactionManagerTest(t, 10)
+
+	var jobIds []string
+	for i := 0; i <= MaxX2ResetJobs; i++ {
+		ranName := RanName + strconv.Itoa(i)
+		transaction, _ := manager.Start(ranName, "misc:om-intervention")
+		manager.Complete(ranName, true)
+		jobIds = append(jobIds, transaction.JobId)
+	}
+
+	_, err := manager.GetJob(jobIds[0])
+	assert.IsType(t, &e2managererrors.ResourceNotFoundError{}, err)
+	_, err = manager.GetJob(jobIds[MaxX2ResetJobs])
+	assert.Nil(t, err)
+}
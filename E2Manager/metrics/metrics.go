@@ -0,0 +1,201 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "e2mgr"
+
+const (
+	E2SetupOutcomeSuccess = "success"
+	E2SetupOutcomeFailure = "failure"
+)
+
+const (
+	DuplicateGlobalNbIdRejected = "rejected"
+	DuplicateGlobalNbIdFlagged  = "flagged"
+)
+
+const (
+	RanListDriftAdded   = "added"
+	RanListDriftRemoved = "removed"
+	RanListDriftUpdated = "updated"
+)
+
+var (
+	RmrMessagesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "messages_received_total",
+		Help:      "Number of RMR messages received, by message type.",
+	}, []string{"message_type"})
+
+	RmrMessagesWithoutHandler = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "messages_without_handler_total",
+		Help:      "Number of RMR messages for which no notification handler was found, by message type.",
+	}, []string{"message_type"})
+
+	RmrMessagesDroppedNotLeader = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "messages_dropped_not_leader_total",
+		Help:      "Number of RMR messages dropped because this E2 Manager replica is not the leader, by message type.",
+	}, []string{"message_type"})
+
+	RmrMessageHandlingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "message_handling_duration_seconds",
+		Help:      "Time spent by the notification handler on an RMR message, by message type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"message_type"})
+
+	NotificationQueueLength = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "notification_queue_length",
+		Help:      "Number of RMR notifications waiting in the queue of a notification worker.",
+	}, []string{"worker"})
+
+	NotificationQueueFull = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "notification_queue_full_total",
+		Help:      "Number of times the RMR receiver was blocked because a notification worker queue was full.",
+	})
+
+	NotificationQueueWait = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rmr",
+		Name:      "notification_queue_wait_seconds",
+		Help:      "Time an RMR notification waited in the queue before its handling started.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	E2SetupOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "e2_setup",
+		Name:      "responses_total",
+		Help:      "Number of E2 Setup responses sent, by outcome and E2AP cause.",
+	}, []string{"outcome", "cause"})
+
+	DuplicateGlobalNbIds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "e2_setup",
+		Name:      "duplicate_global_nb_id_total",
+		Help:      "Number of E2 Setups claiming a global E2 node ID already owned by another RAN, by action taken.",
+	}, []string{"action"})
+
+	ConnectionStatusTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ran",
+		Name:      "connection_status_transitions_total",
+		Help:      "Number of RAN connection status transitions, by previous and next status.",
+	}, []string{"from", "to"})
+
+	RnibRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rnib",
+		Name:      "retries_total",
+		Help:      "Number of rNib operation retries after a connection error, by operation.",
+	}, []string{"operation"})
+
+	RnibOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rnib",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of rNib operations including retries, by operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	RoutingManagerRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "routing_manager",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of Routing Manager requests, by HTTP method and endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+
+	RoutingManagerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "routing_manager",
+		Name:      "request_errors_total",
+		Help:      "Number of failed Routing Manager requests, by HTTP method and endpoint.",
+	}, []string{"method", "endpoint"})
+
+	E2TKeepAliveAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "e2t",
+		Name:      "keep_alive_age_seconds",
+		Help:      "Time since the last keep alive response of an E2T instance, by E2T address.",
+	}, []string{"e2t_address"})
+
+	RanListDrift = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ran_list",
+		Name:      "drift_total",
+		Help:      "Number of in-memory RAN list entries corrected after comparing them with rNib, by kind of correction.",
+	}, []string{"kind"})
+
+	LeaderElectionIsLeader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "leader_election",
+		Name:      "is_leader",
+		Help:      "1 when this E2 Manager replica holds the leader lease, 0 otherwise.",
+	})
+)
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RmrMessagesReceived,
+		RmrMessagesWithoutHandler,
+		RmrMessagesDroppedNotLeader,
+		RmrMessageHandlingDuration,
+		NotificationQueueLength,
+		NotificationQueueFull,
+		NotificationQueueWait,
+		E2SetupOutcomes,
+		DuplicateGlobalNbIds,
+		ConnectionStatusTransitions,
+		RnibRetries,
+		RnibOperationDuration,
+		RoutingManagerRequestDuration,
+		RoutingManagerErrors,
+		E2TKeepAliveAge,
+		RanListDrift,
+		LeaderElectionIsLeader,
+	)
+}
+
+// Handler serves the E2 Manager metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,48 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerExposesE2ManagerMetrics(t *testing.T) {
+	RmrMessagesReceived.WithLabelValues("12001").Inc()
+	E2SetupOutcomes.WithLabelValues(E2SetupOutcomeFailure, "misc/om-intervention").Inc()
+	ConnectionStatusTransitions.WithLabelValues("DISCONNECTED", "CONNECTED").Inc()
+	RnibOperationDuration.WithLabelValues("GetNodeb").Observe(0.01)
+	RoutingManagerErrors.WithLabelValues(http.MethodPost, "e2t").Inc()
+	E2TKeepAliveAge.WithLabelValues("10.0.2.15:38000").Set(1.5)
+
+	rr := httptest.NewRecorder()
+	Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, `e2mgr_rmr_messages_received_total{message_type="12001"} 1`)
+	assert.Contains(t, body, `e2mgr_e2_setup_responses_total{cause="misc/om-intervention",outcome="failure"} 1`)
+	assert.Contains(t, body, `e2mgr_ran_connection_status_transitions_total{from="DISCONNECTED",to="CONNECTED"} 1`)
+	assert.Contains(t, body, `e2mgr_rnib_operation_duration_seconds_count{operation="GetNodeb"} 1`)
+	assert.Contains(t, body, `e2mgr_routing_manager_request_errors_total{endpoint="e2t",method="POST"} 1`)
+	assert.Contains(t, body, `e2mgr_e2t_keep_alive_age_seconds{e2t_address="10.0.2.15:38000"} 1.5`)
+}
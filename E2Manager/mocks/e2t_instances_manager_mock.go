@@ -94,3 +94,7 @@ func (m *E2TInstancesManagerMock) ClearRansOfAllE2TInstances() error {
 	args := m.Called()
 	return args.Error(0)
 }
+
+func (m *E2TInstancesManagerMock) ResetKeepAliveTimestampsForAllE2TInstances() {
+	m.Called()
+}
@@ -0,0 +1,53 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package mocks
+
+import (
+	"e2mgr/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type HealthCheckJobManagerMock struct {
+	mock.Mock
+}
+
+func (m *HealthCheckJobManagerMock) CreateJob() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *HealthCheckJobManagerMock) QuerySent(jobId string, record *models.RicServiceQueryRecord) {
+	m.Called(jobId, record)
+}
+
+func (m *HealthCheckJobManagerMock) QueryFailed(jobId string, ranName string) {
+	m.Called(jobId, ranName)
+}
+
+func (m *HealthCheckJobManagerMock) HandleServiceUpdate(ranName string, transactionId string) {
+	m.Called(ranName, transactionId)
+}
+
+func (m *HealthCheckJobManagerMock) GetJob(jobId string) (*models.HealthCheckJob, error) {
+	args := m.Called(jobId)
+
+	job, _ := args.Get(0).(*models.HealthCheckJob)
+	return job, args.Error(1)
+}
@@ -29,6 +29,11 @@ type HttpClientMock struct {
 	mock.Mock
 }
 
+func (c *HttpClientMock) Get(url string) (resp *http.Response, err error) {
+	args := c.Called(url)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
+
 func (c *HttpClientMock) Post(url, contentType string, body io.Reader) (resp *http.Response, err error) {
 	args := c.Called(url, contentType, body)
 	return args.Get(0).(*http.Response), args.Error(1)
@@ -38,3 +43,8 @@ func (c *HttpClientMock) Delete(url, contentType string, body io.Reader) (resp *
 	args := c.Called(url, contentType, body)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+func (c *HttpClientMock) Do(req *http.Request) (resp *http.Response, err error) {
+	args := c.Called(req)
+	return args.Get(0).(*http.Response), args.Error(1)
+}
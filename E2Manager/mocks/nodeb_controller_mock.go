@@ -42,6 +42,24 @@ func (c *NodebControllerMock) GetNodeb(writer http.ResponseWriter, r *http.Reque
 	c.Called()
 }
 
+func (c *NodebControllerMock) GetErrorIndications(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
+func (c *NodebControllerMock) RicServiceQuery(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusAccepted)
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetRicServiceQueryReport(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
 func (c *NodebControllerMock) GetNodebIdList(writer http.ResponseWriter, r *http.Request) {
 	c.Called()
 }
@@ -73,6 +91,18 @@ func (c *NodebControllerMock) X2Reset(writer http.ResponseWriter, r *http.Reques
 	c.Called()
 }
 
+func (c *NodebControllerMock) E2Reset(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+
+	vars := mux.Vars(r)
+	ranName := vars["ranName"]
+
+	writer.Write([]byte(ranName))
+
+	c.Called()
+}
+
 func (c *NodebControllerMock) UpdateGnb(writer http.ResponseWriter, r *http.Request) {
 	writer.Header().Set("Content-Type", "application/json")
 	writer.WriteHeader(http.StatusOK)
@@ -112,3 +142,39 @@ func (c *NodebControllerMock) HealthCheckRequest(writer http.ResponseWriter, r *
 
 	c.Called()
 }
+
+func (c *NodebControllerMock) GetHealthCheckJob(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetX2ResetJob(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetNodebByGlobalNbId(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetCell(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetRanFunctions(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
+
+func (c *NodebControllerMock) GetRanFunctionNodes(writer http.ResponseWriter, r *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	c.Called()
+}
@@ -32,4 +32,24 @@ func (m *RanAlarmServiceMock) SetConnectivityChangeAlarm(nodebInfo *entities.Nod
 
 	args := m.Called(nodebInfo)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+
+func (m *RanAlarmServiceMock) SetE2TKeepAliveLostAlarm(e2tAddress string) error {
+	args := m.Called(e2tAddress)
+	return args.Error(0)
+}
+
+func (m *RanAlarmServiceMock) ClearE2TKeepAliveLostAlarm(e2tAddress string) error {
+	args := m.Called(e2tAddress)
+	return args.Error(0)
+}
+
+func (m *RanAlarmServiceMock) SetRanUnresponsiveAlarm(ranName string) error {
+	args := m.Called(ranName)
+	return args.Error(0)
+}
+
+func (m *RanAlarmServiceMock) ClearRanUnresponsiveAlarm(ranName string) error {
+	args := m.Called(ranName)
+	return args.Error(0)
+}
@@ -21,6 +21,8 @@
 package mocks
 
 import (
+	"e2mgr/models"
+
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/mock"
 )
@@ -71,6 +73,11 @@ func (m *RanListManagerMock) UpdateHealthcheckTimeStampSent(oldRRanName string)
 	return args.Get(0).(*entities.NbIdentity), args.Get(1).(*entities.NbIdentity)
 }
 
+func (m *RanListManagerMock) UpdateHealthcheckTimeStampsSent(ranNames []string) error {
+	args := m.Called(ranNames)
+	return args.Error(0)
+}
+
 func (m *RanListManagerMock) UpdateHealthcheckTimeStampReceived(oldRRanName string) (*entities.NbIdentity, *entities.NbIdentity){
 	args := m.Called(oldRRanName)
 	return args.Get(0).(*entities.NbIdentity), args.Get(1).(*entities.NbIdentity)
@@ -81,3 +88,59 @@ func (m *RanListManagerMock) UpdateNbIdentities(nodeType entities.Node_Type, old
 	return args.Error(0)
 }
 
+func (m *RanListManagerMock) IsInitialized() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *RanListManagerMock) ReconcileNbIdentityMap() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *RanListManagerMock) LoadNbIdentityAttributes() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *RanListManagerMock) UpdateNbIdentityE2TAddress(ranName string, e2tAddress string) {
+	m.Called(ranName, e2tAddress)
+}
+
+func (m *RanListManagerMock) GetNbIdentityPage(request *models.GetNodebIdListRequest) *models.NbIdentityPage {
+	args := m.Called(request)
+	return args.Get(0).(*models.NbIdentityPage)
+}
+
+func (m *RanListManagerMock) GetRanNameByGlobalNbId(plmnId string, nbId string) (string, error) {
+	args := m.Called(plmnId, nbId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *RanListManagerMock) GetRanNameByCellId(cellId string) (string, error) {
+	args := m.Called(cellId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *RanListManagerMock) UpdateNbIdentityCells(nodebInfo *entities.NodebInfo) {
+	m.Called(nodebInfo)
+}
+
+func (m *RanListManagerMock) InitNodebIndexes() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *RanListManagerMock) UpdateNbIdentityRanFunctions(ranName string, ranFunctions []*entities.RanFunction) {
+	m.Called(ranName, ranFunctions)
+}
+
+func (m *RanListManagerMock) GetRanFunctionCapabilities() []*models.RanFunctionCapability {
+	args := m.Called()
+	return args.Get(0).([]*models.RanFunctionCapability)
+}
+
+func (m *RanListManagerMock) GetRanFunctionNodes(request *models.GetRanFunctionNodesRequest) (*models.RanFunctionCapability, error) {
+	args := m.Called(request)
+	return args.Get(0).(*models.RanFunctionCapability), args.Error(1)
+}
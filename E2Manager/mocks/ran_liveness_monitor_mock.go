@@ -0,0 +1,40 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package mocks
+
+import (
+	"e2mgr/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RanLivenessMonitorMock struct {
+	mock.Mock
+}
+
+func (m *RanLivenessMonitorMock) Check() {
+	m.Called()
+}
+
+func (m *RanLivenessMonitorMock) GetHealthStates() map[string]models.RanHealthState {
+	args := m.Called()
+
+	healthStates, _ := args.Get(0).(map[string]models.RanHealthState)
+	return healthStates
+}
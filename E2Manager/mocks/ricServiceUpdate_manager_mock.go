@@ -30,3 +30,8 @@ func (m *RicServiceUpdateManagerMock) RevertRanFunctions(ranName string) error {
 	args := m.Called(ranName)
 	return args.Error(0)
 }
+
+func (m *RicServiceUpdateManagerMock) StoreExistingRanFunctions(ranName string) error {
+	args := m.Called(ranName)
+	return args.Error(0)
+}
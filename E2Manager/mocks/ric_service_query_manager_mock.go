@@ -0,0 +1,56 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package mocks
+
+import (
+	"e2mgr/models"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type RicServiceQueryManagerMock struct {
+	mock.Mock
+}
+
+func (m *RicServiceQueryManagerMock) Query(ranName string) (*models.RicServiceQueryRecord, error) {
+	args := m.Called(ranName)
+
+	record, _ := args.Get(0).(*models.RicServiceQueryRecord)
+	return record, args.Error(1)
+}
+
+func (m *RicServiceQueryManagerMock) SendQuery(nodebInfo *entities.NodebInfo) (*models.RicServiceQueryRecord, error) {
+	args := m.Called(nodebInfo)
+
+	record, _ := args.Get(0).(*models.RicServiceQueryRecord)
+	return record, args.Error(1)
+}
+
+func (m *RicServiceQueryManagerMock) HandleServiceUpdate(ranName string, transactionId string) bool {
+	args := m.Called(ranName, transactionId)
+	return args.Bool(0)
+}
+
+func (m *RicServiceQueryManagerMock) GetUnresponsiveRans() []*models.RicServiceQueryRecord {
+	args := m.Called()
+
+	records, _ := args.Get(0).([]*models.RicServiceQueryRecord)
+	return records
+}
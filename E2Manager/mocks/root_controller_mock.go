@@ -32,3 +32,11 @@ type RootControllerMock struct {
 func (rc *RootControllerMock) HandleHealthCheckRequest(writer http.ResponseWriter, request *http.Request) {
 	rc.Called()
 }
+
+func (rc *RootControllerMock) HandleReadinessRequest(writer http.ResponseWriter, request *http.Request) {
+	rc.Called()
+}
+
+func (rc *RootControllerMock) HandleLivenessRequest(writer http.ResponseWriter, request *http.Request) {
+	rc.Called()
+}
@@ -55,4 +55,9 @@ func (m *RoutingManagerClientMock) DeleteE2TInstance(e2tAddress string, ransToBe
 
 	args := m.Called(e2tAddress, ransToBeDissociated)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+
+func (m *RoutingManagerClientMock) IsReachable() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
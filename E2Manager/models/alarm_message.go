@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+const (
+	AlarmManagedObjectId = "RIC"
+	AlarmApplicationId   = "E2MGR"
+)
+
+type AlarmSeverity string
+
+const (
+	AlarmSeverityCritical AlarmSeverity = "CRITICAL"
+	AlarmSeverityMajor    AlarmSeverity = "MAJOR"
+	AlarmSeverityMinor    AlarmSeverity = "MINOR"
+	AlarmSeverityWarning  AlarmSeverity = "WARNING"
+)
+
+type AlarmAction string
+
+const (
+	AlarmActionRaise AlarmAction = "RAISE"
+	AlarmActionClear AlarmAction = "CLEAR"
+)
+
+type AlarmId int
+
+const (
+	E2ConnectivityLostToGnbAlarmId   AlarmId = 8006
+	E2ConnectivityLostToEnbAlarmId   AlarmId = 8007
+	RanUnderResetTooLongAlarmId      AlarmId = 8100
+	E2TKeepAliveLostAlarmId          AlarmId = 8101
+	RoutingManagerUnreachableAlarmId AlarmId = 8102
+	RanUnresponsiveAlarmId           AlarmId = 8103
+)
+
+type Alarm struct {
+	ManagedObjectId   string        `json:"managedObjectId"`
+	ApplicationId     string        `json:"applicationId"`
+	SpecificProblem   AlarmId       `json:"specificProblem"`
+	PerceivedSeverity AlarmSeverity `json:"perceivedSeverity"`
+	AdditionalInfo    string        `json:"additionalInfo"`
+	IdentifyingInfo   string        `json:"identifyingInfo"`
+}
+
+type AlarmMessage struct {
+	Alarm
+	AlarmAction AlarmAction `json:"AlarmAction"`
+	AlarmTime   int64       `json:"AlarmTime"`
+}
+
+func NewAlarm(specificProblem AlarmId, severity AlarmSeverity, identifyingInfo string, additionalInfo string) Alarm {
+	return Alarm{
+		ManagedObjectId:   AlarmManagedObjectId,
+		ApplicationId:     AlarmApplicationId,
+		SpecificProblem:   specificProblem,
+		PerceivedSeverity: severity,
+		AdditionalInfo:    additionalInfo,
+		IdentifyingInfo:   identifyingInfo,
+	}
+}
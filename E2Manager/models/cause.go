@@ -19,18 +19,61 @@
 
 package models
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+)
 
 type Cause struct {
 	XMLName    xml.Name         `xml:"Cause"`
 	Text       string           `xml:",chardata"`
 	RicRequest *CauseRic        `xml:"ricRequest"`
 	RicService *CauseRicService `xml:"ricService"`
+	E2Node     *CauseE2Node     `xml:"e2Node"`
 	Transport  *CauseTransport  `xml:"transport"`
 	Protocol   *CauseProtocol   `xml:"protocol"`
 	Misc       *CauseMisc       `xml:"misc"`
 }
 
+// String returns the cause as "<group>/<value>" using the E2AP names, e.g. "misc/om-intervention"
+func (c Cause) String() string {
+	group, groupValue := firstSetField(reflect.ValueOf(c))
+	if len(group) == 0 {
+		return ""
+	}
+
+	value, _ := firstSetField(groupValue.Elem())
+	return group + "/" + value
+}
+
+// ValueName returns the E2AP name of the cause value without its group, e.g. "om-intervention"
+func (c Cause) ValueName() string {
+	group, groupValue := firstSetField(reflect.ValueOf(c))
+	if len(group) == 0 {
+		return ""
+	}
+
+	value, _ := firstSetField(groupValue.Elem())
+	return value
+}
+
+func firstSetField(v reflect.Value) (string, reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		return strings.Split(v.Type().Field(i).Tag.Get("xml"), ",")[0], field
+	}
+	return "", reflect.Value{}
+}
+
+type CauseE2Node struct {
+	Text                   string    `xml:",chardata"`
+	E2nodeComponentUnknown *struct{} `xml:"e2node-component-unknown"`
+}
+
 type CauseTransport struct {
 	Text                         string    `xml:",chardata"`
 	TransportResourceUnavailable *struct{} `xml:"transport-resource-unavailable"`
@@ -0,0 +1,39 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models_test
+
+import (
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCauseString(t *testing.T) {
+	assert.Equal(t, "misc/om-intervention", models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}}.String())
+	assert.Equal(t, "ricService/ric-resource-limit", models.Cause{RicService: &models.CauseRicService{RicResourceLimit: &struct{}{}}}.String())
+	assert.Equal(t, "protocol/semantic-error", models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}}.String())
+	assert.Equal(t, "", models.Cause{}.String())
+}
+
+func TestCauseValueName(t *testing.T) {
+	assert.Equal(t, "om-intervention", models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}}.ValueName())
+	assert.Equal(t, "function-not-required", models.Cause{RicService: &models.CauseRicService{FunctionNotRequired: &struct{}{}}}.ValueName())
+	assert.Equal(t, "", models.Cause{}.ValueName())
+}
@@ -20,7 +20,10 @@ package models
 
 const (
 	ProtocolIE_ID_id_RANfunctionID_Item                    = "6"
+	ProtocolIE_ID_id_RANfunctionIEcause_Item               = "7"
 	ProtocolIE_ID_id_RANfunctionsAccepted                  = "9"
+	ProtocolIE_ID_id_RANfunctionsRejected                  = "13"
+	ProtocolIE_ID_id_TimeToWait                            = "31"
 	ProtocolIE_ID_id_E2nodeComponentConfigUpdateAck        = "35"
 	ProtocolIE_ID_id_E2nodeComponentConfigUpdateAck_Item   = "36"
 	ProtocolIE_ID_id_TransactionID                         = "49"
@@ -31,11 +34,14 @@ const (
 	ProtocolIE_ID_id_E2nodeComponentConfigRemovalAck       = "56"
 	ProtocolIE_ID_id_E2nodeComponentConfigRemovalAck_Item  = "57"
 	ProtocolIE_ID_id_Cause                                 = "1"
+	ProtocolIE_ID_id_CriticalityDiagnostics                = "2"
 )
 
 const (
+	ProcedureCode_id_E2setup                   = "1"
 	ProcedureCode_id_RICserviceQuery           = "6"
 	ProcedureCode_id_E2nodeConfigurationUpdate = "10"
 	ProcedureCode_id_RICserviceUpdate          = "7"
 	ProcedureCode_id_Reset                     = "3"
+	ProcedureCode_id_ErrorIndication           = "2"
 )
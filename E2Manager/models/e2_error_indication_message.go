@@ -2,44 +2,24 @@ package models
 
 import (
 	"encoding/xml"
-	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
-	"sync"
-
 )
 
-
-type ProcedureType int
-
 const (
-	E2SetupProcedureNotInitiated ProcedureType = iota
-	E2SetupProcedureOngoing
-	E2SetupProcedureCompleted
-	E2SetupProcedureFailure
-	RicServiceUpdateCompleted
-	RicServiceUpdateFailure
+	ErrorIndicationCauseIE                  = 1
+	ErrorIndicationCriticalityDiagnosticsIE = 2
+	ErrorIndicationRanFunctionIdIE          = 5
+	ErrorIndicationRicRequestIdIE           = 29
+	ErrorIndicationTransactionIdIE          = 49
 )
 
-var(
-	ProcedureMap = make(map[string]ProcedureType)
-	procedureMapMutex sync.RWMutex	
-) 
-
-func UpdateProcedureType(ranName string, newProcedureType ProcedureType) {
-	procedureMapMutex.Lock()
-	defer procedureMapMutex.Unlock()
-	ProcedureMap[ranName] = newProcedureType
-}
-
-var ExistingRanFunctiuonsMap = make(map[string][]*entities.RanFunction)
-
 type ErrorIndicationMessage struct {
-	XMLName xml.Name                `xml:"ErrorIndicationMessage"`
-	Text    string                  `xml:",chardata"`
+	XMLName xml.Name               `xml:"ErrorIndicationMessage"`
+	Text    string                 `xml:",chardata"`
 	E2APPDU ErrorIndicationE2APPDU `xml:"E2AP-PDU"`
 }
 type ErrorIndicationE2APPDU struct {
-	XMLName           xml.Name                          `xml:"E2AP-PDU"`
-	Text              string                            `xml:",chardata"`
+	XMLName           xml.Name                         `xml:"E2AP-PDU"`
+	Text              string                           `xml:",chardata"`
 	InitiatingMessage ErrorIndicationInitiatingMessage `xml:"initiatingMessage"`
 }
 type ErrorIndicationInitiatingMessage struct {
@@ -50,11 +30,11 @@ type ErrorIndicationInitiatingMessage struct {
 		Reject string `xml:"reject"`
 	} `xml:"criticality"`
 	Value struct {
-		Text             string `xml:",chardata"`
+		Text            string `xml:",chardata"`
 		ErrorIndication struct {
 			Text        string `xml:",chardata"`
 			ProtocolIEs struct {
-				Text                string                `xml:",chardata"`
+				Text               string               `xml:",chardata"`
 				ErrorIndicationIEs []ErrorIndicationIEs `xml:"ErrorIndication-IEs"`
 			} `xml:"protocolIEs"`
 		} `xml:"ErrorIndication"`
@@ -68,26 +48,114 @@ type ErrorIndicationIEs struct {
 		Reject string `xml:"reject"`
 	} `xml:"criticality"`
 	Value struct {
-		Text             string `xml:",chardata"`
-		TransactionID    string `xml:"TransactionID"`
-		RICrequestID  struct {
-			Text           string `xml:",chardata"`
-			RicRequestorID int32 `xml:"ricRequestorID"`
-			RicInstanceID  int32 `xml:"ricInstanceID"`
-		} `xml:"RICrequestID"`
-		RANfunctionID     int32 `xml:"RANfunctionID"`
-		CriticalityDiagnostics struct {
-			Text        string `xml:",chardata"`
-			ProcedureCode string `xml:"procedureCode"`
-			TriggeringMessage TriggeringMessage `xml:"triggeringMessage"`
-		} `xml:"CriticalityDiagnostics"`
-	}`xml:"value"`
+		Text                   string                 `xml:",chardata"`
+		TransactionID          string                 `xml:"TransactionID"`
+		RICrequestID           RicRequestID           `xml:"RICrequestID"`
+		RANfunctionID          int32                  `xml:"RANfunctionID"`
+		Cause                  Cause                  `xml:"Cause"`
+		CriticalityDiagnostics CriticalityDiagnostics `xml:"CriticalityDiagnostics"`
+	} `xml:"value"`
+}
+
+type RicRequestID struct {
+	Text           string `xml:",chardata"`
+	RicRequestorID int32  `xml:"ricRequestorID"`
+	RicInstanceID  int32  `xml:"ricInstanceID"`
+}
+
+type CriticalityDiagnostics struct {
+	Text                 string            `xml:",chardata"`
+	ProcedureCode        string            `xml:"procedureCode,omitempty"`
+	TriggeringMessage    TriggeringMessage `xml:"triggeringMessage"`
+	ProcedureCriticality struct {
+		Text   string    `xml:",chardata"`
+		Reject *struct{} `xml:"reject"`
+		Ignore *struct{} `xml:"ignore"`
+		Notify *struct{} `xml:"notify"`
+	} `xml:"procedureCriticality"`
+	TransactionID string `xml:"transactionID,omitempty"`
 }
 
 type TriggeringMessage struct {
-	Text string    `xml:",chardata"`
-	InitiatingMessage  *struct{} `xml:"initiatingMessage"`
-	SuccessfulOutcome *struct{} `xml:"successful-outcome"`
-	UnsuccessfulOutcome  *struct{} `xml:"unsuccessful-outcome"`
+	Text                string    `xml:",chardata"`
+	InitiatingMessage   *struct{} `xml:"initiating-message"`
+	SuccessfulOutcome   *struct{} `xml:"successful-outcome"`
+	UnsuccessfulOutcome *struct{} `xml:"unsuccessful-outcome"`
+}
+
+// String returns the E2AP name of the triggering message, or an empty string when it is absent
+func (t TriggeringMessage) String() string {
+	switch {
+	case t.InitiatingMessage != nil:
+		return "initiating-message"
+	case t.SuccessfulOutcome != nil:
+		return "successful-outcome"
+	case t.UnsuccessfulOutcome != nil:
+		return "unsuccessful-outcome"
+	}
+	return ""
 }
 
+func (m *ErrorIndicationMessage) getIE(id int) *ErrorIndicationIEs {
+	ies := m.E2APPDU.InitiatingMessage.Value.ErrorIndication.ProtocolIEs.ErrorIndicationIEs
+	for i := range ies {
+		if ies[i].ID == id {
+			return &ies[i]
+		}
+	}
+	return nil
+}
+
+// GetTransactionId returns the TransactionID IE, falling back to the transaction id of the CriticalityDiagnostics IE
+func (m *ErrorIndicationMessage) GetTransactionId() string {
+	if ie := m.getIE(ErrorIndicationTransactionIdIE); ie != nil {
+		return ie.Value.TransactionID
+	}
+	if criticalityDiagnostics := m.GetCriticalityDiagnostics(); criticalityDiagnostics != nil {
+		return criticalityDiagnostics.TransactionID
+	}
+	return ""
+}
+
+func (m *ErrorIndicationMessage) GetRicRequestId() *RicRequestID {
+	if ie := m.getIE(ErrorIndicationRicRequestIdIE); ie != nil {
+		return &ie.Value.RICrequestID
+	}
+	return nil
+}
+
+func (m *ErrorIndicationMessage) GetRanFunctionId() *int32 {
+	if ie := m.getIE(ErrorIndicationRanFunctionIdIE); ie != nil {
+		return &ie.Value.RANfunctionID
+	}
+	return nil
+}
+
+func (m *ErrorIndicationMessage) GetCause() *Cause {
+	if ie := m.getIE(ErrorIndicationCauseIE); ie != nil {
+		return &ie.Value.Cause
+	}
+	return nil
+}
+
+func (m *ErrorIndicationMessage) GetCriticalityDiagnostics() *CriticalityDiagnostics {
+	if ie := m.getIE(ErrorIndicationCriticalityDiagnosticsIE); ie != nil {
+		return &ie.Value.CriticalityDiagnostics
+	}
+	return nil
+}
+
+// GetProcedureType returns the procedure the CriticalityDiagnostics IE refers to, if any. E2 Reset is initiated by either
+// side, an initiating message as triggering message refers to the E2 Reset requested by the RIC
+func (m *ErrorIndicationMessage) GetProcedureType() (RanProcedureType, bool) {
+	criticalityDiagnostics := m.GetCriticalityDiagnostics()
+	if criticalityDiagnostics == nil || criticalityDiagnostics.ProcedureCode == "" {
+		return "", false
+	}
+
+	procedureType, ok := GetRanProcedureType(criticalityDiagnostics.ProcedureCode)
+	if ok && procedureType == E2ResetProcedure && criticalityDiagnostics.TriggeringMessage.InitiatingMessage != nil {
+		return RicE2ResetProcedure, true
+	}
+	return procedureType, ok
+}
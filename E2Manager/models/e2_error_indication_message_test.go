@@ -1,5 +1,5 @@
 //
-// Copyright 2023 Nokia
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,18 +15,75 @@
 
 //  This source code is part of the near-RT RIC (RAN Intelligent Controller)
 //  platform project (RICP).
+
 package models_test
 
 import (
-	"fmt"
-	"testing"
 	"e2mgr/models"
+	"e2mgr/utils"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
+const (
+	ErrorIndicationForSetupRequestXmlPath = "../tests/resources/errorIndication/errorIndicationForSetupRequest.xml"
+	ErrorIndicationWithoutCDXmlPath       = "../tests/resources/errorIndication/errorIndicationWithoutCD.xml"
+	ErrorIndicationForResetRequestXmlPath = "../tests/resources/errorIndication/errorIndicationForResetRequest.xml"
 )
-const RanNameForErrorIndication = "test"
 
-func TestProcedureTypeForErrorIndication(t *testing.T) {
-	models.ProcedureMap[RanNameForErrorIndication] = models.E2SetupProcedureCompleted
-	models.UpdateProcedureType(RanNameForErrorIndication,models.E2SetupProcedureCompleted)
-	fmt.Println("updating the map to E2SetupProcedureCompleted")
+func getErrorIndicationMessage(t *testing.T, xmlPath string) *models.ErrorIndicationMessage {
+	errorIndication := utils.ReadXmlFile(t, xmlPath)
+	errorIndicationMessage := &models.ErrorIndicationMessage{}
+	err := xml.Unmarshal(utils.NormalizeXml(errorIndication), &errorIndicationMessage.E2APPDU)
+	assert.Nil(t, err)
+	return errorIndicationMessage
+}
+
+func TestParseErrorIndication(t *testing.T) {
+	errorIndicationMessage := getErrorIndicationMessage(t, ErrorIndicationForSetupRequestXmlPath)
+
+	assert.Equal(t, "22", errorIndicationMessage.GetTransactionId())
+	assert.Equal(t, int32(1), errorIndicationMessage.GetRicRequestId().RicRequestorID)
+	assert.Equal(t, int32(4), *errorIndicationMessage.GetRanFunctionId())
+	assert.Equal(t, "misc/om-intervention", errorIndicationMessage.GetCause().String())
+	assert.Equal(t, "successful-outcome", errorIndicationMessage.GetCriticalityDiagnostics().TriggeringMessage.String())
+
+	procedureType, ok := errorIndicationMessage.GetProcedureType()
+	assert.True(t, ok)
+	assert.Equal(t, models.E2SetupProcedure, procedureType)
+}
+
+func TestErrorIndicationProcedureTypeOfRicE2Reset(t *testing.T) {
+	errorIndicationMessage := getErrorIndicationMessage(t, ErrorIndicationForResetRequestXmlPath)
+
+	procedureType, ok := errorIndicationMessage.GetProcedureType()
+	assert.True(t, ok)
+	assert.Equal(t, models.RicE2ResetProcedure, procedureType)
+}
+
+func TestParseErrorIndicationWithoutCD(t *testing.T) {
+	errorIndicationMessage := getErrorIndicationMessage(t, ErrorIndicationWithoutCDXmlPath)
+
+	assert.Nil(t, errorIndicationMessage.GetCause())
+	assert.Nil(t, errorIndicationMessage.GetCriticalityDiagnostics())
+
+	_, ok := errorIndicationMessage.GetProcedureType()
+	assert.False(t, ok)
+}
+
+func TestNewErrorIndicationRecord(t *testing.T) {
+	errorIndicationMessage := getErrorIndicationMessage(t, ErrorIndicationForSetupRequestXmlPath)
+
+	record := models.NewErrorIndicationRecord(errorIndicationMessage, 1000)
+
+	assert.Equal(t, int64(1000), record.ReceivedAt)
+	assert.Equal(t, "22", record.TransactionId)
+	assert.Equal(t, int32(1), *record.RicRequestorId)
+	assert.Equal(t, int32(1), *record.RicInstanceId)
+	assert.Equal(t, int32(4), *record.RanFunctionId)
+	assert.Equal(t, "misc/om-intervention", record.Cause)
+	assert.Equal(t, models.ProcedureCode_id_E2setup, record.ProcedureCode)
+	assert.Equal(t, "successful-outcome", record.TriggeringMessage)
 }
@@ -128,6 +128,12 @@ type RemovalProtocolIESingleContainer struct {
 	} `xml:"value"`
 }
 
+// E2nodeComponentConfigOutcome : a nil FailureCause acknowledges the component configuration as successful
+type E2nodeComponentConfigOutcome struct {
+	Config       entities.E2NodeComponentConfig
+	FailureCause *Cause
+}
+
 type ComponentAckDetail struct {
 	Text                         string                `xml:",chardata"`
 	E2nodeComponentInterfaceType E2NodeComponentType   `xml:"e2nodeComponentInterfaceType"`
@@ -135,10 +141,10 @@ type ComponentAckDetail struct {
 	E2nodeConfigUpdateAck        E2nodeConfigUpdateAckResp
 }
 
-func prepareAdditionAckList(e2nodeConfigs []entities.E2NodeComponentConfig) []AdditionListProtocolIESingleContainer {
+func prepareAdditionAckList(outcomes []E2nodeComponentConfigOutcome) []AdditionListProtocolIESingleContainer {
 	additionListAckSingle := []AdditionListProtocolIESingleContainer{}
-	for _, v := range e2nodeConfigs {
-		c := convertEntitiyToModelComponent(v)
+	for _, v := range outcomes {
+		c := convertEntitiyToModelComponent(v.Config, v.FailureCause)
 
 		t := AdditionListProtocolIESingleContainer{
 			ID: ProtocolIE_ID_id_E2nodeComponentConfigAdditionAck_Item,
@@ -154,10 +160,10 @@ func prepareAdditionAckList(e2nodeConfigs []entities.E2NodeComponentConfig) []Ad
 	return additionListAckSingle
 }
 
-func prepareUpdateAckList(e2nodeConfigs []entities.E2NodeComponentConfig) []UpdateProtocolIESingleContainer {
+func prepareUpdateAckList(outcomes []E2nodeComponentConfigOutcome) []UpdateProtocolIESingleContainer {
 	updateListAckSingle := []UpdateProtocolIESingleContainer{}
-	for _, v := range e2nodeConfigs {
-		c := convertEntitiyToModelComponent(v)
+	for _, v := range outcomes {
+		c := convertEntitiyToModelComponent(v.Config, v.FailureCause)
 
 		t := UpdateProtocolIESingleContainer{
 			ID: ProtocolIE_ID_id_E2nodeComponentConfigUpdateAck_Item,
@@ -173,10 +179,10 @@ func prepareUpdateAckList(e2nodeConfigs []entities.E2NodeComponentConfig) []Upda
 	return updateListAckSingle
 }
 
-func prepareRemovalAckList(e2nodeConfigs []entities.E2NodeComponentConfig) []RemovalProtocolIESingleContainer {
+func prepareRemovalAckList(outcomes []E2nodeComponentConfigOutcome) []RemovalProtocolIESingleContainer {
 	removalListAckSingle := []RemovalProtocolIESingleContainer{}
-	for _, v := range e2nodeConfigs {
-		c := convertEntitiyToModelComponent(v)
+	for _, v := range outcomes {
+		c := convertEntitiyToModelComponent(v.Config, v.FailureCause)
 
 		t := RemovalProtocolIESingleContainer{
 			ID: ProtocolIE_ID_id_E2nodeComponentConfigRemovalAck_Item,
@@ -260,14 +266,34 @@ func updateInterfaceType(t *ComponentAckDetail, c entities.E2NodeComponentConfig
 	}
 }
 
-func convertEntitiyToModelComponent(component entities.E2NodeComponentConfig) *ComponentAckDetail {
+func convertEntitiyToModelComponent(component entities.E2NodeComponentConfig, failureCause *Cause) *ComponentAckDetail {
 	componentAckDetail := &ComponentAckDetail{}
 	updateInterfaceType(componentAckDetail, component)
-	updateIDAndStatus(componentAckDetail, component, true)
+	updateIDAndStatus(componentAckDetail, component, failureCause == nil)
+	if failureCause != nil {
+		componentAckDetail.E2nodeConfigUpdateAck.FailureCause = &E2nodeComponentFailureCause{Cause: *failureCause}
+	}
 	return componentAckDetail
 }
 
+func successfulOutcomes(e2nodeConfigs []entities.E2NodeComponentConfig) []E2nodeComponentConfigOutcome {
+	outcomes := make([]E2nodeComponentConfigOutcome, len(e2nodeConfigs))
+	for i, v := range e2nodeConfigs {
+		outcomes[i].Config = v
+	}
+	return outcomes
+}
+
 func NewE2nodeConfigurationUpdateSuccessResponseMessage(e2nodeConfigupdateMessage *E2nodeConfigurationUpdateMessage) *E2nodeConfigurationUpdateAcknowledgeE2APPDU {
+	return NewE2nodeConfigurationUpdateAcknowledgeMessage(
+		e2nodeConfigupdateMessage.E2APPDU.InitiatingMessage.Value.E2nodeConfigurationUpdate.ProtocolIEs.E2nodeConfigurationUpdateIEs[0].Value.TransactionID,
+		successfulOutcomes(e2nodeConfigupdateMessage.ExtractConfigAdditionList()),
+		successfulOutcomes(e2nodeConfigupdateMessage.ExtractConfigUpdateList()),
+		successfulOutcomes(e2nodeConfigupdateMessage.ExtractConfigDeletionList()))
+}
+
+// NewE2nodeConfigurationUpdateAcknowledgeMessage acknowledges every component of the update with its own outcome
+func NewE2nodeConfigurationUpdateAcknowledgeMessage(transactionId string, additions, updates, removals []E2nodeComponentConfigOutcome) *E2nodeConfigurationUpdateAcknowledgeE2APPDU {
 	successfulOutcome := E2nodeConfigurationUpdateAcknowledgeSuccessfulOutcome{
 		ProcedureCode: ProcedureCode_id_E2nodeConfigurationUpdate,
 	}
@@ -276,14 +302,13 @@ func NewE2nodeConfigurationUpdateSuccessResponseMessage(e2nodeConfigupdateMessag
 	txIEs := E2nodeConfigurationUpdateAcknowledgeIEs{
 		ID: ProtocolIE_ID_id_TransactionID,
 		Value: E2nodeConfigurationUpdateAcknowledgeTransID{
-			TransactionID: e2nodeConfigupdateMessage.E2APPDU.InitiatingMessage.Value.E2nodeConfigurationUpdate.ProtocolIEs.E2nodeConfigurationUpdateIEs[0].Value.TransactionID,
+			TransactionID: transactionId,
 		},
 	}
 
 	e2nodeConfigurationUpdateAckIEs = append(e2nodeConfigurationUpdateAckIEs, txIEs)
 
-	items := e2nodeConfigupdateMessage.ExtractConfigAdditionList()
-	if len(items) > 0 {
+	if len(additions) > 0 {
 		addtionListAckIEs := E2nodeConfigurationUpdateAcknowledgeIEs{
 			ID: ProtocolIE_ID_id_E2nodeComponentConfigAdditionAck,
 			Value: E2nodeComponentConfigAdditionAckList{
@@ -291,15 +316,14 @@ func NewE2nodeConfigurationUpdateSuccessResponseMessage(e2nodeConfigupdateMessag
 					Text                      string                                  `xml:",chardata"`
 					ProtocolIESingleContainer []AdditionListProtocolIESingleContainer `xml:"ProtocolIE-SingleContainer"`
 				}{
-					ProtocolIESingleContainer: prepareAdditionAckList(items),
+					ProtocolIESingleContainer: prepareAdditionAckList(additions),
 				},
 			},
 		}
 		e2nodeConfigurationUpdateAckIEs = append(e2nodeConfigurationUpdateAckIEs, addtionListAckIEs)
 	}
 
-	items = e2nodeConfigupdateMessage.ExtractConfigUpdateList()
-	if len(items) > 0 {
+	if len(updates) > 0 {
 		updateListAckIEs := E2nodeConfigurationUpdateAcknowledgeIEs{
 			ID: ProtocolIE_ID_id_E2nodeComponentConfigUpdateAck,
 			Value: E2nodeComponentConfigUpdateAckList{
@@ -307,15 +331,14 @@ func NewE2nodeConfigurationUpdateSuccessResponseMessage(e2nodeConfigupdateMessag
 					Text                      string                            `xml:",chardata"`
 					ProtocolIESingleContainer []UpdateProtocolIESingleContainer `xml:"ProtocolIE-SingleContainer"`
 				}{
-					ProtocolIESingleContainer: prepareUpdateAckList(items),
+					ProtocolIESingleContainer: prepareUpdateAckList(updates),
 				},
 			},
 		}
 		e2nodeConfigurationUpdateAckIEs = append(e2nodeConfigurationUpdateAckIEs, updateListAckIEs)
 	}
 
-	items = e2nodeConfigupdateMessage.ExtractConfigDeletionList()
-	if len(items) > 0 {
+	if len(removals) > 0 {
 		removalListAckIEs := E2nodeConfigurationUpdateAcknowledgeIEs{
 			ID: ProtocolIE_ID_id_E2nodeComponentConfigRemovalAck,
 			Value: E2nodeComponentConfigRemovalAckList{
@@ -323,7 +346,7 @@ func NewE2nodeConfigurationUpdateSuccessResponseMessage(e2nodeConfigupdateMessag
 					Text                      string                             `xml:",chardata"`
 					ProtocolIESingleContainer []RemovalProtocolIESingleContainer `xml:"ProtocolIE-SingleContainer"`
 				}{
-					ProtocolIESingleContainer: prepareRemovalAckList(items),
+					ProtocolIESingleContainer: prepareRemovalAckList(removals),
 				},
 			},
 		}
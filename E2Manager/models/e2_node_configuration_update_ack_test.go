@@ -20,6 +20,8 @@ package models_test
 
 import (
 	"e2mgr/models"
+	"encoding/xml"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -134,3 +136,24 @@ func TestNewE2nodeConfigurationUpdateSuccessResponseW1(t *testing.T) {
 	assert.Equal(t, models.ProtocolIE_ID_id_E2nodeComponentConfigAdditionAck, additionIE.ID)
 	assert.Equal(t, 1, len(additionIE.Value.(models.E2nodeComponentConfigAdditionAckList).E2nodeComponentConfigAdditionAckList.ProtocolIESingleContainer))
 }
+
+func TestNewE2nodeConfigurationUpdateAcknowledgeMessageWithFailedComponent(t *testing.T) {
+	configurationUpdate := getTestE2NodeConfigurationUpdateMessage(t, e2NodeConfigurationUpdateXmlPath)
+	updates := configurationUpdate.ExtractConfigUpdateList()
+	cause := models.Cause{E2Node: &models.CauseE2Node{E2nodeComponentUnknown: &struct{}{}}}
+	outcomes := []models.E2nodeComponentConfigOutcome{{Config: updates[0]}, {Config: updates[1], FailureCause: &cause}}
+
+	ack := models.NewE2nodeConfigurationUpdateAcknowledgeMessage("1234", nil, outcomes, nil)
+	successOutcome := ack.Outcome.(models.E2nodeConfigurationUpdateAcknowledgeSuccessfulOutcome)
+	assert.Equal(t, 2, len(successOutcome.Value.E2nodeConfigurationUpdateAcknowledge.ProtocolIEs.E2nodeConfigurationUpdateAcknowledgeIEs))
+
+	updateIE := successOutcome.Value.E2nodeConfigurationUpdateAcknowledge.ProtocolIEs.E2nodeConfigurationUpdateAcknowledgeIEs[1]
+	items := updateIE.Value.(models.E2nodeComponentConfigUpdateAckList).E2nodeComponentConfigUpdateAckList.ProtocolIESingleContainer
+	assert.Nil(t, items[0].Value.E2nodeComponentConfigUpdateAckItem.E2nodeConfigUpdateAck.FailureCause)
+	assert.Equal(t, cause, items[1].Value.E2nodeComponentConfigUpdateAckItem.E2nodeConfigUpdateAck.FailureCause.Cause)
+
+	payload, err := xml.Marshal(ack)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, strings.Count(string(payload), "<updateOutcome><success></success></updateOutcome></e2nodeComponentConfigurationAck>"))
+	assert.Equal(t, 1, strings.Count(string(payload), "<updateOutcome><failure></failure></updateOutcome><failureCause><e2Node><e2node-component-unknown></e2node-component-unknown></e2Node></failureCause></e2nodeComponentConfigurationAck>"))
+}
@@ -18,10 +18,21 @@
 
 package models
 
+import "strconv"
+
 type E2ResetRequestMessage struct {
 	E2ApPDU E2ApPDU `xml:"E2AP-PDU"`
 }
 
+func (m *E2ResetRequestMessage) GetTransactionId() string {
+	for _, ie := range m.E2ApPDU.InitiatingMessage.Value.E2ResetRequest.ProtocolIes.ResetRequestIEs {
+		if ie.Value.TransactionID != nil {
+			return strconv.FormatInt(*ie.Value.TransactionID, 10)
+		}
+	}
+	return ""
+}
+
 type E2ApPDU struct {
 	InitiatingMessage InitiatingMessageY `xml:"initiatingMessage"`
 }
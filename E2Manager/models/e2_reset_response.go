@@ -89,3 +89,12 @@ func NewE2ResetResponseMessage(request *E2ResetRequestMessage) E2ResetResponseMe
 	}
 	return E2ResetResponseMessage{E2ApPdu: E2ApPdu{SuccessfulOutcome: outcome}}
 }
+
+func (m *E2ResetResponseMessage) GetTransactionId() string {
+	for _, ie := range m.E2ApPdu.SuccessfulOutcome.Value.ResetResponse.ProtocolIEs.ResetResponseIEs {
+		if ie.ID == ProtocolIE_ID_id_TransactionID {
+			return ie.Value.TransactionID
+		}
+	}
+	return ""
+}
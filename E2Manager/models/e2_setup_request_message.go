@@ -593,6 +593,15 @@ func (m *E2SetupRequestMessage) getGlobalE2NodeId() GlobalE2NodeId {
 	return m.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs[index].Value.GlobalE2nodeID
 }
 
+func (m *E2SetupRequestMessage) GetTransactionId() string {
+	for _, ie := range m.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs {
+		if ie.ID == TransactionID {
+			return ie.Value.TransactionID
+		}
+	}
+	return ""
+}
+
 func (m *E2SetupRequestMessage) GetPlmnId() string {
 	globalE2NodeId := m.getGlobalE2NodeId()
 	if id := globalE2NodeId.GNB.GlobalGNBID.PlmnID; id != "" {
@@ -689,3 +698,60 @@ func (m *E2SetupRequestMessage) GetNbId() string {
 func (m *E2SetupRequestMessage) trimSpaces(str string) string {
 	return strings.NewReplacer(" ", "", "\n", "").Replace(str)
 }
+
+func (m *E2SetupRequestMessage) GetNodeType() entities.Node_Type {
+	globalE2NodeId := m.getGlobalE2NodeId()
+
+	if globalE2NodeId.GNB.GlobalGNBID.GnbID.GnbID != "" || globalE2NodeId.EnGNB.GlobalGNBID.GnbID.GnbID != "" {
+		return entities.Node_GNB
+	}
+
+	if m.getInnerEnbId(globalE2NodeId.ENB.GlobalENBID.EnbID) != "" || m.getInnerNgEnbId(globalE2NodeId.NgENB.GlobalNgENBID.EnbID) != "" {
+		return entities.Node_ENB
+	}
+
+	return entities.Node_UNKNOWN
+}
+
+func (m *E2SetupRequestMessage) GetGnbType() entities.GnbType {
+	globalE2NodeId := m.getGlobalE2NodeId()
+
+	if globalE2NodeId.GNB.GlobalGNBID.GnbID.GnbID != "" {
+		return entities.GnbType_GNB
+	}
+
+	if globalE2NodeId.EnGNB.GlobalGNBID.GnbID.GnbID != "" {
+		return entities.GnbType_EN_GNB
+	}
+
+	return entities.GnbType_UNKNOWN_GNB_TYPE
+}
+
+func (m *E2SetupRequestMessage) GetEnbType() entities.EnbType {
+	globalE2NodeId := m.getGlobalE2NodeId()
+	enbId := globalE2NodeId.ENB.GlobalENBID.EnbID
+
+	switch {
+	case enbId.MacroEnbId != "":
+		return entities.EnbType_MACRO_ENB
+	case enbId.HomeEnbId != "":
+		return entities.EnbType_HOME_ENB
+	case enbId.ShortMacroEnbId != "":
+		return entities.EnbType_SHORT_MACRO_ENB
+	case enbId.LongMacroEnbId != "":
+		return entities.EnbType_LONG_MACRO_ENB
+	}
+
+	ngEnbId := globalE2NodeId.NgENB.GlobalNgENBID.EnbID
+
+	switch {
+	case ngEnbId.EnbIdMacro != "":
+		return entities.EnbType_MACRO_NG_ENB
+	case ngEnbId.EnbIdShortMacro != "":
+		return entities.EnbType_SHORT_MACRO_NG_ENB
+	case ngEnbId.EnbIdLongMacro != "":
+		return entities.EnbType_LONG_MACRO_NG_ENB
+	}
+
+	return entities.EnbType_UNKNOWN_ENB_TYPE
+}
@@ -25,6 +25,7 @@ import (
 	"encoding/xml"
 	"testing"
 
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +43,7 @@ const (
 	e2SetupReqXnenbInterfaceGnbSetupRequestWithOIDXmlPath = "../tests/resources/setupRequest/setupRequest_with_oid_gnb_inttype_xnenb.xml"
 	e2SetupReqX2gnbInterfaceGnbSetupRequestWithOIDXmlPath = "../tests/resources/setupRequest/setupRequest_with_oid_gnb_inttype_x2gnb.xml"
 	e2SetupReqX2enbInterfaceGnbSetupRequestWithOIDXmlPath = "../tests/resources/setupRequest/setupRequest_with_oid_gnb_inttype_x2enb.xml"
+	e2SetupReqUnknownNodeSetupRequestXmlPath              = "../tests/resources/setupRequest/setupRequest_unknown_node.xml"
 )
 
 func getTestE2SetupRequest(t *testing.T, reqXmlPath string) *models.E2SetupRequestMessage {
@@ -149,6 +151,42 @@ func TestGetNbIdFromNgEnbRequestSuccess(t *testing.T) {
 	assert.Equal(t, "101010101010101010", nbID)
 }
 
+func TestGetNodeTypeFromGnbRequestSuccess(t *testing.T) {
+	setupRequest := getTestE2SetupRequest(t, e2SetupReqGnbSetupRequestXmlPath)
+
+	assert.Equal(t, entities.Node_GNB, setupRequest.GetNodeType())
+	assert.Equal(t, entities.GnbType_GNB, setupRequest.GetGnbType())
+}
+
+func TestGetNodeTypeFromEnGnbRequestSuccess(t *testing.T) {
+	setupRequest := getTestE2SetupRequest(t, e2SetupReqEnGnbSetupRequestXmlPath)
+
+	assert.Equal(t, entities.Node_GNB, setupRequest.GetNodeType())
+	assert.Equal(t, entities.GnbType_EN_GNB, setupRequest.GetGnbType())
+}
+
+func TestGetNodeTypeFromEnbRequestSuccess(t *testing.T) {
+	setupRequest := getTestE2SetupRequest(t, e2SetupReqEnbSetupRequestXmlPath)
+
+	assert.Equal(t, entities.Node_ENB, setupRequest.GetNodeType())
+	assert.Equal(t, entities.EnbType_MACRO_ENB, setupRequest.GetEnbType())
+}
+
+func TestGetNodeTypeFromNgEnbRequestSuccess(t *testing.T) {
+	setupRequest := getTestE2SetupRequest(t, e2SetupReqNgEnbSetupRequestXmlPath)
+
+	assert.Equal(t, entities.Node_ENB, setupRequest.GetNodeType())
+	assert.Equal(t, entities.EnbType_SHORT_MACRO_NG_ENB, setupRequest.GetEnbType())
+}
+
+func TestGetNodeTypeFromUnknownNodeRequest(t *testing.T) {
+	setupRequest := getTestE2SetupRequest(t, e2SetupReqUnknownNodeSetupRequestXmlPath)
+
+	assert.Equal(t, entities.Node_UNKNOWN, setupRequest.GetNodeType())
+	assert.Equal(t, entities.GnbType_UNKNOWN_GNB_TYPE, setupRequest.GetGnbType())
+	assert.Equal(t, entities.EnbType_UNKNOWN_ENB_TYPE, setupRequest.GetEnbType())
+}
+
 func TestExtractE2nodeIntTypeE1ConfigFail(t *testing.T) {
 	setupRequest := getTestE2SetupRequest(t, e2SetupReqE1InterfaceGnbSetupRequestWithOIDXmlPath)
 	e2nodeConfigs := setupRequest.ExtractE2NodeConfigList()
@@ -103,47 +103,90 @@ var configStatusMap = map[ConfigStatus]interface{}{
 	}{},
 }
 
+// E2SetupRejections lists the RAN functions and component configurations the RIC refuses while accepting the E2 node itself
+type E2SetupRejections struct {
+	RanFunctions []RicServiceRejectedRANFunctionIDItem
+	// ComponentConfigs is keyed by the position of the item in the E2nodeComponentConfigAddition-List
+	ComponentConfigs map[int]Cause
+}
+
+func (r *E2SetupRejections) IsRanFunctionRejected(ranFunctionId uint32) bool {
+	for _, rejected := range r.RanFunctions {
+		if rejected.RanFunctionID == ranFunctionId {
+			return true
+		}
+	}
+	return false
+}
+
 func NewE2SetupSuccessResponseMessage(plmnId string, ricId string, request *E2SetupRequestMessage) E2SetupResponseMessage {
+	return NewE2SetupResponseMessage(plmnId, ricId, request, &E2SetupRejections{})
+}
+
+// NewE2SetupResponseMessage accepts the E2 node, the rejected RAN functions are listed in the RANfunctionsRejected IE
+// and the rejected component configurations are acknowledged with a failure outcome
+func NewE2SetupResponseMessage(plmnId string, ricId string, request *E2SetupRequestMessage, rejections *E2SetupRejections) E2SetupResponseMessage {
 	outcome := SuccessfulOutcome{}
 	outcome.ProcedureCode = "1"
 
 	e2SetupRequestIes := request.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs
 	numOfIes := len(e2SetupRequestIes)
 
-	outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs = make([]E2setupResponseIEs, numOfIes)
+	responseIEs := make([]E2setupResponseIEs, 0, numOfIes+1)
 
 	for ieCount := 0; ieCount < numOfIes; ieCount++ {
+		responseIE := E2setupResponseIEs{}
+
 		switch e2SetupRequestIes[ieCount].ID {
 		case TransactionID:
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].ID = TransactionID
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].Value = TransID{
+			responseIE.ID = TransactionID
+			responseIE.Value = TransID{
 				TransactionID: request.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs[ieCount].Value.TransactionID,
 			}
 
 		case GlobalE2nodeID:
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].ID = GlobalRicID
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].Value = GlobalRICID{GlobalRICID: struct {
+			responseIE.ID = GlobalRicID
+			responseIE.Value = GlobalRICID{GlobalRICID: struct {
 				Text         string `xml:",chardata"`
 				PLMNIdentity string `xml:"pLMN-Identity"`
 				RicID        string `xml:"ric-ID"`
 			}{PLMNIdentity: plmnId, RicID: ricId}}
 
 		case RanFunctionsAddedID:
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].ID = RanFunctionsAcceptedID
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].Value = RANfunctionsIDList{RANfunctionsIDList: struct {
+			acceptedIds := extractRanFunctionsIDList(request, ieCount, rejections)
+			// RANfunctionsAccepted is optional, it is omitted when every RAN function is rejected
+			if len(acceptedIds) == 0 && len(rejections.RanFunctions) != 0 {
+				continue
+			}
+			responseIE.ID = RanFunctionsAcceptedID
+			responseIE.Value = RANfunctionsIDList{RANfunctionsIDList: struct {
 				Text                      string                      `xml:",chardata"`
 				ProtocolIESingleContainer []ProtocolIESingleContainer `xml:"ProtocolIE-SingleContainer"`
-			}{ProtocolIESingleContainer: extractRanFunctionsIDList(request, ieCount)}}
+			}{ProtocolIESingleContainer: acceptedIds}}
 
 		case E2nodeConfigAdditionID:
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].ID = E2nodeConfigAdditionAckID
-			outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs[ieCount].Value = E2NodeConfigUpdateAckList{E2NodeConfigUpdateAckList: struct {
+			responseIE.ID = E2nodeConfigAdditionAckID
+			responseIE.Value = E2NodeConfigUpdateAckList{E2NodeConfigUpdateAckList: struct {
 				Text                        string                        `xml:",chardata"`
 				E2NodeConfigSingleContainer []E2NodeConfigSingleContainer `xml:"ProtocolIE-SingleContainer"`
-			}{E2NodeConfigSingleContainer: extractE2NodeConfigUpdateList(request, ieCount, 0)}}
+			}{E2NodeConfigSingleContainer: extractE2NodeConfigUpdateList(request, ieCount, rejections)}}
 		}
+
+		responseIEs = append(responseIEs, responseIE)
 	}
 
+	if len(rejections.RanFunctions) != 0 {
+		rejectedList := RICserviceUpdateAcknowledgeRANfunctionsRejectedList{}
+		rejectedList.RANfunctionsIDcauseList.ProtocolIESingleContainer = make([]RICserviceUpdateAcknowledgeRejectedProtocolIESingleContainer, len(rejections.RanFunctions))
+		for i, rejectedFunction := range rejections.RanFunctions {
+			rejectedList.RANfunctionsIDcauseList.ProtocolIESingleContainer[i].Id = ProtocolIE_ID_id_RANfunctionIEcause_Item
+			rejectedList.RANfunctionsIDcauseList.ProtocolIESingleContainer[i].Value.RANfunctionIDcauseItem = rejectedFunction
+		}
+		responseIEs = append(responseIEs, E2setupResponseIEs{ID: ProtocolIE_ID_id_RANfunctionsRejected, Value: rejectedList})
+	}
+
+	outcome.Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs = responseIEs
+
 	return E2SetupResponseMessage{E2APPDU: E2APPDU{Outcome: outcome}}
 }
 
@@ -285,8 +328,18 @@ type E2NodeComponentIDResp struct {
 }
 
 type E2nodeConfigUpdateAckResp struct {
-	XMLName xml.Name `xml:"e2nodeComponentConfigurationAck"`
-	Value   interface{}
+	XMLName      xml.Name `xml:"e2nodeComponentConfigurationAck"`
+	Value        interface{}
+	FailureCause *E2nodeComponentFailureCause `xml:"failureCause"`
+}
+
+// E2nodeComponentFailureCause encodes the cause under the "failureCause" tag rather than the "Cause" IE tag
+type E2nodeComponentFailureCause struct {
+	Cause Cause
+}
+
+func (c E2nodeComponentFailureCause) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(c.Cause, start)
 }
 
 type UnsuccessfulOutcome struct {
@@ -322,11 +375,14 @@ type E2setupFailureIEs struct {
 	} `xml:"value"`
 }
 
-func extractRanFunctionsIDList(request *E2SetupRequestMessage, index int) []ProtocolIESingleContainer {
+func extractRanFunctionsIDList(request *E2SetupRequestMessage, index int, rejections *E2SetupRejections) []ProtocolIESingleContainer {
 	list := &request.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs[index].Value.RANfunctionsList
-	ids := make([]ProtocolIESingleContainer, len(list.ProtocolIESingleContainer))
-	for i := 0; i < len(ids); i++ {
-		ids[i] = convertToRANfunctionID(list, i)
+	ids := make([]ProtocolIESingleContainer, 0, len(list.ProtocolIESingleContainer))
+	for i := 0; i < len(list.ProtocolIESingleContainer); i++ {
+		if rejections.IsRanFunctionRejected(list.ProtocolIESingleContainer[i].Value.RANfunctionItem.RanFunctionID) {
+			continue
+		}
+		ids = append(ids, convertToRANfunctionID(list, i))
 	}
 	return ids
 }
@@ -341,11 +397,17 @@ func convertToRANfunctionID(list *RANfunctionsList, i int) ProtocolIESingleConta
 	return id
 }
 
-func extractE2NodeConfigUpdateList(request *E2SetupRequestMessage, index int, outcome int) []E2NodeConfigSingleContainer {
+func extractE2NodeConfigUpdateList(request *E2SetupRequestMessage, index int, rejections *E2SetupRejections) []E2NodeConfigSingleContainer {
 	list := &request.E2APPDU.InitiatingMessage.Value.E2setupRequest.ProtocolIEs.E2setupRequestIEs[index].Value.E2NodeConfigList
 	ids := make([]E2NodeConfigSingleContainer, len(list.ProtocolIESingleContainer))
 	for i := 0; i < len(ids); i++ {
-		ids[i] = convertToE2NodeConfig(list, i, outcome)
+		cause, rejected := rejections.ComponentConfigs[i]
+		if !rejected {
+			ids[i] = convertToE2NodeConfig(list, i, ConfigStatusEnum.Success)
+			continue
+		}
+		ids[i] = convertToE2NodeConfig(list, i, ConfigStatusEnum.Failure)
+		ids[i].Value.E2NodeConfigUpdateAckItem.E2nodeConfigUpdateAck.FailureCause = &E2nodeComponentFailureCause{Cause: cause}
 	}
 	return ids
 }
@@ -24,6 +24,7 @@ import (
 	"e2mgr/models"
 	"e2mgr/utils"
 	"encoding/xml"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,62 @@ func TestNewE2SetupSuccessResponseMessageWithoutRanFunctionsSuccess(t *testing.T
 	assert.Equal(t, 3, len(respIEs))
 }
 
+func TestNewE2SetupResponseMessageWithRejections(t *testing.T) {
+	plmn := "23F749"
+	ricNearRtId := "10101010110011001110"
+	setupRequest := getE2SetupRespTestE2SetupRequest(t, e2SetupRespGnbSetupRequestXmlPath)
+	rejections := &models.E2SetupRejections{
+		RanFunctions: []models.RicServiceRejectedRANFunctionIDItem{
+			{RanFunctionID: 2, Cause: models.Cause{RicRequest: &models.CauseRic{RanFunctionIdInvalid: &struct{}{}}}},
+		},
+		ComponentConfigs: map[int]models.Cause{
+			0: {Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}},
+		},
+	}
+
+	resp := models.NewE2SetupResponseMessage(plmn, ricNearRtId, setupRequest, rejections)
+	respIEs := resp.E2APPDU.Outcome.(models.SuccessfulOutcome).Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs
+	assert.Equal(t, 5, len(respIEs))
+
+	assert.Equal(t, models.RanFunctionsAcceptedID, respIEs[2].ID)
+	accepted := respIEs[2].Value.(models.RANfunctionsIDList).RANfunctionsIDList.ProtocolIESingleContainer
+	assert.Equal(t, 2, len(accepted))
+	assert.Equal(t, uint32(1), accepted[0].Value.RANfunctionIDItem.RanFunctionID)
+	assert.Equal(t, uint32(3), accepted[1].Value.RANfunctionIDItem.RanFunctionID)
+
+	assert.Equal(t, models.E2nodeConfigAdditionAckID, respIEs[3].ID)
+	ackList := respIEs[3].Value.(models.E2NodeConfigUpdateAckList).E2NodeConfigUpdateAckList.E2NodeConfigSingleContainer
+	assert.NotNil(t, ackList[0].Value.E2NodeConfigUpdateAckItem.E2nodeConfigUpdateAck.FailureCause)
+
+	assert.Equal(t, models.ProtocolIE_ID_id_RANfunctionsRejected, respIEs[4].ID)
+	rejected := respIEs[4].Value.(models.RICserviceUpdateAcknowledgeRANfunctionsRejectedList).RANfunctionsIDcauseList.ProtocolIESingleContainer
+	assert.Equal(t, 1, len(rejected))
+	assert.Equal(t, uint32(2), rejected[0].Value.RANfunctionIDcauseItem.RanFunctionID)
+
+	payload, err := xml.Marshal(resp.E2APPDU)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(string(payload), "<ran-function-id-Invalid></ran-function-id-Invalid>"))
+	assert.True(t, strings.Contains(string(payload), "<failureCause><protocol><semantic-error></semantic-error></protocol></failureCause>"))
+}
+
+func TestNewE2SetupResponseMessageAllRanFunctionsRejected(t *testing.T) {
+	setupRequest := getE2SetupRespTestE2SetupRequest(t, e2SetupRespGnbSetupRequestXmlPath)
+	cause := models.Cause{RicRequest: &models.CauseRic{RanFunctionIdInvalid: &struct{}{}}}
+	rejections := &models.E2SetupRejections{
+		RanFunctions: []models.RicServiceRejectedRANFunctionIDItem{
+			{RanFunctionID: 1, Cause: cause}, {RanFunctionID: 2, Cause: cause}, {RanFunctionID: 3, Cause: cause},
+		},
+	}
+
+	resp := models.NewE2SetupResponseMessage("23F749", "10101010110011001110", setupRequest, rejections)
+	respIEs := resp.E2APPDU.Outcome.(models.SuccessfulOutcome).Value.E2setupResponse.ProtocolIEs.E2setupResponseIEs
+	assert.Equal(t, 4, len(respIEs))
+	for _, ie := range respIEs {
+		assert.NotEqual(t, models.RanFunctionsAcceptedID, ie.ID)
+	}
+	assert.Equal(t, models.ProtocolIE_ID_id_RANfunctionsRejected, respIEs[3].ID)
+}
+
 func TestNewE2SetupFailureResponseMessageSuccess(t *testing.T) {
 	waitTime := models.TimeToWaitEnum.V60s
 	cause := models.Cause{Misc: &models.CauseMisc{OmIntervention: &struct{}{}}}
@@ -0,0 +1,65 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+// E2smRanFunctionDefinition is the structured view of a RAN function definition decoded by an E2SM decoder
+type E2smRanFunctionDefinition struct {
+	ServiceModelName    string                   `json:"serviceModelName"`
+	ServiceModelVersion string                   `json:"serviceModelVersion"`
+	ShortName           string                   `json:"shortName"`
+	Oid                 string                   `json:"oid"`
+	Description         string                   `json:"description"`
+	Instance            *int64                   `json:"instance,omitempty"`
+	EventTriggerStyles  []*E2smEventTriggerStyle `json:"eventTriggerStyles,omitempty"`
+	ReportStyles        []*E2smReportStyle       `json:"reportStyles,omitempty"`
+}
+
+type E2smEventTriggerStyle struct {
+	Type       int64  `json:"type"`
+	Name       string `json:"name"`
+	FormatType int64  `json:"formatType"`
+}
+
+// E2smReportStyle carries the action definition of a report style: its format types and, depending on the service
+// model, the measurement names (E2SM-KPM) or the RAN parameters (E2SM-RC) it reports
+type E2smReportStyle struct {
+	Type                           int64               `json:"type"`
+	Name                           string              `json:"name"`
+	SupportedEventTriggerStyleType *int64              `json:"supportedEventTriggerStyleType,omitempty"`
+	ActionFormatType               int64               `json:"actionFormatType"`
+	IndicationHeaderFormatType     int64               `json:"indicationHeaderFormatType"`
+	IndicationMessageFormatType    int64               `json:"indicationMessageFormatType"`
+	MeasurementNames               []string            `json:"measurementNames,omitempty"`
+	RanParameters                  []*E2smRanParameter `json:"ranParameters,omitempty"`
+}
+
+type E2smRanParameter struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// DecodedRanFunction is a RAN function of a node with its definition decoded, or kept raw when no decoder
+// is registered for its OID or decoding failed
+type DecodedRanFunction struct {
+	RanFunctionId       uint32                     `json:"ranFunctionId"`
+	RanFunctionRevision uint32                     `json:"ranFunctionRevision"`
+	RanFunctionOid      string                     `json:"ranFunctionOid"`
+	Definition          *E2smRanFunctionDefinition `json:"definition,omitempty"`
+	RawDefinition       string                     `json:"rawDefinition,omitempty"`
+}
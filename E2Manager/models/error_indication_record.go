@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+)
+
+type ErrorIndicationAction string
+
+const (
+	ErrorIndicationActionIgnore     ErrorIndicationAction = "ignore"
+	ErrorIndicationActionLog        ErrorIndicationAction = "log"
+	ErrorIndicationActionRevert     ErrorIndicationAction = "revert"
+	ErrorIndicationActionReset      ErrorIndicationAction = "reset"
+	ErrorIndicationActionDisconnect ErrorIndicationAction = "disconnect"
+)
+
+type ErrorIndicationRecord struct {
+	ReceivedAt        int64                 `json:"receivedAt"`
+	TransactionId     string                `json:"transactionId,omitempty"`
+	RicRequestorId    *int32                `json:"ricRequestorId,omitempty"`
+	RicInstanceId     *int32                `json:"ricInstanceId,omitempty"`
+	RanFunctionId     *int32                `json:"ranFunctionId,omitempty"`
+	Cause             string                `json:"cause,omitempty"`
+	ProcedureCode     string                `json:"procedureCode,omitempty"`
+	TriggeringMessage string                `json:"triggeringMessage,omitempty"`
+	ProcedureType     RanProcedureType      `json:"procedureType,omitempty"`
+	Action            ErrorIndicationAction `json:"action"`
+}
+
+func NewErrorIndicationRecord(message *ErrorIndicationMessage, receivedAt int64) *ErrorIndicationRecord {
+	record := &ErrorIndicationRecord{
+		ReceivedAt:    receivedAt,
+		TransactionId: message.GetTransactionId(),
+		RanFunctionId: message.GetRanFunctionId(),
+	}
+
+	if ricRequestId := message.GetRicRequestId(); ricRequestId != nil {
+		record.RicRequestorId = &ricRequestId.RicRequestorID
+		record.RicInstanceId = &ricRequestId.RicInstanceID
+	}
+
+	if cause := message.GetCause(); cause != nil {
+		record.Cause = cause.String()
+	}
+
+	if criticalityDiagnostics := message.GetCriticalityDiagnostics(); criticalityDiagnostics != nil {
+		record.ProcedureCode = criticalityDiagnostics.ProcedureCode
+		record.TriggeringMessage = criticalityDiagnostics.TriggeringMessage.String()
+	}
+
+	return record
+}
+
+type GetErrorIndicationsRequest struct {
+	RanName string
+}
+
+type ErrorIndicationsResponse []*ErrorIndicationRecord
+
+func (response ErrorIndicationsResponse) Marshal() ([]byte, error) {
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
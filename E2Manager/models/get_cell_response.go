@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+type GetCellRequest struct {
+	CellId string
+}
+
+type CellGlobalNbId struct {
+	PlmnId string `json:"plmnId"`
+	NbId   string `json:"nbId"`
+}
+
+// CellResponse identifies the RAN serving a cell
+type CellResponse struct {
+	CellId           string          `json:"cellId"`
+	RanName          string          `json:"ranName"`
+	GlobalNbId       *CellGlobalNbId `json:"globalNbId,omitempty"`
+	ConnectionStatus string          `json:"connectionStatus"`
+}
+
+func NewCellResponse(cellId string, nbIdentity *entities.NbIdentity) *CellResponse {
+	response := &CellResponse{
+		CellId:           cellId,
+		RanName:          nbIdentity.GetInventoryName(),
+		ConnectionStatus: nbIdentity.GetConnectionStatus().String(),
+	}
+
+	if globalNbId := nbIdentity.GetGlobalNbId(); globalNbId != nil {
+		response.GlobalNbId = &CellGlobalNbId{PlmnId: globalNbId.GetPlmnId(), NbId: globalNbId.GetNbId()}
+	}
+
+	return response
+}
+
+func (response *CellResponse) Marshal() ([]byte, error) {
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
@@ -0,0 +1,47 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models_test
+
+import (
+	"e2mgr/models"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellResponseMarshalSuccess(t *testing.T) {
+	nbIdentity := &entities.NbIdentity{
+		InventoryName:    "test1",
+		GlobalNbId:       &entities.GlobalNbId{PlmnId: "02f829", NbId: "4a952a0a"},
+		ConnectionStatus: entities.ConnectionStatus_CONNECTED,
+	}
+
+	data, err := models.NewCellResponse("02f8294a952a00", nbIdentity).Marshal()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"cellId":"02f8294a952a00","ranName":"test1","globalNbId":{"plmnId":"02f829","nbId":"4a952a0a"},"connectionStatus":"CONNECTED"}`, string(data))
+}
+
+func TestCellResponseMarshalWithoutGlobalNbId(t *testing.T) {
+	nbIdentity := &entities.NbIdentity{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+
+	data, err := models.NewCellResponse("02f8294a952a00", nbIdentity).Marshal()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"cellId":"02f8294a952a00","ranName":"test1","connectionStatus":"DISCONNECTED"}`, string(data))
+}
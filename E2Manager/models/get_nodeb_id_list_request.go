@@ -0,0 +1,169 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+const (
+	DefaultNodebIdListLimit = 100
+	MaxNodebIdListLimit     = 1000
+)
+
+type NodebIdListSortField string
+
+const (
+	NodebIdListSortByName             NodebIdListSortField = "name"
+	NodebIdListSortByConnectionStatus NodebIdListSortField = "connectionStatus"
+	NodebIdListSortByPlmnId           NodebIdListSortField = "plmnId"
+	NodebIdListSortByNbId             NodebIdListSortField = "nbId"
+)
+
+var NodebIdListSortFields = map[NodebIdListSortField]bool{
+	NodebIdListSortByName:             true,
+	NodebIdListSortByConnectionStatus: true,
+	NodebIdListSortByPlmnId:           true,
+	NodebIdListSortByNbId:             true,
+}
+
+// NodebIdListFields are the fields a projection may select, healthState only exists for the monitored RANs
+var NodebIdListFields = map[string]bool{
+	"inventoryName":                true,
+	"globalNbId":                   true,
+	"connectionStatus":             true,
+	"healthCheckTimestampSent":     true,
+	"healthCheckTimestampReceived": true,
+	"healthState":                  true,
+}
+
+// GetNodebIdListRequest : the filters are combined, an empty filter matches every RAN.
+// Cursor is the position after which the page starts, it is only valid with the sort it was issued for
+type GetNodebIdListRequest struct {
+	ConnectionStatuses []entities.ConnectionStatus
+	NodeType           entities.Node_Type
+	E2TAddress         string
+	PlmnId             string
+	NamePrefix         string
+	SortBy             NodebIdListSortField
+	Descending         bool
+	Cursor             *NodebIdListCursor
+	Limit              int
+	Fields             []string
+}
+
+// Matches tells whether a RAN passes the filters, nodeType and e2tAddress are the attributes kept alongside its identity
+func (request *GetNodebIdListRequest) Matches(nbIdentity *entities.NbIdentity, nodeType entities.Node_Type, e2tAddress string) bool {
+	if len(request.ConnectionStatuses) > 0 && !containsConnectionStatus(request.ConnectionStatuses, nbIdentity.GetConnectionStatus()) {
+		return false
+	}
+
+	if request.NodeType != entities.Node_UNKNOWN && request.NodeType != nodeType {
+		return false
+	}
+
+	if request.E2TAddress != "" && request.E2TAddress != e2tAddress {
+		return false
+	}
+
+	if request.PlmnId != "" && request.PlmnId != nbIdentity.GetGlobalNbId().GetPlmnId() {
+		return false
+	}
+
+	return strings.HasPrefix(nbIdentity.InventoryName, request.NamePrefix)
+}
+
+// SortKey returns the value a RAN is sorted by, RANs with the same value are ordered by name
+func (request *GetNodebIdListRequest) SortKey(nbIdentity *entities.NbIdentity) string {
+	switch request.SortBy {
+	case NodebIdListSortByConnectionStatus:
+		return nbIdentity.GetConnectionStatus().String()
+	case NodebIdListSortByPlmnId:
+		return nbIdentity.GetGlobalNbId().GetPlmnId()
+	case NodebIdListSortByNbId:
+		return nbIdentity.GetGlobalNbId().GetNbId()
+	}
+	return nbIdentity.InventoryName
+}
+
+// Less orders two RANs by sort key then by name, which is unique, so the order is stable between pages
+func (request *GetNodebIdListRequest) Less(sortKey1 string, name1 string, sortKey2 string, name2 string) bool {
+	if sortKey1 == sortKey2 {
+		sortKey1, sortKey2 = name1, name2
+	}
+
+	if request.Descending {
+		return sortKey1 > sortKey2
+	}
+	return sortKey1 < sortKey2
+}
+
+func containsConnectionStatus(connectionStatuses []entities.ConnectionStatus, connectionStatus entities.ConnectionStatus) bool {
+	for _, s := range connectionStatuses {
+		if s == connectionStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// NodebIdListCursor is the position of the last RAN of a page
+type NodebIdListCursor struct {
+	SortBy        NodebIdListSortField `json:"s"`
+	Descending    bool                 `json:"d,omitempty"`
+	SortKey       string               `json:"k"`
+	InventoryName string               `json:"n"`
+}
+
+func (cursor *NodebIdListCursor) Encode() string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeNodebIdListCursor(encoded string) (*NodebIdListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &NodebIdListCursor{}
+
+	if err = json.Unmarshal(data, cursor); err != nil {
+		return nil, err
+	}
+
+	if cursor.InventoryName == "" {
+		return nil, errors.New("cursor without inventory name")
+	}
+
+	return cursor, nil
+}
+
+// NbIdentityPage is a page of the RAN list, TotalCount is the number of RANs matching the filters
+type NbIdentityPage struct {
+	NbIdentities []*entities.NbIdentity
+	TotalCount   int
+	NextCursor   string
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models_test
+
+import (
+	"e2mgr/models"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNodebIdListRequestMatches(t *testing.T) {
+	nbIdentity := &entities.NbIdentity{InventoryName: "gnb_001", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "02f829", NbId: "001"}}
+
+	assert.True(t, (&models.GetNodebIdListRequest{}).Matches(nbIdentity, entities.Node_UNKNOWN, ""))
+	assert.True(t, (&models.GetNodebIdListRequest{
+		ConnectionStatuses: []entities.ConnectionStatus{entities.ConnectionStatus_DISCONNECTED, entities.ConnectionStatus_CONNECTED},
+		NodeType:           entities.Node_GNB,
+		E2TAddress:         "10.0.2.15:38000",
+		PlmnId:             "02f829",
+		NamePrefix:         "gnb_",
+	}).Matches(nbIdentity, entities.Node_GNB, "10.0.2.15:38000"))
+
+	assert.False(t, (&models.GetNodebIdListRequest{ConnectionStatuses: []entities.ConnectionStatus{entities.ConnectionStatus_DISCONNECTED}}).Matches(nbIdentity, entities.Node_GNB, ""))
+	assert.False(t, (&models.GetNodebIdListRequest{NodeType: entities.Node_ENB}).Matches(nbIdentity, entities.Node_GNB, ""))
+	assert.False(t, (&models.GetNodebIdListRequest{E2TAddress: "10.0.2.15:38000"}).Matches(nbIdentity, entities.Node_GNB, ""))
+	assert.False(t, (&models.GetNodebIdListRequest{PlmnId: "02f830"}).Matches(nbIdentity, entities.Node_GNB, ""))
+	assert.False(t, (&models.GetNodebIdListRequest{NamePrefix: "enb_"}).Matches(nbIdentity, entities.Node_GNB, ""))
+}
+
+func TestGetNodebIdListRequestLess(t *testing.T) {
+	request := &models.GetNodebIdListRequest{SortBy: models.NodebIdListSortByConnectionStatus}
+
+	assert.True(t, request.Less("CONNECTED", "ran2", "DISCONNECTED", "ran1"))
+	assert.True(t, request.Less("CONNECTED", "ran1", "CONNECTED", "ran2"))
+
+	request.Descending = true
+	assert.False(t, request.Less("CONNECTED", "ran2", "DISCONNECTED", "ran1"))
+	assert.True(t, request.Less("CONNECTED", "ran2", "CONNECTED", "ran1"))
+}
+
+func TestNodebIdListCursorEncodeDecode(t *testing.T) {
+	cursor := &models.NodebIdListCursor{SortBy: models.NodebIdListSortByPlmnId, Descending: true, SortKey: "02f829", InventoryName: "gnb_001"}
+
+	decoded, err := models.DecodeNodebIdListCursor(cursor.Encode())
+	assert.Nil(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestDecodeNodebIdListCursorFailure(t *testing.T) {
+	_, err := models.DecodeNodebIdListCursor("!!")
+	assert.NotNil(t, err)
+
+	_, err = models.DecodeNodebIdListCursor((&models.NodebIdListCursor{SortBy: models.NodebIdListSortByName}).Encode())
+	assert.NotNil(t, err)
+}
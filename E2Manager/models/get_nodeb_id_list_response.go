@@ -17,32 +17,125 @@
 //  This source code is part of the near-RT RIC (RAN Intelligent Controller)
 //  platform project (RICP).
 
-
 package models
 
 import (
 	"e2mgr/e2managererrors"
-	"e2mgr/utils"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+	"github.com/golang/protobuf/jsonpb"
 )
 
 type GetNodebIdListResponse struct {
-	nodebIdList []*entities.NbIdentity
+	nodebIdList  []*entities.NbIdentity
+	healthStates map[string]RanHealthState
 }
 
-func NewGetNodebIdListResponse(nodebIdList []*entities.NbIdentity) *GetNodebIdListResponse {
+// NewGetNodebIdListResponse : healthStates holds the health state of the monitored RANs, other RANs are returned without it
+func NewGetNodebIdListResponse(nodebIdList []*entities.NbIdentity, healthStates map[string]RanHealthState) *GetNodebIdListResponse {
 	return &GetNodebIdListResponse{
-		nodebIdList: nodebIdList,
+		nodebIdList:  nodebIdList,
+		healthStates: healthStates,
 	}
 }
 
 func (response *GetNodebIdListResponse) Marshal() ([]byte, error) {
-	pmList := utils.ConvertNodebIdListToProtoMessageList(response.nodebIdList)
-	result, err := utils.MarshalProtoMessageListToJsonArray(pmList)
+	nodebIds, err := marshalNbIdentities(response.nodebIdList, response.healthStates, nil)
 
 	if err != nil {
-		return nil, e2managererrors.NewInternalError();
+		return nil, err
+	}
+
+	return []byte("[" + strings.Join(nodebIds, ",") + "]"), nil
+}
+
+type GetNodebIdListPageResponse struct {
+	page         *NbIdentityPage
+	healthStates map[string]RanHealthState
+	fields       []string
+}
+
+// NewGetNodebIdListPageResponse : fields is the projection of the RAN identities, all the fields are returned when it is empty
+func NewGetNodebIdListPageResponse(page *NbIdentityPage, healthStates map[string]RanHealthState, fields []string) *GetNodebIdListPageResponse {
+	return &GetNodebIdListPageResponse{
+		page:         page,
+		healthStates: healthStates,
+		fields:       fields,
+	}
+}
+
+func (response *GetNodebIdListPageResponse) Marshal() ([]byte, error) {
+	nodebIds, err := marshalNbIdentities(response.page.NbIdentities, response.healthStates, response.fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := "{\"nodebs\":[" + strings.Join(nodebIds, ",") + "],\"totalCount\":" + strconv.Itoa(response.page.TotalCount)
+
+	if response.page.NextCursor != "" {
+		data += ",\"nextCursor\":\"" + response.page.NextCursor + "\""
+	}
+
+	return []byte(data + "}"), nil
+}
+
+func marshalNbIdentities(nodebIdList []*entities.NbIdentity, healthStates map[string]RanHealthState, fields []string) ([]string, error) {
+	m := jsonpb.Marshaler{}
+	nodebIds := make([]string, len(nodebIdList))
+
+	for i, nbIdentity := range nodebIdList {
+		nodebId, err := m.MarshalToString(nbIdentity)
+
+		if err != nil {
+			return nil, e2managererrors.NewInternalError()
+		}
+
+		if healthState, ok := healthStates[nbIdentity.InventoryName]; ok {
+			nodebId = appendHealthState(nodebId, healthState)
+		}
+
+		if len(fields) > 0 {
+			if nodebId, err = projectFields(nodebId, fields); err != nil {
+				return nil, e2managererrors.NewInternalError()
+			}
+		}
+
+		nodebIds[i] = nodebId
+	}
+
+	return nodebIds, nil
+}
+
+func projectFields(nodebId string, fields []string) (string, error) {
+	all := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal([]byte(nodebId), &all); err != nil {
+		return "", err
+	}
+
+	projected := make(map[string]json.RawMessage, len(fields))
+
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+
+	data, err := json.Marshal(projected)
+	return string(data), err
+}
+
+func appendHealthState(nodebId string, healthState RanHealthState) string {
+	field := fmt.Sprintf("\"healthState\":\"%s\"}", healthState)
+
+	if nodebId == "{}" {
+		return "{" + field
 	}
 
-	return []byte(result), nil
+	return strings.TrimSuffix(nodebId, "}") + "," + field
 }
@@ -32,7 +32,50 @@ func TestGetNodebIdListResponseMarshalSuccess(t *testing.T) {
 		{InventoryName: "test2", GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId2", NbId: "nbId2"}},
 	}
 
-	nodebIdListResponse := models.NewGetNodebIdListResponse(nodebIdList)
+	nodebIdListResponse := models.NewGetNodebIdListResponse(nodebIdList, nil)
 	_, err := nodebIdListResponse.Marshal()
 	assert.Nil(t, err)
 }
+
+func TestGetNodebIdListResponseMarshalWithHealthState(t *testing.T) {
+	nodebIdList := []*entities.NbIdentity{
+		{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED},
+		{InventoryName: "test2", ConnectionStatus: entities.ConnectionStatus_DISCONNECTED},
+	}
+	healthStates := map[string]models.RanHealthState{"test1": models.RanHealthStateStale}
+
+	nodebIdListResponse := models.NewGetNodebIdListResponse(nodebIdList, healthStates)
+	data, err := nodebIdListResponse.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "[{\"inventoryName\":\"test1\",\"connectionStatus\":\"CONNECTED\",\"healthState\":\"STALE\"},{\"inventoryName\":\"test2\",\"connectionStatus\":\"DISCONNECTED\"}]", string(data))
+}
+
+func TestGetNodebIdListResponseMarshalEmpty(t *testing.T) {
+	data, err := models.NewGetNodebIdListResponse([]*entities.NbIdentity{}, nil).Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestGetNodebIdListPageResponseMarshal(t *testing.T) {
+	page := &models.NbIdentityPage{
+		NbIdentities: []*entities.NbIdentity{{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED}},
+		TotalCount:   2,
+		NextCursor:   "abc",
+	}
+
+	data, err := models.NewGetNodebIdListPageResponse(page, nil, nil).Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"nodebs\":[{\"inventoryName\":\"test1\",\"connectionStatus\":\"CONNECTED\"}],\"totalCount\":2,\"nextCursor\":\"abc\"}", string(data))
+}
+
+func TestGetNodebIdListPageResponseMarshalLastPageWithFields(t *testing.T) {
+	page := &models.NbIdentityPage{
+		NbIdentities: []*entities.NbIdentity{{InventoryName: "test1", ConnectionStatus: entities.ConnectionStatus_CONNECTED, GlobalNbId: &entities.GlobalNbId{PlmnId: "plmnId1", NbId: "nbId1"}}},
+		TotalCount:   1,
+	}
+	healthStates := map[string]models.RanHealthState{"test1": models.RanHealthStateStale}
+
+	data, err := models.NewGetNodebIdListPageResponse(page, healthStates, []string{"inventoryName", "healthState"}).Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"nodebs\":[{\"healthState\":\"STALE\",\"inventoryName\":\"test1\"}],\"totalCount\":1}", string(data))
+}
@@ -21,5 +21,6 @@
 package models
 
 type GetNodebRequest struct {
-	RanName string
+	RanName            string
+	DecodeRanFunctions bool
 }
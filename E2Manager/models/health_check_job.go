@@ -0,0 +1,64 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+)
+
+type HealthCheckRanStatus string
+
+const (
+	HealthCheckQuerySent      HealthCheckRanStatus = "QUERY_SENT"
+	HealthCheckSendFailed     HealthCheckRanStatus = "SEND_FAILED"
+	HealthCheckUpdateReceived HealthCheckRanStatus = "UPDATE_RECEIVED"
+	HealthCheckTimedOut       HealthCheckRanStatus = "TIMED_OUT"
+)
+
+// HealthCheckRanResult is the outcome of the RIC Service Query a health check job sent to a RAN, times are in nanoseconds since epoch
+type HealthCheckRanResult struct {
+	RanName       string               `json:"ranName"`
+	Status        HealthCheckRanStatus `json:"status"`
+	TransactionId string               `json:"transactionId,omitempty"`
+	SentAt        int64                `json:"sentAt,omitempty"`
+	Deadline      int64                `json:"deadline,omitempty"`
+	ReceivedAt    int64                `json:"receivedAt,omitempty"`
+	LatencyMs     int64                `json:"latencyMs,omitempty"`
+}
+
+type HealthCheckJob struct {
+	JobId     string                  `json:"jobId"`
+	CreatedAt int64                   `json:"createdAt"`
+	Rans      []*HealthCheckRanResult `json:"rans"`
+}
+
+func (job *HealthCheckJob) Marshal() ([]byte, error) {
+	data, err := json.Marshal(job)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
+
+type GetHealthCheckJobRequest struct {
+	JobId string
+}
@@ -25,12 +25,14 @@ import (
 )
 
 type HealthCheckSuccessResponse struct {
-	Message string   `json:"message"`
+	Message string `json:"message"`
+	JobId   string `json:"jobId,omitempty"`
 }
 
-func NewHealthCheckSuccessResponse(message string) *HealthCheckSuccessResponse {
+func NewHealthCheckSuccessResponse(message string, jobId string) *HealthCheckSuccessResponse {
 	return &HealthCheckSuccessResponse{
 		Message: message,
+		JobId:   jobId,
 	}
 }
 
@@ -30,10 +30,11 @@ func TestHealthCheckResponseMarshalSuccess(t *testing.T) {
 	healthMsg := "OK"
 	expectedResponse := models.HealthCheckSuccessResponse{
 		Message: healthMsg,
+		JobId:   "1",
 	}
 	expectedData, _ := json.Marshal(expectedResponse)
 
-	healthCheckSuccessResponse := models.NewHealthCheckSuccessResponse(healthMsg)
+	healthCheckSuccessResponse := models.NewHealthCheckSuccessResponse(healthMsg, "1")
 	resp, err := healthCheckSuccessResponse.Marshal()
 	assert.Nil(t, err)
 	assert.Equal(t, expectedData, resp)
@@ -0,0 +1,33 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+// LeaderLease is the record the E2 Manager replicas compete for, ExpiresAt is in unix milliseconds by the clock of the
+// holder. The other replicas only use it to notice that the lease was renewed, never compare it with their own clock
+type LeaderLease struct {
+	HolderId  string `json:"holderId"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+func NewLeaderLease(holderId string, expiresAt int64) *LeaderLease {
+	return &LeaderLease{
+		HolderId:  holderId,
+		ExpiresAt: expiresAt,
+	}
+}
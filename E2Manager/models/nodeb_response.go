@@ -21,12 +21,14 @@ package models
 
 import (
 	"e2mgr/e2managererrors"
+	"encoding/json"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 	"github.com/golang/protobuf/jsonpb"
 )
 
 type NodebResponse struct {
-	nodebInfo *entities.NodebInfo
+	nodebInfo    *entities.NodebInfo
+	ranFunctions []*DecodedRanFunction
 }
 
 func NewNodebResponse(nodebInfo *entities.NodebInfo) *NodebResponse {
@@ -35,6 +37,14 @@ func NewNodebResponse(nodebInfo *entities.NodebInfo) *NodebResponse {
 	}
 }
 
+// NewNodebResponseWithRanFunctions returns a nodeb response which also carries the decoded RAN function definitions
+func NewNodebResponseWithRanFunctions(nodebInfo *entities.NodebInfo, ranFunctions []*DecodedRanFunction) *NodebResponse {
+	return &NodebResponse{
+		nodebInfo:    nodebInfo,
+		ranFunctions: ranFunctions,
+	}
+}
+
 func (response *NodebResponse) Marshal() ([]byte, error) {
 	m := jsonpb.Marshaler{}
 	result, err := m.MarshalToString(response.nodebInfo)
@@ -43,5 +53,21 @@ func (response *NodebResponse) Marshal() ([]byte, error) {
 		return nil, e2managererrors.NewInternalError()
 	}
 
-	return []byte(result), nil
+	if response.ranFunctions == nil {
+		return []byte(result), nil
+	}
+
+	ranFunctions, err := json.Marshal(response.ranFunctions)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	// the decoded definitions are appended to the nodeb object so its fields keep the jsonpb order
+	separator := ","
+	if result == "{}" {
+		separator = ""
+	}
+
+	return []byte(result[:len(result)-1] + separator + `"ranFunctionDefinitions":` + string(ranFunctions) + "}"), nil
 }
@@ -44,3 +44,21 @@ func TestNodebIdResponseMarshalSuccess(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, []byte(expectedData), resp)
 }
+
+func TestNodebResponseWithRanFunctionsMarshalSuccess(t *testing.T) {
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_GNB}
+	ranFunctions := []*models.DecodedRanFunction{{RanFunctionId: 1, RanFunctionOid: "1.2.3", RawDefinition: "334455"}}
+	response := models.NewNodebResponseWithRanFunctions(nodebInfo, ranFunctions)
+	resp, err := response.Marshal()
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"ranName":"test","nodeType":"GNB","ranFunctionDefinitions":[{"ranFunctionId":1,"ranFunctionRevision":0,"ranFunctionOid":"1.2.3","rawDefinition":"334455"}]}`, string(resp))
+}
+
+func TestNodebResponseWithNoRanFunctionsMarshalSuccess(t *testing.T) {
+	response := models.NewNodebResponseWithRanFunctions(&entities.NodebInfo{}, []*models.DecodedRanFunction{})
+	resp, err := response.Marshal()
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"ranFunctionDefinitions":[]}`, string(resp))
+}
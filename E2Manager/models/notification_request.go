@@ -32,6 +32,7 @@ type NotificationRequest struct {
 	StartTime     time.Time
 	TransactionId []byte
 	msgSrc unsafe.Pointer
+	RequestId     string
 }
 
 func NewNotificationRequest(ranName string, payload []byte, startTime time.Time, transactionId []byte, msgSrc unsafe.Pointer) *NotificationRequest {
@@ -42,6 +43,7 @@ func NewNotificationRequest(ranName string, payload []byte, startTime time.Time,
 		startTime,
 		transactionId,
 		msgSrc,
+		"",
 	}
 }
 
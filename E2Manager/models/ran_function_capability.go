@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+type GetRanFunctionNodesRequest struct {
+	RanFunctionOid     string
+	ConnectionStatuses []entities.ConnectionStatus
+}
+
+// RanFunctionNode is a RAN function of a node, as indexed from its last E2 Setup or RIC Service Update
+type RanFunctionNode struct {
+	RanName             string `json:"ranName"`
+	RanFunctionId       uint32 `json:"ranFunctionId"`
+	RanFunctionRevision uint32 `json:"ranFunctionRevision"`
+	ConnectionStatus    string `json:"connectionStatus"`
+}
+
+// RanFunctionCapability lists the nodes supporting a RAN function OID
+type RanFunctionCapability struct {
+	RanFunctionOid string             `json:"ranFunctionOid"`
+	Nodes          []*RanFunctionNode `json:"nodes"`
+}
+
+func (response *RanFunctionCapability) Marshal() ([]byte, error) {
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
+
+type RanFunctionCapabilitiesResponse []*RanFunctionCapability
+
+func (response RanFunctionCapabilitiesResponse) Marshal() ([]byte, error) {
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
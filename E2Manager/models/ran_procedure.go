@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
+)
+
+type RanProcedureType string
+
+const (
+	E2SetupProcedure            RanProcedureType = "E2_SETUP"
+	RicServiceUpdateProcedure   RanProcedureType = "RIC_SERVICE_UPDATE"
+	E2NodeConfigUpdateProcedure RanProcedureType = "E2_NODE_CONFIG_UPDATE"
+	E2ResetProcedure            RanProcedureType = "E2_RESET"
+	RicE2ResetProcedure         RanProcedureType = "RIC_E2_RESET"
+	RicServiceQueryProcedure    RanProcedureType = "RIC_SERVICE_QUERY"
+	X2ResetProcedure            RanProcedureType = "X2_RESET"
+)
+
+var ranProcedureTypesByCode = map[string]RanProcedureType{
+	ProcedureCode_id_E2setup:                   E2SetupProcedure,
+	ProcedureCode_id_RICserviceUpdate:          RicServiceUpdateProcedure,
+	ProcedureCode_id_E2nodeConfigurationUpdate: E2NodeConfigUpdateProcedure,
+	ProcedureCode_id_Reset:                     E2ResetProcedure,
+	ProcedureCode_id_RICserviceQuery:           RicServiceQueryProcedure,
+}
+
+// GetRanProcedureType maps an E2AP procedure code to the tracked procedure type, the Reset code maps to the E2 Reset
+// initiated by the RAN, the E2 Reset initiated by the RIC is tracked as RicE2ResetProcedure
+func GetRanProcedureType(procedureCode string) (RanProcedureType, bool) {
+	procedureType, ok := ranProcedureTypesByCode[procedureCode]
+	return procedureType, ok
+}
+
+type RanProcedureState string
+
+const (
+	RanProcedureOngoing   RanProcedureState = "ONGOING"
+	RanProcedureCompleted RanProcedureState = "COMPLETED"
+	RanProcedureFailed    RanProcedureState = "FAILED"
+	RanProcedureTimedOut  RanProcedureState = "TIMED_OUT"
+)
+
+type RanProcedure struct {
+	Type          RanProcedureType  `json:"type"`
+	TransactionId string            `json:"transactionId"`
+	State         RanProcedureState `json:"state"`
+	StartTime     int64             `json:"startTime"`
+	EndTime       int64             `json:"endTime,omitempty"`
+	// RAN functions as they were before a RIC Service Update, kept for reverting it
+	PreviousRanFunctions []*entities.RanFunction `json:"previousRanFunctions,omitempty"`
+}
+
+func (p *RanProcedure) IsOngoing() bool {
+	return p.State == RanProcedureOngoing
+}
+
+// RanProcedures holds the latest procedure of each type of a RAN, and which of them was the last to start
+type RanProcedures struct {
+	RanName    string                             `json:"ranName"`
+	Procedures map[RanProcedureType]*RanProcedure `json:"procedures"`
+	LastType   RanProcedureType                   `json:"lastType,omitempty"`
+}
+
+func NewRanProcedures(ranName string) *RanProcedures {
+	return &RanProcedures{
+		RanName:    ranName,
+		Procedures: make(map[RanProcedureType]*RanProcedure),
+	}
+}
+
+func (p *RanProcedures) GetLast() *RanProcedure {
+	return p.Procedures[p.LastType]
+}
+
+func (p *RanProcedures) GetByTransactionId(transactionId string) *RanProcedure {
+	if last := p.GetLast(); last != nil && last.TransactionId == transactionId {
+		return last
+	}
+
+	for _, procedure := range p.Procedures {
+		if procedure.TransactionId == transactionId {
+			return procedure
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models_test
+
+import (
+	"e2mgr/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRanProcedureType(t *testing.T) {
+	procedureType, ok := models.GetRanProcedureType(models.ProcedureCode_id_E2setup)
+	assert.True(t, ok)
+	assert.Equal(t, models.E2SetupProcedure, procedureType)
+
+	procedureType, ok = models.GetRanProcedureType(models.ProcedureCode_id_RICserviceUpdate)
+	assert.True(t, ok)
+	assert.Equal(t, models.RicServiceUpdateProcedure, procedureType)
+
+	_, ok = models.GetRanProcedureType("2")
+	assert.False(t, ok)
+}
+
+func TestRanProceduresGetByTransactionId(t *testing.T) {
+	procedures := models.NewRanProcedures("test")
+	assert.Nil(t, procedures.GetLast())
+
+	procedures.Procedures[models.E2SetupProcedure] = &models.RanProcedure{Type: models.E2SetupProcedure, TransactionId: "1", State: models.RanProcedureCompleted}
+	procedures.Procedures[models.RicServiceUpdateProcedure] = &models.RanProcedure{Type: models.RicServiceUpdateProcedure, TransactionId: "2", State: models.RanProcedureOngoing}
+	procedures.LastType = models.RicServiceUpdateProcedure
+
+	assert.Equal(t, models.RicServiceUpdateProcedure, procedures.GetLast().Type)
+	assert.True(t, procedures.GetLast().IsOngoing())
+	assert.Equal(t, models.E2SetupProcedure, procedures.GetByTransactionId("1").Type)
+	assert.Nil(t, procedures.GetByTransactionId("3"))
+}
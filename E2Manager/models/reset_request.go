@@ -23,4 +23,6 @@ package models
 type ResetRequest struct {
 	RanName string
 	Cause   string `json:"cause"`
+	// Async X2 Reset requests are answered with a job id instead of waiting for the RAN response
+	Async bool `json:"async,omitempty"`
 }
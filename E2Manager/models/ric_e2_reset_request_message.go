@@ -0,0 +1,131 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"encoding/xml"
+)
+
+// Used as default cause of a RIC initiated E2 Reset
+const (
+	E2ResetOmInterventionCause = "misc:om-intervention"
+)
+
+var e2ResetKnownCauses = map[string]Cause{
+	"misc:control-processing-overload": {Misc: &CauseMisc{ControlProcessingOverload: &struct{}{}}},
+	"misc:hardware-failure":            {Misc: &CauseMisc{HardwareFailure: &struct{}{}}},
+	E2ResetOmInterventionCause:         {Misc: &CauseMisc{OmIntervention: &struct{}{}}},
+	"misc:unspecified":                 {Misc: &CauseMisc{Unspecified: &struct{}{}}},
+
+	"protocol:transfer-syntax-error":                             {Protocol: &CauseProtocol{TransferSyntaxError: &struct{}{}}},
+	"protocol:abstract-syntax-error-reject":                      {Protocol: &CauseProtocol{AbstractSyntaxErrorReject: &struct{}{}}},
+	"protocol:abstract-syntax-error-ignore-and-notify":           {Protocol: &CauseProtocol{AbstractSyntaxErrorIgnoreAndNotify: &struct{}{}}},
+	"protocol:message-not-compatible-with-receiver-state":        {Protocol: &CauseProtocol{MessageNotCompatibleWithReceiverState: &struct{}{}}},
+	"protocol:semantic-error":                                    {Protocol: &CauseProtocol{SemanticError: &struct{}{}}},
+	"protocol:abstract-syntax-error-falsely-constructed-message": {Protocol: &CauseProtocol{AbstractSyntaxErrorFalselyConstructedMessage: &struct{}{}}},
+	"protocol:unspecified":                                       {Protocol: &CauseProtocol{Unspecified: &struct{}{}}},
+
+	"transport:transport-resource-unavailable": {Transport: &CauseTransport{TransportResourceUnavailable: &struct{}{}}},
+	"transport:unspecified":                    {Transport: &CauseTransport{Unspecified: &struct{}{}}},
+
+	"ricService:function-not-required": {RicService: &CauseRicService{FunctionNotRequired: &struct{}{}}},
+	"ricService:excessive-functions":   {RicService: &CauseRicService{ExcessiveFunctions: &struct{}{}}},
+	"ricService:ric-resource-limit":    {RicService: &CauseRicService{RicResourceLimit: &struct{}{}}},
+}
+
+// GetE2ResetCause maps a northbound cause of the form "group:value" to its E2AP Cause
+func GetE2ResetCause(cause string) (Cause, bool) {
+	if cause == "" {
+		cause = E2ResetOmInterventionCause
+	}
+
+	e2Cause, ok := e2ResetKnownCauses[cause]
+	return e2Cause, ok
+}
+
+type RicE2ResetRequestIEs struct {
+	Text        string `xml:",chardata"`
+	ID          string `xml:"id"`
+	Criticality struct {
+		Text   string    `xml:",chardata"`
+		Reject *struct{} `xml:"reject"`
+		Ignore *struct{} `xml:"ignore"`
+	} `xml:"criticality"`
+	Value interface{} `xml:"value"`
+}
+
+type RicE2ResetRequestTransactionID struct {
+	Text          string `xml:",chardata"`
+	TransactionID string `xml:"TransactionID"`
+}
+
+type RicE2ResetRequestCause struct {
+	Text  string `xml:",chardata"`
+	Cause Cause  `xml:"Cause"`
+}
+
+type RicE2ResetRequestInitiatingMessage struct {
+	Text          string `xml:",chardata"`
+	ProcedureCode string `xml:"procedureCode"`
+	Criticality   struct {
+		Text   string `xml:",chardata"`
+		Reject string `xml:"reject"`
+	} `xml:"criticality"`
+	Value struct {
+		Text         string `xml:",chardata"`
+		ResetRequest struct {
+			Text        string `xml:",chardata"`
+			ProtocolIEs struct {
+				Text            string                 `xml:",chardata"`
+				ResetRequestIEs []RicE2ResetRequestIEs `xml:"ResetRequestIEs"`
+			} `xml:"protocolIEs"`
+		} `xml:"ResetRequest"`
+	} `xml:"value"`
+}
+
+type RicE2ResetRequestE2APPDU struct {
+	XMLName           xml.Name                           `xml:"E2AP-PDU"`
+	Text              string                             `xml:",chardata"`
+	InitiatingMessage RicE2ResetRequestInitiatingMessage `xml:"initiatingMessage"`
+}
+
+type RicE2ResetRequestMessage struct {
+	XMLName xml.Name                 `xml:"RicE2ResetRequestMessage"`
+	Text    string                   `xml:",chardata"`
+	E2APPDU RicE2ResetRequestE2APPDU `xml:"E2AP-PDU"`
+}
+
+func NewRicE2ResetRequestMessage(transactionId string, cause Cause) RicE2ResetRequestMessage {
+	txIE := RicE2ResetRequestIEs{
+		ID:    ProtocolIE_ID_id_TransactionID,
+		Value: RicE2ResetRequestTransactionID{TransactionID: transactionId},
+	}
+	txIE.Criticality.Reject = &struct{}{}
+
+	causeIE := RicE2ResetRequestIEs{
+		ID:    ProtocolIE_ID_id_Cause,
+		Value: RicE2ResetRequestCause{Cause: cause},
+	}
+	causeIE.Criticality.Ignore = &struct{}{}
+
+	initiatingMessage := RicE2ResetRequestInitiatingMessage{ProcedureCode: ProcedureCode_id_Reset}
+	initiatingMessage.Value.ResetRequest.ProtocolIEs.ResetRequestIEs = []RicE2ResetRequestIEs{txIE, causeIE}
+
+	return RicE2ResetRequestMessage{E2APPDU: RicE2ResetRequestE2APPDU{InitiatingMessage: initiatingMessage}}
+}
@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/utils"
+	"encoding/xml"
+)
+
+var ricErrorIndicationEmptyTagsToReplaceToSelfClosingTags = []string{"reject", "ignore", "notify", "protocolIEs",
+	"initiating-message", "successful-outcome", "unsuccessful-outcome"}
+
+type RicErrorIndicationIEs struct {
+	Text        string `xml:",chardata"`
+	ID          string `xml:"id"`
+	Criticality struct {
+		Text   string    `xml:",chardata"`
+		Reject *struct{} `xml:"reject"`
+		Ignore *struct{} `xml:"ignore"`
+	} `xml:"criticality"`
+	Value interface{} `xml:"value"`
+}
+
+type RicErrorIndicationTransactionID struct {
+	Text          string `xml:",chardata"`
+	TransactionID string `xml:"TransactionID"`
+}
+
+type RicErrorIndicationCause struct {
+	Text  string `xml:",chardata"`
+	Cause Cause  `xml:"Cause"`
+}
+
+type RicErrorIndicationCriticalityDiagnostics struct {
+	Text                   string                 `xml:",chardata"`
+	CriticalityDiagnostics CriticalityDiagnostics `xml:"CriticalityDiagnostics"`
+}
+
+type RicErrorIndicationInitiatingMessage struct {
+	Text          string `xml:",chardata"`
+	ProcedureCode string `xml:"procedureCode"`
+	Criticality   struct {
+		Text   string    `xml:",chardata"`
+		Ignore *struct{} `xml:"ignore"`
+	} `xml:"criticality"`
+	Value struct {
+		Text            string `xml:",chardata"`
+		ErrorIndication struct {
+			Text        string `xml:",chardata"`
+			ProtocolIEs struct {
+				Text               string                  `xml:",chardata"`
+				ErrorIndicationIEs []RicErrorIndicationIEs `xml:"ErrorIndication-IEs"`
+			} `xml:"protocolIEs"`
+		} `xml:"ErrorIndication"`
+	} `xml:"value"`
+}
+
+type RicErrorIndicationE2APPDU struct {
+	XMLName           xml.Name                            `xml:"E2AP-PDU"`
+	Text              string                              `xml:",chardata"`
+	InitiatingMessage RicErrorIndicationInitiatingMessage `xml:"initiatingMessage"`
+}
+
+type RicErrorIndicationMessage struct {
+	XMLName xml.Name                  `xml:"RicErrorIndicationMessage"`
+	Text    string                    `xml:",chardata"`
+	E2APPDU RicErrorIndicationE2APPDU `xml:"E2AP-PDU"`
+	cause   Cause
+}
+
+func NewTransferSyntaxErrorCause() Cause {
+	return Cause{Protocol: &CauseProtocol{TransferSyntaxError: &struct{}{}}}
+}
+
+func NewAbstractSyntaxErrorRejectCause() Cause {
+	return Cause{Protocol: &CauseProtocol{AbstractSyntaxErrorReject: &struct{}{}}}
+}
+
+func NewMessageNotCompatibleWithReceiverStateCause() Cause {
+	return Cause{Protocol: &CauseProtocol{MessageNotCompatibleWithReceiverState: &struct{}{}}}
+}
+
+// NewCriticalityDiagnostics describes a rejected initiating message of the procedure. The transaction id is optional
+func NewCriticalityDiagnostics(procedureCode string, transactionId string) *CriticalityDiagnostics {
+	criticalityDiagnostics := &CriticalityDiagnostics{
+		ProcedureCode: procedureCode,
+		TransactionID: transactionId,
+	}
+	criticalityDiagnostics.TriggeringMessage.InitiatingMessage = &struct{}{}
+	criticalityDiagnostics.ProcedureCriticality.Reject = &struct{}{}
+	return criticalityDiagnostics
+}
+
+// NewRicErrorIndicationMessage builds the ERROR INDICATION sent by the RIC. The TransactionID and CriticalityDiagnostics
+// IEs are optional and omitted when empty
+func NewRicErrorIndicationMessage(transactionId string, cause Cause, criticalityDiagnostics *CriticalityDiagnostics) *RicErrorIndicationMessage {
+	var ies []RicErrorIndicationIEs
+
+	if transactionId != "" {
+		txIE := RicErrorIndicationIEs{
+			ID:    ProtocolIE_ID_id_TransactionID,
+			Value: RicErrorIndicationTransactionID{TransactionID: transactionId},
+		}
+		txIE.Criticality.Reject = &struct{}{}
+		ies = append(ies, txIE)
+	}
+
+	causeIE := RicErrorIndicationIEs{
+		ID:    ProtocolIE_ID_id_Cause,
+		Value: RicErrorIndicationCause{Cause: cause},
+	}
+	causeIE.Criticality.Ignore = &struct{}{}
+	ies = append(ies, causeIE)
+
+	if criticalityDiagnostics != nil {
+		criticalityDiagnosticsIE := RicErrorIndicationIEs{
+			ID:    ProtocolIE_ID_id_CriticalityDiagnostics,
+			Value: RicErrorIndicationCriticalityDiagnostics{CriticalityDiagnostics: *criticalityDiagnostics},
+		}
+		criticalityDiagnosticsIE.Criticality.Ignore = &struct{}{}
+		ies = append(ies, criticalityDiagnosticsIE)
+	}
+
+	initiatingMessage := RicErrorIndicationInitiatingMessage{ProcedureCode: ProcedureCode_id_ErrorIndication}
+	initiatingMessage.Criticality.Ignore = &struct{}{}
+	initiatingMessage.Value.ErrorIndication.ProtocolIEs.ErrorIndicationIEs = ies
+
+	return &RicErrorIndicationMessage{
+		E2APPDU: RicErrorIndicationE2APPDU{InitiatingMessage: initiatingMessage},
+		cause:   cause,
+	}
+}
+
+func (m *RicErrorIndicationMessage) GetCause() Cause {
+	return m.cause
+}
+
+// Marshal encodes the E2AP-PDU the way the E2 Termination expects it, with self closing empty tags
+func (m *RicErrorIndicationMessage) Marshal() ([]byte, error) {
+	payload, err := xml.Marshal(m.E2APPDU)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyTags := append([]string{}, ricErrorIndicationEmptyTagsToReplaceToSelfClosingTags...)
+	if causeValue := m.cause.ValueName(); causeValue != "" {
+		emptyTags = append(emptyTags, causeValue)
+	}
+
+	return utils.ReplaceEmptyTagsWithSelfClosing(payload, emptyTags), nil
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models_test
+
+import (
+	"e2mgr/models"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRicErrorIndicationMessageRoundTrip(t *testing.T) {
+	cause := models.NewMessageNotCompatibleWithReceiverStateCause()
+	message := models.NewRicErrorIndicationMessage("5", cause, models.NewCriticalityDiagnostics(models.ProcedureCode_id_RICserviceUpdate, "5"))
+
+	payload, err := message.Marshal()
+	assert.Nil(t, err)
+	assert.Contains(t, string(payload), "<message-not-compatible-with-receiver-state/>")
+	assert.Contains(t, string(payload), "<initiating-message/>")
+
+	errorIndication := &models.ErrorIndicationMessage{}
+	err = xml.Unmarshal(payload, &errorIndication.E2APPDU)
+	assert.Nil(t, err)
+	assert.Equal(t, models.ProcedureCode_id_ErrorIndication, errorIndication.E2APPDU.InitiatingMessage.ProcedureCode)
+	assert.Equal(t, "5", errorIndication.GetTransactionId())
+	assert.Equal(t, "protocol/message-not-compatible-with-receiver-state", errorIndication.GetCause().String())
+	procedureType, ok := errorIndication.GetProcedureType()
+	assert.True(t, ok)
+	assert.Equal(t, models.RicServiceUpdateProcedure, procedureType)
+	assert.Equal(t, "initiating-message", errorIndication.GetCriticalityDiagnostics().TriggeringMessage.String())
+}
+
+func TestRicErrorIndicationMessageWithoutOptionalIEs(t *testing.T) {
+	message := models.NewRicErrorIndicationMessage("", models.NewTransferSyntaxErrorCause(), nil)
+
+	payload, err := message.Marshal()
+	assert.Nil(t, err)
+	assert.Equal(t, "<E2AP-PDU><initiatingMessage><procedureCode>2</procedureCode><criticality><ignore/></criticality><value><ErrorIndication><protocolIEs>"+
+		"<ErrorIndication-IEs><id>1</id><criticality><ignore/></criticality><value><Cause><protocol><transfer-syntax-error/></protocol></Cause></value></ErrorIndication-IEs>"+
+		"</protocolIEs></ErrorIndication></value></initiatingMessage></E2AP-PDU>", string(payload))
+}
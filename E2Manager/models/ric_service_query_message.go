@@ -20,9 +20,6 @@ package models
 
 import (
 	"encoding/xml"
-	"math/rand"
-	"strconv"
-	"time"
 
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 )
@@ -101,13 +98,12 @@ type RICServiceQueryMessage struct {
 	E2APPDU RicServiceQueryE2APPDU `xml:"E2AP-PDU"`
 }
 
-func NewRicServiceQueryMessage(ranFunctions []*entities.RanFunction) RICServiceQueryMessage {
-	rand.Seed(time.Now().Unix())
-
+// NewRicServiceQueryMessage builds a RIC Service Query, transactionId is an E2AP TransactionID (0..255)
+func NewRicServiceQueryMessage(ranFunctions []*entities.RanFunction, transactionId string) RICServiceQueryMessage {
 	txIE := RICServiceQueryIEs{
 		Id: ProtocolIE_ID_id_TransactionID,
 		Value: RICServiceQueryTransactionID{
-			TransactionID: strconv.FormatUint(rand.Uint64(), 10),
+			TransactionID: transactionId,
 		},
 	}
 
@@ -149,3 +145,12 @@ func NewRicServiceQueryMessage(ranFunctions []*entities.RanFunction) RICServiceQ
 
 	return RICServiceQueryMessage{E2APPDU: RicServiceQueryE2APPDU{InitiatingMessage: initiatingMessage}}
 }
+
+func (m *RICServiceQueryMessage) GetTransactionId() string {
+	for _, ie := range m.E2APPDU.InitiatingMessage.Value.RICServiceQuery.ProtocolIEs.RICServiceQueryIEs {
+		if transactionId, ok := ie.Value.(RICServiceQueryTransactionID); ok {
+			return transactionId.TransactionID
+		}
+	}
+	return ""
+}
@@ -46,7 +46,7 @@ func getTestRicServiceQueryRanFunctions(t *testing.T) []*entities.RanFunction {
 func TestRicServiceQueryMessageSuccess(t *testing.T) {
 	ranFunctionList := getTestRicServiceQueryRanFunctions(t)
 
-	serviceQuery := models.NewRicServiceQueryMessage(ranFunctionList)
+	serviceQuery := models.NewRicServiceQueryMessage(ranFunctionList, "1")
 	initMsg := serviceQuery.E2APPDU.InitiatingMessage
 	assert.Equal(t, models.ProcedureCode_id_RICserviceQuery, initMsg.ProcedureCode)
 	assert.Equal(t, models.ProtocolIE_ID_id_TransactionID, initMsg.Value.RICServiceQuery.ProtocolIEs.RICServiceQueryIEs[0].Id)
@@ -56,7 +56,8 @@ func TestRicServiceQueryMessageSuccess(t *testing.T) {
 
 func TestTransactionIdServiceQuery(t *testing.T) {
 	ranFunctionList := getTestRicServiceQueryRanFunctions(t)
-	serviceQuery := models.NewRicServiceQueryMessage(ranFunctionList)
+	serviceQuery := models.NewRicServiceQueryMessage(ranFunctionList, "255")
 	txIE := serviceQuery.E2APPDU.InitiatingMessage.Value.RICServiceQuery.ProtocolIEs.RICServiceQueryIEs[0].Value.(models.RICServiceQueryTransactionID)
-	assert.NotEmpty(t, txIE.TransactionID, "transaction ID should not be empty")
+	assert.Equal(t, "255", txIE.TransactionID)
+	assert.Equal(t, "255", serviceQuery.GetTransactionId())
 }
@@ -0,0 +1,62 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+)
+
+// RicServiceQueryRecord describes a RIC Service Query which is waiting for the RIC Service Update of the E2 node
+type RicServiceQueryRecord struct {
+	RanName       string `json:"ranName"`
+	TransactionId string `json:"transactionId"`
+	SentAt        int64  `json:"sentAt"`
+	Deadline      int64  `json:"deadline"`
+}
+
+func (record *RicServiceQueryRecord) Marshal() ([]byte, error) {
+	data, err := json.Marshal(record)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
+
+type RicServiceQueryRequest struct {
+	RanName string
+}
+
+type GetRicServiceQueryReportRequest struct {
+}
+
+// RicServiceQueryReportResponse lists the RANs which did not answer a RIC Service Query before its deadline
+type RicServiceQueryReportResponse []*RicServiceQueryRecord
+
+func (response RicServiceQueryReportResponse) Marshal() ([]byte, error) {
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
@@ -42,6 +42,42 @@ type RICserviceUpdateAcknowledgeProtocolIESingleContainer struct {
 	} `xml:"value"`
 }
 
+// RicServiceRejectedRANFunctionIDItem is encoded as a RANfunctionIDcause-Item
+type RicServiceRejectedRANFunctionIDItem struct {
+	RanFunctionID uint32
+	Cause         Cause
+}
+
+// MarshalXML encodes the cause under the lowercase "cause" tag of RANfunctionIDcause-Item rather than the "Cause" IE tag
+func (i RicServiceRejectedRANFunctionIDItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	err = e.EncodeElement(i.RanFunctionID, xml.StartElement{Name: xml.Name{Local: "ranFunctionID"}})
+	if err != nil {
+		return err
+	}
+	err = e.EncodeElement(i.Cause, xml.StartElement{Name: xml.Name{Local: "cause"}})
+	if err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+type RICserviceUpdateAcknowledgeRejectedProtocolIESingleContainer struct {
+	Text        string `xml:",chardata"`
+	Id          string `xml:"id"`
+	Criticality struct {
+		Text   string `xml:",chardata"`
+		Ignore string `xml:"ignore"`
+	} `xml:"criticality"`
+	Value struct {
+		Text                   string                              `xml:",chardata"`
+		RANfunctionIDcauseItem RicServiceRejectedRANFunctionIDItem `xml:"RANfunctionIDcause-Item"`
+	} `xml:"value"`
+}
+
 type RICserviceUpdateAcknowledgeIEs struct {
 	Text        string `xml:",chardata"`
 	ID          string `xml:"id"`
@@ -64,6 +100,15 @@ type RICserviceUpdateAcknowledgeRANfunctionsList struct {
 		ProtocolIESingleContainer []RICserviceUpdateAcknowledgeProtocolIESingleContainer `xml:"ProtocolIE-SingleContainer"`
 	} `xml:"RANfunctionsID-List"`
 }
+
+type RICserviceUpdateAcknowledgeRANfunctionsRejectedList struct {
+	Text                    string `xml:",chardata"`
+	RANfunctionsIDcauseList struct {
+		Text                      string                                                         `xml:",chardata"`
+		ProtocolIESingleContainer []RICserviceUpdateAcknowledgeRejectedProtocolIESingleContainer `xml:"ProtocolIE-SingleContainer"`
+	} `xml:"RANfunctionsIDcause-List"`
+}
+
 type RicServiceUpdateAckSuccessfulOutcome struct {
 	XMLName       xml.Name `xml:"successfulOutcome"`
 	Text          string   `xml:",chardata"`
@@ -92,7 +137,8 @@ type RicServiceUpdateAckE2APPDU struct {
 	SuccessfulOutcome interface{}
 }
 
-func NewServiceUpdateAck(ricServiceUpdate []RicServiceAckRANFunctionIDItem, txId string) RicServiceUpdateAckE2APPDU {
+// NewServiceUpdateAck builds the RIC SERVICE UPDATE ACKNOWLEDGE. The RANfunctionsRejected IE is optional and omitted when no function was rejected
+func NewServiceUpdateAck(ricServiceUpdate []RicServiceAckRANFunctionIDItem, rejectedFunctions []RicServiceRejectedRANFunctionIDItem, txId string) RicServiceUpdateAckE2APPDU {
 
 	txIE := RICserviceUpdateAcknowledgeIEs{
 		ID: ProtocolIE_ID_id_TransactionID,
@@ -120,6 +166,22 @@ func NewServiceUpdateAck(ricServiceUpdate []RicServiceAckRANFunctionIDItem, txId
 		},
 	}
 
+	ies := []RICserviceUpdateAcknowledgeIEs{txIE, functionListIE}
+
+	if len(rejectedFunctions) != 0 {
+		rejectedProtocolIESingleContainer := make([]RICserviceUpdateAcknowledgeRejectedProtocolIESingleContainer, len(rejectedFunctions))
+		for i := 0; i < len(rejectedFunctions); i++ {
+			rejectedProtocolIESingleContainer[i].Value.RANfunctionIDcauseItem = rejectedFunctions[i]
+			rejectedProtocolIESingleContainer[i].Id = ProtocolIE_ID_id_RANfunctionIEcause_Item
+		}
+
+		rejectedListIE := RICserviceUpdateAcknowledgeIEs{ID: ProtocolIE_ID_id_RANfunctionsRejected}
+		rejectedList := RICserviceUpdateAcknowledgeRANfunctionsRejectedList{}
+		rejectedList.RANfunctionsIDcauseList.ProtocolIESingleContainer = rejectedProtocolIESingleContainer
+		rejectedListIE.Value = rejectedList
+		ies = append(ies, rejectedListIE)
+	}
+
 	successfulOutcome := RicServiceUpdateAckSuccessfulOutcome{
 		ProcedureCode: ProcedureCode_id_RICserviceUpdate,
 		Value: struct {
@@ -131,7 +193,7 @@ func NewServiceUpdateAck(ricServiceUpdate []RicServiceAckRANFunctionIDItem, txId
 					Text                           string                           `xml:",chardata"`
 					RICserviceUpdateAcknowledgeIEs []RICserviceUpdateAcknowledgeIEs `xml:"RICserviceUpdateAcknowledge-IEs"`
 				}{
-					RICserviceUpdateAcknowledgeIEs: []RICserviceUpdateAcknowledgeIEs{txIE, functionListIE},
+					RICserviceUpdateAcknowledgeIEs: ies,
 				},
 			},
 		},
@@ -21,6 +21,7 @@ package models_test
 
 import (
 	"e2mgr/models"
+	"encoding/xml"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,7 +35,7 @@ func TestRicServiceUpdateAckMessageSuccess(t *testing.T) {
 		item2,
 	}
 
-	serviceUpdateAck := models.NewServiceUpdateAck(serviceupdateAckFunctionIds, "1234")
+	serviceUpdateAck := models.NewServiceUpdateAck(serviceupdateAckFunctionIds, nil, "1234")
 	ies := serviceUpdateAck.SuccessfulOutcome.(models.RicServiceUpdateAckSuccessfulOutcome).Value.RICserviceUpdateAcknowledge.ProtocolIEs.RICserviceUpdateAcknowledgeIEs
 	assert.Equal(t, models.ProtocolIE_ID_id_RANfunctionsAccepted, ies[1].ID)
 	assert.Equal(t, models.ProtocolIE_ID_id_RANfunctionID_Item, ies[1].Value.(models.RICserviceUpdateAcknowledgeRANfunctionsList).RANfunctionsIDList.ProtocolIESingleContainer[0].Id)
@@ -48,6 +49,22 @@ func TestRicServiceUpdateAckMessageSuccess(t *testing.T) {
 }
 
 func TestRicServiceUpdateAckMessageNoRanFunctionIdItemsSuccess(t *testing.T) {
-	serviceUpdateAck := models.NewServiceUpdateAck(nil, "1234")
+	serviceUpdateAck := models.NewServiceUpdateAck(nil, nil, "1234")
 	assert.Equal(t, 2, len(serviceUpdateAck.SuccessfulOutcome.(models.RicServiceUpdateAckSuccessfulOutcome).Value.RICserviceUpdateAcknowledge.ProtocolIEs.RICserviceUpdateAcknowledgeIEs), "Trasaction ID is mandatory IE")
 }
+
+func TestRicServiceUpdateAckMessageWithRejectedFunctions(t *testing.T) {
+	accepted := []models.RicServiceAckRANFunctionIDItem{{RanFunctionID: 100, RanFunctionRevision: 200}}
+	rejected := []models.RicServiceRejectedRANFunctionIDItem{{RanFunctionID: 101, Cause: models.Cause{Protocol: &models.CauseProtocol{SemanticError: &struct{}{}}}}}
+
+	serviceUpdateAck := models.NewServiceUpdateAck(accepted, rejected, "1234")
+	ies := serviceUpdateAck.SuccessfulOutcome.(models.RicServiceUpdateAckSuccessfulOutcome).Value.RICserviceUpdateAcknowledge.ProtocolIEs.RICserviceUpdateAcknowledgeIEs
+	assert.Equal(t, 3, len(ies))
+	assert.Equal(t, models.ProtocolIE_ID_id_RANfunctionsRejected, ies[2].ID)
+
+	payload, err := xml.Marshal(serviceUpdateAck)
+	assert.Nil(t, err)
+	assert.Contains(t, string(payload), "<RICserviceUpdateAcknowledge-IEs><id>13</id><criticality><reject></reject></criticality><value><RANfunctionsIDcause-List>"+
+		"<ProtocolIE-SingleContainer><id>7</id><criticality><ignore></ignore></criticality><value><RANfunctionIDcause-Item><ranFunctionID>101</ranFunctionID>"+
+		"<cause><protocol><semantic-error></semantic-error></protocol></cause></RANfunctionIDcause-Item></value></ProtocolIE-SingleContainer></RANfunctionsIDcause-List></value></RICserviceUpdateAcknowledge-IEs>")
+}
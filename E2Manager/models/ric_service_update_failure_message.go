@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"encoding/xml"
+)
+
+type RICserviceUpdateFailureIEs struct {
+	Text        string `xml:",chardata"`
+	ID          string `xml:"id"`
+	Criticality struct {
+		Text   string    `xml:",chardata"`
+		Reject *struct{} `xml:"reject"`
+		Ignore *struct{} `xml:"ignore"`
+	} `xml:"criticality"`
+	Value struct {
+		Text  string `xml:",chardata"`
+		Value interface{}
+	} `xml:"value"`
+}
+
+type RICserviceUpdateFailure struct {
+	Text        string `xml:",chardata"`
+	ProtocolIEs struct {
+		Text                       string                       `xml:",chardata"`
+		RICserviceUpdateFailureIEs []RICserviceUpdateFailureIEs `xml:"RICserviceUpdateFailure-IEs"`
+	} `xml:"protocolIEs"`
+}
+
+type RicServiceUpdateFailureUnsuccessfulOutcome struct {
+	XMLName       xml.Name `xml:"unsuccessfulOutcome"`
+	Text          string   `xml:",chardata"`
+	ProcedureCode string   `xml:"procedureCode"`
+	Criticality   struct {
+		Text   string `xml:",chardata"`
+		Reject string `xml:"reject"`
+	} `xml:"criticality"`
+	Value struct {
+		Text                    string                  `xml:",chardata"`
+		RICserviceUpdateFailure RICserviceUpdateFailure `xml:"RICserviceUpdateFailure"`
+	} `xml:"value"`
+}
+
+type RicServiceUpdateFailureE2APPDU struct {
+	XMLName             xml.Name `xml:"E2AP-PDU"`
+	Text                string   `xml:",chardata"`
+	UnsuccessfulOutcome RicServiceUpdateFailureUnsuccessfulOutcome
+}
+
+// NewServiceUpdateFailure builds the RIC SERVICE UPDATE FAILURE sent when the whole update is rejected
+func NewServiceUpdateFailure(txId string, cause Cause, timeToWait TimeToWait) RicServiceUpdateFailureE2APPDU {
+	ies := make([]RICserviceUpdateFailureIEs, 3)
+
+	ies[0].ID = ProtocolIE_ID_id_TransactionID
+	ies[0].Criticality.Reject = &struct{}{}
+	ies[0].Value.Value = TransFailID{ID: txId}
+
+	ies[1].ID = ProtocolIE_ID_id_Cause
+	ies[1].Criticality.Ignore = &struct{}{}
+	ies[1].Value.Value = cause
+
+	ies[2].ID = ProtocolIE_ID_id_TimeToWait
+	ies[2].Criticality.Ignore = &struct{}{}
+	ies[2].Value.Value = timeToWaitMap[timeToWait]
+
+	outcome := RicServiceUpdateFailureUnsuccessfulOutcome{ProcedureCode: ProcedureCode_id_RICserviceUpdate}
+	outcome.Value.RICserviceUpdateFailure.ProtocolIEs.RICserviceUpdateFailureIEs = ies
+
+	return RicServiceUpdateFailureE2APPDU{UnsuccessfulOutcome: outcome}
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models_test
+
+import (
+	"e2mgr/models"
+	"e2mgr/utils"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRicServiceUpdateFailureMessage(t *testing.T) {
+	cause := models.Cause{Protocol: &models.CauseProtocol{MessageNotCompatibleWithReceiverState: &struct{}{}}}
+
+	serviceUpdateFailure := models.NewServiceUpdateFailure("1234", cause, models.TimeToWaitEnum.V10s)
+	payload, err := xml.Marshal(serviceUpdateFailure)
+	assert.Nil(t, err)
+
+	payload = utils.ReplaceEmptyTagsWithSelfClosing(payload, []string{"reject", "ignore", "v10s", cause.ValueName()})
+	assert.Equal(t, "<E2AP-PDU><unsuccessfulOutcome><procedureCode>7</procedureCode><criticality><reject/></criticality><value><RICserviceUpdateFailure><protocolIEs>"+
+		"<RICserviceUpdateFailure-IEs><id>49</id><criticality><reject/></criticality><value><TransactionID>1234</TransactionID></value></RICserviceUpdateFailure-IEs>"+
+		"<RICserviceUpdateFailure-IEs><id>1</id><criticality><ignore/></criticality><value><Cause><protocol><message-not-compatible-with-receiver-state/></protocol></Cause></value></RICserviceUpdateFailure-IEs>"+
+		"<RICserviceUpdateFailure-IEs><id>31</id><criticality><ignore/></criticality><value><TimeToWait><v10s/></TimeToWait></value></RICserviceUpdateFailure-IEs>"+
+		"</protocolIEs></RICserviceUpdateFailure></value></unsuccessfulOutcome></E2AP-PDU>", string(payload))
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package models
+
+import (
+	"e2mgr/e2managererrors"
+	"encoding/json"
+)
+
+type X2ResetStatus string
+
+const (
+	X2ResetInProgress X2ResetStatus = "IN_PROGRESS"
+	X2ResetSucceeded  X2ResetStatus = "SUCCEEDED"
+	X2ResetFailed     X2ResetStatus = "FAILED"
+	X2ResetTimedOut   X2ResetStatus = "TIMED_OUT"
+)
+
+// X2ResetJob is the outcome of a RIC initiated X2 Reset, times are in nanoseconds since epoch
+type X2ResetJob struct {
+	JobId       string        `json:"jobId"`
+	RanName     string        `json:"ranName"`
+	Cause       string        `json:"cause"`
+	Status      X2ResetStatus `json:"status"`
+	StartedAt   int64         `json:"startedAt"`
+	CompletedAt int64         `json:"completedAt,omitempty"`
+	Reason      string        `json:"reason,omitempty"`
+}
+
+func (job *X2ResetJob) Marshal() ([]byte, error) {
+	data, err := json.Marshal(job)
+
+	if err != nil {
+		return nil, e2managererrors.NewInternalError()
+	}
+
+	return data, nil
+}
+
+type GetX2ResetJobRequest struct {
+	JobId string
+}
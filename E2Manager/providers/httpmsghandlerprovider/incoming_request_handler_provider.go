@@ -24,6 +24,7 @@ import (
 	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/e2managererrors"
+	"e2mgr/e2sm"
 	"e2mgr/handlers/httpmsghandlers"
 	"e2mgr/logger"
 	"e2mgr/managers"
@@ -39,45 +40,83 @@ const (
 	ResetRequest                   IncomingRequest = "Reset"
 	GetNodebRequest                IncomingRequest = "GetNodebRequest"
 	GetNodebIdListRequest          IncomingRequest = "GetNodebIdListRequest"
-	GetNodebIdRequest          	   IncomingRequest = "GetNodebIdRequest"
+	GetNodebIdRequest              IncomingRequest = "GetNodebIdRequest"
 	GetE2TInstancesRequest         IncomingRequest = "GetE2TInstancesRequest"
 	UpdateGnbRequest               IncomingRequest = "UpdateGnbRequest"
 	UpdateEnbRequest               IncomingRequest = "UpdateEnbRequest"
 	AddEnbRequest                  IncomingRequest = "AddEnbRequest"
 	DeleteEnbRequest               IncomingRequest = "DeleteEnbRequest"
 	HealthCheckRequest             IncomingRequest = "HealthCheckRequest"
+	GetHealthCheckJobRequest       IncomingRequest = "GetHealthCheckJobRequest"
+	E2ResetRequest                 IncomingRequest = "E2ResetRequest"
+	GetErrorIndicationsRequest     IncomingRequest = "GetErrorIndicationsRequest"
+	RicServiceQueryRequest         IncomingRequest = "RicServiceQueryRequest"
+	RicServiceQueryReportRequest   IncomingRequest = "RicServiceQueryReportRequest"
+	GetX2ResetJobRequest           IncomingRequest = "GetX2ResetJobRequest"
+	GetNodebByGlobalNbIdRequest    IncomingRequest = "GetNodebByGlobalNbIdRequest"
+	GetCellRequest                 IncomingRequest = "GetCellRequest"
+	GetRanFunctionsRequest         IncomingRequest = "GetRanFunctionsRequest"
+	GetRanFunctionNodesRequest     IncomingRequest = "GetRanFunctionNodesRequest"
 )
 
+// followerRequests are served from rNib, so any replica can handle them. All other requests need the in-memory
+// state of the leader or change it. The RMR notifications are likewise handled by the leader only, see NotificationManager
+var followerRequests = map[IncomingRequest]bool{
+	GetNodebRequest:             true,
+	GetNodebIdListRequest:       true,
+	GetNodebIdRequest:           true,
+	GetE2TInstancesRequest:      true,
+	GetErrorIndicationsRequest:  true,
+	GetNodebByGlobalNbIdRequest: true,
+}
+
 type IncomingRequestHandlerProvider struct {
 	requestMap                    map[IncomingRequest]httpmsghandlers.RequestHandler
 	logger                        *logger.Logger
 	ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager
+	leaderElector                 managers.ILeaderElector
 }
 
-func NewIncomingRequestHandlerProvider(logger *logger.Logger, rmrSender *rmrsender.RmrSender, config *configuration.Configuration, rNibDataService services.RNibDataService, e2tInstancesManager managers.IE2TInstancesManager, rmClient clients.IRoutingManagerClient, ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, nodebValidator *managers.NodebValidator, updateEnbManager managers.IUpdateNodebManager, updateGnbManager managers.IUpdateNodebManager, ranListManager managers.RanListManager) *IncomingRequestHandlerProvider {
+func NewIncomingRequestHandlerProvider(logger *logger.Logger, rmrSender *rmrsender.RmrSender, config *configuration.Configuration, rNibDataService services.RNibDataService, e2tInstancesManager managers.IE2TInstancesManager, rmClient clients.IRoutingManagerClient, ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, nodebValidator *managers.NodebValidator, updateEnbManager managers.IUpdateNodebManager, updateGnbManager managers.IUpdateNodebManager, ranListManager managers.RanListManager, e2ResetTransactionManager managers.IE2ResetTransactionManager, ranProcedureTracker managers.IRanProcedureTracker, errorIndicationStore services.ErrorIndicationStore, ricServiceQueryManager managers.IRicServiceQueryManager, ranLivenessMonitor managers.IRanLivenessMonitor, healthCheckJobManager managers.IHealthCheckJobManager, x2ResetTransactionManager managers.IX2ResetTransactionManager, leaderElector managers.ILeaderElector) *IncomingRequestHandlerProvider {
 
 	return &IncomingRequestHandlerProvider{
-		requestMap:                    initRequestHandlerMap(logger, rmrSender, config, rNibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager),
+		requestMap:                    initRequestHandlerMap(logger, rmrSender, config, rNibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager, e2ResetTransactionManager, ranProcedureTracker, errorIndicationStore, ricServiceQueryManager, ranLivenessMonitor, healthCheckJobManager, x2ResetTransactionManager),
 		logger:                        logger,
 		ranConnectStatusChangeManager: ranConnectStatusChangeManager,
+		leaderElector:                 leaderElector,
 	}
 }
 
-func initRequestHandlerMap(logger *logger.Logger, rmrSender *rmrsender.RmrSender, config *configuration.Configuration, rNibDataService services.RNibDataService, e2tInstancesManager managers.IE2TInstancesManager, rmClient clients.IRoutingManagerClient, ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, nodebValidator *managers.NodebValidator, updateEnbManager managers.IUpdateNodebManager, updateGnbManager managers.IUpdateNodebManager, ranListManager managers.RanListManager) map[IncomingRequest]httpmsghandlers.RequestHandler {
+func initRequestHandlerMap(logger *logger.Logger, rmrSender *rmrsender.RmrSender, config *configuration.Configuration, rNibDataService services.RNibDataService, e2tInstancesManager managers.IE2TInstancesManager, rmClient clients.IRoutingManagerClient, ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, nodebValidator *managers.NodebValidator, updateEnbManager managers.IUpdateNodebManager, updateGnbManager managers.IUpdateNodebManager, ranListManager managers.RanListManager, e2ResetTransactionManager managers.IE2ResetTransactionManager, ranProcedureTracker managers.IRanProcedureTracker, errorIndicationStore services.ErrorIndicationStore, ricServiceQueryManager managers.IRicServiceQueryManager, ranLivenessMonitor managers.IRanLivenessMonitor, healthCheckJobManager managers.IHealthCheckJobManager, x2ResetTransactionManager managers.IX2ResetTransactionManager) map[IncomingRequest]httpmsghandlers.RequestHandler {
+
+	ranResetManager := managers.NewRanResetManager(logger, rNibDataService, ranConnectStatusChangeManager)
+	changeStatusToConnectedRanManager := managers.NewChangeStatusToConnectedRanManager(logger, rNibDataService, ranConnectStatusChangeManager)
+	ricE2ResetManager := managers.NewRicE2ResetManager(logger, rmrSender, rNibDataService, ranResetManager, changeStatusToConnectedRanManager, e2ResetTransactionManager)
+	e2smDecoderRegistry := e2sm.NewDecoderRegistry(logger)
 
 	return map[IncomingRequest]httpmsghandlers.RequestHandler{
 		ShutdownRequest:                httpmsghandlers.NewDeleteAllRequestHandler(logger, rmrSender, config, rNibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, ranListManager),
-		ResetRequest:                   httpmsghandlers.NewX2ResetRequestHandler(logger, rmrSender, rNibDataService),
+		ResetRequest:                   httpmsghandlers.NewX2ResetRequestHandler(logger, rmrSender, rNibDataService, x2ResetTransactionManager),
 		SetGeneralConfigurationRequest: httpmsghandlers.NewSetGeneralConfigurationHandler(logger, rNibDataService),
-		GetNodebRequest:                httpmsghandlers.NewGetNodebRequestHandler(logger, rNibDataService),
-		GetNodebIdListRequest:          httpmsghandlers.NewGetNodebIdListRequestHandler(logger, rNibDataService, ranListManager),
-		GetNodebIdRequest:          	httpmsghandlers.NewGetNodebIdRequestHandler(logger, ranListManager),
+		GetNodebRequest:                httpmsghandlers.NewGetNodebRequestHandler(logger, rNibDataService, e2smDecoderRegistry),
+		GetNodebIdListRequest:          httpmsghandlers.NewGetNodebIdListRequestHandler(logger, rNibDataService, ranListManager, ranLivenessMonitor),
+		GetNodebIdRequest:              httpmsghandlers.NewGetNodebIdRequestHandler(logger, ranListManager),
 		GetE2TInstancesRequest:         httpmsghandlers.NewGetE2TInstancesRequestHandler(logger, e2tInstancesManager),
-		UpdateGnbRequest:               httpmsghandlers.NewUpdateNodebRequestHandler(logger, rNibDataService, updateGnbManager),
-		UpdateEnbRequest:               httpmsghandlers.NewUpdateNodebRequestHandler(logger, rNibDataService, updateEnbManager),
+		UpdateGnbRequest:               httpmsghandlers.NewUpdateNodebRequestHandler(logger, rNibDataService, updateGnbManager, ranListManager),
+		UpdateEnbRequest:               httpmsghandlers.NewUpdateNodebRequestHandler(logger, rNibDataService, updateEnbManager, ranListManager),
 		AddEnbRequest:                  httpmsghandlers.NewAddEnbRequestHandler(logger, rNibDataService, nodebValidator, ranListManager),
-		DeleteEnbRequest:               httpmsghandlers.NewDeleteEnbRequestHandler(logger, rNibDataService, ranListManager),
-		HealthCheckRequest:             httpmsghandlers.NewHealthCheckRequestHandler(logger, rNibDataService, ranListManager, rmrSender),
+		DeleteEnbRequest:               httpmsghandlers.NewDeleteEnbRequestHandler(logger, rNibDataService, ranListManager, ranProcedureTracker, errorIndicationStore),
+		HealthCheckRequest:             httpmsghandlers.NewHealthCheckRequestHandler(logger, rNibDataService, ranListManager, ricServiceQueryManager, healthCheckJobManager),
+		GetHealthCheckJobRequest:       httpmsghandlers.NewGetHealthCheckJobRequestHandler(logger, healthCheckJobManager),
+		E2ResetRequest:                 httpmsghandlers.NewE2ResetRequestHandler(logger, ricE2ResetManager),
+		GetErrorIndicationsRequest:     httpmsghandlers.NewGetErrorIndicationsRequestHandler(logger, rNibDataService, errorIndicationStore),
+		RicServiceQueryRequest:         httpmsghandlers.NewRicServiceQueryRequestHandler(logger, ricServiceQueryManager),
+		RicServiceQueryReportRequest:   httpmsghandlers.NewGetRicServiceQueryReportRequestHandler(logger, ricServiceQueryManager),
+		GetX2ResetJobRequest:           httpmsghandlers.NewGetX2ResetJobRequestHandler(logger, x2ResetTransactionManager),
+		GetNodebByGlobalNbIdRequest:    httpmsghandlers.NewGetNodebByGlobalNbIdRequestHandler(logger, rNibDataService, ranListManager),
+		GetCellRequest:                 httpmsghandlers.NewGetCellRequestHandler(logger, ranListManager),
+		GetRanFunctionsRequest:         httpmsghandlers.NewGetRanFunctionsRequestHandler(logger, ranListManager),
+		GetRanFunctionNodesRequest:     httpmsghandlers.NewGetRanFunctionNodesRequestHandler(logger, ranListManager),
 	}
 }
 
@@ -89,5 +128,10 @@ func (provider IncomingRequestHandlerProvider) GetHandler(requestType IncomingRe
 		return nil, e2managererrors.NewInternalError()
 	}
 
+	if !followerRequests[requestType] && !provider.leaderElector.IsLeader() {
+		provider.logger.Warnf("#incoming_request_handler_provider.GetHandler - request type: %s is served by the leader only", requestType)
+		return nil, e2managererrors.NewNotLeaderError()
+	}
+
 	return handler, nil
 }
@@ -32,7 +32,9 @@ import (
 	"e2mgr/services"
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"reflect"
 	"testing"
 )
@@ -44,6 +46,12 @@ func getRmrSender(rmrMessengerMock *mocks.RmrMessengerMock, log *logger.Logger)
 }
 
 func setupTest(t *testing.T) *IncomingRequestHandlerProvider {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(true)
+	return setupTestWithLeaderElector(t, leaderElectorMock)
+}
+
+func setupTestWithLeaderElector(t *testing.T, leaderElector managers.ILeaderElector) *IncomingRequestHandlerProvider {
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	log := initLog(t)
 	config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3, RnibWriter: configuration.RnibWriterConfig{StateChangeMessageChannel: "RAN_CONNECTION_STATUS_CHANGE", RanManipulationMessageChannel: "RAN_MANIPULATION"}}
@@ -54,14 +62,20 @@ func setupTest(t *testing.T) *IncomingRequestHandlerProvider {
 	rmrSender := getRmrSender(rmrMessengerMock, log)
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, log)
 	httpClientMock := &mocks.HttpClientMock{}
-	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock)
+	alarmManagerClient := clients.NewAlarmManagerClient(log, config, httpClientMock)
+	rmClient := clients.NewRoutingManagerClient(log, config, httpClientMock, alarmManagerClient)
 	ranListManager := managers.NewRanListManager(log, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(log, config)
+	ranAlarmService := services.NewRanAlarmService(log, config, alarmManagerClient)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(log, rnibDataService, ranListManager, ranAlarmService)
 	nodebValidator := managers.NewNodebValidator()
 	updateEnbManager := managers.NewUpdateEnbManager(log, rnibDataService, nodebValidator)
 	updateGnbManager := managers.NewUpdateEnbManager(log, rnibDataService, nodebValidator)
-	return NewIncomingRequestHandlerProvider(log, rmrSender, configuration.ParseConfiguration(), rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(log, config, ranProcedureStoreMock)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(log, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
+	return NewIncomingRequestHandlerProvider(log, rmrSender, configuration.ParseConfiguration(), rnibDataService, e2tInstancesManager, rmClient, ranConnectStatusChangeManager, nodebValidator, updateEnbManager, updateGnbManager, ranListManager, e2ResetTransactionManager, ranProcedureTracker, &mocks.ErrorIndicationStoreMock{}, &mocks.RicServiceQueryManagerMock{}, &mocks.RanLivenessMonitorMock{}, &mocks.HealthCheckJobManagerMock{}, managers.NewX2ResetTransactionManager(log, config, ranProcedureTracker), leaderElector)
 }
 
 func TestNewIncomingRequestHandlerProvider(t *testing.T) {
@@ -118,6 +132,86 @@ func TestHealthCheckRequest(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestGetHealthCheckJobRequest(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetHealthCheckJobRequest)
+
+	assert.NotNil(t, provider)
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetHealthCheckJobRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestGetNodebByGlobalNbIdRequest(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetNodebByGlobalNbIdRequest)
+
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetNodebByGlobalNbIdRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestGetCellRequest(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetCellRequest)
+
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetCellRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestGetRanFunctionsRequest(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetRanFunctionsRequest)
+
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetRanFunctionsRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestGetRanFunctionNodesRequest(t *testing.T) {
+	provider := setupTest(t)
+	handler, err := provider.GetHandler(GetRanFunctionNodesRequest)
+
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetRanFunctionNodesRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestFollowerServesReadRequest(t *testing.T) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(false)
+	provider := setupTestWithLeaderElector(t, leaderElectorMock)
+	handler, err := provider.GetHandler(GetNodebRequest)
+
+	assert.Nil(t, err)
+
+	_, ok := handler.(*httpmsghandlers.GetNodebRequestHandler)
+
+	assert.True(t, ok)
+}
+
+func TestFollowerRejectsLeaderOnlyRequest(t *testing.T) {
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(false)
+	provider := setupTestWithLeaderElector(t, leaderElectorMock)
+	_, err := provider.GetHandler(ShutdownRequest)
+
+	_, ok := err.(*e2managererrors.NotLeaderError)
+
+	assert.True(t, ok)
+}
+
 func TestGetShutdownHandlerFailure(t *testing.T) {
 	provider := setupTest(t)
 	_, actual := provider.GetHandler("test")
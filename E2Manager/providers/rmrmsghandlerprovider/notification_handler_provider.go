@@ -61,7 +61,10 @@ func (provider *NotificationHandlerProvider) Register(msgType int, handler rmrms
 func (provider *NotificationHandlerProvider) Init(logger *logger.Logger, config *configuration.Configuration,
 	rnibDataService services.RNibDataService, rmrSender *rmrsender.RmrSender, e2tInstancesManager managers.IE2TInstancesManager,
 	routingManagerClient clients.IRoutingManagerClient, e2tAssociationManager *managers.E2TAssociationManager,
-	ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, ranListManager managers.RanListManager,RicServiceUpdateManager managers.IRicServiceUpdateManager) {
+	ranConnectStatusChangeManager managers.IRanConnectStatusChangeManager, ranListManager managers.RanListManager,RicServiceUpdateManager managers.IRicServiceUpdateManager,
+	e2ResetTransactionManager managers.IE2ResetTransactionManager, ranAlarmService services.RanAlarmService, ranProcedureTracker managers.IRanProcedureTracker,
+	errorIndicationStore services.ErrorIndicationStore, ricServiceQueryManager managers.IRicServiceQueryManager, healthCheckJobManager managers.IHealthCheckJobManager,
+	x2ResetTransactionManager managers.IX2ResetTransactionManager, notificationScheduler rmrmsghandlers.NotificationScheduler) {
 
 	// Init converters
 	x2SetupResponseConverter := converters.NewX2SetupResponseConverter(logger)
@@ -75,6 +78,10 @@ func (provider *NotificationHandlerProvider) Init(logger *logger.Logger, config
 	ranReconnectionManager := managers.NewRanDisconnectionManager(logger, config, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager)
 	ranResetChangeManager := managers.NewRanResetManager(logger, rnibDataService, ranConnectStatusChangeManager)
 	changeStatusToConnectedRanManager := managers.NewChangeStatusToConnectedRanManager(logger, rnibDataService, ranConnectStatusChangeManager)
+	e2SetupAdmissionPolicy := managers.NewE2SetupAdmissionPolicy(logger, config)
+	e2SetupAdmissionPolicy.AddRule(managers.NewDuplicateGlobalNbIdRule(logger, config, ranListManager))
+	ranFunctionValidator := managers.NewRanFunctionValidator(config)
+	ricE2ResetManager := managers.NewRicE2ResetManager(logger, rmrSender, rnibDataService, ranResetChangeManager, changeStatusToConnectedRanManager, e2ResetTransactionManager)
 	ranStatusChangeManager := managers.NewRanStatusChangeManager(logger, rmrSender)
 	x2SetupResponseManager := managers.NewX2SetupResponseManager(x2SetupResponseConverter)
 	x2SetupFailureResponseManager := managers.NewX2SetupFailureResponseManager(x2SetupFailureResponseConverter)
@@ -90,15 +97,16 @@ func (provider *NotificationHandlerProvider) Init(logger *logger.Logger, config
 	//enbLoadInformationNotificationHandler := rmrmsghandlers.NewEnbLoadInformationNotificationHandler(logger, rnibDataService, enbLoadInformationExtractor)
 	x2EnbConfigurationUpdateHandler := rmrmsghandlers.NewX2EnbConfigurationUpdateHandler(logger, rmrSender)
 	endcConfigurationUpdateHandler := rmrmsghandlers.NewEndcConfigurationUpdateHandler(logger, rmrSender)
-	x2ResetResponseHandler := rmrmsghandlers.NewX2ResetResponseHandler(logger, rnibDataService, ranStatusChangeManager, x2ResetResponseExtractor)
+	x2ResetResponseHandler := rmrmsghandlers.NewX2ResetResponseHandler(logger, rnibDataService, ranStatusChangeManager, x2ResetResponseExtractor, x2ResetTransactionManager)
 	x2ResetRequestNotificationHandler := rmrmsghandlers.NewX2ResetRequestNotificationHandler(logger, rnibDataService, ranStatusChangeManager, rmrSender)
-	e2TermInitNotificationHandler := rmrmsghandlers.NewE2TermInitNotificationHandler(logger, ranReconnectionManager, e2tInstancesManager, routingManagerClient)
+	e2TermInitNotificationHandler := rmrmsghandlers.NewE2TermInitNotificationHandler(logger, ranReconnectionManager, e2tInstancesManager, routingManagerClient, ranAlarmService)
 	e2TKeepAliveResponseHandler := rmrmsghandlers.NewE2TKeepAliveResponseHandler(logger, rnibDataService, e2tInstancesManager)
-	e2SetupRequestNotificationHandler := rmrmsghandlers.NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManager, rmrSender, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager)
-	ricServiceUpdateHandler := rmrmsghandlers.NewRicServiceUpdateHandler(logger, rmrSender, rnibDataService, ranListManager, RicServiceUpdateManager)
-	ricE2nodeConfigUpdateHandler := rmrmsghandlers.NewE2nodeConfigUpdateNotificationHandler(logger, rnibDataService, rmrSender)
-	e2ResetRequestNotificationHandler := rmrmsghandlers.NewE2ResetRequestNotificationHandler(logger, rnibDataService, config, rmrSender, ranResetChangeManager, changeStatusToConnectedRanManager)
-	errorIndicationNotificationHandler := rmrmsghandlers.ErrorIndicationNotificationHandler(logger, ranReconnectionManager, RicServiceUpdateManager)
+	e2SetupRequestNotificationHandler := rmrmsghandlers.NewE2SetupRequestNotificationHandler(logger, config, e2tInstancesManager, rmrSender, rnibDataService, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, e2SetupAdmissionPolicy, ranProcedureTracker, ranFunctionValidator)
+	ricServiceUpdateHandler := rmrmsghandlers.NewRicServiceUpdateHandler(logger, config, rmrSender, rnibDataService, ranListManager, RicServiceUpdateManager, ranFunctionValidator, ranProcedureTracker, ricServiceQueryManager, healthCheckJobManager)
+	ricE2nodeConfigUpdateHandler := rmrmsghandlers.NewE2nodeConfigUpdateNotificationHandler(logger, config, rnibDataService, rmrSender, ranProcedureTracker)
+	e2ResetRequestNotificationHandler := rmrmsghandlers.NewE2ResetRequestNotificationHandler(logger, rnibDataService, config, rmrSender, ranResetChangeManager, changeStatusToConnectedRanManager, ranProcedureTracker, notificationScheduler)
+	e2ResetResponseNotificationHandler := rmrmsghandlers.NewE2ResetResponseNotificationHandler(logger, e2ResetTransactionManager)
+	errorIndicationNotificationHandler := rmrmsghandlers.ErrorIndicationNotificationHandler(logger, config, ranReconnectionManager, RicServiceUpdateManager, ranProcedureTracker, e2ResetTransactionManager, ricE2ResetManager, errorIndicationStore)
 
 	provider.Register(rmrCgo.RIC_X2_SETUP_RESP, x2SetupResponseHandler)
 	provider.Register(rmrCgo.RIC_X2_SETUP_FAILURE, x2SetupFailureResponseHandler)
@@ -116,5 +124,6 @@ func (provider *NotificationHandlerProvider) Init(logger *logger.Logger, config
 	provider.Register(rmrCgo.RIC_SERVICE_UPDATE, ricServiceUpdateHandler)
 	provider.Register(rmrCgo.RIC_E2NODE_CONFIG_UPDATE, ricE2nodeConfigUpdateHandler)
 	provider.Register(rmrCgo.RIC_E2_RESET_REQ, e2ResetRequestNotificationHandler)
+	provider.Register(rmrCgo.RIC_E2_RESET_RESP, e2ResetResponseNotificationHandler)
 	provider.Register(rmrCgo.RIC_E2_RIC_ERROR_INDICATION, errorIndicationNotificationHandler)
 }
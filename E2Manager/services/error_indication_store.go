@@ -0,0 +1,118 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package services
+
+import (
+	"e2mgr/models"
+	"encoding/json"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+)
+
+const errorIndicationsKeyPrefix = "ERROR_INDICATIONS:"
+
+type ErrorIndicationStore interface {
+	Get(ranName string) ([]*models.ErrorIndicationRecord, error)
+	Add(ranName string, record *models.ErrorIndicationRecord, maxRecords int) error
+	Delete(ranName string) error
+}
+
+type errorIndicationStore struct {
+	sdl common.ISdlSyncStorage
+	ns  string
+}
+
+// NewErrorIndicationStore keeps the last Error Indications received from each RAN in the rNib namespace
+func NewErrorIndicationStore(sdl common.ISdlSyncStorage) *errorIndicationStore {
+	return &errorIndicationStore{
+		sdl: sdl,
+		ns:  common.GetRNibNamespace(),
+	}
+}
+
+func buildErrorIndicationsKey(ranName string) string {
+	return errorIndicationsKeyPrefix + ranName
+}
+
+// Get returns the stored Error Indications of the RAN, oldest first
+func (s *errorIndicationStore) Get(ranName string) ([]*models.ErrorIndicationRecord, error) {
+	key := buildErrorIndicationsKey(ranName)
+	values, err := s.sdl.Get(s.ns, []string{key})
+
+	if err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	records := []*models.ErrorIndicationRecord{}
+	data, ok := values[key].(string)
+
+	if !ok {
+		return records, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &records); err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	return records, nil
+}
+
+// Add appends the record and drops the oldest ones, so that at most maxRecords are kept
+func (s *errorIndicationStore) Add(ranName string, record *models.ErrorIndicationRecord, maxRecords int) error {
+	if maxRecords <= 0 {
+		return nil
+	}
+
+	records, err := s.Get(ranName)
+
+	if err != nil {
+		return err
+	}
+
+	records = append(records, record)
+
+	if len(records) > maxRecords {
+		records = records[len(records)-maxRecords:]
+	}
+
+	data, err := json.Marshal(records)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	err = s.sdl.Set(s.ns, buildErrorIndicationsKey(ranName), data)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// Delete removes the stored Error Indications of a deleted RAN
+func (s *errorIndicationStore) Delete(ranName string) error {
+	err := s.sdl.Remove(s.ns, []string{buildErrorIndicationsKey(ranName)})
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package services
+
+import (
+	"e2mgr/models"
+	"encoding/json"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+)
+
+const leaderLeaseKey = "E2MANAGER_LEADER_LEASE"
+
+type LeaderLeaseStore interface {
+	Get() (*models.LeaderLease, error)
+	Acquire(lease *models.LeaderLease) (bool, error)
+	Replace(oldLease *models.LeaderLease, newLease *models.LeaderLease) (bool, error)
+	Release(lease *models.LeaderLease) (bool, error)
+}
+
+type leaderLeaseStore struct {
+	sdl common.ISdlSyncStorage
+	ns  string
+}
+
+// NewLeaderLeaseStore keeps the leader lease in the rNib namespace. Every change is conditional on the stored value,
+// so two replicas can never both believe they hold the lease
+func NewLeaderLeaseStore(sdl common.ISdlSyncStorage) *leaderLeaseStore {
+	return &leaderLeaseStore{
+		sdl: sdl,
+		ns:  common.GetRNibNamespace(),
+	}
+}
+
+// Get returns a ResourceNotFoundError when no replica holds the lease
+func (s *leaderLeaseStore) Get() (*models.LeaderLease, error) {
+	values, err := s.sdl.Get(s.ns, []string{leaderLeaseKey})
+
+	if err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	data, ok := values[leaderLeaseKey].(string)
+
+	if !ok {
+		return nil, common.NewResourceNotFoundError("#leaderLeaseStore.Get - leader lease not found")
+	}
+
+	lease := &models.LeaderLease{}
+
+	if err := json.Unmarshal([]byte(data), lease); err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	return lease, nil
+}
+
+// Acquire stores the lease only if no replica holds it
+func (s *leaderLeaseStore) Acquire(lease *models.LeaderLease) (bool, error) {
+	data, err := json.Marshal(lease)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	ok, err := s.sdl.SetIfNotExists(s.ns, leaderLeaseKey, data)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	return ok, nil
+}
+
+// Replace stores newLease only if the stored lease is still oldLease, it serves both renewal and takeover of an expired lease
+func (s *leaderLeaseStore) Replace(oldLease *models.LeaderLease, newLease *models.LeaderLease) (bool, error) {
+	oldData, err := json.Marshal(oldLease)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	newData, err := json.Marshal(newLease)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	ok, err := s.sdl.SetIf(s.ns, leaderLeaseKey, oldData, newData)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	return ok, nil
+}
+
+// Release removes the lease only if it is still the given one
+func (s *leaderLeaseStore) Release(lease *models.LeaderLease) (bool, error) {
+	data, err := json.Marshal(lease)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	ok, err := s.sdl.RemoveIf(s.ns, leaderLeaseKey, data)
+
+	if err != nil {
+		return false, common.NewInternalError(err)
+	}
+
+	return ok, nil
+}
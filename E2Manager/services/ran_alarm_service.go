@@ -20,28 +20,142 @@
 package services
 
 import (
+	"e2mgr/clients"
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/models"
+	"fmt"
+	"sync"
+	"time"
+
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
 )
 
 type ranAlarmServiceInstance struct {
-	logger *logger.Logger
-	config *configuration.Configuration
+	logger             *logger.Logger
+	config             *configuration.Configuration
+	alarmManagerClient clients.IAlarmManagerClient
+	mutex              sync.Mutex
+	underResetTimers   map[string]*time.Timer
 }
 
 type RanAlarmService interface {
 	SetConnectivityChangeAlarm(nodebInfo *entities.NodebInfo) error
+	SetE2TKeepAliveLostAlarm(e2tAddress string) error
+	ClearE2TKeepAliveLostAlarm(e2tAddress string) error
+	SetRanUnresponsiveAlarm(ranName string) error
+	ClearRanUnresponsiveAlarm(ranName string) error
 }
 
-func NewRanAlarmService(logger *logger.Logger, config *configuration.Configuration) RanAlarmService {
+func NewRanAlarmService(logger *logger.Logger, config *configuration.Configuration, alarmManagerClient clients.IAlarmManagerClient) RanAlarmService {
 	return &ranAlarmServiceInstance{
-		logger: logger,
-		config: config,
+		logger:             logger,
+		config:             config,
+		alarmManagerClient: alarmManagerClient,
+		underResetTimers:   make(map[string]*time.Timer),
 	}
 }
 
 func (m *ranAlarmServiceInstance) SetConnectivityChangeAlarm(nodebInfo *entities.NodebInfo) error {
 	m.logger.Infof("#ranAlarmServiceInstance.SetConnectivityChangeAlarm - RAN name: %s - Connectivity state was changed to %s", nodebInfo.RanName, nodebInfo.ConnectionStatus)
-	return nil
+
+	ranName := nodebInfo.RanName
+	disconnectedAlarm := models.NewAlarm(getConnectivityLostAlarmId(nodebInfo), models.AlarmSeverityMajor, ranName,
+		fmt.Sprintf("connection status: %s", nodebInfo.GetConnectionStatus()))
+
+	switch nodebInfo.GetConnectionStatus() {
+	case entities.ConnectionStatus_CONNECTED:
+		m.stopUnderResetTimer(ranName)
+		if err := m.alarmManagerClient.Clear(newRanUnderResetTooLongAlarm(ranName)); err != nil {
+			return err
+		}
+		return m.alarmManagerClient.Clear(disconnectedAlarm)
+	case entities.ConnectionStatus_UNDER_RESET:
+		m.startUnderResetTimer(ranName)
+		return nil
+	}
+
+	m.stopUnderResetTimer(ranName)
+	if err := m.alarmManagerClient.Clear(newRanUnderResetTooLongAlarm(ranName)); err != nil {
+		return err
+	}
+	return m.alarmManagerClient.Raise(disconnectedAlarm)
+}
+
+func (m *ranAlarmServiceInstance) SetE2TKeepAliveLostAlarm(e2tAddress string) error {
+	m.logger.Infof("#ranAlarmServiceInstance.SetE2TKeepAliveLostAlarm - E2T %s - keep alive was lost", e2tAddress)
+	return m.alarmManagerClient.Raise(newE2TKeepAliveLostAlarm(e2tAddress))
+}
+
+func (m *ranAlarmServiceInstance) ClearE2TKeepAliveLostAlarm(e2tAddress string) error {
+	return m.alarmManagerClient.Clear(newE2TKeepAliveLostAlarm(e2tAddress))
+}
+
+func (m *ranAlarmServiceInstance) SetRanUnresponsiveAlarm(ranName string) error {
+	m.logger.Infof("#ranAlarmServiceInstance.SetRanUnresponsiveAlarm - RAN name: %s - RAN does not answer health checks", ranName)
+	return m.alarmManagerClient.Raise(newRanUnresponsiveAlarm(ranName))
+}
+
+func (m *ranAlarmServiceInstance) ClearRanUnresponsiveAlarm(ranName string) error {
+	return m.alarmManagerClient.Clear(newRanUnresponsiveAlarm(ranName))
+}
+
+func (m *ranAlarmServiceInstance) startUnderResetTimer(ranName string) {
+	thresholdSec := m.config.AlarmManager.RanUnderResetThresholdSec
+	if thresholdSec <= 0 {
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.underResetTimers[ranName]; ok {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Duration(thresholdSec)*time.Second, func() {
+		m.mutex.Lock()
+		if m.underResetTimers[ranName] != timer {
+			m.mutex.Unlock()
+			return
+		}
+		delete(m.underResetTimers, ranName)
+		m.mutex.Unlock()
+
+		m.logger.Warnf("#ranAlarmServiceInstance.startUnderResetTimer - RAN name: %s - RAN is under reset for more than %d seconds", ranName, thresholdSec)
+		if err := m.alarmManagerClient.Raise(newRanUnderResetTooLongAlarm(ranName)); err != nil {
+			m.logger.Errorf("#ranAlarmServiceInstance.startUnderResetTimer - RAN name: %s - Failed raising alarm. Error: %v", ranName, err)
+		}
+	})
+	m.underResetTimers[ranName] = timer
+}
+
+func (m *ranAlarmServiceInstance) stopUnderResetTimer(ranName string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if timer, ok := m.underResetTimers[ranName]; ok {
+		timer.Stop()
+		delete(m.underResetTimers, ranName)
+	}
+}
+
+func getConnectivityLostAlarmId(nodebInfo *entities.NodebInfo) models.AlarmId {
+	if nodebInfo.GetNodeType() == entities.Node_ENB {
+		return models.E2ConnectivityLostToEnbAlarmId
+	}
+	return models.E2ConnectivityLostToGnbAlarmId
+}
+
+func newRanUnderResetTooLongAlarm(ranName string) models.Alarm {
+	return models.NewAlarm(models.RanUnderResetTooLongAlarmId, models.AlarmSeverityMinor, ranName, "RAN is under reset for too long")
+}
+
+func newRanUnresponsiveAlarm(ranName string) models.Alarm {
+	return models.NewAlarm(models.RanUnresponsiveAlarmId, models.AlarmSeverityMajor, ranName, "RAN health check response was not received")
+}
+
+func newE2TKeepAliveLostAlarm(e2tAddress string) models.Alarm {
+	return models.NewAlarm(models.E2TKeepAliveLostAlarmId, models.AlarmSeverityCritical, e2tAddress, "E2T keep alive response was not received")
 }
@@ -1,10 +1,14 @@
 package services
 
 import (
+        "e2mgr/clients"
         "e2mgr/configuration"
         "e2mgr/logger"
+        "e2mgr/models"
+        "e2mgr/tests/alarmmanagerstub"
         "gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
         "testing"
+        "time"
         "github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +20,7 @@ func RanAlarmServiceTest(t *testing.T) (RanAlarmService, *logger.Logger, *config
                 t.Errorf("#... - failed to initialize logger, error: %s", err)
         }
     config := &configuration.Configuration{RnibRetryIntervalMs: 10, MaxRnibConnectionAttempts: 3}
-    ranAlarmServiceInstance := NewRanAlarmService(logger , config)
+    ranAlarmServiceInstance := NewRanAlarmService(logger, config, clients.NewAlarmManagerClient(logger, config, clients.NewHttpClient()))
     return ranAlarmServiceInstance,logger, config
 }
 
@@ -28,3 +32,123 @@ func TestSetConnectivityChangeAlarmTest(t *testing.T){
      assert.Nil(t,err)
 }
 
+func initRanAlarmServiceWithStubTest(t *testing.T, ranUnderResetThresholdSec int) (RanAlarmService, *alarmmanagerstub.AlarmManagerStub) {
+	ranAlarmServiceInstance, _, config := RanAlarmServiceTest(t)
+	stub := alarmmanagerstub.NewAlarmManagerStub()
+	t.Cleanup(stub.Close)
+	config.AlarmManager.BaseUrl = stub.BaseUrl()
+	config.AlarmManager.RanUnderResetThresholdSec = ranUnderResetThresholdSec
+	return ranAlarmServiceInstance, stub
+}
+
+// alarms are sent in the background, assertAlarmActive waits for the stub to reach the expected state
+func assertAlarmActive(t *testing.T, stub *alarmmanagerstub.AlarmManagerStub, alarmId models.AlarmId, identifyingInfo string, active bool) {
+	assert.Eventually(t, func() bool {
+		return stub.IsActive(alarmId, identifyingInfo) == active
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSetConnectivityChangeAlarmDisconnectedRaisesAndConnectedClears(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 0)
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_GNB, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+
+	err := ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.E2ConnectivityLostToGnbAlarmId, "test", true)
+
+	nodebInfo.ConnectionStatus = entities.ConnectionStatus_CONNECTED
+	err = ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.E2ConnectivityLostToGnbAlarmId, "test", false)
+}
+
+func TestSetConnectivityChangeAlarmEnbAlarmId(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 0)
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_ENB, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+
+	err := ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.E2ConnectivityLostToEnbAlarmId, "test", true)
+	assert.False(t, stub.IsActive(models.E2ConnectivityLostToGnbAlarmId, "test"))
+}
+
+func TestSetConnectivityChangeAlarmDeduplication(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 0)
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_GNB, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+
+	_ = ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assertAlarmActive(t, stub, models.E2ConnectivityLostToGnbAlarmId, "test", true)
+	messagesCount := len(stub.Messages())
+
+	_ = ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	_ = ranAlarmServiceInstance.SetE2TKeepAliveLostAlarm("10.0.2.15:38000")
+	assertAlarmActive(t, stub, models.E2TKeepAliveLostAlarmId, "10.0.2.15:38000", true)
+	assert.Equal(t, messagesCount+1, len(stub.Messages()))
+}
+
+func TestSetConnectivityChangeAlarmUnderResetTooLong(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 1)
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_GNB, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+
+	err := ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assert.Nil(t, err)
+	assert.False(t, stub.IsActive(models.RanUnderResetTooLongAlarmId, "test"))
+
+	assert.Eventually(t, func() bool {
+		return stub.IsActive(models.RanUnderResetTooLongAlarmId, "test")
+	}, 3*time.Second, 100*time.Millisecond)
+
+	nodebInfo.ConnectionStatus = entities.ConnectionStatus_CONNECTED
+	err = ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.RanUnderResetTooLongAlarmId, "test", false)
+}
+
+func TestSetConnectivityChangeAlarmUnderResetEndedInTime(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 1)
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_GNB, ConnectionStatus: entities.ConnectionStatus_UNDER_RESET}
+
+	_ = ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	nodebInfo.ConnectionStatus = entities.ConnectionStatus_CONNECTED
+	_ = ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+
+	time.Sleep(1500 * time.Millisecond)
+	assert.False(t, stub.IsActive(models.RanUnderResetTooLongAlarmId, "test"))
+}
+
+func TestSetConnectivityChangeAlarmAlarmManagerFailure(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 0)
+	stub.SetStatusCode(500)
+	nodebInfo := &entities.NodebInfo{RanName: "test", NodeType: entities.Node_GNB, ConnectionStatus: entities.ConnectionStatus_DISCONNECTED}
+
+	err := ranAlarmServiceInstance.SetConnectivityChangeAlarm(nodebInfo)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return stub.Rejected() == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, stub.IsActive(models.E2ConnectivityLostToGnbAlarmId, "test"))
+}
+
+func TestE2TKeepAliveLostAlarm(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 0)
+
+	err := ranAlarmServiceInstance.SetE2TKeepAliveLostAlarm("10.0.2.15:38000")
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.E2TKeepAliveLostAlarmId, "10.0.2.15:38000", true)
+
+	err = ranAlarmServiceInstance.ClearE2TKeepAliveLostAlarm("10.0.2.15:38000")
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.E2TKeepAliveLostAlarmId, "10.0.2.15:38000", false)
+}
+
+func TestRanUnresponsiveAlarm(t *testing.T) {
+	ranAlarmServiceInstance, stub := initRanAlarmServiceWithStubTest(t, 0)
+
+	err := ranAlarmServiceInstance.SetRanUnresponsiveAlarm("gnb_208_092_303030")
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.RanUnresponsiveAlarmId, "gnb_208_092_303030", true)
+
+	err = ranAlarmServiceInstance.ClearRanUnresponsiveAlarm("gnb_208_092_303030")
+	assert.Nil(t, err)
+	assertAlarmActive(t, stub, models.RanUnresponsiveAlarmId, "gnb_208_092_303030", false)
+}
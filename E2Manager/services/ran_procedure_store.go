@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package services
+
+import (
+	"e2mgr/configuration"
+	"e2mgr/logger"
+	"e2mgr/models"
+	"encoding/json"
+	"fmt"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+)
+
+const ranProceduresKeyPrefix = "RAN_PROCEDURES:"
+
+type RanProcedureStore interface {
+	Get(ranName string) (*models.RanProcedures, error)
+	Save(procedures *models.RanProcedures) error
+	Delete(ranName string) error
+}
+
+type ranProcedureStore struct {
+	logger *logger.Logger
+	config *configuration.Configuration
+	sdl    common.ISdlSyncStorage
+	ns     string
+}
+
+// NewRanProcedureStore persists the procedures of each RAN in the rNib namespace, so they survive a restart of the E2 Manager.
+// The rNib operations are retried like those of the rNib data service
+func NewRanProcedureStore(logger *logger.Logger, config *configuration.Configuration, sdl common.ISdlSyncStorage) *ranProcedureStore {
+	return &ranProcedureStore{
+		logger: logger,
+		config: config,
+		sdl:    sdl,
+		ns:     common.GetRNibNamespace(),
+	}
+}
+
+func buildRanProceduresKey(ranName string) string {
+	return ranProceduresKeyPrefix + ranName
+}
+
+func (s *ranProcedureStore) Get(ranName string) (*models.RanProcedures, error) {
+	key := buildRanProceduresKey(ranName)
+	var values map[string]interface{}
+
+	err := retryRnib(s.logger, s.config, "GetRanProcedures", func() (err error) {
+		values, err = s.sdl.Get(s.ns, []string{key})
+		if err != nil {
+			return common.NewInternalError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := values[key].(string)
+
+	if !ok {
+		return nil, common.NewResourceNotFoundError(fmt.Sprintf("#ranProcedureStore.Get - procedures of RAN %s not found", ranName))
+	}
+
+	procedures := models.NewRanProcedures(ranName)
+
+	if err := json.Unmarshal([]byte(data), procedures); err != nil {
+		return nil, common.NewInternalError(err)
+	}
+
+	return procedures, nil
+}
+
+func (s *ranProcedureStore) Save(procedures *models.RanProcedures) error {
+	data, err := json.Marshal(procedures)
+
+	if err != nil {
+		return common.NewInternalError(err)
+	}
+
+	return retryRnib(s.logger, s.config, "SaveRanProcedures", func() error {
+		if err := s.sdl.Set(s.ns, buildRanProceduresKey(procedures.RanName), data); err != nil {
+			return common.NewInternalError(err)
+		}
+		return nil
+	})
+}
+
+func (s *ranProcedureStore) Delete(ranName string) error {
+	return retryRnib(s.logger, s.config, "DeleteRanProcedures", func() error {
+		if err := s.sdl.Remove(s.ns, []string{buildRanProceduresKey(ranName)}); err != nil {
+			return common.NewInternalError(err)
+		}
+		return nil
+	})
+}
@@ -24,12 +24,15 @@ import (
 	"e2mgr/logger"
 	"e2mgr/managers/notificationmanager"
 	"e2mgr/rmrCgo"
+	"sync"
 )
 
 type RmrReceiver struct {
 	logger    *logger.Logger
 	nManager  *notificationmanager.NotificationManager
 	messenger rmrCgo.RmrMessenger
+	stopped   bool
+	mux       sync.Mutex
 }
 
 func NewRmrReceiver(logger *logger.Logger, messenger rmrCgo.RmrMessenger, nManager *notificationmanager.NotificationManager) *RmrReceiver {
@@ -45,14 +48,37 @@ func (r *RmrReceiver) ListenAndHandle() {
 	for {
 		mbuf, err := r.messenger.RecvMsg()
 
-		if err != nil {
-			r.logger.Errorf("#RmrReceiver.ListenAndHandle - error: %s", err)
-			continue
+		if !r.handle(mbuf, err) {
+			r.logger.Infof("#RmrReceiver.ListenAndHandle - receiver stopped")
+			return
 		}
+	}
+}
+
+// Stop makes the receiver drop the messages it receives from now on. It returns once the message being handled, if any, has been handed over.
+func (r *RmrReceiver) Stop() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.stopped = true
+	r.logger.Infof("#RmrReceiver.Stop - no more RMR messages will be handled")
+}
 
-		r.logger.Debugf("#RmrReceiver.ListenAndHandle - Going to handle received message: %#v\n", mbuf)
+func (r *RmrReceiver) handle(mbuf *rmrCgo.MBuf, err error) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
 
-		// TODO: go routine?
-		_ = r.nManager.HandleMessage(mbuf)
+	if r.stopped {
+		return false
 	}
+
+	if err != nil {
+		r.logger.Errorf("#RmrReceiver.ListenAndHandle - error: %s", err)
+		return true
+	}
+
+	r.logger.Debugf("#RmrReceiver.ListenAndHandle - Going to handle received message: %#v\n", mbuf)
+
+	_ = r.nManager.HandleMessage(mbuf)
+	return true
 }
\ No newline at end of file
@@ -32,6 +32,8 @@ import (
 	"e2mgr/services/rmrsender"
 	"e2mgr/tests"
 	"fmt"
+	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
+	"github.com/stretchr/testify/mock"
 	"testing"
 	"time"
 )
@@ -47,6 +49,28 @@ func TestListenAndHandle(t *testing.T) {
 	time.Sleep(time.Microsecond * 10)
 }
 
+func TestListenAndHandleReturnsWhenStopped(t *testing.T) {
+	DebugLevel := int8(4)
+	log, err := logger.InitLogger(DebugLevel)
+	if err != nil {
+		t.Errorf("#rmr_service_test.TestListenAndHandleReturnsWhenStopped - failed to initialize logger, error: %s", err)
+	}
+	rmrReceiver := initRmrReceiver(log)
+	done := make(chan struct{})
+	go func() {
+		rmrReceiver.ListenAndHandle()
+		close(done)
+	}()
+
+	rmrReceiver.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("#rmr_service_test.TestListenAndHandleReturnsWhenStopped - receiver did not stop")
+	}
+}
+
 func initRmrMessenger(log *logger.Logger) rmrCgo.RmrMessenger {
 	rmrMessengerMock := &mocks.RmrMessengerMock{}
 	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
@@ -70,14 +94,24 @@ func initRmrReceiver(logger *logger.Logger) *RmrReceiver {
 	rmrMessenger := initRmrMessenger(logger)
 	rmrSender := rmrsender.NewRmrSender(logger, rmrMessenger)
 	e2tInstancesManager := managers.NewE2TInstancesManager(rnibDataService, logger)
-	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClient)
+	alarmManagerClient := clients.NewAlarmManagerClient(logger, config, httpClient)
+	routingManagerClient := clients.NewRoutingManagerClient(logger, config, httpClient, alarmManagerClient)
 	ranListManager := managers.NewRanListManager(logger, rnibDataService)
-	ranAlarmService := services.NewRanAlarmService(logger, config)
-	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService)
+	ranAlarmService := services.NewRanAlarmService(logger, config, alarmManagerClient)
+	ranProcedureStoreMock := &mocks.RanProcedureStoreMock{}
+	ranProcedureStoreMock.On("Get", mock.Anything).Return(nil, common.NewResourceNotFoundError("#test - not found"))
+	ranProcedureStoreMock.On("Save", mock.Anything).Return(nil)
+	ranProcedureTracker := managers.NewRanProcedureTracker(logger, config, ranProcedureStoreMock)
+	RicServiceUpdateManager := managers.NewRicServiceUpdateManager(logger, rnibDataService, ranProcedureTracker, ranListManager)
 	ranConnectStatusChangeManager := managers.NewRanConnectStatusChangeManager(logger, rnibDataService, ranListManager, ranAlarmService)
 	e2tAssociationManager := managers.NewE2TAssociationManager(logger, rnibDataService, e2tInstancesManager, routingManagerClient, ranConnectStatusChangeManager)
+	e2ResetTransactionManager := managers.NewE2ResetTransactionManager(logger, config, rnibDataService, ranConnectStatusChangeManager, ranProcedureTracker)
 	rmrNotificationHandlerProvider := rmrmsghandlerprovider.NewNotificationHandlerProvider()
-	rmrNotificationHandlerProvider.Init(logger, config, rnibDataService, rmrSender, e2tInstancesManager, routingManagerClient, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, RicServiceUpdateManager)
-	notificationManager := notificationmanager.NewNotificationManager(logger, rmrNotificationHandlerProvider)
+	rmrNotificationHandlerProvider.Init(logger, config, rnibDataService, rmrSender, e2tInstancesManager, routingManagerClient, e2tAssociationManager, ranConnectStatusChangeManager, ranListManager, RicServiceUpdateManager, e2ResetTransactionManager, ranAlarmService, ranProcedureTracker, &mocks.ErrorIndicationStoreMock{}, &mocks.RicServiceQueryManagerMock{}, &mocks.HealthCheckJobManagerMock{}, managers.NewX2ResetTransactionManager(logger, config, ranProcedureTracker), nil)
+	notificationDispatcher := notificationmanager.NewNotificationDispatcher(logger, config.NotificationWorkers, config.NotificationResponseBuffer)
+	notificationDispatcher.Start()
+	leaderElectorMock := &mocks.LeaderElectorMock{}
+	leaderElectorMock.On("IsLeader").Return(true)
+	notificationManager := notificationmanager.NewNotificationManager(logger, rmrNotificationHandlerProvider, notificationDispatcher, leaderElectorMock)
 	return NewRmrReceiver(logger, rmrMessenger, notificationManager)
 }
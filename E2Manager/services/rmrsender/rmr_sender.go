@@ -24,6 +24,7 @@ import (
 	"e2mgr/logger"
 	"e2mgr/models"
 	"e2mgr/rmrCgo"
+	"unsafe"
 )
 
 type RmrSender struct {
@@ -78,3 +79,20 @@ func (r *RmrSender) SendWithoutLogs(rmrMessage *models.RmrMessage) error {
 
 	return nil
 }
+
+// SendErrorIndication notifies the RAN about a message the RIC could not process
+func (r *RmrSender) SendErrorIndication(ranName string, errorIndication *models.RicErrorIndicationMessage) error {
+	payload, err := errorIndication.Marshal()
+
+	if err != nil {
+		r.logger.Errorf("#RmrSender.SendErrorIndication - RAN name: %s - Failed marshalling ERROR INDICATION. Error: %v", ranName, err)
+		return err
+	}
+
+	r.logger.Infof("#RmrSender.SendErrorIndication - RAN name: %s - sending ERROR INDICATION, cause: %s", ranName, errorIndication.GetCause())
+
+	var xAction []byte
+	var msgSrc unsafe.Pointer
+	rmrMessage := models.NewRmrMessage(rmrCgo.RIC_E2_RIC_ERROR_INDICATION, ranName, payload, xAction, msgSrc)
+	return r.Send(rmrMessage)
+}
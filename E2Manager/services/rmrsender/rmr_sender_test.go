@@ -161,3 +161,20 @@ func initLog(t *testing.T) *logger.Logger {
 	}
 	return log
 }
+
+func TestRmrSenderSendErrorIndicationSuccess(t *testing.T) {
+	logger, rmrMessengerMock := initRmrSenderTest(t)
+
+	ranName := "test"
+	errorIndication := models.NewRicErrorIndicationMessage("1", models.NewTransferSyntaxErrorCause(), models.NewCriticalityDiagnostics(models.ProcedureCode_id_RICserviceUpdate, "1"))
+	payload, _ := errorIndication.Marshal()
+	var xAction []byte
+	var msgSrc unsafe.Pointer
+	mbuf := rmrCgo.NewMBuf(rmrCgo.RIC_E2_RIC_ERROR_INDICATION, len(payload), ranName, &payload, &xAction, msgSrc)
+	rmrMessengerMock.On("SendMsg", mbuf, true).Return(&rmrCgo.MBuf{}, nil)
+	rmrMessenger := rmrCgo.RmrMessenger(rmrMessengerMock)
+	rmrSender := NewRmrSender(logger, rmrMessenger)
+	err := rmrSender.SendErrorIndication(ranName, errorIndication)
+	assert.Nil(t, err)
+	rmrMessengerMock.AssertCalled(t, "SendMsg", mbuf, true)
+}
@@ -23,6 +23,7 @@ package services
 import (
 	"e2mgr/configuration"
 	"e2mgr/logger"
+	"e2mgr/metrics"
 	"e2mgr/rNibWriter"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/common"
 	"gerrit.o-ran-sc.org/r/ric-plt/nodeb-rnib.git/entities"
@@ -38,6 +39,8 @@ type RNibDataService interface {
 	SaveRanLoadInformation(inventoryName string, ranLoadInformation *entities.RanLoadInformation) error
 	GetNodeb(ranName string) (*entities.NodebInfo, error)
 	GetListNodebIds() ([]*entities.NbIdentity, error)
+	GetListEnbIds() ([]*entities.NbIdentity, error)
+	GetListGnbIds() ([]*entities.NbIdentity, error)
 	PingRnib() bool
 	GetE2TInstance(address string) (*entities.E2TInstance, error)
 	GetE2TInstances(addresses []string) ([]*entities.E2TInstance, error)
@@ -65,20 +68,18 @@ type RNibDataService interface {
 }
 
 type rNibDataService struct {
-	logger        *logger.Logger
-	rnibReader    reader.RNibReader
-	rnibWriter    rNibWriter.RNibWriter
-	maxAttempts   int
-	retryInterval time.Duration
+	logger     *logger.Logger
+	config     *configuration.Configuration
+	rnibReader reader.RNibReader
+	rnibWriter rNibWriter.RNibWriter
 }
 
 func NewRnibDataService(logger *logger.Logger, config *configuration.Configuration, rnibReader reader.RNibReader, rnibWriter rNibWriter.RNibWriter) *rNibDataService {
 	return &rNibDataService{
-		logger:        logger,
-		rnibReader:    rnibReader,
-		rnibWriter:    rnibWriter,
-		maxAttempts:   config.MaxRnibConnectionAttempts,
-		retryInterval: time.Duration(config.RnibRetryIntervalMs) * time.Millisecond,
+		logger:     logger,
+		config:     config,
+		rnibReader: rnibReader,
+		rnibWriter: rnibWriter,
 	}
 }
 
@@ -210,6 +211,36 @@ func (w *rNibDataService) GetListNodebIds() ([]*entities.NbIdentity, error) {
 	return nodeIds, err
 }
 
+func (w *rNibDataService) GetListEnbIds() ([]*entities.NbIdentity, error) {
+	var nodeIds []*entities.NbIdentity = nil
+
+	err := w.retry("GetListEnbIds", func() (err error) {
+		nodeIds, err = w.rnibReader.GetListEnbIds()
+		return
+	})
+
+	if err == nil {
+		w.logger.Infof("#RnibDataService.GetListEnbIds - ENBs count: %d", len(nodeIds))
+	}
+
+	return nodeIds, err
+}
+
+func (w *rNibDataService) GetListGnbIds() ([]*entities.NbIdentity, error) {
+	var nodeIds []*entities.NbIdentity = nil
+
+	err := w.retry("GetListGnbIds", func() (err error) {
+		nodeIds, err = w.rnibReader.GetListGnbIds()
+		return
+	})
+
+	if err == nil {
+		w.logger.Infof("#RnibDataService.GetListGnbIds - GNBs count: %d", len(nodeIds))
+	}
+
+	return nodeIds, err
+}
+
 func (w *rNibDataService) GetE2TInstance(address string) (*entities.E2TInstance, error) {
 	var e2tInstance *entities.E2TInstance = nil
 
@@ -414,7 +445,18 @@ func (w *rNibDataService) UpdateNbIdentities(nodeType entities.Node_Type, oldNbI
 }
 
 func (w *rNibDataService) retry(rnibFunc string, f func() error) (err error) {
-	attempts := w.maxAttempts
+	return retryRnib(w.logger, w.config, rnibFunc, f)
+}
+
+// retryRnib runs f until it succeeds, fails with an error which is not an rNib connection error or the configured attempts are exhausted
+func retryRnib(logger *logger.Logger, config *configuration.Configuration, rnibFunc string, f func() error) (err error) {
+	attempts := config.GetMaxRnibConnectionAttempts()
+	retryInterval := time.Duration(config.GetRnibRetryIntervalMs()) * time.Millisecond
+
+	start := time.Now()
+	defer func() {
+		metrics.RnibOperationDuration.WithLabelValues(rnibFunc).Observe(time.Since(start).Seconds())
+	}()
 
 	for i := 1; ; i++ {
 		err = f()
@@ -425,12 +467,13 @@ func (w *rNibDataService) retry(rnibFunc string, f func() error) (err error) {
 			return err
 		}
 		if i >= attempts {
-			w.logger.Errorf("#RnibDataService.retry - after %d attempts of %s, last error: %s", attempts, rnibFunc, err)
+			logger.Errorf("#RnibDataService.retry - after %d attempts of %s, last error: %s", attempts, rnibFunc, err)
 			return err
 		}
-		time.Sleep(w.retryInterval)
+		time.Sleep(retryInterval)
 
-		w.logger.Infof("#RnibDataService.retry - retrying %d %s after error: %s", i, rnibFunc, err)
+		logger.Infof("#RnibDataService.retry - retrying %d %s after error: %s", i, rnibFunc, err)
+		metrics.RnibRetries.WithLabelValues(rnibFunc).Inc()
 	}
 }
 
@@ -191,6 +191,23 @@ func TestSuccessfulGetNodebIdList(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestSuccessfulGetListEnbAndGnbIds(t *testing.T) {
+	rnibDataService, readerMock, _ := setupRnibDataServiceTest(t)
+
+	enbIds := []*entities.NbIdentity{{InventoryName: "enb"}}
+	gnbIds := []*entities.NbIdentity{{InventoryName: "gnb"}}
+	readerMock.On("GetListEnbIds").Return(enbIds, nil)
+	readerMock.On("GetListGnbIds").Return(gnbIds, nil)
+
+	res, err := rnibDataService.GetListEnbIds()
+	assert.Nil(t, err)
+	assert.Equal(t, enbIds, res)
+
+	res, err = rnibDataService.GetListGnbIds()
+	assert.Nil(t, err)
+	assert.Equal(t, gnbIds, res)
+}
+
 func TestConnFailureGetNodebIdList(t *testing.T) {
 	rnibDataService, readerMock, _ := setupRnibDataServiceTest(t)
 
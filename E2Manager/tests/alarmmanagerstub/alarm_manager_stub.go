@@ -0,0 +1,124 @@
+//
+// Copyright (c) 2023 Samsung Electronics Co., Ltd. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//  This source code is part of the near-RT RIC (RAN Intelligent Controller)
+//  platform project (RICP).
+
+package alarmmanagerstub
+
+import (
+	"e2mgr/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+const alarmsPath = "/ric/v1/alarms"
+
+// AlarmManagerStub is a local HTTP stand-in for the Alarm Manager REST API, used by unit tests.
+type AlarmManagerStub struct {
+	server       *httptest.Server
+	mutex        sync.Mutex
+	statusCode   int
+	messages     []models.AlarmMessage
+	rejected     int
+	activeAlarms map[models.AlarmId]map[string]models.Alarm
+}
+
+func NewAlarmManagerStub() *AlarmManagerStub {
+	stub := &AlarmManagerStub{
+		statusCode:   http.StatusOK,
+		activeAlarms: make(map[models.AlarmId]map[string]models.Alarm),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(alarmsPath, stub.handleAlarm)
+	stub.server = httptest.NewServer(mux)
+	return stub
+}
+
+func (s *AlarmManagerStub) BaseUrl() string {
+	return s.server.URL + "/ric/v1/"
+}
+
+func (s *AlarmManagerStub) Close() {
+	s.server.Close()
+}
+
+func (s *AlarmManagerStub) SetStatusCode(statusCode int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.statusCode = statusCode
+}
+
+func (s *AlarmManagerStub) Messages() []models.AlarmMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]models.AlarmMessage(nil), s.messages...)
+}
+
+// Rejected returns the number of alarm requests answered with a failure status
+func (s *AlarmManagerStub) Rejected() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rejected
+}
+
+func (s *AlarmManagerStub) IsActive(specificProblem models.AlarmId, identifyingInfo string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, ok := s.activeAlarms[specificProblem][identifyingInfo]
+	return ok
+}
+
+func (s *AlarmManagerStub) handleAlarm(writer http.ResponseWriter, request *http.Request) {
+	alarmMessage := models.AlarmMessage{}
+
+	if err := json.NewDecoder(request.Body).Decode(&alarmMessage); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	isRaise := request.Method == http.MethodPost && alarmMessage.AlarmAction == models.AlarmActionRaise
+	isClear := request.Method == http.MethodDelete && alarmMessage.AlarmAction == models.AlarmActionClear
+
+	if !isRaise && !isClear {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.statusCode < http.StatusOK || s.statusCode >= http.StatusMultipleChoices {
+		s.rejected++
+		writer.WriteHeader(s.statusCode)
+		return
+	}
+
+	s.messages = append(s.messages, alarmMessage)
+	alarm := alarmMessage.Alarm
+
+	if isRaise {
+		if s.activeAlarms[alarm.SpecificProblem] == nil {
+			s.activeAlarms[alarm.SpecificProblem] = make(map[string]models.Alarm)
+		}
+		s.activeAlarms[alarm.SpecificProblem][alarm.IdentifyingInfo] = alarm
+	} else {
+		delete(s.activeAlarms[alarm.SpecificProblem], alarm.IdentifyingInfo)
+	}
+
+	writer.WriteHeader(s.statusCode)
+}